@@ -4,6 +4,7 @@ import (
 	"net/http"
 	
 	"github.com/devsirose/hotel-reservation/middleware"
+	"github.com/devsirose/hotel-reservation/model"
 	"github.com/gin-gonic/gin"
 )
 
@@ -14,6 +15,7 @@ func (server *Server) setupRoutes() *gin.Engine {
 	router.Use(middleware.RecoveryWithLogger)
 	router.Use(middleware.CORSMiddleware())
 	router.Use(middleware.LoggerMiddleware())
+	router.Use(middleware.ActorMiddleware(server.authUserHeader))
 	
 	// API v1 routes
 	v1 := router.Group("/api/v1")
@@ -26,6 +28,12 @@ func (server *Server) setupRoutes() *gin.Engine {
 			hotels.GET("", server.hotelHandler.ListHotels)
 			hotels.PUT("/:id", server.hotelHandler.UpdateHotel)
 			hotels.DELETE("/:id", server.hotelHandler.DeleteHotel)
+			hotels.GET("/:id/calendar", server.roomHandler.GetHotelCalendar)
+			hotels.GET("/:id/housekeeping", server.hkHandler.GetHousekeepingBoard)
+			hotels.GET("/:id/day-use-slots", server.roomHandler.GetDayUseSlots)
+			hotels.GET("/:id/overbooking-report", server.overbookHandler.GetOverbookingReport)
+			hotels.GET("/:id/extras-report", server.extraHandler.GetExtrasReport)
+			hotels.GET("/:id/availability/stream", server.streamHandler.StreamAvailability)
 		}
 		
 		// Room routes
@@ -36,21 +44,172 @@ func (server *Server) setupRoutes() *gin.Engine {
 			rooms.GET("", server.roomHandler.ListRooms)
 			rooms.GET("/hotel/:hotel_id", server.roomHandler.ListRoomsByHotel)
 			rooms.GET("/available", server.roomHandler.GetAvailableRooms)
+			rooms.GET("/available/types", server.roomHandler.GetRoomTypeAvailability)
 			rooms.PUT("/:id", server.roomHandler.UpdateRoom)
+			rooms.PUT("/:id/housekeeping", server.hkHandler.UpdateHousekeepingStatus)
 			rooms.DELETE("/:id", server.roomHandler.DeleteRoom)
+			rooms.POST("/:id/ical-token", server.icalHandler.IssueExportToken)
+			rooms.GET("/:id/calendar.ics", server.icalHandler.ExportRoomCalendar)
 		}
 		
-		// Reservation routes
+		// iCal feed routes
+		icalFeeds := v1.Group("/ical-feeds")
+		{
+			icalFeeds.POST("", server.icalHandler.CreateICalFeed)
+			icalFeeds.GET("/:id", server.icalHandler.GetICalFeed)
+			icalFeeds.GET("/room/:room_id", server.icalHandler.ListICalFeedsByRoom)
+			icalFeeds.PUT("/:id", server.icalHandler.UpdateICalFeed)
+			icalFeeds.DELETE("/:id", server.icalHandler.DeleteICalFeed)
+			icalFeeds.POST("/:id/sync", server.icalHandler.SyncICalFeed)
+		}
+		
+		// Room block routes
+		roomBlocks := v1.Group("/room-blocks")
+		{
+			roomBlocks.POST("", server.blockHandler.CreateRoomBlock)
+			roomBlocks.GET("/:id", server.blockHandler.GetRoomBlock)
+			roomBlocks.GET("/room/:room_id", server.blockHandler.ListRoomBlocksByRoom)
+			roomBlocks.DELETE("/:id", server.blockHandler.DeleteRoomBlock)
+		}
+		
+		// Stay restriction routes
+		restrictions := v1.Group("/stay-restrictions")
+		{
+			restrictions.POST("", server.restrHandler.CreateStayRestriction)
+			restrictions.GET("/:id", server.restrHandler.GetStayRestriction)
+			restrictions.GET("/hotel/:hotel_id", server.restrHandler.ListStayRestrictionsByHotel)
+			restrictions.PUT("/:id", server.restrHandler.UpdateStayRestriction)
+			restrictions.DELETE("/:id", server.restrHandler.DeleteStayRestriction)
+		}
+		
+		// Overbooking allowance routes
+		overbooking := v1.Group("/overbooking-allowances")
+		{
+			overbooking.POST("", server.overbookHandler.CreateOverbookingAllowance)
+			overbooking.GET("/:id", server.overbookHandler.GetOverbookingAllowance)
+			overbooking.GET("/hotel/:hotel_id", server.overbookHandler.ListOverbookingAllowancesByHotel)
+			overbooking.PUT("/:id", server.overbookHandler.UpdateOverbookingAllowance)
+			overbooking.DELETE("/:id", server.overbookHandler.DeleteOverbookingAllowance)
+		}
+		
+		// Allotment routes
+		allotments := v1.Group("/allotments")
+		{
+			allotments.POST("", server.allotHandler.CreateAllotment)
+			allotments.GET("/:id", server.allotHandler.GetAllotment)
+			allotments.GET("/code/:code", server.allotHandler.GetAllotmentByCode)
+			allotments.GET("/hotel/:hotel_id", server.allotHandler.ListAllotmentsByHotel)
+			allotments.PUT("/:id", server.allotHandler.UpdateAllotment)
+			allotments.PUT("/:id/release", server.allotHandler.ReleaseAllotment)
+			allotments.DELETE("/:id", server.allotHandler.CancelAllotment)
+		}
+		
+		// Extra routes
+		extras := v1.Group("/extras")
+		{
+			extras.POST("", server.extraHandler.CreateExtra)
+			extras.GET("/:id", server.extraHandler.GetExtra)
+			extras.GET("/hotel/:hotel_id", server.extraHandler.ListExtrasByHotel)
+			extras.PUT("/:id", server.extraHandler.UpdateExtra)
+			extras.DELETE("/:id", server.extraHandler.DeleteExtra)
+		}
+		
+		// Reservation routes. Every route acting on one reservation is guarded the same way; the
+		// staff operations accept no magic links.
+		authRequired := server.authUserHeader != ""
+		viewAccess := middleware.MagicLinkMiddleware(server.magicLinkService, model.MagicLinkView, authRequired)
+		modifyAccess := middleware.MagicLinkMiddleware(server.magicLinkService, model.MagicLinkModify, authRequired)
+		cancelAccess := middleware.MagicLinkMiddleware(server.magicLinkService, model.MagicLinkCancel, authRequired)
+		staffAccess := middleware.MagicLinkMiddleware(server.magicLinkService, "", authRequired)
 		reservations := v1.Group("/reservations")
 		{
 			reservations.POST("", server.reservHandler.CreateReservation)
-			reservations.GET("/:id", server.reservHandler.GetReservation)
+			reservations.GET("/lookup", server.reservHandler.LookupReservation)
+			reservations.PUT("/lookup", server.reservHandler.UpdateReservationByLookup)
+			reservations.DELETE("/lookup", server.reservHandler.CancelReservationByLookup)
+			reservations.GET("/:id", viewAccess, server.reservHandler.GetReservation)
 			reservations.GET("", server.reservHandler.ListReservations)
 			reservations.GET("/user/:user_id", server.reservHandler.ListReservationsByUser)
 			reservations.GET("/room/:room_id", server.reservHandler.ListReservationsByRoom)
-			reservations.PUT("/:id", server.reservHandler.UpdateReservation)
-			reservations.PUT("/:id/status", server.reservHandler.UpdateReservationStatus)
-			reservations.DELETE("/:id", server.reservHandler.DeleteReservation)
+			reservations.PUT("/:id", modifyAccess, server.reservHandler.UpdateReservation)
+			reservations.GET("/:id/modifications", viewAccess, server.reservHandler.ListReservationModifications)
+			reservations.PUT("/:id/status", staffAccess, server.reservHandler.UpdateReservationStatus)
+			reservations.PUT("/:id/assign", staffAccess, server.reservHandler.AssignRoom)
+			reservations.PUT("/:id/move", server.reservHandler.MoveGuest)
+			reservations.PUT("/:id/extras", modifyAccess, server.reservHandler.UpdateReservationExtras)
+			reservations.GET("/:id/folio", viewAccess, server.reservHandler.GetReservationFolio)
+			reservations.POST("/:id/magic-links", server.linkHandler.IssueMagicLink)
+			reservations.PUT("/:id/check-out", staffAccess, server.reservHandler.CheckOutReservation)
+			reservations.DELETE("/:id", cancelAccess, server.reservHandler.DeleteReservation)
+		}
+		
+		// Booking group routes
+		bookingGroups := v1.Group("/booking-groups")
+		{
+			bookingGroups.POST("", server.groupHandler.CreateBookingGroup)
+			bookingGroups.GET("/:id", server.groupHandler.GetBookingGroup)
+			bookingGroups.GET("/user/:user_id", server.groupHandler.ListBookingGroupsByUser)
+			bookingGroups.PUT("/:id/status", server.groupHandler.UpdateBookingGroupStatus)
+			bookingGroups.DELETE("/:id", server.groupHandler.CancelBookingGroup)
+		}
+		
+		// Waitlist routes
+		waitlist := v1.Group("/waitlist")
+		{
+			waitlist.POST("", server.waitlistHandler.JoinWaitlist)
+			waitlist.GET("/:id", server.waitlistHandler.GetWaitlistEntry)
+			waitlist.GET("/hotel/:hotel_id", server.waitlistHandler.ListWaitlistEntriesByHotel)
+			waitlist.GET("/user/:user_id", server.waitlistHandler.ListWaitlistEntriesByUser)
+			waitlist.DELETE("/:id", server.waitlistHandler.WithdrawWaitlistEntry)
+		}
+		
+		// Webhook routes
+		webhooks := v1.Group("/webhooks")
+		{
+			webhooks.POST("", server.webhookHandler.CreateWebhookSubscription)
+			webhooks.GET("", server.webhookHandler.ListWebhookSubscriptions)
+			webhooks.GET("/:id", server.webhookHandler.GetWebhookSubscription)
+			webhooks.PUT("/:id", server.webhookHandler.UpdateWebhookSubscription)
+			webhooks.DELETE("/:id", server.webhookHandler.DeleteWebhookSubscription)
+			webhooks.GET("/:id/deliveries", server.webhookHandler.ListWebhookDeliveries)
+			webhooks.GET("/:id/deliveries/:delivery_id", server.webhookHandler.GetWebhookDelivery)
+			webhooks.POST("/:id/deliveries/:delivery_id/replay", server.webhookHandler.ReplayWebhookDelivery)
+		}
+		
+		// Channel manager routes
+		v1.GET("/channels", server.channelHandler.ListChannels)
+		channelConnections := v1.Group("/channel-connections")
+		{
+			channelConnections.POST("", server.channelHandler.CreateChannelConnection)
+			channelConnections.GET("/:id", server.channelHandler.GetChannelConnection)
+			channelConnections.GET("/hotel/:hotel_id", server.channelHandler.ListChannelConnectionsByHotel)
+			channelConnections.PUT("/:id", server.channelHandler.UpdateChannelConnection)
+			channelConnections.DELETE("/:id", server.channelHandler.DeleteChannelConnection)
+			channelConnections.GET("/:id/mappings", server.channelHandler.ListChannelMappings)
+			channelConnections.POST("/:id/mappings", server.channelHandler.CreateChannelMapping)
+			channelConnections.POST("/:id/push", server.channelHandler.PushChannelARI)
+			channelConnections.POST("/:id/pull", server.channelHandler.PullChannelReservations)
+		}
+		v1.DELETE("/channel-mappings/:id", server.channelHandler.DeleteChannelMapping)
+		
+		// Promo code routes
+		promoCodes := v1.Group("/promo-codes")
+		{
+			promoCodes.POST("", server.promoHandler.CreatePromoCode)
+			promoCodes.GET("/:code", server.promoHandler.GetPromoCode)
+			promoCodes.GET("", server.promoHandler.ListPromoCodes)
+			promoCodes.PUT("/:code", server.promoHandler.UpdatePromoCode)
+			promoCodes.DELETE("/:code", server.promoHandler.DeletePromoCode)
+		}
+		
+		// Room type routes
+		roomTypes := v1.Group("/room-types")
+		{
+			roomTypes.POST("", server.typeHandler.CreateRoomType)
+			roomTypes.GET("/:code", server.typeHandler.GetRoomType)
+			roomTypes.GET("", server.typeHandler.ListRoomTypes)
+			roomTypes.PUT("/:code", server.typeHandler.UpdateRoomType)
+			roomTypes.DELETE("/:code", server.typeHandler.DeleteRoomType)
 		}
 	}
 	
@@ -1,46 +1,169 @@
 package api
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
+	"github.com/devsirose/hotel-reservation/channel"
+	"github.com/devsirose/hotel-reservation/config"
 	db "github.com/devsirose/hotel-reservation/db/sqlc"
 	"github.com/devsirose/hotel-reservation/handler"
+	"github.com/devsirose/hotel-reservation/logger"
+	"github.com/devsirose/hotel-reservation/notification"
 	"github.com/devsirose/hotel-reservation/repository"
 	"github.com/devsirose/hotel-reservation/service"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
 )
 
+// outboxPollInterval is how often the outbox is checked for events to dispatch
+const outboxPollInterval = 2 * time.Second
+
 type Server struct {
-	store         db.Store
-	router        *gin.Engine
-	hotelHandler  *handler.HotelHandler
-	roomHandler   *handler.RoomHandler
-	reservHandler *handler.ReservationHandler
+	store           db.Store
+	router          *gin.Engine
+	authUserHeader  string
+	hotelHandler    *handler.HotelHandler
+	roomHandler     *handler.RoomHandler
+	reservHandler   *handler.ReservationHandler
+	promoHandler    *handler.PromoCodeHandler
+	typeHandler     *handler.RoomTypeHandler
+	groupHandler    *handler.BookingGroupHandler
+	blockHandler    *handler.RoomBlockHandler
+	hkHandler       *handler.HousekeepingHandler
+	restrHandler    *handler.StayRestrictionHandler
+	waitlistHandler *handler.WaitlistHandler
+	overbookHandler *handler.OverbookingHandler
+	allotHandler    *handler.AllotmentHandler
+	extraHandler    *handler.ExtraHandler
+	linkHandler     *handler.MagicLinkHandler
+	webhookHandler  *handler.WebhookHandler
+	streamHandler   *handler.AvailabilityStreamHandler
+	icalHandler     *handler.ICalHandler
+	channelHandler  *handler.ChannelHandler
+
+	waitlistService  service.WaitlistService
+	allotmentService service.AllotmentService
+	magicLinkService service.MagicLinkService
+	webhookService   service.WebhookService
+	availability     service.AvailabilityStream
+	notifications    service.NotificationService
+	icalService      service.ICalService
+	channelService   service.ChannelService
+	outbox           service.OutboxDispatcher
+	events           *service.InProcessEventPublisher
 }
 
-func NewServer(store db.Store, sqlDB *sql.DB) *Server {
+func NewServer(cfg config.Config, store db.Store, sqlDB *sql.DB) *Server {
 	// Initialize repositories
 	hotelRepo := repository.NewHotelRepository(sqlDB)
 	roomRepo := repository.NewRoomRepository(sqlDB)
 	reservationRepo := repository.NewReservationRepository(sqlDB)
+	promoCodeRepo := repository.NewPromoCodeRepository(sqlDB)
+	roomTypeRepo := repository.NewRoomTypeRepository(sqlDB)
+	bookingGroupRepo := repository.NewBookingGroupRepository(sqlDB)
+	roomBlockRepo := repository.NewRoomBlockRepository(sqlDB)
+	restrictionRepo := repository.NewStayRestrictionRepository(sqlDB)
+	waitlistRepo := repository.NewWaitlistRepository(sqlDB)
+	overbookingRepo := repository.NewOverbookingRepository(sqlDB)
+	allotmentRepo := repository.NewAllotmentRepository(sqlDB)
+	extraRepo := repository.NewExtraRepository(sqlDB)
+	webhookRepo := repository.NewWebhookRepository(sqlDB)
+	icalRepo := repository.NewICalRepository(sqlDB)
+	channelRepo := repository.NewChannelRepository(sqlDB)
 	
 	// Initialize services
-	hotelService := service.NewHotelService(hotelRepo)
-	roomService := service.NewRoomService(roomRepo, hotelRepo)
-	reservationService := service.NewReservationService(reservationRepo, roomRepo)
+	hotelService := service.NewHotelService(store, hotelRepo, roomTypeRepo)
+	roomService := service.NewRoomService(store, roomRepo, hotelRepo, roomTypeRepo)
+	waitlistService := service.NewWaitlistService(store, waitlistRepo, roomRepo)
+	reservationService := service.NewReservationService(store, reservationRepo, roomRepo, waitlistService)
+	promoCodeService := service.NewPromoCodeService(promoCodeRepo, hotelRepo, roomTypeRepo)
+	roomTypeService := service.NewRoomTypeService(roomTypeRepo)
+	bookingGroupService := service.NewBookingGroupService(store, bookingGroupRepo, reservationRepo, waitlistService)
+	roomBlockService := service.NewRoomBlockService(store, roomBlockRepo, roomRepo)
+	housekeepingService := service.NewHousekeepingService(roomRepo, hotelRepo, reservationRepo)
+	restrictionService := service.NewStayRestrictionService(store, restrictionRepo, hotelRepo, roomTypeRepo)
+	overbookingService := service.NewOverbookingService(store, overbookingRepo, hotelRepo, roomTypeRepo)
+	allotmentService := service.NewAllotmentService(store, allotmentRepo, roomTypeRepo, waitlistService)
+	extraService := service.NewExtraService(extraRepo, hotelRepo)
+	magicLinkService := service.NewMagicLinkService(store, reservationRepo, cfg.MagicLinkKeys, cfg.MagicLinkTTL)
+	webhookService := service.NewWebhookService(store, webhookRepo, hotelRepo, nil)
+	availability := service.NewAvailabilityStream(store, cfg.DbSource)
+	notifications := newNotificationService(cfg, store)
+	icalService := service.NewICalService(store, icalRepo, roomRepo, hotelRepo, nil)
+	channelService := service.NewChannelService(store, channelRepo, hotelRepo, roomRepo, roomTypeRepo, newChannelRegistry(cfg), waitlistService)
+	
+	// Events recorded in the outbox are delivered to the log, to in-process subscribers, to
+	// webhook subscriptions, to availability stream clients, to connected channels and, when mail
+	// is configured, to guests
+	events := service.NewInProcessEventPublisher()
+	sinks := []service.OutboxSink{
+		{Name: "log", Publisher: service.NewLogEventPublisher()},
+		{Name: "events", Publisher: events},
+		{Name: "webhooks", Publisher: webhookService},
+		{Name: "availability", Publisher: availability},
+		{Name: "channels", Publisher: channelService},
+	}
+	if notifications != nil {
+		sinks = append(sinks, service.OutboxSink{Name: "notifications", Publisher: notifications})
+	}
+	outbox := service.NewOutboxDispatcher(store, sinks...)
 	
 	// Initialize handlers
 	hotelHandler := handler.NewHotelHandler(hotelService)
 	roomHandler := handler.NewRoomHandler(roomService)
 	reservHandler := handler.NewReservationHandler(reservationService)
+	promoHandler := handler.NewPromoCodeHandler(promoCodeService)
+	typeHandler := handler.NewRoomTypeHandler(roomTypeService)
+	groupHandler := handler.NewBookingGroupHandler(bookingGroupService)
+	blockHandler := handler.NewRoomBlockHandler(roomBlockService)
+	hkHandler := handler.NewHousekeepingHandler(housekeepingService)
+	restrHandler := handler.NewStayRestrictionHandler(restrictionService)
+	waitlistHandler := handler.NewWaitlistHandler(waitlistService)
+	overbookHandler := handler.NewOverbookingHandler(overbookingService)
+	allotHandler := handler.NewAllotmentHandler(allotmentService)
+	extraHandler := handler.NewExtraHandler(extraService)
+	linkHandler := handler.NewMagicLinkHandler(magicLinkService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	streamHandler := handler.NewAvailabilityStreamHandler(hotelService, availability)
+	icalHandler := handler.NewICalHandler(icalService)
+	channelHandler := handler.NewChannelHandler(channelService)
 
 	server := &Server{
-		store:         store,
-		hotelHandler:  hotelHandler,
-		roomHandler:   roomHandler,
-		reservHandler: reservHandler,
+		store:           store,
+		authUserHeader:  cfg.AuthUserHeader,
+		hotelHandler:    hotelHandler,
+		roomHandler:     roomHandler,
+		reservHandler:   reservHandler,
+		promoHandler:    promoHandler,
+		typeHandler:     typeHandler,
+		groupHandler:    groupHandler,
+		blockHandler:    blockHandler,
+		hkHandler:       hkHandler,
+		restrHandler:    restrHandler,
+		waitlistHandler: waitlistHandler,
+		overbookHandler: overbookHandler,
+		allotHandler:    allotHandler,
+		extraHandler:    extraHandler,
+		linkHandler:     linkHandler,
+		webhookHandler:  webhookHandler,
+		streamHandler:   streamHandler,
+		icalHandler:     icalHandler,
+		channelHandler:  channelHandler,
+
+		waitlistService:  waitlistService,
+		allotmentService: allotmentService,
+		magicLinkService: magicLinkService,
+		webhookService:   webhookService,
+		availability:     availability,
+		notifications:    notifications,
+		icalService:      icalService,
+		channelService:   channelService,
+		outbox:           outbox,
+		events:           events,
 	}
 
 	// Setup routes
@@ -55,6 +178,114 @@ func NewServer(store db.Store, sqlDB *sql.DB) *Server {
 	return server
 }
 
+// StartJobs runs the server's background jobs until ctx is cancelled
+func (server *Server) StartJobs(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if err := server.waitlistService.ExpireOffers(ctx, now); err != nil {
+					logger.Log.Error("Failed to expire waitlist offers", zap.Error(err))
+				}
+				if err := server.allotmentService.ReleaseDueAllotments(ctx, now); err != nil {
+					logger.Log.Error("Failed to release allotments", zap.Error(err))
+				}
+				if server.notifications != nil {
+					if _, err := server.notifications.SendArrivalReminders(ctx, now); err != nil {
+						logger.Log.Error("Failed to send arrival reminders", zap.Error(err))
+					}
+				}
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(outboxPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if _, err := server.outbox.DispatchPending(ctx, now); err != nil {
+					logger.Log.Error("Failed to dispatch outbox events", zap.Error(err))
+				}
+				if _, err := server.webhookService.DeliverPending(ctx, now); err != nil {
+					logger.Log.Error("Failed to deliver webhooks", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	// iCal feeds are fetched apart from the other jobs, since slow feed servers can take a while
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if _, err := server.icalService.SyncDueICalFeeds(ctx, now); err != nil {
+					logger.Log.Error("Failed to synchronize iCal feeds", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	// Channels are synchronized apart from the other jobs for the same reason
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if _, err := server.channelService.SyncDueChannels(ctx, now); err != nil {
+					logger.Log.Error("Failed to synchronize channels", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	go func() {
+		if err := server.availability.Listen(ctx); err != nil {
+			logger.Log.Error("Failed to listen for availability changes", zap.Error(err))
+		}
+	}()
+}
+
+// newNotificationService returns the service that emails guests, or nil when no mail server or
+// mail directory is configured
+func newNotificationService(cfg config.Config, store db.Store) service.NotificationService {
+	var sender notification.Sender
+	switch {
+	case cfg.SMTPHost != "":
+		port := cfg.SMTPPort
+		if port == 0 {
+			port = 587
+		}
+		sender = notification.NewSMTPSender(cfg.SMTPHost, port, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	case cfg.MailDir != "":
+		sender = notification.NewFileSender(cfg.MailDir, cfg.MailFrom)
+	default:
+		return nil
+	}
+
+	renderer, err := notification.NewRenderer()
+	if err != nil {
+		logger.Log.Error("Failed to parse notification templates", zap.Error(err))
+		return nil
+	}
+
+	return service.NewNotificationService(store, renderer, sender, cfg.ReminderDays, cfg.ReviewURL)
+}
+
 func (server *Server) Start(address string) error {
 	return server.router.Run(address)
 }
@@ -81,3 +312,13 @@ func validCurrency(fl validator.FieldLevel) bool {
 	currency := fl.Field().String()
 	return currency != ""
 }
+
+// newChannelRegistry returns the channels hotels can be connected to. No real channel is built in;
+// the simulator is registered when CHANNEL_SIMULATOR is set.
+func newChannelRegistry(cfg config.Config) *channel.Registry {
+	var providers []channel.Provider
+	if cfg.ChannelSimulator {
+		providers = append(providers, channel.NewSimulator(channel.SimulatorCode))
+	}
+	return channel.NewRegistry(providers...)
+}
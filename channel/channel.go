@@ -0,0 +1,126 @@
+// Package channel connects the hotel's inventory to online travel agencies and other
+// distribution channels. A Provider speaks one channel's API: it receives availability, rates
+// and restrictions (ARI) for the rooms mapped to the channel, and hands back the reservations
+// guests made on it.
+package channel
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DateLayout is how channel dates are written
+const DateLayout = "2006-01-02"
+
+// Statuses of a reservation on a channel
+const (
+	StatusBooked    = "BOOKED"
+	StatusModified  = "MODIFIED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Provider is a distribution channel. Pushes replace what the channel has for the dates they
+// cover, so pushing the same ARI twice is harmless. Pulls return every reservation on the
+// hotel's listing that was made, modified or cancelled since a point in time, and may return a
+// reservation again; callers recognize reservations by their external ID.
+type Provider interface {
+	Code() string
+	PushARI(ctx context.Context, update *ARIUpdate) error
+	PullReservations(ctx context.Context, hotelCode string, since time.Time) ([]*Reservation, error)
+}
+
+// ARIUpdate carries the availability, rates and restrictions of one hotel on a channel
+type ARIUpdate struct {
+	HotelCode string
+	Items     []*ARIItem
+}
+
+// ARIItem is what a channel may sell of one room code on one date. Date is a calendar date at
+// midnight UTC. Restrictions apply to stays arriving on the date, except ClosedToDeparture which
+// applies to stays leaving on it. A zero MinNights or MaxNights leaves the stay length open.
+type ARIItem struct {
+	RoomCode          string
+	RateCode          string
+	Date              time.Time
+	Available         int32
+	Rate              int32
+	MinNights         int32
+	MaxNights         int32
+	ClosedToArrival   bool
+	ClosedToDeparture bool
+	StopSell          bool
+}
+
+// Reservation is a booking made on a channel. Arrival and Departure are calendar dates at
+// midnight UTC. UpdatedAt changes whenever the channel changes the reservation.
+type Reservation struct {
+	ExternalID      string
+	HotelCode       string
+	RoomCode        string
+	RateCode        string
+	Status          string
+	Arrival         time.Time
+	Departure       time.Time
+	Adults          int32
+	Children        int32
+	TotalPrice      int32
+	Guest           Guest
+	SpecialRequests string
+	BookedAt        time.Time
+	UpdatedAt       time.Time
+}
+
+// Guest is the guest a channel reservation was made for
+type Guest struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Phone     string
+}
+
+// Nights returns how many nights the reservation spans
+func (r *Reservation) Nights() int {
+	return int(Date(r.Departure).Sub(Date(r.Arrival)) / (24 * time.Hour))
+}
+
+// Date returns the calendar date of t, at midnight UTC
+func Date(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// NormalizeCode returns the form channel codes are compared and stored in
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Registry holds the channels the application can connect to, by code
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	registry := &Registry{providers: make(map[string]Provider, len(providers))}
+	for _, provider := range providers {
+		registry.providers[NormalizeCode(provider.Code())] = provider
+	}
+	return registry
+}
+
+// Provider returns the channel with the given code, or false if none is registered
+func (r *Registry) Provider(code string) (Provider, bool) {
+	provider, ok := r.providers[NormalizeCode(code)]
+	return provider, ok
+}
+
+// Codes returns the codes of the registered channels in alphabetical order
+func (r *Registry) Codes() []string {
+	codes := make([]string, 0, len(r.providers))
+	for code := range r.providers {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
\ No newline at end of file
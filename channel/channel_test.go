@@ -0,0 +1,216 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// pushNights pushes the same ARI for a room code on every date from first for n days
+func pushNights(t *testing.T, sim *Simulator, roomCode string, first time.Time, n int, item ARIItem) {
+	t.Helper()
+
+	update := &ARIUpdate{HotelCode: "H1"}
+	for i := 0; i < n; i++ {
+		night := item
+		night.RoomCode = roomCode
+		night.Date = first.AddDate(0, 0, i)
+		update.Items = append(update.Items, &night)
+	}
+	if err := sim.PushARI(context.Background(), update); err != nil {
+		t.Fatalf("PushARI: %v", err)
+	}
+}
+
+func TestSimulatorSellsPushedInventory(t *testing.T) {
+	sim := NewSimulator("")
+	pushNights(t, sim, "DBL", date(2026, 11, 1), 5, ARIItem{Available: 1, Rate: 100})
+
+	booked, err := sim.Book(&Reservation{HotelCode: "H1", RoomCode: "DBL", Arrival: date(2026, 11, 1), Departure: date(2026, 11, 3), Adults: 2})
+	if err != nil {
+		t.Fatalf("Book: %v", err)
+	}
+	if booked.ExternalID != "SIMULATOR-000001" || booked.Status != StatusBooked || booked.TotalPrice != 200 {
+		t.Errorf("booked = %+v", booked)
+	}
+
+	item, ok := sim.Inventory("H1", "DBL", date(2026, 11, 2))
+	if !ok || item.Available != 0 {
+		t.Errorf("inventory after booking = %+v, %v", item, ok)
+	}
+	if item, _ := sim.Inventory("H1", "DBL", date(2026, 11, 3)); item.Available != 1 {
+		t.Errorf("departure date availability = %d, want 1", item.Available)
+	}
+
+	// The last room is gone, and dates never pushed cannot be sold
+	if _, err := sim.Book(&Reservation{HotelCode: "H1", RoomCode: "DBL", Arrival: date(2026, 11, 2), Departure: date(2026, 11, 4)}); err == nil || !strings.Contains(err.Error(), "sold out on 2026-11-02") {
+		t.Errorf("overbooking: err = %v", err)
+	}
+	if _, err := sim.Book(&Reservation{HotelCode: "H1", RoomCode: "DBL", Arrival: date(2026, 11, 5), Departure: date(2026, 11, 7)}); err == nil || !strings.Contains(err.Error(), "not for sale on 2026-11-06") {
+		t.Errorf("unpushed dates: err = %v", err)
+	}
+
+	if _, err := sim.Cancel(booked.ExternalID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if item, _ := sim.Inventory("H1", "DBL", date(2026, 11, 2)); item.Available != 1 {
+		t.Errorf("availability after cancelling = %d, want 1", item.Available)
+	}
+	if _, err := sim.Cancel(booked.ExternalID); err == nil {
+		t.Error("cancelling twice succeeded")
+	}
+}
+
+func TestSimulatorRestrictions(t *testing.T) {
+	sim := NewSimulator("")
+	pushNights(t, sim, "DBL", date(2026, 11, 1), 10, ARIItem{Available: 5, Rate: 100})
+	pushNights(t, sim, "DBL", date(2026, 11, 2), 1, ARIItem{Available: 5, Rate: 100, ClosedToArrival: true})
+	pushNights(t, sim, "DBL", date(2026, 11, 3), 1, ARIItem{Available: 5, Rate: 120, MinNights: 3, MaxNights: 4})
+	pushNights(t, sim, "DBL", date(2026, 11, 5), 1, ARIItem{Available: 5, Rate: 100, ClosedToDeparture: true})
+	pushNights(t, sim, "DBL", date(2026, 11, 8), 1, ARIItem{Available: 5, Rate: 100, StopSell: true})
+
+	tests := []struct {
+		name      string
+		arrival   time.Time
+		departure time.Time
+		err       string
+	}{
+		{"closed to arrival", date(2026, 11, 2), date(2026, 11, 4), "closed to arrival on 2026-11-02"},
+		{"minimum stay", date(2026, 11, 3), date(2026, 11, 4), "at least 3 nights"},
+		{"maximum stay", date(2026, 11, 3), date(2026, 11, 8), "at most 4 nights"},
+		{"closed to departure", date(2026, 11, 1), date(2026, 11, 5), "closed to departure on 2026-11-05"},
+		{"stop sell", date(2026, 11, 7), date(2026, 11, 9), "sold out on 2026-11-08"},
+		{"no nights", date(2026, 11, 6), date(2026, 11, 6), "departure must be after arrival"},
+		{"allowed", date(2026, 11, 3), date(2026, 11, 6), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booked, err := sim.Book(&Reservation{HotelCode: "H1", RoomCode: "DBL", Arrival: tt.arrival, Departure: tt.departure})
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("Book: %v", err)
+				}
+				if booked.TotalPrice != 320 {
+					t.Errorf("TotalPrice = %d, want 320", booked.TotalPrice)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("err = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSimulatorModify(t *testing.T) {
+	sim := NewSimulator("")
+	pushNights(t, sim, "DBL", date(2026, 11, 1), 4, ARIItem{Available: 1, Rate: 100})
+
+	booked, err := sim.Book(&Reservation{HotelCode: "H1", RoomCode: "DBL", Arrival: date(2026, 11, 1), Departure: date(2026, 11, 3)})
+	if err != nil {
+		t.Fatalf("Book: %v", err)
+	}
+
+	// Moving past the pushed dates fails and keeps the reservation where it was
+	if _, err := sim.Modify(booked.ExternalID, date(2026, 11, 3), date(2026, 11, 6)); err == nil {
+		t.Fatal("moving to unpushed dates succeeded")
+	}
+	if item, _ := sim.Inventory("H1", "DBL", date(2026, 11, 1)); item.Available != 0 {
+		t.Errorf("availability of the kept night = %d, want 0", item.Available)
+	}
+
+	moved, err := sim.Modify(booked.ExternalID, date(2026, 11, 2), date(2026, 11, 5))
+	if err != nil {
+		t.Fatalf("Modify: %v", err)
+	}
+	if moved.Status != StatusModified || moved.TotalPrice != 300 || moved.Nights() != 3 {
+		t.Errorf("moved = %+v", moved)
+	}
+	if item, _ := sim.Inventory("H1", "DBL", date(2026, 11, 1)); item.Available != 1 {
+		t.Errorf("availability of the released night = %d, want 1", item.Available)
+	}
+	if item, _ := sim.Inventory("H1", "DBL", date(2026, 11, 4)); item.Available != 0 {
+		t.Errorf("availability of the new night = %d, want 0", item.Available)
+	}
+}
+
+func TestSimulatorPullsChangesSince(t *testing.T) {
+	sim := NewSimulator("ota")
+	clock := date(2026, 10, 1)
+	sim.now = func() time.Time { return clock }
+	pushNights(t, sim, "DBL", date(2026, 11, 1), 5, ARIItem{Available: 3, Rate: 100})
+
+	first, err := sim.Book(&Reservation{HotelCode: "H1", RoomCode: "DBL", Arrival: date(2026, 11, 1), Departure: date(2026, 11, 2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock = clock.Add(time.Hour)
+	second, err := sim.Book(&Reservation{HotelCode: "H1", RoomCode: "DBL", Arrival: date(2026, 11, 2), Departure: date(2026, 11, 3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.ExternalID != "OTA-000002" {
+		t.Errorf("ExternalID = %q", second.ExternalID)
+	}
+
+	pulled, err := sim.PullReservations(context.Background(), "H1", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pulled) != 2 || pulled[0].ExternalID != first.ExternalID || pulled[1].ExternalID != second.ExternalID {
+		t.Fatalf("pulled = %+v", pulled)
+	}
+
+	cursor := clock
+	clock = clock.Add(time.Hour)
+	if _, err := sim.Cancel(first.ExternalID); err != nil {
+		t.Fatal(err)
+	}
+
+	// A pull returns what changed at or after the cursor, and nothing of other hotels
+	pulled, err = sim.PullReservations(context.Background(), "H1", cursor.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pulled) != 1 || pulled[0].ExternalID != first.ExternalID || pulled[0].Status != StatusCancelled {
+		t.Errorf("pulled after cancelling = %+v", pulled)
+	}
+	if pulled, _ := sim.PullReservations(context.Background(), "H2", time.Time{}); len(pulled) != 0 {
+		t.Errorf("pulled for another hotel = %+v", pulled)
+	}
+}
+
+func TestSimulatorFailPushes(t *testing.T) {
+	sim := NewSimulator("")
+	sim.FailPushes(errors.New("channel unavailable"))
+
+	if err := sim.PushARI(context.Background(), &ARIUpdate{HotelCode: "H1"}); err == nil {
+		t.Fatal("push succeeded")
+	}
+
+	sim.FailPushes(nil)
+	pushNights(t, sim, "DBL", date(2026, 11, 1), 1, ARIItem{Available: 1})
+	if sim.Pushes() != 1 {
+		t.Errorf("Pushes = %d, want 1", sim.Pushes())
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(NewSimulator("ota-b"), NewSimulator(""))
+
+	if codes := registry.Codes(); len(codes) != 2 || codes[0] != "OTA-B" || codes[1] != SimulatorCode {
+		t.Errorf("Codes = %v", codes)
+	}
+	if provider, ok := registry.Provider(" ota-b "); !ok || provider.Code() != "OTA-B" {
+		t.Errorf("Provider(ota-b) = %v, %v", provider, ok)
+	}
+	if _, ok := registry.Provider("missing"); ok {
+		t.Error("found an unregistered channel")
+	}
+}
\ No newline at end of file
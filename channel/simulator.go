@@ -0,0 +1,278 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SimulatorCode is the code the simulator is registered under unless it is given another
+const SimulatorCode = "SIMULATOR"
+
+// Simulator is an in-memory channel for tests and local development. It keeps the ARI pushed to
+// it and sells against it the way a channel would: Book, Modify and Cancel play the part of
+// guests on the channel, and the reservations they make are returned by pulls.
+type Simulator struct {
+	code string
+	now  func() time.Time
+
+	mu           sync.Mutex
+	inventory    map[inventoryKey]ARIItem
+	reservations map[string]*Reservation
+	pushes       int
+	pushErr      error
+	lastID       int
+}
+
+type inventoryKey struct {
+	hotelCode string
+	roomCode  string
+	date      string
+}
+
+// NewSimulator returns an empty simulator registered under code, or under SimulatorCode when
+// code is empty
+func NewSimulator(code string) *Simulator {
+	if code == "" {
+		code = SimulatorCode
+	}
+
+	return &Simulator{
+		code:         NormalizeCode(code),
+		now:          time.Now,
+		inventory:    make(map[inventoryKey]ARIItem),
+		reservations: make(map[string]*Reservation),
+	}
+}
+
+func (s *Simulator) Code() string {
+	return s.code
+}
+
+// PushARI stores the pushed items, replacing what was pushed before for their room codes and dates
+func (s *Simulator) PushARI(ctx context.Context, update *ARIUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pushErr != nil {
+		return s.pushErr
+	}
+
+	for _, item := range update.Items {
+		stored := *item
+		stored.Date = Date(item.Date)
+		s.inventory[inventoryKey{hotelCode: update.HotelCode, roomCode: item.RoomCode, date: stored.Date.Format(DateLayout)}] = stored
+	}
+	s.pushes++
+	return nil
+}
+
+// PullReservations returns the hotel's reservations last changed at or after since, oldest first
+func (s *Simulator) PullReservations(ctx context.Context, hotelCode string, since time.Time) ([]*Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var reservations []*Reservation
+	for _, reservation := range s.reservations {
+		if reservation.HotelCode != hotelCode || reservation.UpdatedAt.Before(since) {
+			continue
+		}
+		pulled := *reservation
+		reservations = append(reservations, &pulled)
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		if !reservations[i].UpdatedAt.Equal(reservations[j].UpdatedAt) {
+			return reservations[i].UpdatedAt.Before(reservations[j].UpdatedAt)
+		}
+		return reservations[i].ExternalID < reservations[j].ExternalID
+	})
+	return reservations, nil
+}
+
+// Inventory returns the ARI last pushed for a room code on a date
+func (s *Simulator) Inventory(hotelCode, roomCode string, date time.Time) (ARIItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.inventory[inventoryKey{hotelCode: hotelCode, roomCode: roomCode, date: Date(date).Format(DateLayout)}]
+	return item, ok
+}
+
+// Pushes returns how many pushes the simulator has accepted
+func (s *Simulator) Pushes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pushes
+}
+
+// FailPushes makes every push fail with err, until FailPushes is called with nil
+func (s *Simulator) FailPushes(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pushErr = err
+}
+
+// Book makes a reservation on the channel against the ARI pushed to it. The reservation is given
+// an external ID unless it has one, and is priced at the pushed rates unless it has a price.
+func (s *Simulator) Book(reservation *Reservation) (*Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	booked := *reservation
+	booked.Arrival = Date(reservation.Arrival)
+	booked.Departure = Date(reservation.Departure)
+	if booked.ExternalID == "" {
+		s.lastID++
+		booked.ExternalID = fmt.Sprintf("%s-%06d", s.code, s.lastID)
+	}
+	if _, ok := s.reservations[booked.ExternalID]; ok {
+		return nil, fmt.Errorf("reservation %s already exists", booked.ExternalID)
+	}
+
+	total, err := s.take(&booked)
+	if err != nil {
+		return nil, err
+	}
+	if booked.TotalPrice == 0 {
+		booked.TotalPrice = total
+	}
+
+	now := s.now()
+	booked.Status = StatusBooked
+	booked.BookedAt = now
+	booked.UpdatedAt = now
+	s.reservations[booked.ExternalID] = &booked
+
+	result := booked
+	return &result, nil
+}
+
+// Modify moves a reservation to new dates and reprices it at the pushed rates. The reservation
+// keeps its dates if the new ones cannot be sold.
+func (s *Simulator) Modify(externalID string, arrival, departure time.Time) (*Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reservation, ok := s.reservations[externalID]
+	if !ok {
+		return nil, fmt.Errorf("reservation %s not found", externalID)
+	}
+	if reservation.Status == StatusCancelled {
+		return nil, fmt.Errorf("reservation %s is cancelled", externalID)
+	}
+
+	s.release(reservation)
+
+	moved := *reservation
+	moved.Arrival = Date(arrival)
+	moved.Departure = Date(departure)
+	total, err := s.take(&moved)
+	if err != nil {
+		// The old nights were free a moment ago, so taking them back cannot fail
+		_, _ = s.take(reservation)
+		return nil, err
+	}
+
+	moved.TotalPrice = total
+	moved.Status = StatusModified
+	moved.UpdatedAt = s.now()
+	*reservation = moved
+
+	result := moved
+	return &result, nil
+}
+
+// Cancel cancels a reservation and returns its nights to the channel's inventory
+func (s *Simulator) Cancel(externalID string) (*Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reservation, ok := s.reservations[externalID]
+	if !ok {
+		return nil, fmt.Errorf("reservation %s not found", externalID)
+	}
+	if reservation.Status == StatusCancelled {
+		return nil, fmt.Errorf("reservation %s is already cancelled", externalID)
+	}
+
+	s.release(reservation)
+	reservation.Status = StatusCancelled
+	reservation.UpdatedAt = s.now()
+
+	result := *reservation
+	return &result, nil
+}
+
+// take checks the reservation against the pushed restrictions and availability and takes a room
+// on each of its nights, returning its price at the pushed rates
+func (s *Simulator) take(reservation *Reservation) (int32, error) {
+	nights := reservation.Nights()
+	if nights < 1 {
+		return 0, errors.New("departure must be after arrival")
+	}
+
+	keys := make([]inventoryKey, 0, nights)
+	var total int32
+	for i := 0; i < nights; i++ {
+		key := inventoryKey{
+			hotelCode: reservation.HotelCode,
+			roomCode:  reservation.RoomCode,
+			date:      reservation.Arrival.AddDate(0, 0, i).Format(DateLayout),
+		}
+		item, ok := s.inventory[key]
+		if !ok {
+			return 0, fmt.Errorf("%s is not for sale on %s", reservation.RoomCode, key.date)
+		}
+		if item.StopSell || item.Available < 1 {
+			return 0, fmt.Errorf("%s is sold out on %s", reservation.RoomCode, key.date)
+		}
+
+		if i == 0 {
+			if item.ClosedToArrival {
+				return 0, fmt.Errorf("%s is closed to arrival on %s", reservation.RoomCode, key.date)
+			}
+			if item.MinNights > 0 && int32(nights) < item.MinNights {
+				return 0, fmt.Errorf("%s requires a stay of at least %d nights from %s", reservation.RoomCode, item.MinNights, key.date)
+			}
+			if item.MaxNights > 0 && int32(nights) > item.MaxNights {
+				return 0, fmt.Errorf("%s allows a stay of at most %d nights from %s", reservation.RoomCode, item.MaxNights, key.date)
+			}
+		}
+
+		keys = append(keys, key)
+		total += item.Rate
+	}
+
+	departure := inventoryKey{hotelCode: reservation.HotelCode, roomCode: reservation.RoomCode, date: reservation.Departure.Format(DateLayout)}
+	if item, ok := s.inventory[departure]; ok && item.ClosedToDeparture {
+		return 0, fmt.Errorf("%s is closed to departure on %s", reservation.RoomCode, departure.date)
+	}
+
+	for _, key := range keys {
+		item := s.inventory[key]
+		item.Available--
+		s.inventory[key] = item
+	}
+	return total, nil
+}
+
+// release returns the nights of a reservation to the channel's inventory
+func (s *Simulator) release(reservation *Reservation) {
+	for i := 0; i < reservation.Nights(); i++ {
+		key := inventoryKey{
+			hotelCode: reservation.HotelCode,
+			roomCode:  reservation.RoomCode,
+			date:      reservation.Arrival.AddDate(0, 0, i).Format(DateLayout),
+		}
+		if item, ok := s.inventory[key]; ok {
+			item.Available++
+			s.inventory[key] = item
+		}
+	}
+}
\ No newline at end of file
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -13,6 +15,29 @@ type Config struct {
 	ServerHost     string `mapstructure:"SERVER_HOST"`
 	HTTPServerPort string `mapstructure:"HTTP_SERVER_PORT"`
 	GRPCServerPort string `mapstructure:"GRPC_SERVER_PORT"`
+	// MagicLinkKeys is a comma-separated list of keys for signing magic links. The first key
+	// signs new links and every key verifies them, so keys can be rotated without breaking links.
+	MagicLinkKeys []string      `mapstructure:"MAGIC_LINK_KEYS"`
+	MagicLinkTTL  time.Duration `mapstructure:"MAGIC_LINK_TTL"`
+	// AuthUserHeader names the header an authenticating proxy in front of the API sets to the
+	// username of the caller. Requests acting for a user, such as modifying a reservation, are
+	// refused when it is not set. When it is set, routes acting on one reservation also refuse
+	// anonymous callers without a magic link.
+	AuthUserHeader string `mapstructure:"AUTH_USER_HEADER"`
+	// Guest emails are sent through SMTP_HOST when it is set, or else written to MAIL_DIR as .eml
+	// files when it is set. With neither, guests are not emailed.
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	MailFrom     string `mapstructure:"MAIL_FROM"`
+	MailDir      string `mapstructure:"MAIL_DIR"`
+	// ReminderDays is how many days before arrival guests are reminded of their stay
+	ReminderDays int    `mapstructure:"REMINDER_DAYS"`
+	ReviewURL    string `mapstructure:"REVIEW_URL"`
+	// ChannelSimulator registers the in-memory channel simulator, so channel connections can be
+	// tried out without an account on a real channel
+	ChannelSimulator bool `mapstructure:"CHANNEL_SIMULATOR"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -0,0 +1,13 @@
+ALTER TABLE IF EXISTS "reservation" DROP CONSTRAINT IF EXISTS reservation_promo_code_fkey;
+ALTER TABLE IF EXISTS "promo_redemption" DROP CONSTRAINT IF EXISTS promo_redemption_code_fkey;
+ALTER TABLE IF EXISTS "promo_redemption" DROP CONSTRAINT IF EXISTS promo_redemption_reservation_id_fkey;
+ALTER TABLE IF EXISTS "promo_redemption" DROP CONSTRAINT IF EXISTS promo_redemption_user_id_fkey;
+ALTER TABLE IF EXISTS "promo_code" DROP CONSTRAINT IF EXISTS promo_code_hotel_id_fkey;
+ALTER TABLE IF EXISTS "promo_code" DROP CONSTRAINT IF EXISTS promo_code_type_id_fkey;
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "discount_amount";
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "promo_code";
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "total_price";
+
+DROP TABLE IF EXISTS "promo_redemption";
+DROP TABLE IF EXISTS "promo_code";
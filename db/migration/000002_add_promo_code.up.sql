@@ -0,0 +1,52 @@
+CREATE TABLE "promo_code" (
+  "code" varchar PRIMARY KEY,
+  "description" varchar,
+  "discount_type" varchar,
+  "discount_value" integer,
+  "valid_from" TIMESTAMPTZ,
+  "valid_to" TIMESTAMPTZ,
+  "stay_from" TIMESTAMPTZ,
+  "stay_to" TIMESTAMPTZ,
+  "min_nights" integer,
+  "hotel_id" uuid,
+  "type_id" varchar,
+  "max_redemptions" integer,
+  "max_redemptions_per_user" integer,
+  "redemption_count" integer DEFAULT 0,
+  "is_active" boolean DEFAULT true,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+CREATE TABLE "promo_redemption" (
+  "redemption_id" uuid PRIMARY KEY,
+  "code" varchar,
+  "reservation_id" uuid,
+  "user_id" varchar,
+  "discount_amount" integer,
+  "created_at" TIMESTAMPTZ
+);
+
+ALTER TABLE "reservation" ADD COLUMN "total_price" integer;
+
+ALTER TABLE "reservation" ADD COLUMN "promo_code" varchar;
+
+ALTER TABLE "reservation" ADD COLUMN "discount_amount" integer;
+
+ALTER TABLE "promo_code" ADD FOREIGN KEY ("hotel_id") REFERENCES "hotel" ("hotel_id");
+
+ALTER TABLE "promo_code" ADD FOREIGN KEY ("type_id") REFERENCES "type" ("type_code");
+
+ALTER TABLE "promo_redemption" ADD FOREIGN KEY ("code") REFERENCES "promo_code" ("code");
+
+ALTER TABLE "promo_redemption" ADD FOREIGN KEY ("reservation_id") REFERENCES "reservation" ("reservation_id");
+
+ALTER TABLE "promo_redemption" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("username");
+
+ALTER TABLE "reservation" ADD FOREIGN KEY ("promo_code") REFERENCES "promo_code" ("code");
+
+CREATE UNIQUE INDEX ON "promo_redemption" ("reservation_id");
+
+CREATE INDEX ON "promo_redemption" ("code", "user_id");
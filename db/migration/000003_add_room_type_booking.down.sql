@@ -0,0 +1,8 @@
+ALTER TABLE IF EXISTS "reservation" DROP CONSTRAINT IF EXISTS reservation_hotel_id_fkey;
+ALTER TABLE IF EXISTS "reservation" DROP CONSTRAINT IF EXISTS reservation_type_id_fkey;
+
+DROP INDEX IF EXISTS reservation_hotel_id_type_id_start_date_end_date_idx;
+DROP INDEX IF EXISTS room_hotel_id_type_id_idx;
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "type_id";
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "hotel_id";
@@ -0,0 +1,17 @@
+ALTER TABLE "reservation" ADD COLUMN "hotel_id" uuid;
+
+ALTER TABLE "reservation" ADD COLUMN "type_id" varchar;
+
+-- Existing reservations were always made against a concrete room
+UPDATE "reservation" res
+SET hotel_id = r.hotel_id, type_id = r.type_id
+FROM "room" r
+WHERE res.room_id = r.room_id;
+
+ALTER TABLE "reservation" ADD FOREIGN KEY ("hotel_id") REFERENCES "hotel" ("hotel_id");
+
+ALTER TABLE "reservation" ADD FOREIGN KEY ("type_id") REFERENCES "type" ("type_code");
+
+CREATE INDEX ON "reservation" ("hotel_id", "type_id", "start_date", "end_date");
+
+CREATE INDEX ON "room" ("hotel_id", "type_id");
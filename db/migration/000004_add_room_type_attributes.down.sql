@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS "type" DROP COLUMN IF EXISTS "base_occupancy";
+ALTER TABLE IF EXISTS "type" DROP COLUMN IF EXISTS "default_capacity";
+ALTER TABLE IF EXISTS "type" DROP COLUMN IF EXISTS "size_sqm";
+ALTER TABLE IF EXISTS "type" DROP COLUMN IF EXISTS "bed_configuration";
+ALTER TABLE IF EXISTS "type" DROP COLUMN IF EXISTS "name";
@@ -0,0 +1,9 @@
+ALTER TABLE "type" ADD COLUMN "name" varchar;
+
+ALTER TABLE "type" ADD COLUMN "bed_configuration" varchar;
+
+ALTER TABLE "type" ADD COLUMN "size_sqm" integer;
+
+ALTER TABLE "type" ADD COLUMN "default_capacity" integer;
+
+ALTER TABLE "type" ADD COLUMN "base_occupancy" integer;
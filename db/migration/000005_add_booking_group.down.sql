@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS "reservation" DROP CONSTRAINT IF EXISTS reservation_group_id_fkey;
+
+DROP INDEX IF EXISTS reservation_group_id_idx;
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "group_id";
+
+DROP TABLE IF EXISTS "booking_group";
@@ -0,0 +1,23 @@
+CREATE TABLE "booking_group" (
+  "group_id" uuid PRIMARY KEY,
+  "user_id" varchar,
+  "contact_name" varchar,
+  "contact_email" varchar,
+  "contact_phone" varchar,
+  "start_date" TIMESTAMPTZ,
+  "end_date" TIMESTAMPTZ,
+  "status" varchar,
+  "total_price" integer,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+ALTER TABLE "reservation" ADD COLUMN "group_id" uuid;
+
+ALTER TABLE "booking_group" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("username");
+
+ALTER TABLE "reservation" ADD FOREIGN KEY ("group_id") REFERENCES "booking_group" ("group_id");
+
+CREATE INDEX ON "reservation" ("group_id");
@@ -0,0 +1,24 @@
+CREATE TABLE "reservation_modification" (
+  "modification_id" uuid PRIMARY KEY,
+  "reservation_id" uuid,
+  "modified_by" varchar,
+  "modified_by_role" varchar,
+  "reason" varchar,
+  "old_room_id" uuid,
+  "new_room_id" uuid,
+  "old_user_id" varchar,
+  "new_user_id" varchar,
+  "old_start_date" TIMESTAMPTZ,
+  "new_start_date" TIMESTAMPTZ,
+  "old_end_date" TIMESTAMPTZ,
+  "new_end_date" TIMESTAMPTZ,
+  "old_total_price" integer,
+  "new_total_price" integer,
+  "created_at" TIMESTAMPTZ
+);
+
+ALTER TABLE "reservation_modification" ADD FOREIGN KEY ("reservation_id") REFERENCES "reservation" ("reservation_id");
+
+ALTER TABLE "reservation_modification" ADD FOREIGN KEY ("modified_by") REFERENCES "user" ("username");
+
+CREATE INDEX ON "reservation_modification" ("reservation_id", "created_at");
@@ -0,0 +1,16 @@
+CREATE TABLE "room_block" (
+  "block_id" uuid PRIMARY KEY,
+  "room_id" uuid,
+  "block_type" varchar,
+  "reason" varchar,
+  "start_date" TIMESTAMPTZ,
+  "end_date" TIMESTAMPTZ,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+ALTER TABLE "room_block" ADD FOREIGN KEY ("room_id") REFERENCES "room" ("room_id");
+
+CREATE INDEX ON "room_block" ("room_id", "start_date", "end_date");
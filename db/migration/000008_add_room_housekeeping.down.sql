@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "room" DROP COLUMN IF EXISTS "housekeeping_updated_at";
+ALTER TABLE IF EXISTS "room" DROP COLUMN IF EXISTS "housekeeping_status";
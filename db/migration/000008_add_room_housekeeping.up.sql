@@ -0,0 +1,5 @@
+ALTER TABLE "room" ADD COLUMN "housekeeping_status" varchar DEFAULT 'CLEAN';
+
+ALTER TABLE "room" ADD COLUMN "housekeeping_updated_at" TIMESTAMPTZ;
+
+UPDATE "room" SET housekeeping_status = 'CLEAN' WHERE housekeeping_status IS NULL;
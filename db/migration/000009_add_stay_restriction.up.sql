@@ -0,0 +1,21 @@
+CREATE TABLE "stay_restriction" (
+  "restriction_id" uuid PRIMARY KEY,
+  "hotel_id" uuid,
+  "type_id" varchar,
+  "start_date" TIMESTAMPTZ,
+  "end_date" TIMESTAMPTZ,
+  "min_nights" integer,
+  "max_nights" integer,
+  "closed_to_arrival" boolean DEFAULT false,
+  "closed_to_departure" boolean DEFAULT false,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+ALTER TABLE "stay_restriction" ADD FOREIGN KEY ("hotel_id") REFERENCES "hotel" ("hotel_id");
+
+ALTER TABLE "stay_restriction" ADD FOREIGN KEY ("type_id") REFERENCES "type" ("type_code");
+
+CREATE INDEX ON "stay_restriction" ("hotel_id", "start_date", "end_date");
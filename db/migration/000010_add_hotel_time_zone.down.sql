@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "hotel" DROP COLUMN IF EXISTS "check_out_time";
+ALTER TABLE IF EXISTS "hotel" DROP COLUMN IF EXISTS "check_in_time";
+ALTER TABLE IF EXISTS "hotel" DROP COLUMN IF EXISTS "time_zone";
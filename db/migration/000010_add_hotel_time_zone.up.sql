@@ -0,0 +1,10 @@
+ALTER TABLE "hotel" ADD COLUMN "time_zone" varchar DEFAULT 'UTC';
+
+ALTER TABLE "hotel" ADD COLUMN "check_in_time" varchar DEFAULT '14:00';
+
+ALTER TABLE "hotel" ADD COLUMN "check_out_time" varchar DEFAULT '12:00';
+
+UPDATE "hotel"
+SET time_zone = COALESCE(time_zone, 'UTC'),
+    check_in_time = COALESCE(check_in_time, '14:00'),
+    check_out_time = COALESCE(check_out_time, '12:00');
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS reservation_room_id_start_date_end_date_idx;
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "stay_type";
+ALTER TABLE IF EXISTS "room" DROP COLUMN IF EXISTS "day_use_price";
+ALTER TABLE IF EXISTS "room" DROP COLUMN IF EXISTS "day_use";
+ALTER TABLE IF EXISTS "hotel" DROP COLUMN IF EXISTS "day_use_buffer_minutes";
+ALTER TABLE IF EXISTS "hotel" DROP COLUMN IF EXISTS "day_use_slot_minutes";
+ALTER TABLE IF EXISTS "hotel" DROP COLUMN IF EXISTS "day_use_end_time";
+ALTER TABLE IF EXISTS "hotel" DROP COLUMN IF EXISTS "day_use_start_time";
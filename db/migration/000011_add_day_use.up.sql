@@ -0,0 +1,25 @@
+ALTER TABLE "hotel" ADD COLUMN "day_use_start_time" varchar DEFAULT '09:00';
+
+ALTER TABLE "hotel" ADD COLUMN "day_use_end_time" varchar DEFAULT '18:00';
+
+ALTER TABLE "hotel" ADD COLUMN "day_use_slot_minutes" integer DEFAULT 60;
+
+ALTER TABLE "hotel" ADD COLUMN "day_use_buffer_minutes" integer DEFAULT 0;
+
+ALTER TABLE "room" ADD COLUMN "day_use" boolean DEFAULT false;
+
+ALTER TABLE "room" ADD COLUMN "day_use_price" integer;
+
+ALTER TABLE "reservation" ADD COLUMN "stay_type" varchar DEFAULT 'OVERNIGHT';
+
+UPDATE "hotel"
+SET day_use_start_time = COALESCE(day_use_start_time, '09:00'),
+    day_use_end_time = COALESCE(day_use_end_time, '18:00'),
+    day_use_slot_minutes = COALESCE(day_use_slot_minutes, 60),
+    day_use_buffer_minutes = COALESCE(day_use_buffer_minutes, 0);
+
+UPDATE "room" SET day_use = false WHERE day_use IS NULL;
+
+UPDATE "reservation" SET stay_type = 'OVERNIGHT' WHERE stay_type IS NULL;
+
+CREATE INDEX ON "reservation" ("room_id", "start_date", "end_date");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS reservation_status_hold_expires_at_idx;
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "hold_expires_at";
+
+DROP TABLE IF EXISTS "waitlist_entry";
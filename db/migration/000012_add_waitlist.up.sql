@@ -0,0 +1,38 @@
+CREATE TABLE "waitlist_entry" (
+  "entry_id" uuid PRIMARY KEY,
+  "hotel_id" uuid,
+  "type_id" varchar,
+  "room_id" uuid,
+  "user_id" varchar,
+  "start_date" TIMESTAMPTZ,
+  "end_date" TIMESTAMPTZ,
+  "guests" integer,
+  "contact_name" varchar,
+  "contact_email" varchar,
+  "contact_phone" varchar,
+  "status" varchar DEFAULT 'WAITING',
+  "reservation_id" uuid,
+  "offer_expires_at" TIMESTAMPTZ,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+ALTER TABLE "reservation" ADD COLUMN "hold_expires_at" TIMESTAMPTZ;
+
+ALTER TABLE "waitlist_entry" ADD FOREIGN KEY ("hotel_id") REFERENCES "hotel" ("hotel_id");
+
+ALTER TABLE "waitlist_entry" ADD FOREIGN KEY ("type_id") REFERENCES "type" ("type_code");
+
+ALTER TABLE "waitlist_entry" ADD FOREIGN KEY ("room_id") REFERENCES "room" ("room_id");
+
+ALTER TABLE "waitlist_entry" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("username");
+
+ALTER TABLE "waitlist_entry" ADD FOREIGN KEY ("reservation_id") REFERENCES "reservation" ("reservation_id");
+
+CREATE INDEX ON "waitlist_entry" ("hotel_id", "status", "created_at");
+
+CREATE INDEX ON "waitlist_entry" ("reservation_id");
+
+CREATE INDEX ON "reservation" ("status", "hold_expires_at");
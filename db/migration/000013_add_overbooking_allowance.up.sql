@@ -0,0 +1,18 @@
+CREATE TABLE "overbooking_allowance" (
+  "allowance_id" uuid PRIMARY KEY,
+  "hotel_id" uuid,
+  "type_id" varchar,
+  "start_date" TIMESTAMPTZ,
+  "end_date" TIMESTAMPTZ,
+  "overbook_percent" integer,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+ALTER TABLE "overbooking_allowance" ADD FOREIGN KEY ("hotel_id") REFERENCES "hotel" ("hotel_id");
+
+ALTER TABLE "overbooking_allowance" ADD FOREIGN KEY ("type_id") REFERENCES "type" ("type_code");
+
+CREATE INDEX ON "overbooking_allowance" ("hotel_id", "type_id", "start_date", "end_date");
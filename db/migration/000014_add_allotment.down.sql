@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS reservation_block_code_idx;
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "block_code";
+
+DROP TABLE IF EXISTS "allotment";
@@ -0,0 +1,32 @@
+CREATE TABLE "allotment" (
+  "allotment_id" uuid PRIMARY KEY,
+  "block_code" varchar UNIQUE NOT NULL,
+  "name" varchar,
+  "hotel_id" uuid,
+  "type_id" varchar,
+  "start_date" TIMESTAMPTZ,
+  "end_date" TIMESTAMPTZ,
+  "quantity" integer,
+  "rate" integer,
+  "release_date" TIMESTAMPTZ,
+  "status" varchar DEFAULT 'ACTIVE',
+  "released_at" TIMESTAMPTZ,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+ALTER TABLE "reservation" ADD COLUMN "block_code" varchar;
+
+ALTER TABLE "allotment" ADD FOREIGN KEY ("hotel_id") REFERENCES "hotel" ("hotel_id");
+
+ALTER TABLE "allotment" ADD FOREIGN KEY ("type_id") REFERENCES "type" ("type_code");
+
+ALTER TABLE "reservation" ADD FOREIGN KEY ("block_code") REFERENCES "allotment" ("block_code");
+
+CREATE INDEX ON "allotment" ("hotel_id", "type_id", "start_date", "end_date");
+
+CREATE INDEX ON "allotment" ("status", "release_date");
+
+CREATE INDEX ON "reservation" ("block_code");
@@ -0,0 +1,24 @@
+CREATE TABLE "reservation_segment" (
+  "segment_id" uuid PRIMARY KEY,
+  "reservation_id" uuid NOT NULL,
+  "room_id" uuid NOT NULL,
+  "start_date" TIMESTAMPTZ NOT NULL,
+  "end_date" TIMESTAMPTZ NOT NULL,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid
+);
+
+ALTER TABLE "reservation_segment" ADD FOREIGN KEY ("reservation_id") REFERENCES "reservation" ("reservation_id") ON DELETE CASCADE;
+
+ALTER TABLE "reservation_segment" ADD FOREIGN KEY ("room_id") REFERENCES "room" ("room_id");
+
+CREATE INDEX ON "reservation_segment" ("reservation_id", "start_date");
+
+CREATE INDEX ON "reservation_segment" ("room_id", "start_date", "end_date");
+
+INSERT INTO "reservation_segment" ("segment_id", "reservation_id", "room_id", "start_date", "end_date", "created_at", "created_by")
+SELECT gen_random_uuid(), "reservation_id", "room_id", "start_date", "end_date", "created_at", "created_by"
+FROM "reservation"
+WHERE "room_id" IS NOT NULL
+  AND "start_date" IS NOT NULL
+  AND "end_date" IS NOT NULL;
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS "reservation_guest";
+
+ALTER TABLE IF EXISTS "type" DROP COLUMN IF EXISTS "extra_child_price";
+
+ALTER TABLE IF EXISTS "type" DROP COLUMN IF EXISTS "extra_adult_price";
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "extra_person_charge";
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "child_ages";
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "children";
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "adults";
@@ -0,0 +1,28 @@
+ALTER TABLE "reservation" ADD COLUMN "adults" integer DEFAULT 1;
+
+ALTER TABLE "reservation" ADD COLUMN "children" integer DEFAULT 0;
+
+ALTER TABLE "reservation" ADD COLUMN "child_ages" integer[];
+
+ALTER TABLE "reservation" ADD COLUMN "extra_person_charge" integer;
+
+ALTER TABLE "type" ADD COLUMN "extra_adult_price" integer;
+
+ALTER TABLE "type" ADD COLUMN "extra_child_price" integer;
+
+CREATE TABLE "reservation_guest" (
+  "guest_id" uuid PRIMARY KEY,
+  "reservation_id" uuid NOT NULL,
+  "first_name" varchar,
+  "last_name" varchar,
+  "age" integer,
+  "is_child" boolean DEFAULT false,
+  "is_primary" boolean DEFAULT false,
+  "email" varchar,
+  "phone" varchar,
+  "created_at" TIMESTAMPTZ
+);
+
+ALTER TABLE "reservation_guest" ADD FOREIGN KEY ("reservation_id") REFERENCES "reservation" ("reservation_id") ON DELETE CASCADE;
+
+CREATE INDEX ON "reservation_guest" ("reservation_id");
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "reservation_extra";
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "special_requests";
+
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "extras_amount";
+
+DROP TABLE IF EXISTS "extra";
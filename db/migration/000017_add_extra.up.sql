@@ -0,0 +1,41 @@
+CREATE TABLE "extra" (
+  "extra_id" uuid PRIMARY KEY,
+  "hotel_id" uuid,
+  "code" varchar,
+  "name" varchar,
+  "description" varchar,
+  "pricing" varchar,
+  "price" integer,
+  "is_active" boolean DEFAULT true,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+CREATE TABLE "reservation_extra" (
+  "reservation_extra_id" uuid PRIMARY KEY,
+  "reservation_id" uuid NOT NULL,
+  "extra_id" uuid NOT NULL,
+  "pricing" varchar,
+  "quantity" integer NOT NULL,
+  "unit_price" integer,
+  "amount" integer,
+  "created_at" TIMESTAMPTZ
+);
+
+ALTER TABLE "reservation" ADD COLUMN "extras_amount" integer;
+
+ALTER TABLE "reservation" ADD COLUMN "special_requests" varchar;
+
+ALTER TABLE "extra" ADD FOREIGN KEY ("hotel_id") REFERENCES "hotel" ("hotel_id");
+
+ALTER TABLE "reservation_extra" ADD FOREIGN KEY ("reservation_id") REFERENCES "reservation" ("reservation_id") ON DELETE CASCADE;
+
+ALTER TABLE "reservation_extra" ADD FOREIGN KEY ("extra_id") REFERENCES "extra" ("extra_id");
+
+CREATE UNIQUE INDEX ON "extra" ("hotel_id", "code");
+
+CREATE INDEX ON "reservation_extra" ("reservation_id");
+
+CREATE INDEX ON "reservation_extra" ("extra_id");
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "confirmation_code";
@@ -0,0 +1,7 @@
+ALTER TABLE "reservation" ADD COLUMN "confirmation_code" varchar;
+
+UPDATE "reservation"
+SET "confirmation_code" = upper(substr(replace(gen_random_uuid()::text, '-', ''), 1, 10))
+WHERE "confirmation_code" IS NULL;
+
+CREATE UNIQUE INDEX ON "reservation" ("confirmation_code");
@@ -0,0 +1,17 @@
+CREATE TABLE "outbox_event" (
+  "event_id" uuid PRIMARY KEY,
+  "event_type" varchar NOT NULL,
+  "aggregate_type" varchar NOT NULL,
+  "aggregate_id" uuid NOT NULL,
+  "hotel_id" uuid,
+  "payload" jsonb NOT NULL,
+  "occurred_at" TIMESTAMPTZ NOT NULL,
+  "attempts" integer NOT NULL DEFAULT 0,
+  "next_attempt_at" TIMESTAMPTZ,
+  "last_error" varchar,
+  "dispatched_at" TIMESTAMPTZ
+);
+
+CREATE INDEX ON "outbox_event" ("occurred_at") WHERE "dispatched_at" IS NULL;
+
+CREATE INDEX ON "outbox_event" ("aggregate_id");
@@ -0,0 +1,40 @@
+CREATE TABLE "webhook_subscription" (
+  "subscription_id" uuid PRIMARY KEY,
+  "url" varchar NOT NULL,
+  "event_types" varchar[],
+  "hotel_id" uuid,
+  "secret" varchar NOT NULL,
+  "description" varchar,
+  "is_active" boolean DEFAULT true,
+  "consecutive_failures" integer NOT NULL DEFAULT 0,
+  "disabled_at" TIMESTAMPTZ,
+  "disabled_reason" varchar,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+CREATE TABLE "webhook_delivery" (
+  "delivery_id" uuid PRIMARY KEY,
+  "subscription_id" uuid NOT NULL,
+  "event_id" uuid NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL,
+  "attempts" integer NOT NULL DEFAULT 0,
+  "next_attempt_at" TIMESTAMPTZ,
+  "last_attempt_at" TIMESTAMPTZ,
+  "response_status" integer,
+  "last_error" varchar,
+  "created_at" TIMESTAMPTZ NOT NULL,
+  "delivered_at" TIMESTAMPTZ
+);
+
+ALTER TABLE "webhook_subscription" ADD FOREIGN KEY ("hotel_id") REFERENCES "hotel" ("hotel_id");
+
+ALTER TABLE "webhook_delivery" ADD FOREIGN KEY ("subscription_id") REFERENCES "webhook_subscription" ("subscription_id") ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX ON "webhook_delivery" ("subscription_id", "event_id");
+
+CREATE INDEX ON "webhook_delivery" ("next_attempt_at") WHERE "status" = 'PENDING';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "notification";
+
+ALTER TABLE IF EXISTS "reservation_guest" DROP COLUMN IF EXISTS "locale";
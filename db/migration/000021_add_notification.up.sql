@@ -0,0 +1,18 @@
+ALTER TABLE "reservation_guest" ADD COLUMN "locale" varchar;
+
+CREATE TABLE "notification" (
+  "notification_id" uuid PRIMARY KEY,
+  "reservation_id" uuid NOT NULL,
+  "kind" varchar NOT NULL,
+  "locale" varchar NOT NULL,
+  "recipient" varchar NOT NULL,
+  "subject" varchar,
+  "status" varchar NOT NULL,
+  "last_error" varchar,
+  "created_at" TIMESTAMPTZ NOT NULL,
+  "sent_at" TIMESTAMPTZ
+);
+
+ALTER TABLE "notification" ADD FOREIGN KEY ("reservation_id") REFERENCES "reservation" ("reservation_id") ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX ON "notification" ("reservation_id", "kind");
@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS "room_block" DROP COLUMN IF EXISTS "external_uid";
+ALTER TABLE IF EXISTS "room_block" DROP COLUMN IF EXISTS "feed_id";
+
+DROP TABLE IF EXISTS "ical_export";
+
+DROP TABLE IF EXISTS "ical_feed";
@@ -0,0 +1,35 @@
+CREATE TABLE "ical_feed" (
+  "feed_id" uuid PRIMARY KEY,
+  "room_id" uuid NOT NULL,
+  "url" varchar NOT NULL,
+  "name" varchar,
+  "is_active" boolean NOT NULL DEFAULT true,
+  "last_attempt_at" TIMESTAMPTZ,
+  "last_synced_at" TIMESTAMPTZ,
+  "last_error" varchar,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+CREATE TABLE "ical_export" (
+  "room_id" uuid PRIMARY KEY,
+  "token_hash" varchar NOT NULL,
+  "created_at" TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE "room_block" ADD COLUMN "feed_id" uuid;
+ALTER TABLE "room_block" ADD COLUMN "external_uid" varchar;
+
+ALTER TABLE "ical_feed" ADD FOREIGN KEY ("room_id") REFERENCES "room" ("room_id") ON DELETE CASCADE;
+
+ALTER TABLE "ical_export" ADD FOREIGN KEY ("room_id") REFERENCES "room" ("room_id") ON DELETE CASCADE;
+
+ALTER TABLE "room_block" ADD FOREIGN KEY ("feed_id") REFERENCES "ical_feed" ("feed_id") ON DELETE CASCADE;
+
+CREATE INDEX ON "ical_feed" ("room_id");
+
+CREATE INDEX ON "ical_feed" ("is_active", "last_attempt_at");
+
+CREATE UNIQUE INDEX ON "room_block" ("feed_id", "external_uid");
@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "external_id";
+ALTER TABLE IF EXISTS "reservation" DROP COLUMN IF EXISTS "source";
+
+DROP TABLE IF EXISTS "channel_mapping";
+
+DROP TABLE IF EXISTS "channel_connection";
@@ -0,0 +1,53 @@
+CREATE TABLE "channel_connection" (
+  "connection_id" uuid PRIMARY KEY,
+  "hotel_id" uuid NOT NULL,
+  "channel" varchar NOT NULL,
+  "hotel_code" varchar NOT NULL,
+  "is_active" boolean NOT NULL DEFAULT true,
+  "last_pushed_at" TIMESTAMPTZ,
+  "push_error" varchar,
+  "last_pulled_at" TIMESTAMPTZ,
+  "pull_error" varchar,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  "update_at" TIMESTAMPTZ,
+  "update_by" uuid
+);
+
+CREATE TABLE "channel_mapping" (
+  "mapping_id" uuid PRIMARY KEY,
+  "connection_id" uuid NOT NULL,
+  "type_id" varchar,
+  "room_id" uuid,
+  "room_code" varchar NOT NULL,
+  "rate_code" varchar,
+  "created_at" TIMESTAMPTZ,
+  "created_by" uuid,
+  CHECK (("type_id" IS NULL) != ("room_id" IS NULL))
+);
+
+ALTER TABLE "reservation" ADD COLUMN "source" varchar DEFAULT 'DIRECT';
+
+ALTER TABLE "reservation" ADD COLUMN "external_id" varchar;
+
+UPDATE "reservation" SET source = 'DIRECT' WHERE source IS NULL;
+
+ALTER TABLE "channel_connection" ADD FOREIGN KEY ("hotel_id") REFERENCES "hotel" ("hotel_id") ON DELETE CASCADE;
+
+ALTER TABLE "channel_mapping" ADD FOREIGN KEY ("connection_id") REFERENCES "channel_connection" ("connection_id") ON DELETE CASCADE;
+
+ALTER TABLE "channel_mapping" ADD FOREIGN KEY ("type_id") REFERENCES "type" ("type_code");
+
+ALTER TABLE "channel_mapping" ADD FOREIGN KEY ("room_id") REFERENCES "room" ("room_id") ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX ON "channel_connection" ("channel", "hotel_code");
+
+CREATE INDEX ON "channel_connection" ("hotel_id");
+
+CREATE UNIQUE INDEX ON "channel_mapping" ("connection_id", "room_code");
+
+CREATE UNIQUE INDEX ON "channel_mapping" ("connection_id", "type_id") WHERE "type_id" IS NOT NULL;
+
+CREATE UNIQUE INDEX ON "channel_mapping" ("connection_id", "room_id") WHERE "room_id" IS NOT NULL;
+
+CREATE UNIQUE INDEX ON "reservation" ("source", "external_id") WHERE "external_id" IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "notification" DROP COLUMN IF EXISTS "claimed_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "notification" ADD COLUMN "claimed_at" TIMESTAMPTZ;
+
+UPDATE "notification" SET "claimed_at" = "created_at";
+
+ALTER TABLE "notification" ALTER COLUMN "claimed_at" SET NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "channel_connection" DROP COLUMN IF EXISTS "push_due_at";
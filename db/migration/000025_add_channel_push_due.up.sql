@@ -0,0 +1 @@
+ALTER TABLE "channel_connection" ADD COLUMN "push_due_at" TIMESTAMPTZ;
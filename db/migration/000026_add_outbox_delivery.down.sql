@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "outbox_delivery";
+ALTER TABLE IF EXISTS "outbox_event" DROP COLUMN IF EXISTS "failed_at";
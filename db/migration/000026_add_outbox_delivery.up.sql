@@ -0,0 +1,8 @@
+ALTER TABLE "outbox_event" ADD COLUMN "failed_at" TIMESTAMPTZ;
+
+CREATE TABLE "outbox_delivery" (
+  "event_id" uuid NOT NULL REFERENCES "outbox_event" ("event_id") ON DELETE CASCADE,
+  "sink" varchar NOT NULL,
+  "delivered_at" TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY ("event_id", "sink")
+);
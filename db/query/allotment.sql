@@ -0,0 +1,79 @@
+-- name: CreateAllotment :one
+INSERT INTO allotment (
+  allotment_id,
+  block_code,
+  name,
+  hotel_id,
+  type_id,
+  start_date,
+  end_date,
+  quantity,
+  rate,
+  release_date,
+  status,
+  created_at,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING *;
+
+-- name: GetAllotmentForUpdate :one
+SELECT * FROM allotment
+WHERE allotment_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetAllotmentByCodeForUpdate :one
+SELECT * FROM allotment
+WHERE block_code = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: UpdateAllotment :one
+UPDATE allotment
+SET
+  name = $2,
+  start_date = $3,
+  end_date = $4,
+  quantity = $5,
+  rate = $6,
+  release_date = $7,
+  update_at = $8,
+  update_by = $9
+WHERE allotment_id = $1
+RETURNING *;
+
+-- name: UpdateAllotmentStatus :one
+UPDATE allotment
+SET
+  status = $2,
+  released_at = $3,
+  update_at = $4
+WHERE allotment_id = $1
+RETURNING *;
+
+-- name: ListDueAllotments :many
+-- Active allotments whose release date has passed, oldest cutoff first
+SELECT * FROM allotment
+WHERE status = 'ACTIVE'
+  AND release_date <= sqlc.arg(now)::timestamptz
+ORDER BY release_date;
+
+-- name: GetMaxNightlyAllotmentPickup :one
+-- Bookings made against the block code on the busiest night of the range
+SELECT COALESCE(MAX(n.picked_up), 0)::int AS max_picked_up
+FROM (
+  SELECT (
+    SELECT COUNT(*)
+    FROM reservation res
+    WHERE res.block_code = sqlc.arg(block_code)
+      AND res.reservation_id != sqlc.arg(exclude_reservation_id)
+      AND res.status != 'CANCELLED'
+      AND res.start_date < LEAST(d.night + INTERVAL '1 day', sqlc.arg(end_date)::timestamptz)
+      AND res.end_date > d.night
+  ) AS picked_up
+  FROM generate_series(sqlc.arg(start_date)::timestamptz, sqlc.arg(end_date)::timestamptz - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+) n;
+
+-- name: CountAllotmentReservations :one
+SELECT COUNT(*) FROM reservation
+WHERE block_code = $1
+  AND status != 'CANCELLED';
\ No newline at end of file
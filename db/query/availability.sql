@@ -0,0 +1,2 @@
+-- name: NotifyAvailabilityChange :exec
+SELECT pg_notify('availability_change', sqlc.arg(payload)::text);
\ No newline at end of file
@@ -0,0 +1,46 @@
+-- name: CreateBookingGroup :one
+INSERT INTO booking_group (
+  group_id,
+  user_id,
+  contact_name,
+  contact_email,
+  contact_phone,
+  start_date,
+  end_date,
+  status,
+  total_price,
+  created_at,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetBookingGroup :one
+SELECT * FROM booking_group
+WHERE group_id = $1 LIMIT 1;
+
+-- name: GetBookingGroupForUpdate :one
+SELECT * FROM booking_group
+WHERE group_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: UpdateBookingGroupStatus :one
+UPDATE booking_group
+SET
+  status = $2,
+  update_at = $3,
+  update_by = $4
+WHERE group_id = $1
+RETURNING *;
+
+-- name: ListReservationsByGroupForUpdate :many
+SELECT * FROM reservation
+WHERE group_id = $1
+ORDER BY created_at
+FOR UPDATE;
+
+-- name: UpdateBookingGroupTotalPrice :one
+UPDATE booking_group
+SET total_price = $2
+WHERE group_id = $1
+RETURNING *;
\ No newline at end of file
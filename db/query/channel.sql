@@ -0,0 +1,147 @@
+-- name: MarkHotelChannelsDue :exec
+-- Makes every active connection of a hotel due a full push on the next pass
+UPDATE channel_connection
+SET push_due_at = $2
+WHERE hotel_id = $1
+  AND is_active = true;
+
+-- name: MarkChannelPushDue :exec
+UPDATE channel_connection
+SET push_due_at = $2
+WHERE connection_id = $1;
+
+-- name: ListDueChannelPulls :many
+SELECT * FROM channel_connection
+WHERE is_active = true
+  AND (last_pulled_at IS NULL OR last_pulled_at <= sqlc.arg(pulled_before))
+ORDER BY last_pulled_at NULLS FIRST, connection_id
+LIMIT sqlc.arg(max_connections);
+
+-- name: ListDueChannelPushes :many
+-- Connections due a full push: never pushed, last pushed before pushed_before, marked due since
+-- their last push, or whose last push failed
+SELECT * FROM channel_connection
+WHERE is_active = true
+  AND (last_pushed_at IS NULL OR last_pushed_at <= sqlc.arg(pushed_before) OR push_due_at IS NOT NULL OR push_error IS NOT NULL)
+ORDER BY last_pushed_at NULLS FIRST, connection_id
+LIMIT sqlc.arg(max_connections);
+
+-- name: ListChannelMappingsByConnection :many
+SELECT * FROM channel_mapping
+WHERE connection_id = $1
+ORDER BY room_code;
+
+-- name: MarkChannelPushed :exec
+-- Clears the push due mark only if it is still the one read before the push, so changes marked
+-- while the push ran are pushed again
+UPDATE channel_connection
+SET
+  last_pushed_at = sqlc.arg(last_pushed_at),
+  push_error = NULL,
+  push_due_at = CASE WHEN push_due_at = sqlc.narg(due_seen)::timestamptz THEN NULL ELSE push_due_at END
+WHERE connection_id = sqlc.arg(connection_id);
+
+-- name: MarkChannelPushFailed :exec
+UPDATE channel_connection
+SET push_error = $2
+WHERE connection_id = $1;
+
+-- name: MarkChannelPulled :exec
+UPDATE channel_connection
+SET
+  last_pulled_at = $2,
+  pull_error = NULL
+WHERE connection_id = $1;
+
+-- name: MarkChannelPullFailed :exec
+UPDATE channel_connection
+SET pull_error = $2
+WHERE connection_id = $1;
+
+-- name: GetChannelReservationForUpdate :one
+SELECT * FROM reservation
+WHERE source = sqlc.arg(source)
+  AND external_id = sqlc.arg(external_id)
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListNightlyTypeAvailability :many
+-- Rooms of each of the hotel's room types still free on every night from start_date until
+-- end_date, counted like GetMinNightlyTypeAvailability, with the price of the type's cheapest room
+SELECT
+  rt.type_id::varchar AS type_id,
+  d.night::timestamptz AS night,
+  (rt.total_rooms + FLOOR(rt.total_rooms * COALESCE((
+    SELECT MAX(a.overbook_percent)
+    FROM overbooking_allowance a
+    WHERE a.hotel_id = sqlc.arg(hotel_id)
+      AND a.type_id = rt.type_id
+      AND a.start_date <= d.night
+      AND a.end_date > d.night
+  ), 0) / 100.0) - (
+    SELECT COUNT(DISTINCT COALESCE(seg.room_id, res.reservation_id))
+    FROM reservation res
+    LEFT JOIN reservation_segment seg ON seg.reservation_id = res.reservation_id
+    LEFT JOIN room sr ON sr.room_id = seg.room_id
+    WHERE res.hotel_id = sqlc.arg(hotel_id)
+      AND COALESCE(sr.type_id, res.type_id) = rt.type_id
+      AND res.status != 'CANCELLED'
+      AND COALESCE(seg.start_date, res.start_date) < d.night + INTERVAL '1 day'
+      AND COALESCE(seg.end_date, res.end_date) > d.night
+  ) - (
+    SELECT COUNT(b.block_id)
+    FROM room_block b
+    JOIN room r ON r.room_id = b.room_id
+    WHERE r.hotel_id = sqlc.arg(hotel_id)
+      AND r.type_id = rt.type_id
+      AND b.start_date < d.night + INTERVAL '1 day'
+      AND b.end_date > d.night
+  ) - (
+    SELECT COALESCE(SUM(GREATEST(al.quantity - (
+      SELECT COUNT(*)
+      FROM reservation ar
+      WHERE ar.block_code = al.block_code
+        AND ar.status != 'CANCELLED'
+        AND ar.start_date < d.night + INTERVAL '1 day'
+        AND ar.end_date > d.night
+    ), 0)), 0)
+    FROM allotment al
+    WHERE al.hotel_id = sqlc.arg(hotel_id)
+      AND al.type_id = rt.type_id
+      AND al.status = 'ACTIVE'
+      AND al.start_date < d.night + INTERVAL '1 day'
+      AND al.end_date > d.night
+  ))::int AS available,
+  rt.min_price::int AS min_price
+FROM (
+  SELECT type_id, COUNT(*) AS total_rooms, COALESCE(MIN(price), 0) AS min_price
+  FROM room
+  WHERE hotel_id = sqlc.arg(hotel_id) AND type_id IS NOT NULL
+  GROUP BY type_id
+) rt
+CROSS JOIN generate_series(sqlc.arg(start_date)::timestamptz, sqlc.arg(end_date)::timestamptz - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+ORDER BY rt.type_id, d.night;
+
+-- name: ListNightlyRoomOccupancy :many
+-- Whether each of the hotel's rooms is taken by a stay that is not cancelled or by a block on
+-- every night from start_date until end_date
+SELECT
+  r.room_id,
+  d.night::timestamptz AS night,
+  (EXISTS (
+    SELECT 1 FROM reservation_segment seg
+    JOIN reservation res ON res.reservation_id = seg.reservation_id
+    WHERE seg.room_id = r.room_id
+      AND res.status != 'CANCELLED'
+      AND seg.start_date < d.night + INTERVAL '1 day'
+      AND seg.end_date > d.night
+  ) OR EXISTS (
+    SELECT 1 FROM room_block b
+    WHERE b.room_id = r.room_id
+      AND b.start_date < d.night + INTERVAL '1 day'
+      AND b.end_date > d.night
+  ))::boolean AS occupied
+FROM room r
+CROSS JOIN generate_series(sqlc.arg(start_date)::timestamptz, sqlc.arg(end_date)::timestamptz - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+WHERE r.hotel_id = sqlc.arg(hotel_id)
+ORDER BY r.room_id, d.night;
\ No newline at end of file
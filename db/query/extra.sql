@@ -0,0 +1,43 @@
+-- name: GetExtra :one
+SELECT * FROM extra
+WHERE extra_id = $1 LIMIT 1;
+
+-- name: CreateReservationExtra :one
+INSERT INTO reservation_extra (
+  reservation_extra_id,
+  reservation_id,
+  extra_id,
+  pricing,
+  quantity,
+  unit_price,
+  amount,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListReservationExtrasForUpdate :many
+SELECT * FROM reservation_extra
+WHERE reservation_id = $1
+ORDER BY created_at, reservation_extra_id
+FOR UPDATE;
+
+-- name: UpdateReservationExtraAmount :exec
+UPDATE reservation_extra
+SET amount = $2
+WHERE reservation_extra_id = $1;
+
+-- name: DeleteReservationExtras :exec
+DELETE FROM reservation_extra
+WHERE reservation_id = $1;
+
+-- name: UpdateReservationExtras :one
+-- Special requests are kept when none are given
+UPDATE reservation
+SET
+  extras_amount = sqlc.arg(extras_amount),
+  total_price = sqlc.arg(total_price),
+  special_requests = COALESCE(sqlc.narg(special_requests)::varchar, special_requests),
+  update_at = sqlc.arg(update_at)
+WHERE reservation_id = sqlc.arg(reservation_id)
+RETURNING *;
\ No newline at end of file
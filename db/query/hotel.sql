@@ -4,9 +4,16 @@ INSERT INTO hotel (
   destination_id,
   type_id,
   total_room,
-  rating
+  rating,
+  time_zone,
+  check_in_time,
+  check_out_time,
+  day_use_start_time,
+  day_use_end_time,
+  day_use_slot_minutes,
+  day_use_buffer_minutes
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 ) RETURNING *;
 
 -- name: GetHotel :one
@@ -32,7 +39,14 @@ SET
   destination_id = $2,
   type_id = $3,
   total_room = $4,
-  rating = $5
+  rating = $5,
+  time_zone = $6,
+  check_in_time = $7,
+  check_out_time = $8,
+  day_use_start_time = $9,
+  day_use_end_time = $10,
+  day_use_slot_minutes = $11,
+  day_use_buffer_minutes = $12
 WHERE hotel_id = $1
 RETURNING *;
 
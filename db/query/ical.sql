@@ -0,0 +1,94 @@
+-- name: GetICalExport :one
+SELECT * FROM ical_export
+WHERE room_id = $1 LIMIT 1;
+
+-- name: UpsertICalExport :one
+INSERT INTO ical_export (
+  room_id,
+  token_hash,
+  created_at
+) VALUES (
+  $1, $2, $3
+) ON CONFLICT (room_id) DO UPDATE
+SET
+  token_hash = EXCLUDED.token_hash,
+  created_at = EXCLUDED.created_at
+RETURNING *;
+
+-- name: ListICalRoomSegments :many
+SELECT
+  seg.segment_id,
+  seg.reservation_id,
+  seg.start_date,
+  seg.end_date,
+  res.status,
+  res.stay_type,
+  res.created_at,
+  res.update_at
+FROM reservation_segment seg
+JOIN reservation res ON res.reservation_id = seg.reservation_id
+WHERE seg.room_id = $1
+  AND res.status != 'CANCELLED'
+ORDER BY seg.start_date;
+
+-- name: ListICalRoomBlocks :many
+SELECT * FROM room_block
+WHERE room_id = $1
+ORDER BY start_date;
+
+-- name: ListDueICalFeeds :many
+SELECT * FROM ical_feed
+WHERE is_active = true
+  AND (last_attempt_at IS NULL OR last_attempt_at <= sqlc.arg(attempted_before))
+ORDER BY last_attempt_at NULLS FIRST, feed_id
+LIMIT sqlc.arg(max_feeds);
+
+-- name: GetICalFeedForUpdate :one
+SELECT * FROM ical_feed
+WHERE feed_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: UpsertICalBlock :one
+INSERT INTO room_block (
+  block_id,
+  room_id,
+  block_type,
+  reason,
+  start_date,
+  end_date,
+  created_at,
+  feed_id,
+  external_uid
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) ON CONFLICT (feed_id, external_uid) DO UPDATE
+SET
+  reason = EXCLUDED.reason,
+  start_date = EXCLUDED.start_date,
+  end_date = EXCLUDED.end_date,
+  update_at = EXCLUDED.created_at
+WHERE room_block.start_date IS DISTINCT FROM EXCLUDED.start_date
+  OR room_block.end_date IS DISTINCT FROM EXCLUDED.end_date
+  OR room_block.reason IS DISTINCT FROM EXCLUDED.reason
+RETURNING *;
+
+-- name: DeleteStaleICalBlocks :execrows
+DELETE FROM room_block
+WHERE feed_id = sqlc.arg(feed_id)
+  AND end_date > sqlc.arg(ends_after)::timestamptz
+  AND NOT (external_uid = ANY(sqlc.arg(external_uids)::varchar[]));
+
+-- name: MarkICalFeedSynced :exec
+UPDATE ical_feed
+SET
+  last_attempt_at = sqlc.arg(synced_at),
+  last_synced_at = sqlc.arg(synced_at),
+  last_error = NULL
+WHERE feed_id = sqlc.arg(feed_id);
+
+-- name: MarkICalFeedFailed :exec
+UPDATE ical_feed
+SET
+  last_attempt_at = $2,
+  last_error = $3
+WHERE feed_id = $1;
\ No newline at end of file
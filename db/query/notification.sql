@@ -0,0 +1,57 @@
+-- name: ClaimNotification :one
+INSERT INTO notification (
+  notification_id,
+  reservation_id,
+  kind,
+  locale,
+  recipient,
+  status,
+  created_at,
+  claimed_at
+) VALUES (
+  sqlc.arg(notification_id),
+  sqlc.arg(reservation_id),
+  sqlc.arg(kind),
+  sqlc.arg(locale),
+  sqlc.arg(recipient),
+  'PENDING',
+  sqlc.arg(created_at),
+  sqlc.arg(claimed_at)
+) ON CONFLICT (reservation_id, kind) DO UPDATE
+SET
+  locale = EXCLUDED.locale,
+  recipient = EXCLUDED.recipient,
+  status = 'PENDING',
+  last_error = NULL,
+  claimed_at = EXCLUDED.claimed_at
+WHERE notification.status = 'FAILED'
+  OR (notification.status = 'PENDING' AND notification.claimed_at <= sqlc.arg(stale_before))
+RETURNING *;
+
+-- name: MarkNotificationSent :exec
+UPDATE notification
+SET
+  status = 'SENT',
+  subject = $2,
+  sent_at = $3
+WHERE notification_id = $1;
+
+-- name: MarkNotificationFailed :exec
+UPDATE notification
+SET
+  status = 'FAILED',
+  last_error = $2
+WHERE notification_id = $1;
+
+-- name: ListReservationsDueForReminder :many
+SELECT * FROM reservation
+WHERE status = 'CONFIRMED'
+  AND start_date > sqlc.arg(now)
+  AND start_date <= sqlc.arg(until)
+  AND reservation_id NOT IN (
+    SELECT reservation_id FROM notification
+    WHERE kind = 'ARRIVAL_REMINDER'
+      AND (status = 'SENT' OR (status = 'PENDING' AND claimed_at > sqlc.arg(stale_before)))
+  )
+ORDER BY start_date, reservation_id
+LIMIT sqlc.arg('limit');
\ No newline at end of file
@@ -0,0 +1,61 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_event (
+  event_id,
+  event_type,
+  aggregate_type,
+  aggregate_id,
+  hotel_id,
+  payload,
+  occurred_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: ListPendingOutboxEvents :many
+SELECT * FROM outbox_event
+WHERE dispatched_at IS NULL
+  AND failed_at IS NULL
+  AND (next_attempt_at IS NULL OR next_attempt_at <= sqlc.arg(now))
+ORDER BY occurred_at, event_id
+LIMIT sqlc.arg('limit')
+FOR UPDATE SKIP LOCKED;
+
+-- name: LeaseOutboxEvent :exec
+-- Holds back a claimed event from other dispatchers until next_attempt_at, while it is delivered
+-- outside the claiming transaction
+UPDATE outbox_event
+SET next_attempt_at = $2
+WHERE event_id = $1;
+
+-- name: MarkOutboxEventDispatched :exec
+UPDATE outbox_event
+SET
+  attempts = attempts + 1,
+  last_error = NULL,
+  dispatched_at = $2
+WHERE event_id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+-- A failed_at set gives up on the event, so it is no longer claimed
+UPDATE outbox_event
+SET
+  attempts = attempts + 1,
+  last_error = $2,
+  next_attempt_at = $3,
+  failed_at = $4
+WHERE event_id = $1;
+
+-- name: ListOutboxEventSinks :many
+-- Lists the sinks that already accepted an event, so a retry skips them
+SELECT sink FROM outbox_delivery
+WHERE event_id = $1;
+
+-- name: CreateOutboxDelivery :exec
+INSERT INTO outbox_delivery (
+  event_id,
+  sink,
+  delivered_at
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (event_id, sink) DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,99 @@
+-- name: CreatePromoCode :one
+INSERT INTO promo_code (
+  code,
+  description,
+  discount_type,
+  discount_value,
+  valid_from,
+  valid_to,
+  stay_from,
+  stay_to,
+  min_nights,
+  hotel_id,
+  type_id,
+  max_redemptions,
+  max_redemptions_per_user,
+  redemption_count,
+  is_active,
+  created_at,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+) RETURNING *;
+
+-- name: GetPromoCode :one
+SELECT * FROM promo_code
+WHERE code = $1 LIMIT 1;
+
+-- name: GetPromoCodeForUpdate :one
+SELECT * FROM promo_code
+WHERE code = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListPromoCodes :many
+SELECT * FROM promo_code
+ORDER BY created_at DESC
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdatePromoCode :one
+UPDATE promo_code
+SET
+  description = $2,
+  discount_type = $3,
+  discount_value = $4,
+  valid_from = $5,
+  valid_to = $6,
+  stay_from = $7,
+  stay_to = $8,
+  min_nights = $9,
+  hotel_id = $10,
+  type_id = $11,
+  max_redemptions = $12,
+  max_redemptions_per_user = $13,
+  is_active = $14,
+  update_at = $15,
+  update_by = $16
+WHERE code = $1
+RETURNING *;
+
+-- name: IncrementPromoCodeRedemptions :one
+UPDATE promo_code
+SET redemption_count = redemption_count + 1
+WHERE code = $1
+RETURNING *;
+
+-- name: DecrementPromoCodeRedemptions :one
+UPDATE promo_code
+SET redemption_count = GREATEST(redemption_count - 1, 0)
+WHERE code = $1
+RETURNING *;
+
+-- name: DeletePromoCode :exec
+DELETE FROM promo_code
+WHERE code = $1;
+
+-- name: CreatePromoRedemption :one
+INSERT INTO promo_redemption (
+  redemption_id,
+  code,
+  reservation_id,
+  user_id,
+  discount_amount,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: CountPromoRedemptionsByUser :one
+SELECT COUNT(*) FROM promo_redemption
+WHERE code = $1 AND user_id = $2;
+
+-- name: DeletePromoRedemptionByReservation :execrows
+DELETE FROM promo_redemption
+WHERE reservation_id = $1;
+
+-- name: UpdatePromoRedemptionDiscount :exec
+UPDATE promo_redemption
+SET discount_amount = $2
+WHERE reservation_id = $1;
\ No newline at end of file
@@ -9,15 +9,42 @@ INSERT INTO reservation (
   created_at,
   created_by,
   update_at,
-  update_by
+  update_by,
+  total_price,
+  promo_code,
+  discount_amount,
+  hotel_id,
+  type_id,
+  group_id,
+  stay_type,
+  hold_expires_at,
+  block_code,
+  adults,
+  children,
+  child_ages,
+  extra_person_charge,
+  extras_amount,
+  special_requests,
+  confirmation_code,
+  source,
+  external_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28
 ) RETURNING *;
 
 -- name: GetReservation :one
 SELECT * FROM reservation
 WHERE reservation_id = $1 LIMIT 1;
 
+-- name: CountReservationsByConfirmationCode :one
+SELECT COUNT(*) FROM reservation
+WHERE confirmation_code = $1;
+
+-- name: GetReservationForUpdate :one
+SELECT * FROM reservation
+WHERE reservation_id = $1 LIMIT 1
+FOR UPDATE;
+
 -- name: ListReservations :many
 SELECT * FROM reservation
 ORDER BY created_at DESC
@@ -32,20 +59,25 @@ LIMIT $2
 OFFSET $3;
 
 -- name: ListReservationsByRoom :many
-SELECT * FROM reservation
-WHERE room_id = $1
+SELECT * FROM reservation res
+WHERE EXISTS (
+  SELECT 1 FROM reservation_segment seg
+  WHERE seg.reservation_id = res.reservation_id
+    AND seg.room_id = $1
+)
 ORDER BY start_date
 LIMIT $2
 OFFSET $3;
 
 -- name: GetReservationsByDateRange :many
-SELECT * FROM reservation
-WHERE room_id = $1
-  AND status = $2
-  AND (
-    (start_date <= $3 AND end_date > $3) OR
-    (start_date < $4 AND end_date >= $4) OR
-    (start_date >= $3 AND end_date <= $4)
+SELECT * FROM reservation res
+WHERE res.status = sqlc.arg(status)
+  AND EXISTS (
+    SELECT 1 FROM reservation_segment seg
+    WHERE seg.reservation_id = res.reservation_id
+      AND seg.room_id = sqlc.arg(room_id)
+      AND seg.start_date < sqlc.arg(end_date)::timestamptz
+      AND seg.end_date > sqlc.arg(start_date)::timestamptz
   )
 ORDER BY start_date;
 
@@ -73,4 +105,133 @@ RETURNING *;
 
 -- name: DeleteReservation :exec
 DELETE FROM reservation
+WHERE reservation_id = $1;
+
+-- name: CountOverlappingRoomReservations :one
+-- Stays hold their rooms segment by segment over the half-open interval [start_date, end_date).
+-- Day-use stays also hold the room for the hotel's cleaning buffer after they end.
+SELECT COUNT(*) FROM reservation_segment seg
+JOIN reservation res ON res.reservation_id = seg.reservation_id
+WHERE seg.room_id = sqlc.arg(room_id)
+  AND seg.reservation_id != sqlc.arg(exclude_reservation_id)
+  AND res.status != 'CANCELLED'
+  AND seg.start_date < sqlc.arg(end_date)::timestamptz
+  AND seg.end_date + CASE WHEN res.stay_type = 'DAY_USE' THEN make_interval(mins => sqlc.arg(buffer_minutes)::int) ELSE INTERVAL '0 minutes' END > sqlc.arg(start_date)::timestamptz;
+
+-- name: GetMinNightlyTypeAvailability :one
+-- Rooms of the type still free on the fullest night of the range. A night's capacity is the
+-- type's rooms plus the overbooking allowance covering it. Stays with rooms count against the
+-- type of the room each segment uses; rooms taken out of service by a block
+-- and rooms an active allotment holds but has not had picked up count as booked for the nights
+-- they cover. The allotment named by block_code does not hold rooms away from its own bookings.
+SELECT COALESCE(MIN(n.capacity - n.booked), 0)::int AS min_available
+FROM (
+  SELECT d.night,
+    sqlc.arg(total_rooms)::int + FLOOR(sqlc.arg(total_rooms)::int * COALESCE((
+      SELECT MAX(a.overbook_percent)
+      FROM overbooking_allowance a
+      WHERE a.hotel_id = sqlc.arg(hotel_id)
+        AND a.type_id = sqlc.arg(type_id)
+        AND a.start_date <= d.night
+        AND a.end_date > d.night
+    ), 0) / 100.0)::int AS capacity,
+    (
+      SELECT COUNT(DISTINCT COALESCE(seg.room_id, res.reservation_id))
+      FROM reservation res
+      LEFT JOIN reservation_segment seg ON seg.reservation_id = res.reservation_id
+      LEFT JOIN room sr ON sr.room_id = seg.room_id
+      WHERE res.hotel_id = sqlc.arg(hotel_id)
+        AND COALESCE(sr.type_id, res.type_id) = sqlc.arg(type_id)
+        AND res.reservation_id != sqlc.arg(exclude_reservation_id)
+        AND res.status != 'CANCELLED'
+        AND COALESCE(seg.start_date, res.start_date) < LEAST(d.night + INTERVAL '1 day', sqlc.arg(end_date)::timestamptz)
+        AND COALESCE(seg.end_date, res.end_date) > d.night
+    ) + (
+      SELECT COUNT(b.block_id)
+      FROM room_block b
+      JOIN room r ON r.room_id = b.room_id
+      WHERE r.hotel_id = sqlc.arg(hotel_id)
+        AND r.type_id = sqlc.arg(type_id)
+        AND b.start_date < LEAST(d.night + INTERVAL '1 day', sqlc.arg(end_date)::timestamptz)
+        AND b.end_date > d.night
+    ) + (
+      SELECT COALESCE(SUM(GREATEST(al.quantity - (
+        SELECT COUNT(*)
+        FROM reservation ar
+        WHERE ar.block_code = al.block_code
+          AND ar.status != 'CANCELLED'
+          AND ar.start_date < LEAST(d.night + INTERVAL '1 day', sqlc.arg(end_date)::timestamptz)
+          AND ar.end_date > d.night
+      ), 0)), 0)
+      FROM allotment al
+      WHERE al.hotel_id = sqlc.arg(hotel_id)
+        AND al.type_id = sqlc.arg(type_id)
+        AND al.status = 'ACTIVE'
+        AND (sqlc.narg(block_code)::varchar IS NULL OR al.block_code != sqlc.narg(block_code)::varchar)
+        AND al.start_date < LEAST(d.night + INTERVAL '1 day', sqlc.arg(end_date)::timestamptz)
+        AND al.end_date > d.night
+    ) AS booked
+  FROM generate_series(sqlc.arg(start_date)::timestamptz, sqlc.arg(end_date)::timestamptz - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+) n;
+
+-- name: AssignReservationRoom :one
+UPDATE reservation
+SET
+  room_id = $2,
+  update_at = $3,
+  update_by = $4
+WHERE reservation_id = $1
+RETURNING *;
+
+-- name: ModifyReservation :one
+UPDATE reservation
+SET
+  room_id = $2,
+  user_id = $3,
+  start_date = $4,
+  end_date = $5,
+  hotel_id = $6,
+  type_id = $7,
+  total_price = $8,
+  discount_amount = $9,
+  update_at = $10,
+  adults = $11,
+  children = $12,
+  child_ages = $13,
+  extra_person_charge = $14,
+  extras_amount = $15
+WHERE reservation_id = $1
+RETURNING *;
+
+-- name: CreateReservationModification :one
+INSERT INTO reservation_modification (
+  modification_id,
+  reservation_id,
+  modified_by,
+  modified_by_role,
+  reason,
+  old_room_id,
+  new_room_id,
+  old_user_id,
+  new_user_id,
+  old_start_date,
+  new_start_date,
+  old_end_date,
+  new_end_date,
+  old_total_price,
+  new_total_price,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+) RETURNING *;
+
+-- name: ListExpiredHolds :many
+SELECT * FROM reservation
+WHERE status = 'PENDING'
+  AND hold_expires_at < sqlc.arg(now)::timestamptz
+ORDER BY hold_expires_at;
+
+-- name: ClearReservationHold :exec
+UPDATE reservation
+SET hold_expires_at = NULL
 WHERE reservation_id = $1;
\ No newline at end of file
@@ -0,0 +1,25 @@
+-- name: CreateReservationGuest :one
+INSERT INTO reservation_guest (
+  guest_id,
+  reservation_id,
+  first_name,
+  last_name,
+  age,
+  is_child,
+  is_primary,
+  email,
+  phone,
+  created_at,
+  locale
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: ListReservationGuests :many
+SELECT * FROM reservation_guest
+WHERE reservation_id = $1
+ORDER BY is_primary DESC, created_at, guest_id;
+
+-- name: DeleteReservationGuests :exec
+DELETE FROM reservation_guest
+WHERE reservation_id = $1;
\ No newline at end of file
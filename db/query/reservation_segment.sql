@@ -0,0 +1,39 @@
+-- name: CreateReservationSegment :one
+INSERT INTO reservation_segment (
+  segment_id,
+  reservation_id,
+  room_id,
+  start_date,
+  end_date,
+  created_at,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: ListReservationSegments :many
+SELECT * FROM reservation_segment
+WHERE reservation_id = $1
+ORDER BY start_date;
+
+-- name: ListReservationSegmentsForUpdate :many
+SELECT * FROM reservation_segment
+WHERE reservation_id = $1
+ORDER BY start_date
+FOR UPDATE;
+
+-- name: UpdateReservationSegmentRoom :one
+UPDATE reservation_segment
+SET room_id = $2
+WHERE segment_id = $1
+RETURNING *;
+
+-- name: UpdateReservationSegmentEnd :one
+UPDATE reservation_segment
+SET end_date = $2
+WHERE segment_id = $1
+RETURNING *;
+
+-- name: DeleteReservationSegments :exec
+DELETE FROM reservation_segment
+WHERE reservation_id = $1;
\ No newline at end of file
@@ -12,15 +12,22 @@ INSERT INTO room (
   created_at,
   created_by,
   update_at,
-  update_by
+  update_by,
+  day_use,
+  day_use_price
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 ) RETURNING *;
 
 -- name: GetRoom :one
 SELECT * FROM room
 WHERE room_id = $1 LIMIT 1;
 
+-- name: GetRoomForUpdate :one
+SELECT * FROM room
+WHERE room_id = $1 LIMIT 1
+FOR UPDATE;
+
 -- name: ListRooms :many
 SELECT * FROM room
 ORDER BY room_id
@@ -34,22 +41,11 @@ ORDER BY floor, room_name
 LIMIT $2
 OFFSET $3;
 
--- name: GetAvailableRooms :many
-SELECT r.* FROM room r
-WHERE r.hotel_id = $1
-  AND r.room_id NOT IN (
-    SELECT res.room_id FROM reservation res
-    WHERE res.room_id = r.room_id
-      AND res.status = 'confirmed'
-      AND (
-        (res.start_date <= $2 AND res.end_date > $2) OR
-        (res.start_date < $3 AND res.end_date >= $3) OR
-        (res.start_date >= $2 AND res.end_date <= $3)
-      )
-  )
-ORDER BY r.price
-LIMIT $4
-OFFSET $5;
+-- name: LockRoomsByHotelAndType :many
+SELECT * FROM room
+WHERE hotel_id = $1 AND type_id = $2
+ORDER BY room_id
+FOR UPDATE;
 
 -- name: UpdateRoom :one
 UPDATE room
@@ -63,10 +59,20 @@ SET
   description = $8,
   price = $9,
   update_at = $10,
-  update_by = $11
+  update_by = $11,
+  day_use = $12,
+  day_use_price = $13
 WHERE room_id = $1
 RETURNING *;
 
 -- name: DeleteRoom :exec
 DELETE FROM room
-WHERE room_id = $1;
\ No newline at end of file
+WHERE room_id = $1;
+
+-- name: UpdateRoomHousekeepingStatus :one
+UPDATE room
+SET
+  housekeeping_status = $2,
+  housekeeping_updated_at = $3
+WHERE room_id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,31 @@
+-- name: CreateRoomBlock :one
+INSERT INTO room_block (
+  block_id,
+  room_id,
+  block_type,
+  reason,
+  start_date,
+  end_date,
+  created_at,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: CountOverlappingRoomBlocks :one
+SELECT COUNT(*) FROM room_block
+WHERE room_id = sqlc.arg(room_id)
+  AND start_date < sqlc.arg(end_date)::timestamptz
+  AND end_date > sqlc.arg(start_date)::timestamptz;
+
+-- name: ListOverlappingRoomReservations :many
+SELECT * FROM reservation res
+WHERE res.status != 'CANCELLED'
+  AND EXISTS (
+    SELECT 1 FROM reservation_segment seg
+    WHERE seg.reservation_id = res.reservation_id
+      AND seg.room_id = sqlc.arg(room_id)
+      AND seg.start_date < sqlc.arg(end_date)::timestamptz
+      AND seg.end_date > sqlc.arg(start_date)::timestamptz
+  )
+ORDER BY start_date;
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- name: ListApplicableStayRestrictions :many
+-- Restrictions covering either the arrival or the departure date of a stay.
+-- A restriction without a room type applies to every room type of the hotel.
+SELECT * FROM stay_restriction
+WHERE hotel_id = sqlc.arg(hotel_id)
+  AND (type_id IS NULL OR type_id = sqlc.arg(type_id))
+  AND (
+    (start_date <= sqlc.arg(arrival)::timestamptz AND end_date > sqlc.arg(arrival)::timestamptz) OR
+    (start_date <= sqlc.arg(departure)::timestamptz AND end_date > sqlc.arg(departure)::timestamptz)
+  )
+ORDER BY start_date;
+
+-- name: ListStayRestrictionsInRange :many
+-- Restrictions of the hotel, for any room type, covering part of [start_date, end_date)
+SELECT * FROM stay_restriction
+WHERE hotel_id = sqlc.arg(hotel_id)
+  AND start_date < sqlc.arg(end_date)::timestamptz
+  AND end_date > sqlc.arg(start_date)::timestamptz
+ORDER BY start_date;
\ No newline at end of file
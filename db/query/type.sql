@@ -0,0 +1,46 @@
+-- name: CreateType :one
+INSERT INTO type (
+  type_code,
+  name,
+  description,
+  bed_configuration,
+  size_sqm,
+  default_capacity,
+  base_occupancy,
+  extra_adult_price,
+  extra_child_price,
+  created_at,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetType :one
+SELECT * FROM type
+WHERE type_code = $1 LIMIT 1;
+
+-- name: ListTypes :many
+SELECT * FROM type
+ORDER BY type_code
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdateType :one
+UPDATE type
+SET
+  name = $2,
+  description = $3,
+  bed_configuration = $4,
+  size_sqm = $5,
+  default_capacity = $6,
+  base_occupancy = $7,
+  extra_adult_price = $8,
+  extra_child_price = $9,
+  update_at = $10,
+  update_by = $11
+WHERE type_code = $1
+RETURNING *;
+
+-- name: DeleteType :exec
+DELETE FROM type
+WHERE type_code = $1;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: GetUser :one
+SELECT * FROM "user"
+WHERE username = $1 LIMIT 1;
\ No newline at end of file
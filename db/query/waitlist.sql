@@ -0,0 +1,69 @@
+-- name: CreateWaitlistEntry :one
+INSERT INTO waitlist_entry (
+  entry_id,
+  hotel_id,
+  type_id,
+  room_id,
+  user_id,
+  start_date,
+  end_date,
+  guests,
+  contact_name,
+  contact_email,
+  contact_phone,
+  status,
+  created_at,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING *;
+
+-- name: GetWaitlistEntryForUpdate :one
+SELECT * FROM waitlist_entry
+WHERE entry_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListWaitingEntriesForRelease :many
+-- Entries of the hotel still waiting for dates that overlap the released stay, oldest first
+SELECT * FROM waitlist_entry
+WHERE hotel_id = sqlc.arg(hotel_id)
+  AND status = 'WAITING'
+  AND start_date < sqlc.arg(end_date)::timestamptz
+  AND end_date > sqlc.arg(start_date)::timestamptz
+ORDER BY created_at;
+
+-- name: UpdateWaitlistEntryOffer :one
+UPDATE waitlist_entry
+SET
+  status = 'OFFERED',
+  reservation_id = $2,
+  offer_expires_at = $3,
+  update_at = $4
+WHERE entry_id = $1
+RETURNING *;
+
+-- name: UpdateWaitlistEntryStatus :one
+UPDATE waitlist_entry
+SET
+  status = $2,
+  update_at = $3,
+  update_by = $4
+WHERE entry_id = $1
+RETURNING *;
+
+-- name: ResolveWaitlistOffer :exec
+-- Closes the open offer backed by a hold once the hold is confirmed, cancelled or expires
+UPDATE waitlist_entry
+SET
+  status = sqlc.arg(status),
+  update_at = sqlc.arg(update_at)
+WHERE reservation_id = sqlc.arg(reservation_id)
+  AND status = 'OFFERED';
+
+-- name: ExpireStaleWaitlistEntries :execrows
+UPDATE waitlist_entry
+SET
+  status = 'EXPIRED',
+  update_at = sqlc.arg(now)::timestamptz
+WHERE status = 'WAITING'
+  AND start_date < sqlc.arg(now)::timestamptz;
\ No newline at end of file
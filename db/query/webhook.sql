@@ -0,0 +1,101 @@
+-- name: ListActiveWebhookSubscriptionsForHotel :many
+SELECT * FROM webhook_subscription
+WHERE is_active = true
+  AND (hotel_id IS NULL OR hotel_id = $1)
+ORDER BY created_at, subscription_id;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscription
+WHERE subscription_id = $1 LIMIT 1;
+
+-- name: GetWebhookSubscriptionForUpdate :one
+SELECT * FROM webhook_subscription
+WHERE subscription_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: RecordWebhookSubscriptionFailure :one
+UPDATE webhook_subscription
+SET consecutive_failures = consecutive_failures + 1
+WHERE subscription_id = $1
+RETURNING *;
+
+-- name: ResetWebhookSubscriptionFailures :exec
+UPDATE webhook_subscription
+SET consecutive_failures = 0
+WHERE subscription_id = $1;
+
+-- name: DisableWebhookSubscription :exec
+UPDATE webhook_subscription
+SET
+  is_active = false,
+  disabled_at = $2,
+  disabled_reason = $3
+WHERE subscription_id = $1;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_delivery (
+  delivery_id,
+  subscription_id,
+  event_id,
+  event_type,
+  payload,
+  status,
+  next_attempt_at,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) ON CONFLICT (subscription_id, event_id) DO NOTHING;
+
+-- name: ListDueWebhookDeliveries :many
+SELECT * FROM webhook_delivery
+WHERE status = 'PENDING'
+  AND next_attempt_at <= sqlc.arg(now)
+  AND subscription_id IN (
+    SELECT subscription_id FROM webhook_subscription WHERE is_active = true
+  )
+ORDER BY next_attempt_at, created_at
+LIMIT sqlc.arg('limit')
+FOR UPDATE SKIP LOCKED;
+
+-- name: LeaseWebhookDelivery :exec
+-- Holds back a claimed delivery from other senders until next_attempt_at, while it is sent
+-- outside the claiming transaction
+UPDATE webhook_delivery
+SET next_attempt_at = $2
+WHERE delivery_id = $1;
+
+-- name: GetWebhookDeliveryForUpdate :one
+SELECT * FROM webhook_delivery
+WHERE delivery_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_delivery
+SET
+  status = 'SUCCEEDED',
+  attempts = attempts + 1,
+  next_attempt_at = NULL,
+  last_attempt_at = $2,
+  response_status = $3,
+  last_error = NULL,
+  delivered_at = $2
+WHERE delivery_id = $1;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_delivery
+SET
+  status = $2,
+  attempts = attempts + 1,
+  next_attempt_at = $3,
+  last_attempt_at = $4,
+  response_status = $5,
+  last_error = $6
+WHERE delivery_id = $1;
+
+-- name: ReplayWebhookDelivery :exec
+UPDATE webhook_delivery
+SET
+  status = 'PENDING',
+  attempts = 0,
+  next_attempt_at = $2
+WHERE delivery_id = $1;
\ No newline at end of file
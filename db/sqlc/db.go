@@ -24,110 +24,646 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.assignReservationRoomStmt, err = db.PrepareContext(ctx, assignReservationRoom); err != nil {
+		return nil, fmt.Errorf("error preparing query AssignReservationRoom: %w", err)
+	}
+	if q.claimNotificationStmt, err = db.PrepareContext(ctx, claimNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimNotification: %w", err)
+	}
+	if q.clearReservationHoldStmt, err = db.PrepareContext(ctx, clearReservationHold); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearReservationHold: %w", err)
+	}
+	if q.countAllotmentReservationsStmt, err = db.PrepareContext(ctx, countAllotmentReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query CountAllotmentReservations: %w", err)
+	}
+	if q.countOverlappingRoomBlocksStmt, err = db.PrepareContext(ctx, countOverlappingRoomBlocks); err != nil {
+		return nil, fmt.Errorf("error preparing query CountOverlappingRoomBlocks: %w", err)
+	}
+	if q.countOverlappingRoomReservationsStmt, err = db.PrepareContext(ctx, countOverlappingRoomReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query CountOverlappingRoomReservations: %w", err)
+	}
+	if q.countPromoRedemptionsByUserStmt, err = db.PrepareContext(ctx, countPromoRedemptionsByUser); err != nil {
+		return nil, fmt.Errorf("error preparing query CountPromoRedemptionsByUser: %w", err)
+	}
+	if q.countReservationsByConfirmationCodeStmt, err = db.PrepareContext(ctx, countReservationsByConfirmationCode); err != nil {
+		return nil, fmt.Errorf("error preparing query CountReservationsByConfirmationCode: %w", err)
+	}
+	if q.createAllotmentStmt, err = db.PrepareContext(ctx, createAllotment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAllotment: %w", err)
+	}
+	if q.createBookingGroupStmt, err = db.PrepareContext(ctx, createBookingGroup); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBookingGroup: %w", err)
+	}
 	if q.createHotelStmt, err = db.PrepareContext(ctx, createHotel); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateHotel: %w", err)
 	}
+	if q.createOutboxDeliveryStmt, err = db.PrepareContext(ctx, createOutboxDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOutboxDelivery: %w", err)
+	}
+	if q.createOutboxEventStmt, err = db.PrepareContext(ctx, createOutboxEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOutboxEvent: %w", err)
+	}
+	if q.createPromoCodeStmt, err = db.PrepareContext(ctx, createPromoCode); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePromoCode: %w", err)
+	}
+	if q.createPromoRedemptionStmt, err = db.PrepareContext(ctx, createPromoRedemption); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePromoRedemption: %w", err)
+	}
 	if q.createReservationStmt, err = db.PrepareContext(ctx, createReservation); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateReservation: %w", err)
 	}
+	if q.createReservationExtraStmt, err = db.PrepareContext(ctx, createReservationExtra); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationExtra: %w", err)
+	}
+	if q.createReservationGuestStmt, err = db.PrepareContext(ctx, createReservationGuest); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationGuest: %w", err)
+	}
+	if q.createReservationModificationStmt, err = db.PrepareContext(ctx, createReservationModification); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationModification: %w", err)
+	}
+	if q.createReservationSegmentStmt, err = db.PrepareContext(ctx, createReservationSegment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateReservationSegment: %w", err)
+	}
 	if q.createRoomStmt, err = db.PrepareContext(ctx, createRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateRoom: %w", err)
 	}
+	if q.createRoomBlockStmt, err = db.PrepareContext(ctx, createRoomBlock); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRoomBlock: %w", err)
+	}
+	if q.createTypeStmt, err = db.PrepareContext(ctx, createType); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateType: %w", err)
+	}
+	if q.createWaitlistEntryStmt, err = db.PrepareContext(ctx, createWaitlistEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWaitlistEntry: %w", err)
+	}
+	if q.createWebhookDeliveryStmt, err = db.PrepareContext(ctx, createWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWebhookDelivery: %w", err)
+	}
+	if q.decrementPromoCodeRedemptionsStmt, err = db.PrepareContext(ctx, decrementPromoCodeRedemptions); err != nil {
+		return nil, fmt.Errorf("error preparing query DecrementPromoCodeRedemptions: %w", err)
+	}
 	if q.deleteHotelStmt, err = db.PrepareContext(ctx, deleteHotel); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteHotel: %w", err)
 	}
+	if q.deletePromoCodeStmt, err = db.PrepareContext(ctx, deletePromoCode); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePromoCode: %w", err)
+	}
+	if q.deletePromoRedemptionByReservationStmt, err = db.PrepareContext(ctx, deletePromoRedemptionByReservation); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePromoRedemptionByReservation: %w", err)
+	}
 	if q.deleteReservationStmt, err = db.PrepareContext(ctx, deleteReservation); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteReservation: %w", err)
 	}
+	if q.deleteReservationExtrasStmt, err = db.PrepareContext(ctx, deleteReservationExtras); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReservationExtras: %w", err)
+	}
+	if q.deleteReservationGuestsStmt, err = db.PrepareContext(ctx, deleteReservationGuests); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReservationGuests: %w", err)
+	}
+	if q.deleteReservationSegmentsStmt, err = db.PrepareContext(ctx, deleteReservationSegments); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReservationSegments: %w", err)
+	}
 	if q.deleteRoomStmt, err = db.PrepareContext(ctx, deleteRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteRoom: %w", err)
 	}
-	if q.getAvailableRoomsStmt, err = db.PrepareContext(ctx, getAvailableRooms); err != nil {
-		return nil, fmt.Errorf("error preparing query GetAvailableRooms: %w", err)
+	if q.deleteStaleICalBlocksStmt, err = db.PrepareContext(ctx, deleteStaleICalBlocks); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStaleICalBlocks: %w", err)
+	}
+	if q.deleteTypeStmt, err = db.PrepareContext(ctx, deleteType); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteType: %w", err)
+	}
+	if q.disableWebhookSubscriptionStmt, err = db.PrepareContext(ctx, disableWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query DisableWebhookSubscription: %w", err)
+	}
+	if q.expireStaleWaitlistEntriesStmt, err = db.PrepareContext(ctx, expireStaleWaitlistEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ExpireStaleWaitlistEntries: %w", err)
+	}
+	if q.getAllotmentByCodeForUpdateStmt, err = db.PrepareContext(ctx, getAllotmentByCodeForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAllotmentByCodeForUpdate: %w", err)
+	}
+	if q.getAllotmentForUpdateStmt, err = db.PrepareContext(ctx, getAllotmentForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAllotmentForUpdate: %w", err)
+	}
+	if q.getBookingGroupStmt, err = db.PrepareContext(ctx, getBookingGroup); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBookingGroup: %w", err)
+	}
+	if q.getBookingGroupForUpdateStmt, err = db.PrepareContext(ctx, getBookingGroupForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBookingGroupForUpdate: %w", err)
+	}
+	if q.getChannelReservationForUpdateStmt, err = db.PrepareContext(ctx, getChannelReservationForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetChannelReservationForUpdate: %w", err)
+	}
+	if q.getExtraStmt, err = db.PrepareContext(ctx, getExtra); err != nil {
+		return nil, fmt.Errorf("error preparing query GetExtra: %w", err)
 	}
 	if q.getHotelStmt, err = db.PrepareContext(ctx, getHotel); err != nil {
 		return nil, fmt.Errorf("error preparing query GetHotel: %w", err)
 	}
+	if q.getICalExportStmt, err = db.PrepareContext(ctx, getICalExport); err != nil {
+		return nil, fmt.Errorf("error preparing query GetICalExport: %w", err)
+	}
+	if q.getICalFeedForUpdateStmt, err = db.PrepareContext(ctx, getICalFeedForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetICalFeedForUpdate: %w", err)
+	}
+	if q.getMaxNightlyAllotmentPickupStmt, err = db.PrepareContext(ctx, getMaxNightlyAllotmentPickup); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMaxNightlyAllotmentPickup: %w", err)
+	}
+	if q.getMinNightlyTypeAvailabilityStmt, err = db.PrepareContext(ctx, getMinNightlyTypeAvailability); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMinNightlyTypeAvailability: %w", err)
+	}
+	if q.getPromoCodeStmt, err = db.PrepareContext(ctx, getPromoCode); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPromoCode: %w", err)
+	}
+	if q.getPromoCodeForUpdateStmt, err = db.PrepareContext(ctx, getPromoCodeForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPromoCodeForUpdate: %w", err)
+	}
 	if q.getReservationStmt, err = db.PrepareContext(ctx, getReservation); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReservation: %w", err)
 	}
+	if q.getReservationForUpdateStmt, err = db.PrepareContext(ctx, getReservationForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReservationForUpdate: %w", err)
+	}
 	if q.getReservationsByDateRangeStmt, err = db.PrepareContext(ctx, getReservationsByDateRange); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReservationsByDateRange: %w", err)
 	}
 	if q.getRoomStmt, err = db.PrepareContext(ctx, getRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query GetRoom: %w", err)
 	}
+	if q.getRoomForUpdateStmt, err = db.PrepareContext(ctx, getRoomForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRoomForUpdate: %w", err)
+	}
+	if q.getTypeStmt, err = db.PrepareContext(ctx, getType); err != nil {
+		return nil, fmt.Errorf("error preparing query GetType: %w", err)
+	}
+	if q.getUserStmt, err = db.PrepareContext(ctx, getUser); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUser: %w", err)
+	}
+	if q.getWaitlistEntryForUpdateStmt, err = db.PrepareContext(ctx, getWaitlistEntryForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWaitlistEntryForUpdate: %w", err)
+	}
+	if q.getWebhookDeliveryForUpdateStmt, err = db.PrepareContext(ctx, getWebhookDeliveryForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookDeliveryForUpdate: %w", err)
+	}
+	if q.getWebhookSubscriptionStmt, err = db.PrepareContext(ctx, getWebhookSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookSubscription: %w", err)
+	}
+	if q.getWebhookSubscriptionForUpdateStmt, err = db.PrepareContext(ctx, getWebhookSubscriptionForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWebhookSubscriptionForUpdate: %w", err)
+	}
+	if q.incrementPromoCodeRedemptionsStmt, err = db.PrepareContext(ctx, incrementPromoCodeRedemptions); err != nil {
+		return nil, fmt.Errorf("error preparing query IncrementPromoCodeRedemptions: %w", err)
+	}
+	if q.leaseOutboxEventStmt, err = db.PrepareContext(ctx, leaseOutboxEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query LeaseOutboxEvent: %w", err)
+	}
+	if q.leaseWebhookDeliveryStmt, err = db.PrepareContext(ctx, leaseWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query LeaseWebhookDelivery: %w", err)
+	}
+	if q.listActiveWebhookSubscriptionsForHotelStmt, err = db.PrepareContext(ctx, listActiveWebhookSubscriptionsForHotel); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveWebhookSubscriptionsForHotel: %w", err)
+	}
+	if q.listApplicableStayRestrictionsStmt, err = db.PrepareContext(ctx, listApplicableStayRestrictions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListApplicableStayRestrictions: %w", err)
+	}
+	if q.listChannelMappingsByConnectionStmt, err = db.PrepareContext(ctx, listChannelMappingsByConnection); err != nil {
+		return nil, fmt.Errorf("error preparing query ListChannelMappingsByConnection: %w", err)
+	}
+	if q.listDueAllotmentsStmt, err = db.PrepareContext(ctx, listDueAllotments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueAllotments: %w", err)
+	}
+	if q.listDueChannelPullsStmt, err = db.PrepareContext(ctx, listDueChannelPulls); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueChannelPulls: %w", err)
+	}
+	if q.listDueChannelPushesStmt, err = db.PrepareContext(ctx, listDueChannelPushes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueChannelPushes: %w", err)
+	}
+	if q.listDueICalFeedsStmt, err = db.PrepareContext(ctx, listDueICalFeeds); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueICalFeeds: %w", err)
+	}
+	if q.listDueWebhookDeliveriesStmt, err = db.PrepareContext(ctx, listDueWebhookDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueWebhookDeliveries: %w", err)
+	}
+	if q.listExpiredHoldsStmt, err = db.PrepareContext(ctx, listExpiredHolds); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExpiredHolds: %w", err)
+	}
 	if q.listHotelsStmt, err = db.PrepareContext(ctx, listHotels); err != nil {
 		return nil, fmt.Errorf("error preparing query ListHotels: %w", err)
 	}
 	if q.listHotelsByDestinationStmt, err = db.PrepareContext(ctx, listHotelsByDestination); err != nil {
 		return nil, fmt.Errorf("error preparing query ListHotelsByDestination: %w", err)
 	}
+	if q.listICalRoomBlocksStmt, err = db.PrepareContext(ctx, listICalRoomBlocks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListICalRoomBlocks: %w", err)
+	}
+	if q.listICalRoomSegmentsStmt, err = db.PrepareContext(ctx, listICalRoomSegments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListICalRoomSegments: %w", err)
+	}
+	if q.listNightlyRoomOccupancyStmt, err = db.PrepareContext(ctx, listNightlyRoomOccupancy); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNightlyRoomOccupancy: %w", err)
+	}
+	if q.listNightlyTypeAvailabilityStmt, err = db.PrepareContext(ctx, listNightlyTypeAvailability); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNightlyTypeAvailability: %w", err)
+	}
+	if q.listOutboxEventSinksStmt, err = db.PrepareContext(ctx, listOutboxEventSinks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOutboxEventSinks: %w", err)
+	}
+	if q.listOverlappingRoomReservationsStmt, err = db.PrepareContext(ctx, listOverlappingRoomReservations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOverlappingRoomReservations: %w", err)
+	}
+	if q.listPendingOutboxEventsStmt, err = db.PrepareContext(ctx, listPendingOutboxEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingOutboxEvents: %w", err)
+	}
+	if q.listPromoCodesStmt, err = db.PrepareContext(ctx, listPromoCodes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPromoCodes: %w", err)
+	}
+	if q.listReservationExtrasForUpdateStmt, err = db.PrepareContext(ctx, listReservationExtrasForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationExtrasForUpdate: %w", err)
+	}
+	if q.listReservationGuestsStmt, err = db.PrepareContext(ctx, listReservationGuests); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationGuests: %w", err)
+	}
+	if q.listReservationSegmentsStmt, err = db.PrepareContext(ctx, listReservationSegments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationSegments: %w", err)
+	}
+	if q.listReservationSegmentsForUpdateStmt, err = db.PrepareContext(ctx, listReservationSegmentsForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationSegmentsForUpdate: %w", err)
+	}
 	if q.listReservationsStmt, err = db.PrepareContext(ctx, listReservations); err != nil {
 		return nil, fmt.Errorf("error preparing query ListReservations: %w", err)
 	}
+	if q.listReservationsByGroupForUpdateStmt, err = db.PrepareContext(ctx, listReservationsByGroupForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationsByGroupForUpdate: %w", err)
+	}
 	if q.listReservationsByRoomStmt, err = db.PrepareContext(ctx, listReservationsByRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query ListReservationsByRoom: %w", err)
 	}
 	if q.listReservationsByUserStmt, err = db.PrepareContext(ctx, listReservationsByUser); err != nil {
 		return nil, fmt.Errorf("error preparing query ListReservationsByUser: %w", err)
 	}
+	if q.listReservationsDueForReminderStmt, err = db.PrepareContext(ctx, listReservationsDueForReminder); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReservationsDueForReminder: %w", err)
+	}
 	if q.listRoomsStmt, err = db.PrepareContext(ctx, listRooms); err != nil {
 		return nil, fmt.Errorf("error preparing query ListRooms: %w", err)
 	}
 	if q.listRoomsByHotelStmt, err = db.PrepareContext(ctx, listRoomsByHotel); err != nil {
 		return nil, fmt.Errorf("error preparing query ListRoomsByHotel: %w", err)
 	}
+	if q.listStayRestrictionsInRangeStmt, err = db.PrepareContext(ctx, listStayRestrictionsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStayRestrictionsInRange: %w", err)
+	}
+	if q.listTypesStmt, err = db.PrepareContext(ctx, listTypes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTypes: %w", err)
+	}
+	if q.listWaitingEntriesForReleaseStmt, err = db.PrepareContext(ctx, listWaitingEntriesForRelease); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWaitingEntriesForRelease: %w", err)
+	}
+	if q.lockRoomsByHotelAndTypeStmt, err = db.PrepareContext(ctx, lockRoomsByHotelAndType); err != nil {
+		return nil, fmt.Errorf("error preparing query LockRoomsByHotelAndType: %w", err)
+	}
+	if q.markChannelPullFailedStmt, err = db.PrepareContext(ctx, markChannelPullFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkChannelPullFailed: %w", err)
+	}
+	if q.markChannelPulledStmt, err = db.PrepareContext(ctx, markChannelPulled); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkChannelPulled: %w", err)
+	}
+	if q.markChannelPushDueStmt, err = db.PrepareContext(ctx, markChannelPushDue); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkChannelPushDue: %w", err)
+	}
+	if q.markChannelPushFailedStmt, err = db.PrepareContext(ctx, markChannelPushFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkChannelPushFailed: %w", err)
+	}
+	if q.markChannelPushedStmt, err = db.PrepareContext(ctx, markChannelPushed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkChannelPushed: %w", err)
+	}
+	if q.markHotelChannelsDueStmt, err = db.PrepareContext(ctx, markHotelChannelsDue); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkHotelChannelsDue: %w", err)
+	}
+	if q.markICalFeedFailedStmt, err = db.PrepareContext(ctx, markICalFeedFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkICalFeedFailed: %w", err)
+	}
+	if q.markICalFeedSyncedStmt, err = db.PrepareContext(ctx, markICalFeedSynced); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkICalFeedSynced: %w", err)
+	}
+	if q.markNotificationFailedStmt, err = db.PrepareContext(ctx, markNotificationFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationFailed: %w", err)
+	}
+	if q.markNotificationSentStmt, err = db.PrepareContext(ctx, markNotificationSent); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationSent: %w", err)
+	}
+	if q.markOutboxEventDispatchedStmt, err = db.PrepareContext(ctx, markOutboxEventDispatched); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkOutboxEventDispatched: %w", err)
+	}
+	if q.markOutboxEventFailedStmt, err = db.PrepareContext(ctx, markOutboxEventFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkOutboxEventFailed: %w", err)
+	}
+	if q.markWebhookDeliveryFailedStmt, err = db.PrepareContext(ctx, markWebhookDeliveryFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkWebhookDeliveryFailed: %w", err)
+	}
+	if q.markWebhookDeliverySucceededStmt, err = db.PrepareContext(ctx, markWebhookDeliverySucceeded); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkWebhookDeliverySucceeded: %w", err)
+	}
+	if q.modifyReservationStmt, err = db.PrepareContext(ctx, modifyReservation); err != nil {
+		return nil, fmt.Errorf("error preparing query ModifyReservation: %w", err)
+	}
+	if q.notifyAvailabilityChangeStmt, err = db.PrepareContext(ctx, notifyAvailabilityChange); err != nil {
+		return nil, fmt.Errorf("error preparing query NotifyAvailabilityChange: %w", err)
+	}
+	if q.recordWebhookSubscriptionFailureStmt, err = db.PrepareContext(ctx, recordWebhookSubscriptionFailure); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordWebhookSubscriptionFailure: %w", err)
+	}
+	if q.replayWebhookDeliveryStmt, err = db.PrepareContext(ctx, replayWebhookDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query ReplayWebhookDelivery: %w", err)
+	}
+	if q.resetWebhookSubscriptionFailuresStmt, err = db.PrepareContext(ctx, resetWebhookSubscriptionFailures); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetWebhookSubscriptionFailures: %w", err)
+	}
+	if q.resolveWaitlistOfferStmt, err = db.PrepareContext(ctx, resolveWaitlistOffer); err != nil {
+		return nil, fmt.Errorf("error preparing query ResolveWaitlistOffer: %w", err)
+	}
+	if q.updateAllotmentStmt, err = db.PrepareContext(ctx, updateAllotment); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAllotment: %w", err)
+	}
+	if q.updateAllotmentStatusStmt, err = db.PrepareContext(ctx, updateAllotmentStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAllotmentStatus: %w", err)
+	}
+	if q.updateBookingGroupStatusStmt, err = db.PrepareContext(ctx, updateBookingGroupStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateBookingGroupStatus: %w", err)
+	}
+	if q.updateBookingGroupTotalPriceStmt, err = db.PrepareContext(ctx, updateBookingGroupTotalPrice); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateBookingGroupTotalPrice: %w", err)
+	}
 	if q.updateHotelStmt, err = db.PrepareContext(ctx, updateHotel); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateHotel: %w", err)
 	}
+	if q.updatePromoCodeStmt, err = db.PrepareContext(ctx, updatePromoCode); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePromoCode: %w", err)
+	}
+	if q.updatePromoRedemptionDiscountStmt, err = db.PrepareContext(ctx, updatePromoRedemptionDiscount); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePromoRedemptionDiscount: %w", err)
+	}
 	if q.updateReservationStmt, err = db.PrepareContext(ctx, updateReservation); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateReservation: %w", err)
 	}
+	if q.updateReservationExtraAmountStmt, err = db.PrepareContext(ctx, updateReservationExtraAmount); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateReservationExtraAmount: %w", err)
+	}
+	if q.updateReservationExtrasStmt, err = db.PrepareContext(ctx, updateReservationExtras); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateReservationExtras: %w", err)
+	}
+	if q.updateReservationSegmentEndStmt, err = db.PrepareContext(ctx, updateReservationSegmentEnd); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateReservationSegmentEnd: %w", err)
+	}
+	if q.updateReservationSegmentRoomStmt, err = db.PrepareContext(ctx, updateReservationSegmentRoom); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateReservationSegmentRoom: %w", err)
+	}
 	if q.updateReservationStatusStmt, err = db.PrepareContext(ctx, updateReservationStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateReservationStatus: %w", err)
 	}
 	if q.updateRoomStmt, err = db.PrepareContext(ctx, updateRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateRoom: %w", err)
 	}
+	if q.updateRoomHousekeepingStatusStmt, err = db.PrepareContext(ctx, updateRoomHousekeepingStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateRoomHousekeepingStatus: %w", err)
+	}
+	if q.updateTypeStmt, err = db.PrepareContext(ctx, updateType); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateType: %w", err)
+	}
+	if q.updateWaitlistEntryOfferStmt, err = db.PrepareContext(ctx, updateWaitlistEntryOffer); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWaitlistEntryOffer: %w", err)
+	}
+	if q.updateWaitlistEntryStatusStmt, err = db.PrepareContext(ctx, updateWaitlistEntryStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWaitlistEntryStatus: %w", err)
+	}
+	if q.upsertICalBlockStmt, err = db.PrepareContext(ctx, upsertICalBlock); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertICalBlock: %w", err)
+	}
+	if q.upsertICalExportStmt, err = db.PrepareContext(ctx, upsertICalExport); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertICalExport: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.assignReservationRoomStmt != nil {
+		if cerr := q.assignReservationRoomStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing assignReservationRoomStmt: %w", cerr)
+		}
+	}
+	if q.claimNotificationStmt != nil {
+		if cerr := q.claimNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimNotificationStmt: %w", cerr)
+		}
+	}
+	if q.clearReservationHoldStmt != nil {
+		if cerr := q.clearReservationHoldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearReservationHoldStmt: %w", cerr)
+		}
+	}
+	if q.countAllotmentReservationsStmt != nil {
+		if cerr := q.countAllotmentReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countAllotmentReservationsStmt: %w", cerr)
+		}
+	}
+	if q.countOverlappingRoomBlocksStmt != nil {
+		if cerr := q.countOverlappingRoomBlocksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countOverlappingRoomBlocksStmt: %w", cerr)
+		}
+	}
+	if q.countOverlappingRoomReservationsStmt != nil {
+		if cerr := q.countOverlappingRoomReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countOverlappingRoomReservationsStmt: %w", cerr)
+		}
+	}
+	if q.countPromoRedemptionsByUserStmt != nil {
+		if cerr := q.countPromoRedemptionsByUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countPromoRedemptionsByUserStmt: %w", cerr)
+		}
+	}
+	if q.countReservationsByConfirmationCodeStmt != nil {
+		if cerr := q.countReservationsByConfirmationCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countReservationsByConfirmationCodeStmt: %w", cerr)
+		}
+	}
+	if q.createAllotmentStmt != nil {
+		if cerr := q.createAllotmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAllotmentStmt: %w", cerr)
+		}
+	}
+	if q.createBookingGroupStmt != nil {
+		if cerr := q.createBookingGroupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBookingGroupStmt: %w", cerr)
+		}
+	}
 	if q.createHotelStmt != nil {
 		if cerr := q.createHotelStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createHotelStmt: %w", cerr)
 		}
 	}
+	if q.createOutboxDeliveryStmt != nil {
+		if cerr := q.createOutboxDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOutboxDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.createOutboxEventStmt != nil {
+		if cerr := q.createOutboxEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOutboxEventStmt: %w", cerr)
+		}
+	}
+	if q.createPromoCodeStmt != nil {
+		if cerr := q.createPromoCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPromoCodeStmt: %w", cerr)
+		}
+	}
+	if q.createPromoRedemptionStmt != nil {
+		if cerr := q.createPromoRedemptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPromoRedemptionStmt: %w", cerr)
+		}
+	}
 	if q.createReservationStmt != nil {
 		if cerr := q.createReservationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createReservationStmt: %w", cerr)
 		}
 	}
+	if q.createReservationExtraStmt != nil {
+		if cerr := q.createReservationExtraStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationExtraStmt: %w", cerr)
+		}
+	}
+	if q.createReservationGuestStmt != nil {
+		if cerr := q.createReservationGuestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationGuestStmt: %w", cerr)
+		}
+	}
+	if q.createReservationModificationStmt != nil {
+		if cerr := q.createReservationModificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationModificationStmt: %w", cerr)
+		}
+	}
+	if q.createReservationSegmentStmt != nil {
+		if cerr := q.createReservationSegmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createReservationSegmentStmt: %w", cerr)
+		}
+	}
 	if q.createRoomStmt != nil {
 		if cerr := q.createRoomStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createRoomStmt: %w", cerr)
 		}
 	}
+	if q.createRoomBlockStmt != nil {
+		if cerr := q.createRoomBlockStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRoomBlockStmt: %w", cerr)
+		}
+	}
+	if q.createTypeStmt != nil {
+		if cerr := q.createTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTypeStmt: %w", cerr)
+		}
+	}
+	if q.createWaitlistEntryStmt != nil {
+		if cerr := q.createWaitlistEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWaitlistEntryStmt: %w", cerr)
+		}
+	}
+	if q.createWebhookDeliveryStmt != nil {
+		if cerr := q.createWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.decrementPromoCodeRedemptionsStmt != nil {
+		if cerr := q.decrementPromoCodeRedemptionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing decrementPromoCodeRedemptionsStmt: %w", cerr)
+		}
+	}
 	if q.deleteHotelStmt != nil {
 		if cerr := q.deleteHotelStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteHotelStmt: %w", cerr)
 		}
 	}
+	if q.deletePromoCodeStmt != nil {
+		if cerr := q.deletePromoCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePromoCodeStmt: %w", cerr)
+		}
+	}
+	if q.deletePromoRedemptionByReservationStmt != nil {
+		if cerr := q.deletePromoRedemptionByReservationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePromoRedemptionByReservationStmt: %w", cerr)
+		}
+	}
 	if q.deleteReservationStmt != nil {
 		if cerr := q.deleteReservationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteReservationStmt: %w", cerr)
 		}
 	}
+	if q.deleteReservationExtrasStmt != nil {
+		if cerr := q.deleteReservationExtrasStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReservationExtrasStmt: %w", cerr)
+		}
+	}
+	if q.deleteReservationGuestsStmt != nil {
+		if cerr := q.deleteReservationGuestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReservationGuestsStmt: %w", cerr)
+		}
+	}
+	if q.deleteReservationSegmentsStmt != nil {
+		if cerr := q.deleteReservationSegmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReservationSegmentsStmt: %w", cerr)
+		}
+	}
 	if q.deleteRoomStmt != nil {
 		if cerr := q.deleteRoomStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteRoomStmt: %w", cerr)
 		}
 	}
-	if q.getAvailableRoomsStmt != nil {
-		if cerr := q.getAvailableRoomsStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing getAvailableRoomsStmt: %w", cerr)
+	if q.deleteStaleICalBlocksStmt != nil {
+		if cerr := q.deleteStaleICalBlocksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStaleICalBlocksStmt: %w", cerr)
+		}
+	}
+	if q.deleteTypeStmt != nil {
+		if cerr := q.deleteTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTypeStmt: %w", cerr)
+		}
+	}
+	if q.disableWebhookSubscriptionStmt != nil {
+		if cerr := q.disableWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing disableWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.expireStaleWaitlistEntriesStmt != nil {
+		if cerr := q.expireStaleWaitlistEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing expireStaleWaitlistEntriesStmt: %w", cerr)
+		}
+	}
+	if q.getAllotmentByCodeForUpdateStmt != nil {
+		if cerr := q.getAllotmentByCodeForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAllotmentByCodeForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getAllotmentForUpdateStmt != nil {
+		if cerr := q.getAllotmentForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAllotmentForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getBookingGroupStmt != nil {
+		if cerr := q.getBookingGroupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBookingGroupStmt: %w", cerr)
+		}
+	}
+	if q.getBookingGroupForUpdateStmt != nil {
+		if cerr := q.getBookingGroupForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBookingGroupForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getChannelReservationForUpdateStmt != nil {
+		if cerr := q.getChannelReservationForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getChannelReservationForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getExtraStmt != nil {
+		if cerr := q.getExtraStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getExtraStmt: %w", cerr)
 		}
 	}
 	if q.getHotelStmt != nil {
@@ -135,11 +671,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getHotelStmt: %w", cerr)
 		}
 	}
+	if q.getICalExportStmt != nil {
+		if cerr := q.getICalExportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getICalExportStmt: %w", cerr)
+		}
+	}
+	if q.getICalFeedForUpdateStmt != nil {
+		if cerr := q.getICalFeedForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getICalFeedForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getMaxNightlyAllotmentPickupStmt != nil {
+		if cerr := q.getMaxNightlyAllotmentPickupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMaxNightlyAllotmentPickupStmt: %w", cerr)
+		}
+	}
+	if q.getMinNightlyTypeAvailabilityStmt != nil {
+		if cerr := q.getMinNightlyTypeAvailabilityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMinNightlyTypeAvailabilityStmt: %w", cerr)
+		}
+	}
+	if q.getPromoCodeStmt != nil {
+		if cerr := q.getPromoCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPromoCodeStmt: %w", cerr)
+		}
+	}
+	if q.getPromoCodeForUpdateStmt != nil {
+		if cerr := q.getPromoCodeForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPromoCodeForUpdateStmt: %w", cerr)
+		}
+	}
 	if q.getReservationStmt != nil {
 		if cerr := q.getReservationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getReservationStmt: %w", cerr)
 		}
 	}
+	if q.getReservationForUpdateStmt != nil {
+		if cerr := q.getReservationForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReservationForUpdateStmt: %w", cerr)
+		}
+	}
 	if q.getReservationsByDateRangeStmt != nil {
 		if cerr := q.getReservationsByDateRangeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getReservationsByDateRangeStmt: %w", cerr)
@@ -150,6 +721,101 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getRoomStmt: %w", cerr)
 		}
 	}
+	if q.getRoomForUpdateStmt != nil {
+		if cerr := q.getRoomForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRoomForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getTypeStmt != nil {
+		if cerr := q.getTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTypeStmt: %w", cerr)
+		}
+	}
+	if q.getUserStmt != nil {
+		if cerr := q.getUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserStmt: %w", cerr)
+		}
+	}
+	if q.getWaitlistEntryForUpdateStmt != nil {
+		if cerr := q.getWaitlistEntryForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWaitlistEntryForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookDeliveryForUpdateStmt != nil {
+		if cerr := q.getWebhookDeliveryForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookDeliveryForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookSubscriptionStmt != nil {
+		if cerr := q.getWebhookSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.getWebhookSubscriptionForUpdateStmt != nil {
+		if cerr := q.getWebhookSubscriptionForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWebhookSubscriptionForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.incrementPromoCodeRedemptionsStmt != nil {
+		if cerr := q.incrementPromoCodeRedemptionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing incrementPromoCodeRedemptionsStmt: %w", cerr)
+		}
+	}
+	if q.leaseOutboxEventStmt != nil {
+		if cerr := q.leaseOutboxEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing leaseOutboxEventStmt: %w", cerr)
+		}
+	}
+	if q.leaseWebhookDeliveryStmt != nil {
+		if cerr := q.leaseWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing leaseWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.listActiveWebhookSubscriptionsForHotelStmt != nil {
+		if cerr := q.listActiveWebhookSubscriptionsForHotelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveWebhookSubscriptionsForHotelStmt: %w", cerr)
+		}
+	}
+	if q.listApplicableStayRestrictionsStmt != nil {
+		if cerr := q.listApplicableStayRestrictionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listApplicableStayRestrictionsStmt: %w", cerr)
+		}
+	}
+	if q.listChannelMappingsByConnectionStmt != nil {
+		if cerr := q.listChannelMappingsByConnectionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listChannelMappingsByConnectionStmt: %w", cerr)
+		}
+	}
+	if q.listDueAllotmentsStmt != nil {
+		if cerr := q.listDueAllotmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueAllotmentsStmt: %w", cerr)
+		}
+	}
+	if q.listDueChannelPullsStmt != nil {
+		if cerr := q.listDueChannelPullsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueChannelPullsStmt: %w", cerr)
+		}
+	}
+	if q.listDueChannelPushesStmt != nil {
+		if cerr := q.listDueChannelPushesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueChannelPushesStmt: %w", cerr)
+		}
+	}
+	if q.listDueICalFeedsStmt != nil {
+		if cerr := q.listDueICalFeedsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueICalFeedsStmt: %w", cerr)
+		}
+	}
+	if q.listDueWebhookDeliveriesStmt != nil {
+		if cerr := q.listDueWebhookDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueWebhookDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.listExpiredHoldsStmt != nil {
+		if cerr := q.listExpiredHoldsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExpiredHoldsStmt: %w", cerr)
+		}
+	}
 	if q.listHotelsStmt != nil {
 		if cerr := q.listHotelsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listHotelsStmt: %w", cerr)
@@ -160,11 +826,76 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listHotelsByDestinationStmt: %w", cerr)
 		}
 	}
+	if q.listICalRoomBlocksStmt != nil {
+		if cerr := q.listICalRoomBlocksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listICalRoomBlocksStmt: %w", cerr)
+		}
+	}
+	if q.listICalRoomSegmentsStmt != nil {
+		if cerr := q.listICalRoomSegmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listICalRoomSegmentsStmt: %w", cerr)
+		}
+	}
+	if q.listNightlyRoomOccupancyStmt != nil {
+		if cerr := q.listNightlyRoomOccupancyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNightlyRoomOccupancyStmt: %w", cerr)
+		}
+	}
+	if q.listNightlyTypeAvailabilityStmt != nil {
+		if cerr := q.listNightlyTypeAvailabilityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNightlyTypeAvailabilityStmt: %w", cerr)
+		}
+	}
+	if q.listOutboxEventSinksStmt != nil {
+		if cerr := q.listOutboxEventSinksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOutboxEventSinksStmt: %w", cerr)
+		}
+	}
+	if q.listOverlappingRoomReservationsStmt != nil {
+		if cerr := q.listOverlappingRoomReservationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOverlappingRoomReservationsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingOutboxEventsStmt != nil {
+		if cerr := q.listPendingOutboxEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingOutboxEventsStmt: %w", cerr)
+		}
+	}
+	if q.listPromoCodesStmt != nil {
+		if cerr := q.listPromoCodesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPromoCodesStmt: %w", cerr)
+		}
+	}
+	if q.listReservationExtrasForUpdateStmt != nil {
+		if cerr := q.listReservationExtrasForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationExtrasForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.listReservationGuestsStmt != nil {
+		if cerr := q.listReservationGuestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationGuestsStmt: %w", cerr)
+		}
+	}
+	if q.listReservationSegmentsStmt != nil {
+		if cerr := q.listReservationSegmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationSegmentsStmt: %w", cerr)
+		}
+	}
+	if q.listReservationSegmentsForUpdateStmt != nil {
+		if cerr := q.listReservationSegmentsForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationSegmentsForUpdateStmt: %w", cerr)
+		}
+	}
 	if q.listReservationsStmt != nil {
 		if cerr := q.listReservationsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listReservationsStmt: %w", cerr)
 		}
 	}
+	if q.listReservationsByGroupForUpdateStmt != nil {
+		if cerr := q.listReservationsByGroupForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationsByGroupForUpdateStmt: %w", cerr)
+		}
+	}
 	if q.listReservationsByRoomStmt != nil {
 		if cerr := q.listReservationsByRoomStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listReservationsByRoomStmt: %w", cerr)
@@ -175,6 +906,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listReservationsByUserStmt: %w", cerr)
 		}
 	}
+	if q.listReservationsDueForReminderStmt != nil {
+		if cerr := q.listReservationsDueForReminderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReservationsDueForReminderStmt: %w", cerr)
+		}
+	}
 	if q.listRoomsStmt != nil {
 		if cerr := q.listRoomsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listRoomsStmt: %w", cerr)
@@ -185,16 +921,186 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listRoomsByHotelStmt: %w", cerr)
 		}
 	}
+	if q.listStayRestrictionsInRangeStmt != nil {
+		if cerr := q.listStayRestrictionsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStayRestrictionsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listTypesStmt != nil {
+		if cerr := q.listTypesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTypesStmt: %w", cerr)
+		}
+	}
+	if q.listWaitingEntriesForReleaseStmt != nil {
+		if cerr := q.listWaitingEntriesForReleaseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWaitingEntriesForReleaseStmt: %w", cerr)
+		}
+	}
+	if q.lockRoomsByHotelAndTypeStmt != nil {
+		if cerr := q.lockRoomsByHotelAndTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockRoomsByHotelAndTypeStmt: %w", cerr)
+		}
+	}
+	if q.markChannelPullFailedStmt != nil {
+		if cerr := q.markChannelPullFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markChannelPullFailedStmt: %w", cerr)
+		}
+	}
+	if q.markChannelPulledStmt != nil {
+		if cerr := q.markChannelPulledStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markChannelPulledStmt: %w", cerr)
+		}
+	}
+	if q.markChannelPushDueStmt != nil {
+		if cerr := q.markChannelPushDueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markChannelPushDueStmt: %w", cerr)
+		}
+	}
+	if q.markChannelPushFailedStmt != nil {
+		if cerr := q.markChannelPushFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markChannelPushFailedStmt: %w", cerr)
+		}
+	}
+	if q.markChannelPushedStmt != nil {
+		if cerr := q.markChannelPushedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markChannelPushedStmt: %w", cerr)
+		}
+	}
+	if q.markHotelChannelsDueStmt != nil {
+		if cerr := q.markHotelChannelsDueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markHotelChannelsDueStmt: %w", cerr)
+		}
+	}
+	if q.markICalFeedFailedStmt != nil {
+		if cerr := q.markICalFeedFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markICalFeedFailedStmt: %w", cerr)
+		}
+	}
+	if q.markICalFeedSyncedStmt != nil {
+		if cerr := q.markICalFeedSyncedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markICalFeedSyncedStmt: %w", cerr)
+		}
+	}
+	if q.markNotificationFailedStmt != nil {
+		if cerr := q.markNotificationFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationFailedStmt: %w", cerr)
+		}
+	}
+	if q.markNotificationSentStmt != nil {
+		if cerr := q.markNotificationSentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationSentStmt: %w", cerr)
+		}
+	}
+	if q.markOutboxEventDispatchedStmt != nil {
+		if cerr := q.markOutboxEventDispatchedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markOutboxEventDispatchedStmt: %w", cerr)
+		}
+	}
+	if q.markOutboxEventFailedStmt != nil {
+		if cerr := q.markOutboxEventFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markOutboxEventFailedStmt: %w", cerr)
+		}
+	}
+	if q.markWebhookDeliveryFailedStmt != nil {
+		if cerr := q.markWebhookDeliveryFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markWebhookDeliveryFailedStmt: %w", cerr)
+		}
+	}
+	if q.markWebhookDeliverySucceededStmt != nil {
+		if cerr := q.markWebhookDeliverySucceededStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markWebhookDeliverySucceededStmt: %w", cerr)
+		}
+	}
+	if q.modifyReservationStmt != nil {
+		if cerr := q.modifyReservationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing modifyReservationStmt: %w", cerr)
+		}
+	}
+	if q.notifyAvailabilityChangeStmt != nil {
+		if cerr := q.notifyAvailabilityChangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing notifyAvailabilityChangeStmt: %w", cerr)
+		}
+	}
+	if q.recordWebhookSubscriptionFailureStmt != nil {
+		if cerr := q.recordWebhookSubscriptionFailureStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordWebhookSubscriptionFailureStmt: %w", cerr)
+		}
+	}
+	if q.replayWebhookDeliveryStmt != nil {
+		if cerr := q.replayWebhookDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing replayWebhookDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.resetWebhookSubscriptionFailuresStmt != nil {
+		if cerr := q.resetWebhookSubscriptionFailuresStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetWebhookSubscriptionFailuresStmt: %w", cerr)
+		}
+	}
+	if q.resolveWaitlistOfferStmt != nil {
+		if cerr := q.resolveWaitlistOfferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resolveWaitlistOfferStmt: %w", cerr)
+		}
+	}
+	if q.updateAllotmentStmt != nil {
+		if cerr := q.updateAllotmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAllotmentStmt: %w", cerr)
+		}
+	}
+	if q.updateAllotmentStatusStmt != nil {
+		if cerr := q.updateAllotmentStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAllotmentStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateBookingGroupStatusStmt != nil {
+		if cerr := q.updateBookingGroupStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateBookingGroupStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateBookingGroupTotalPriceStmt != nil {
+		if cerr := q.updateBookingGroupTotalPriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateBookingGroupTotalPriceStmt: %w", cerr)
+		}
+	}
 	if q.updateHotelStmt != nil {
 		if cerr := q.updateHotelStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateHotelStmt: %w", cerr)
 		}
 	}
+	if q.updatePromoCodeStmt != nil {
+		if cerr := q.updatePromoCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePromoCodeStmt: %w", cerr)
+		}
+	}
+	if q.updatePromoRedemptionDiscountStmt != nil {
+		if cerr := q.updatePromoRedemptionDiscountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePromoRedemptionDiscountStmt: %w", cerr)
+		}
+	}
 	if q.updateReservationStmt != nil {
 		if cerr := q.updateReservationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateReservationStmt: %w", cerr)
 		}
 	}
+	if q.updateReservationExtraAmountStmt != nil {
+		if cerr := q.updateReservationExtraAmountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateReservationExtraAmountStmt: %w", cerr)
+		}
+	}
+	if q.updateReservationExtrasStmt != nil {
+		if cerr := q.updateReservationExtrasStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateReservationExtrasStmt: %w", cerr)
+		}
+	}
+	if q.updateReservationSegmentEndStmt != nil {
+		if cerr := q.updateReservationSegmentEndStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateReservationSegmentEndStmt: %w", cerr)
+		}
+	}
+	if q.updateReservationSegmentRoomStmt != nil {
+		if cerr := q.updateReservationSegmentRoomStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateReservationSegmentRoomStmt: %w", cerr)
+		}
+	}
 	if q.updateReservationStatusStmt != nil {
 		if cerr := q.updateReservationStatusStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateReservationStatusStmt: %w", cerr)
@@ -205,6 +1111,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateRoomStmt: %w", cerr)
 		}
 	}
+	if q.updateRoomHousekeepingStatusStmt != nil {
+		if cerr := q.updateRoomHousekeepingStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateRoomHousekeepingStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateTypeStmt != nil {
+		if cerr := q.updateTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTypeStmt: %w", cerr)
+		}
+	}
+	if q.updateWaitlistEntryOfferStmt != nil {
+		if cerr := q.updateWaitlistEntryOfferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWaitlistEntryOfferStmt: %w", cerr)
+		}
+	}
+	if q.updateWaitlistEntryStatusStmt != nil {
+		if cerr := q.updateWaitlistEntryStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWaitlistEntryStatusStmt: %w", cerr)
+		}
+	}
+	if q.upsertICalBlockStmt != nil {
+		if cerr := q.upsertICalBlockStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertICalBlockStmt: %w", cerr)
+		}
+	}
+	if q.upsertICalExportStmt != nil {
+		if cerr := q.upsertICalExportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertICalExportStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -242,57 +1178,291 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                             DBTX
-	tx                             *sql.Tx
-	createHotelStmt                *sql.Stmt
-	createReservationStmt          *sql.Stmt
-	createRoomStmt                 *sql.Stmt
-	deleteHotelStmt                *sql.Stmt
-	deleteReservationStmt          *sql.Stmt
-	deleteRoomStmt                 *sql.Stmt
-	getAvailableRoomsStmt          *sql.Stmt
-	getHotelStmt                   *sql.Stmt
-	getReservationStmt             *sql.Stmt
-	getReservationsByDateRangeStmt *sql.Stmt
-	getRoomStmt                    *sql.Stmt
-	listHotelsStmt                 *sql.Stmt
-	listHotelsByDestinationStmt    *sql.Stmt
-	listReservationsStmt           *sql.Stmt
-	listReservationsByRoomStmt     *sql.Stmt
-	listReservationsByUserStmt     *sql.Stmt
-	listRoomsStmt                  *sql.Stmt
-	listRoomsByHotelStmt           *sql.Stmt
-	updateHotelStmt                *sql.Stmt
-	updateReservationStmt          *sql.Stmt
-	updateReservationStatusStmt    *sql.Stmt
-	updateRoomStmt                 *sql.Stmt
+	db                                         DBTX
+	tx                                         *sql.Tx
+	assignReservationRoomStmt                  *sql.Stmt
+	claimNotificationStmt                      *sql.Stmt
+	clearReservationHoldStmt                   *sql.Stmt
+	countAllotmentReservationsStmt             *sql.Stmt
+	countOverlappingRoomBlocksStmt             *sql.Stmt
+	countOverlappingRoomReservationsStmt       *sql.Stmt
+	countPromoRedemptionsByUserStmt            *sql.Stmt
+	countReservationsByConfirmationCodeStmt    *sql.Stmt
+	createAllotmentStmt                        *sql.Stmt
+	createBookingGroupStmt                     *sql.Stmt
+	createHotelStmt                            *sql.Stmt
+	createOutboxDeliveryStmt                   *sql.Stmt
+	createOutboxEventStmt                      *sql.Stmt
+	createPromoCodeStmt                        *sql.Stmt
+	createPromoRedemptionStmt                  *sql.Stmt
+	createReservationStmt                      *sql.Stmt
+	createReservationExtraStmt                 *sql.Stmt
+	createReservationGuestStmt                 *sql.Stmt
+	createReservationModificationStmt          *sql.Stmt
+	createReservationSegmentStmt               *sql.Stmt
+	createRoomStmt                             *sql.Stmt
+	createRoomBlockStmt                        *sql.Stmt
+	createTypeStmt                             *sql.Stmt
+	createWaitlistEntryStmt                    *sql.Stmt
+	createWebhookDeliveryStmt                  *sql.Stmt
+	decrementPromoCodeRedemptionsStmt          *sql.Stmt
+	deleteHotelStmt                            *sql.Stmt
+	deletePromoCodeStmt                        *sql.Stmt
+	deletePromoRedemptionByReservationStmt     *sql.Stmt
+	deleteReservationStmt                      *sql.Stmt
+	deleteReservationExtrasStmt                *sql.Stmt
+	deleteReservationGuestsStmt                *sql.Stmt
+	deleteReservationSegmentsStmt              *sql.Stmt
+	deleteRoomStmt                             *sql.Stmt
+	deleteStaleICalBlocksStmt                  *sql.Stmt
+	deleteTypeStmt                             *sql.Stmt
+	disableWebhookSubscriptionStmt             *sql.Stmt
+	expireStaleWaitlistEntriesStmt             *sql.Stmt
+	getAllotmentByCodeForUpdateStmt            *sql.Stmt
+	getAllotmentForUpdateStmt                  *sql.Stmt
+	getBookingGroupStmt                        *sql.Stmt
+	getBookingGroupForUpdateStmt               *sql.Stmt
+	getChannelReservationForUpdateStmt         *sql.Stmt
+	getExtraStmt                               *sql.Stmt
+	getHotelStmt                               *sql.Stmt
+	getICalExportStmt                          *sql.Stmt
+	getICalFeedForUpdateStmt                   *sql.Stmt
+	getMaxNightlyAllotmentPickupStmt           *sql.Stmt
+	getMinNightlyTypeAvailabilityStmt          *sql.Stmt
+	getPromoCodeStmt                           *sql.Stmt
+	getPromoCodeForUpdateStmt                  *sql.Stmt
+	getReservationStmt                         *sql.Stmt
+	getReservationForUpdateStmt                *sql.Stmt
+	getReservationsByDateRangeStmt             *sql.Stmt
+	getRoomStmt                                *sql.Stmt
+	getRoomForUpdateStmt                       *sql.Stmt
+	getTypeStmt                                *sql.Stmt
+	getUserStmt                                *sql.Stmt
+	getWaitlistEntryForUpdateStmt              *sql.Stmt
+	getWebhookDeliveryForUpdateStmt            *sql.Stmt
+	getWebhookSubscriptionStmt                 *sql.Stmt
+	getWebhookSubscriptionForUpdateStmt        *sql.Stmt
+	incrementPromoCodeRedemptionsStmt          *sql.Stmt
+	leaseOutboxEventStmt                       *sql.Stmt
+	leaseWebhookDeliveryStmt                   *sql.Stmt
+	listActiveWebhookSubscriptionsForHotelStmt *sql.Stmt
+	listApplicableStayRestrictionsStmt         *sql.Stmt
+	listChannelMappingsByConnectionStmt        *sql.Stmt
+	listDueAllotmentsStmt                      *sql.Stmt
+	listDueChannelPullsStmt                    *sql.Stmt
+	listDueChannelPushesStmt                   *sql.Stmt
+	listDueICalFeedsStmt                       *sql.Stmt
+	listDueWebhookDeliveriesStmt               *sql.Stmt
+	listExpiredHoldsStmt                       *sql.Stmt
+	listHotelsStmt                             *sql.Stmt
+	listHotelsByDestinationStmt                *sql.Stmt
+	listICalRoomBlocksStmt                     *sql.Stmt
+	listICalRoomSegmentsStmt                   *sql.Stmt
+	listNightlyRoomOccupancyStmt               *sql.Stmt
+	listNightlyTypeAvailabilityStmt            *sql.Stmt
+	listOutboxEventSinksStmt                   *sql.Stmt
+	listOverlappingRoomReservationsStmt        *sql.Stmt
+	listPendingOutboxEventsStmt                *sql.Stmt
+	listPromoCodesStmt                         *sql.Stmt
+	listReservationExtrasForUpdateStmt         *sql.Stmt
+	listReservationGuestsStmt                  *sql.Stmt
+	listReservationSegmentsStmt                *sql.Stmt
+	listReservationSegmentsForUpdateStmt       *sql.Stmt
+	listReservationsStmt                       *sql.Stmt
+	listReservationsByGroupForUpdateStmt       *sql.Stmt
+	listReservationsByRoomStmt                 *sql.Stmt
+	listReservationsByUserStmt                 *sql.Stmt
+	listReservationsDueForReminderStmt         *sql.Stmt
+	listRoomsStmt                              *sql.Stmt
+	listRoomsByHotelStmt                       *sql.Stmt
+	listStayRestrictionsInRangeStmt            *sql.Stmt
+	listTypesStmt                              *sql.Stmt
+	listWaitingEntriesForReleaseStmt           *sql.Stmt
+	lockRoomsByHotelAndTypeStmt                *sql.Stmt
+	markChannelPullFailedStmt                  *sql.Stmt
+	markChannelPulledStmt                      *sql.Stmt
+	markChannelPushDueStmt                     *sql.Stmt
+	markChannelPushFailedStmt                  *sql.Stmt
+	markChannelPushedStmt                      *sql.Stmt
+	markHotelChannelsDueStmt                   *sql.Stmt
+	markICalFeedFailedStmt                     *sql.Stmt
+	markICalFeedSyncedStmt                     *sql.Stmt
+	markNotificationFailedStmt                 *sql.Stmt
+	markNotificationSentStmt                   *sql.Stmt
+	markOutboxEventDispatchedStmt              *sql.Stmt
+	markOutboxEventFailedStmt                  *sql.Stmt
+	markWebhookDeliveryFailedStmt              *sql.Stmt
+	markWebhookDeliverySucceededStmt           *sql.Stmt
+	modifyReservationStmt                      *sql.Stmt
+	notifyAvailabilityChangeStmt               *sql.Stmt
+	recordWebhookSubscriptionFailureStmt       *sql.Stmt
+	replayWebhookDeliveryStmt                  *sql.Stmt
+	resetWebhookSubscriptionFailuresStmt       *sql.Stmt
+	resolveWaitlistOfferStmt                   *sql.Stmt
+	updateAllotmentStmt                        *sql.Stmt
+	updateAllotmentStatusStmt                  *sql.Stmt
+	updateBookingGroupStatusStmt               *sql.Stmt
+	updateBookingGroupTotalPriceStmt           *sql.Stmt
+	updateHotelStmt                            *sql.Stmt
+	updatePromoCodeStmt                        *sql.Stmt
+	updatePromoRedemptionDiscountStmt          *sql.Stmt
+	updateReservationStmt                      *sql.Stmt
+	updateReservationExtraAmountStmt           *sql.Stmt
+	updateReservationExtrasStmt                *sql.Stmt
+	updateReservationSegmentEndStmt            *sql.Stmt
+	updateReservationSegmentRoomStmt           *sql.Stmt
+	updateReservationStatusStmt                *sql.Stmt
+	updateRoomStmt                             *sql.Stmt
+	updateRoomHousekeepingStatusStmt           *sql.Stmt
+	updateTypeStmt                             *sql.Stmt
+	updateWaitlistEntryOfferStmt               *sql.Stmt
+	updateWaitlistEntryStatusStmt              *sql.Stmt
+	upsertICalBlockStmt                        *sql.Stmt
+	upsertICalExportStmt                       *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                             tx,
-		tx:                             tx,
-		createHotelStmt:                q.createHotelStmt,
-		createReservationStmt:          q.createReservationStmt,
-		createRoomStmt:                 q.createRoomStmt,
-		deleteHotelStmt:                q.deleteHotelStmt,
-		deleteReservationStmt:          q.deleteReservationStmt,
-		deleteRoomStmt:                 q.deleteRoomStmt,
-		getAvailableRoomsStmt:          q.getAvailableRoomsStmt,
-		getHotelStmt:                   q.getHotelStmt,
-		getReservationStmt:             q.getReservationStmt,
-		getReservationsByDateRangeStmt: q.getReservationsByDateRangeStmt,
-		getRoomStmt:                    q.getRoomStmt,
-		listHotelsStmt:                 q.listHotelsStmt,
-		listHotelsByDestinationStmt:    q.listHotelsByDestinationStmt,
-		listReservationsStmt:           q.listReservationsStmt,
-		listReservationsByRoomStmt:     q.listReservationsByRoomStmt,
-		listReservationsByUserStmt:     q.listReservationsByUserStmt,
-		listRoomsStmt:                  q.listRoomsStmt,
-		listRoomsByHotelStmt:           q.listRoomsByHotelStmt,
-		updateHotelStmt:                q.updateHotelStmt,
-		updateReservationStmt:          q.updateReservationStmt,
-		updateReservationStatusStmt:    q.updateReservationStatusStmt,
-		updateRoomStmt:                 q.updateRoomStmt,
+		db:                                         tx,
+		tx:                                         tx,
+		assignReservationRoomStmt:                  q.assignReservationRoomStmt,
+		claimNotificationStmt:                      q.claimNotificationStmt,
+		clearReservationHoldStmt:                   q.clearReservationHoldStmt,
+		countAllotmentReservationsStmt:             q.countAllotmentReservationsStmt,
+		countOverlappingRoomBlocksStmt:             q.countOverlappingRoomBlocksStmt,
+		countOverlappingRoomReservationsStmt:       q.countOverlappingRoomReservationsStmt,
+		countPromoRedemptionsByUserStmt:            q.countPromoRedemptionsByUserStmt,
+		countReservationsByConfirmationCodeStmt:    q.countReservationsByConfirmationCodeStmt,
+		createAllotmentStmt:                        q.createAllotmentStmt,
+		createBookingGroupStmt:                     q.createBookingGroupStmt,
+		createHotelStmt:                            q.createHotelStmt,
+		createOutboxDeliveryStmt:                   q.createOutboxDeliveryStmt,
+		createOutboxEventStmt:                      q.createOutboxEventStmt,
+		createPromoCodeStmt:                        q.createPromoCodeStmt,
+		createPromoRedemptionStmt:                  q.createPromoRedemptionStmt,
+		createReservationStmt:                      q.createReservationStmt,
+		createReservationExtraStmt:                 q.createReservationExtraStmt,
+		createReservationGuestStmt:                 q.createReservationGuestStmt,
+		createReservationModificationStmt:          q.createReservationModificationStmt,
+		createReservationSegmentStmt:               q.createReservationSegmentStmt,
+		createRoomStmt:                             q.createRoomStmt,
+		createRoomBlockStmt:                        q.createRoomBlockStmt,
+		createTypeStmt:                             q.createTypeStmt,
+		createWaitlistEntryStmt:                    q.createWaitlistEntryStmt,
+		createWebhookDeliveryStmt:                  q.createWebhookDeliveryStmt,
+		decrementPromoCodeRedemptionsStmt:          q.decrementPromoCodeRedemptionsStmt,
+		deleteHotelStmt:                            q.deleteHotelStmt,
+		deletePromoCodeStmt:                        q.deletePromoCodeStmt,
+		deletePromoRedemptionByReservationStmt:     q.deletePromoRedemptionByReservationStmt,
+		deleteReservationStmt:                      q.deleteReservationStmt,
+		deleteReservationExtrasStmt:                q.deleteReservationExtrasStmt,
+		deleteReservationGuestsStmt:                q.deleteReservationGuestsStmt,
+		deleteReservationSegmentsStmt:              q.deleteReservationSegmentsStmt,
+		deleteRoomStmt:                             q.deleteRoomStmt,
+		deleteStaleICalBlocksStmt:                  q.deleteStaleICalBlocksStmt,
+		deleteTypeStmt:                             q.deleteTypeStmt,
+		disableWebhookSubscriptionStmt:             q.disableWebhookSubscriptionStmt,
+		expireStaleWaitlistEntriesStmt:             q.expireStaleWaitlistEntriesStmt,
+		getAllotmentByCodeForUpdateStmt:            q.getAllotmentByCodeForUpdateStmt,
+		getAllotmentForUpdateStmt:                  q.getAllotmentForUpdateStmt,
+		getBookingGroupStmt:                        q.getBookingGroupStmt,
+		getBookingGroupForUpdateStmt:               q.getBookingGroupForUpdateStmt,
+		getChannelReservationForUpdateStmt:         q.getChannelReservationForUpdateStmt,
+		getExtraStmt:                               q.getExtraStmt,
+		getHotelStmt:                               q.getHotelStmt,
+		getICalExportStmt:                          q.getICalExportStmt,
+		getICalFeedForUpdateStmt:                   q.getICalFeedForUpdateStmt,
+		getMaxNightlyAllotmentPickupStmt:           q.getMaxNightlyAllotmentPickupStmt,
+		getMinNightlyTypeAvailabilityStmt:          q.getMinNightlyTypeAvailabilityStmt,
+		getPromoCodeStmt:                           q.getPromoCodeStmt,
+		getPromoCodeForUpdateStmt:                  q.getPromoCodeForUpdateStmt,
+		getReservationStmt:                         q.getReservationStmt,
+		getReservationForUpdateStmt:                q.getReservationForUpdateStmt,
+		getReservationsByDateRangeStmt:             q.getReservationsByDateRangeStmt,
+		getRoomStmt:                                q.getRoomStmt,
+		getRoomForUpdateStmt:                       q.getRoomForUpdateStmt,
+		getTypeStmt:                                q.getTypeStmt,
+		getUserStmt:                                q.getUserStmt,
+		getWaitlistEntryForUpdateStmt:              q.getWaitlistEntryForUpdateStmt,
+		getWebhookDeliveryForUpdateStmt:            q.getWebhookDeliveryForUpdateStmt,
+		getWebhookSubscriptionStmt:                 q.getWebhookSubscriptionStmt,
+		getWebhookSubscriptionForUpdateStmt:        q.getWebhookSubscriptionForUpdateStmt,
+		incrementPromoCodeRedemptionsStmt:          q.incrementPromoCodeRedemptionsStmt,
+		leaseOutboxEventStmt:                       q.leaseOutboxEventStmt,
+		leaseWebhookDeliveryStmt:                   q.leaseWebhookDeliveryStmt,
+		listActiveWebhookSubscriptionsForHotelStmt: q.listActiveWebhookSubscriptionsForHotelStmt,
+		listApplicableStayRestrictionsStmt:         q.listApplicableStayRestrictionsStmt,
+		listChannelMappingsByConnectionStmt:        q.listChannelMappingsByConnectionStmt,
+		listDueAllotmentsStmt:                      q.listDueAllotmentsStmt,
+		listDueChannelPullsStmt:                    q.listDueChannelPullsStmt,
+		listDueChannelPushesStmt:                   q.listDueChannelPushesStmt,
+		listDueICalFeedsStmt:                       q.listDueICalFeedsStmt,
+		listDueWebhookDeliveriesStmt:               q.listDueWebhookDeliveriesStmt,
+		listExpiredHoldsStmt:                       q.listExpiredHoldsStmt,
+		listHotelsStmt:                             q.listHotelsStmt,
+		listHotelsByDestinationStmt:                q.listHotelsByDestinationStmt,
+		listICalRoomBlocksStmt:                     q.listICalRoomBlocksStmt,
+		listICalRoomSegmentsStmt:                   q.listICalRoomSegmentsStmt,
+		listNightlyRoomOccupancyStmt:               q.listNightlyRoomOccupancyStmt,
+		listNightlyTypeAvailabilityStmt:            q.listNightlyTypeAvailabilityStmt,
+		listOutboxEventSinksStmt:                   q.listOutboxEventSinksStmt,
+		listOverlappingRoomReservationsStmt:        q.listOverlappingRoomReservationsStmt,
+		listPendingOutboxEventsStmt:                q.listPendingOutboxEventsStmt,
+		listPromoCodesStmt:                         q.listPromoCodesStmt,
+		listReservationExtrasForUpdateStmt:         q.listReservationExtrasForUpdateStmt,
+		listReservationGuestsStmt:                  q.listReservationGuestsStmt,
+		listReservationSegmentsStmt:                q.listReservationSegmentsStmt,
+		listReservationSegmentsForUpdateStmt:       q.listReservationSegmentsForUpdateStmt,
+		listReservationsStmt:                       q.listReservationsStmt,
+		listReservationsByGroupForUpdateStmt:       q.listReservationsByGroupForUpdateStmt,
+		listReservationsByRoomStmt:                 q.listReservationsByRoomStmt,
+		listReservationsByUserStmt:                 q.listReservationsByUserStmt,
+		listReservationsDueForReminderStmt:         q.listReservationsDueForReminderStmt,
+		listRoomsStmt:                              q.listRoomsStmt,
+		listRoomsByHotelStmt:                       q.listRoomsByHotelStmt,
+		listStayRestrictionsInRangeStmt:            q.listStayRestrictionsInRangeStmt,
+		listTypesStmt:                              q.listTypesStmt,
+		listWaitingEntriesForReleaseStmt:           q.listWaitingEntriesForReleaseStmt,
+		lockRoomsByHotelAndTypeStmt:                q.lockRoomsByHotelAndTypeStmt,
+		markChannelPullFailedStmt:                  q.markChannelPullFailedStmt,
+		markChannelPulledStmt:                      q.markChannelPulledStmt,
+		markChannelPushDueStmt:                     q.markChannelPushDueStmt,
+		markChannelPushFailedStmt:                  q.markChannelPushFailedStmt,
+		markChannelPushedStmt:                      q.markChannelPushedStmt,
+		markHotelChannelsDueStmt:                   q.markHotelChannelsDueStmt,
+		markICalFeedFailedStmt:                     q.markICalFeedFailedStmt,
+		markICalFeedSyncedStmt:                     q.markICalFeedSyncedStmt,
+		markNotificationFailedStmt:                 q.markNotificationFailedStmt,
+		markNotificationSentStmt:                   q.markNotificationSentStmt,
+		markOutboxEventDispatchedStmt:              q.markOutboxEventDispatchedStmt,
+		markOutboxEventFailedStmt:                  q.markOutboxEventFailedStmt,
+		markWebhookDeliveryFailedStmt:              q.markWebhookDeliveryFailedStmt,
+		markWebhookDeliverySucceededStmt:           q.markWebhookDeliverySucceededStmt,
+		modifyReservationStmt:                      q.modifyReservationStmt,
+		notifyAvailabilityChangeStmt:               q.notifyAvailabilityChangeStmt,
+		recordWebhookSubscriptionFailureStmt:       q.recordWebhookSubscriptionFailureStmt,
+		replayWebhookDeliveryStmt:                  q.replayWebhookDeliveryStmt,
+		resetWebhookSubscriptionFailuresStmt:       q.resetWebhookSubscriptionFailuresStmt,
+		resolveWaitlistOfferStmt:                   q.resolveWaitlistOfferStmt,
+		updateAllotmentStmt:                        q.updateAllotmentStmt,
+		updateAllotmentStatusStmt:                  q.updateAllotmentStatusStmt,
+		updateBookingGroupStatusStmt:               q.updateBookingGroupStatusStmt,
+		updateBookingGroupTotalPriceStmt:           q.updateBookingGroupTotalPriceStmt,
+		updateHotelStmt:                            q.updateHotelStmt,
+		updatePromoCodeStmt:                        q.updatePromoCodeStmt,
+		updatePromoRedemptionDiscountStmt:          q.updatePromoRedemptionDiscountStmt,
+		updateReservationStmt:                      q.updateReservationStmt,
+		updateReservationExtraAmountStmt:           q.updateReservationExtraAmountStmt,
+		updateReservationExtrasStmt:                q.updateReservationExtrasStmt,
+		updateReservationSegmentEndStmt:            q.updateReservationSegmentEndStmt,
+		updateReservationSegmentRoomStmt:           q.updateReservationSegmentRoomStmt,
+		updateReservationStatusStmt:                q.updateReservationStatusStmt,
+		updateRoomStmt:                             q.updateRoomStmt,
+		updateRoomHousekeepingStatusStmt:           q.updateRoomHousekeepingStatusStmt,
+		updateTypeStmt:                             q.updateTypeStmt,
+		updateWaitlistEntryOfferStmt:               q.updateWaitlistEntryOfferStmt,
+		updateWaitlistEntryStatusStmt:              q.updateWaitlistEntryStatusStmt,
+		upsertICalBlockStmt:                        q.upsertICalBlockStmt,
+		upsertICalExportStmt:                       q.upsertICalExportStmt,
 	}
 }
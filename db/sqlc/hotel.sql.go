@@ -18,18 +18,32 @@ INSERT INTO hotel (
   destination_id,
   type_id,
   total_room,
-  rating
+  rating,
+  time_zone,
+  check_in_time,
+  check_out_time,
+  day_use_start_time,
+  day_use_end_time,
+  day_use_slot_minutes,
+  day_use_buffer_minutes
 ) VALUES (
-  $1, $2, $3, $4, $5
-) RETURNING hotel_id, destination_id, type_id, total_room, rating
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING hotel_id, destination_id, type_id, total_room, rating, time_zone, check_in_time, check_out_time, day_use_start_time, day_use_end_time, day_use_slot_minutes, day_use_buffer_minutes
 `
 
 type CreateHotelParams struct {
-	HotelID       uuid.UUID       `json:"hotel_id"`
-	DestinationID uuid.NullUUID   `json:"destination_id"`
-	TypeID        sql.NullString  `json:"type_id"`
-	TotalRoom     sql.NullInt32   `json:"total_room"`
-	Rating        sql.NullFloat64 `json:"rating"`
+	HotelID             uuid.UUID       `json:"hotel_id"`
+	DestinationID       uuid.NullUUID   `json:"destination_id"`
+	TypeID              sql.NullString  `json:"type_id"`
+	TotalRoom           sql.NullInt32   `json:"total_room"`
+	Rating              sql.NullFloat64 `json:"rating"`
+	TimeZone            sql.NullString  `json:"time_zone"`
+	CheckInTime         sql.NullString  `json:"check_in_time"`
+	CheckOutTime        sql.NullString  `json:"check_out_time"`
+	DayUseStartTime     sql.NullString  `json:"day_use_start_time"`
+	DayUseEndTime       sql.NullString  `json:"day_use_end_time"`
+	DayUseSlotMinutes   sql.NullInt32   `json:"day_use_slot_minutes"`
+	DayUseBufferMinutes sql.NullInt32   `json:"day_use_buffer_minutes"`
 }
 
 func (q *Queries) CreateHotel(ctx context.Context, arg CreateHotelParams) (Hotel, error) {
@@ -39,6 +53,13 @@ func (q *Queries) CreateHotel(ctx context.Context, arg CreateHotelParams) (Hotel
 		arg.TypeID,
 		arg.TotalRoom,
 		arg.Rating,
+		arg.TimeZone,
+		arg.CheckInTime,
+		arg.CheckOutTime,
+		arg.DayUseStartTime,
+		arg.DayUseEndTime,
+		arg.DayUseSlotMinutes,
+		arg.DayUseBufferMinutes,
 	)
 	var i Hotel
 	err := row.Scan(
@@ -47,6 +68,13 @@ func (q *Queries) CreateHotel(ctx context.Context, arg CreateHotelParams) (Hotel
 		&i.TypeID,
 		&i.TotalRoom,
 		&i.Rating,
+		&i.TimeZone,
+		&i.CheckInTime,
+		&i.CheckOutTime,
+		&i.DayUseStartTime,
+		&i.DayUseEndTime,
+		&i.DayUseSlotMinutes,
+		&i.DayUseBufferMinutes,
 	)
 	return i, err
 }
@@ -62,7 +90,7 @@ func (q *Queries) DeleteHotel(ctx context.Context, hotelID uuid.UUID) error {
 }
 
 const getHotel = `-- name: GetHotel :one
-SELECT hotel_id, destination_id, type_id, total_room, rating FROM hotel
+SELECT hotel_id, destination_id, type_id, total_room, rating, time_zone, check_in_time, check_out_time, day_use_start_time, day_use_end_time, day_use_slot_minutes, day_use_buffer_minutes FROM hotel
 WHERE hotel_id = $1 LIMIT 1
 `
 
@@ -75,12 +103,19 @@ func (q *Queries) GetHotel(ctx context.Context, hotelID uuid.UUID) (Hotel, error
 		&i.TypeID,
 		&i.TotalRoom,
 		&i.Rating,
+		&i.TimeZone,
+		&i.CheckInTime,
+		&i.CheckOutTime,
+		&i.DayUseStartTime,
+		&i.DayUseEndTime,
+		&i.DayUseSlotMinutes,
+		&i.DayUseBufferMinutes,
 	)
 	return i, err
 }
 
 const listHotels = `-- name: ListHotels :many
-SELECT hotel_id, destination_id, type_id, total_room, rating FROM hotel
+SELECT hotel_id, destination_id, type_id, total_room, rating, time_zone, check_in_time, check_out_time, day_use_start_time, day_use_end_time, day_use_slot_minutes, day_use_buffer_minutes FROM hotel
 ORDER BY hotel_id
 LIMIT $1
 OFFSET $2
@@ -106,6 +141,13 @@ func (q *Queries) ListHotels(ctx context.Context, arg ListHotelsParams) ([]Hotel
 			&i.TypeID,
 			&i.TotalRoom,
 			&i.Rating,
+			&i.TimeZone,
+			&i.CheckInTime,
+			&i.CheckOutTime,
+			&i.DayUseStartTime,
+			&i.DayUseEndTime,
+			&i.DayUseSlotMinutes,
+			&i.DayUseBufferMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -121,7 +163,7 @@ func (q *Queries) ListHotels(ctx context.Context, arg ListHotelsParams) ([]Hotel
 }
 
 const listHotelsByDestination = `-- name: ListHotelsByDestination :many
-SELECT hotel_id, destination_id, type_id, total_room, rating FROM hotel
+SELECT hotel_id, destination_id, type_id, total_room, rating, time_zone, check_in_time, check_out_time, day_use_start_time, day_use_end_time, day_use_slot_minutes, day_use_buffer_minutes FROM hotel
 WHERE destination_id = $1
 ORDER BY rating DESC
 LIMIT $2
@@ -149,6 +191,13 @@ func (q *Queries) ListHotelsByDestination(ctx context.Context, arg ListHotelsByD
 			&i.TypeID,
 			&i.TotalRoom,
 			&i.Rating,
+			&i.TimeZone,
+			&i.CheckInTime,
+			&i.CheckOutTime,
+			&i.DayUseStartTime,
+			&i.DayUseEndTime,
+			&i.DayUseSlotMinutes,
+			&i.DayUseBufferMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -169,17 +218,31 @@ SET
   destination_id = $2,
   type_id = $3,
   total_room = $4,
-  rating = $5
+  rating = $5,
+  time_zone = $6,
+  check_in_time = $7,
+  check_out_time = $8,
+  day_use_start_time = $9,
+  day_use_end_time = $10,
+  day_use_slot_minutes = $11,
+  day_use_buffer_minutes = $12
 WHERE hotel_id = $1
-RETURNING hotel_id, destination_id, type_id, total_room, rating
+RETURNING hotel_id, destination_id, type_id, total_room, rating, time_zone, check_in_time, check_out_time, day_use_start_time, day_use_end_time, day_use_slot_minutes, day_use_buffer_minutes
 `
 
 type UpdateHotelParams struct {
-	HotelID       uuid.UUID       `json:"hotel_id"`
-	DestinationID uuid.NullUUID   `json:"destination_id"`
-	TypeID        sql.NullString  `json:"type_id"`
-	TotalRoom     sql.NullInt32   `json:"total_room"`
-	Rating        sql.NullFloat64 `json:"rating"`
+	HotelID             uuid.UUID       `json:"hotel_id"`
+	DestinationID       uuid.NullUUID   `json:"destination_id"`
+	TypeID              sql.NullString  `json:"type_id"`
+	TotalRoom           sql.NullInt32   `json:"total_room"`
+	Rating              sql.NullFloat64 `json:"rating"`
+	TimeZone            sql.NullString  `json:"time_zone"`
+	CheckInTime         sql.NullString  `json:"check_in_time"`
+	CheckOutTime        sql.NullString  `json:"check_out_time"`
+	DayUseStartTime     sql.NullString  `json:"day_use_start_time"`
+	DayUseEndTime       sql.NullString  `json:"day_use_end_time"`
+	DayUseSlotMinutes   sql.NullInt32   `json:"day_use_slot_minutes"`
+	DayUseBufferMinutes sql.NullInt32   `json:"day_use_buffer_minutes"`
 }
 
 func (q *Queries) UpdateHotel(ctx context.Context, arg UpdateHotelParams) (Hotel, error) {
@@ -189,6 +252,13 @@ func (q *Queries) UpdateHotel(ctx context.Context, arg UpdateHotelParams) (Hotel
 		arg.TypeID,
 		arg.TotalRoom,
 		arg.Rating,
+		arg.TimeZone,
+		arg.CheckInTime,
+		arg.CheckOutTime,
+		arg.DayUseStartTime,
+		arg.DayUseEndTime,
+		arg.DayUseSlotMinutes,
+		arg.DayUseBufferMinutes,
 	)
 	var i Hotel
 	err := row.Scan(
@@ -197,6 +267,13 @@ func (q *Queries) UpdateHotel(ctx context.Context, arg UpdateHotelParams) (Hotel
 		&i.TypeID,
 		&i.TotalRoom,
 		&i.Rating,
+		&i.TimeZone,
+		&i.CheckInTime,
+		&i.CheckOutTime,
+		&i.DayUseStartTime,
+		&i.DayUseEndTime,
+		&i.DayUseSlotMinutes,
+		&i.DayUseBufferMinutes,
 	)
 	return i, err
 }
@@ -6,15 +6,80 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+type Allotment struct {
+	AllotmentID uuid.UUID      `json:"allotment_id"`
+	BlockCode   string         `json:"block_code"`
+	Name        sql.NullString `json:"name"`
+	HotelID     uuid.NullUUID  `json:"hotel_id"`
+	TypeID      sql.NullString `json:"type_id"`
+	StartDate   sql.NullTime   `json:"start_date"`
+	EndDate     sql.NullTime   `json:"end_date"`
+	Quantity    sql.NullInt32  `json:"quantity"`
+	Rate        sql.NullInt32  `json:"rate"`
+	ReleaseDate sql.NullTime   `json:"release_date"`
+	Status      sql.NullString `json:"status"`
+	ReleasedAt  sql.NullTime   `json:"released_at"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	CreatedBy   uuid.NullUUID  `json:"created_by"`
+	UpdateAt    sql.NullTime   `json:"update_at"`
+	UpdateBy    uuid.NullUUID  `json:"update_by"`
+}
+
 type Amenity struct {
 	AmenityCode string         `json:"amenity_code"`
 	Description sql.NullString `json:"description"`
 }
 
+type BookingGroup struct {
+	GroupID      uuid.UUID      `json:"group_id"`
+	UserID       sql.NullString `json:"user_id"`
+	ContactName  sql.NullString `json:"contact_name"`
+	ContactEmail sql.NullString `json:"contact_email"`
+	ContactPhone sql.NullString `json:"contact_phone"`
+	StartDate    sql.NullTime   `json:"start_date"`
+	EndDate      sql.NullTime   `json:"end_date"`
+	Status       sql.NullString `json:"status"`
+	TotalPrice   sql.NullInt32  `json:"total_price"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	CreatedBy    uuid.NullUUID  `json:"created_by"`
+	UpdateAt     sql.NullTime   `json:"update_at"`
+	UpdateBy     uuid.NullUUID  `json:"update_by"`
+}
+
+type ChannelConnection struct {
+	ConnectionID uuid.UUID      `json:"connection_id"`
+	HotelID      uuid.UUID      `json:"hotel_id"`
+	Channel      string         `json:"channel"`
+	HotelCode    string         `json:"hotel_code"`
+	IsActive     bool           `json:"is_active"`
+	LastPushedAt sql.NullTime   `json:"last_pushed_at"`
+	PushError    sql.NullString `json:"push_error"`
+	LastPulledAt sql.NullTime   `json:"last_pulled_at"`
+	PullError    sql.NullString `json:"pull_error"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	CreatedBy    uuid.NullUUID  `json:"created_by"`
+	UpdateAt     sql.NullTime   `json:"update_at"`
+	UpdateBy     uuid.NullUUID  `json:"update_by"`
+	PushDueAt    sql.NullTime   `json:"push_due_at"`
+}
+
+type ChannelMapping struct {
+	MappingID    uuid.UUID      `json:"mapping_id"`
+	ConnectionID uuid.UUID      `json:"connection_id"`
+	TypeID       sql.NullString `json:"type_id"`
+	RoomID       uuid.NullUUID  `json:"room_id"`
+	RoomCode     string         `json:"room_code"`
+	RateCode     sql.NullString `json:"rate_code"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	CreatedBy    uuid.NullUUID  `json:"created_by"`
+}
+
 type Destination struct {
 	DestinationID uuid.UUID      `json:"destination_id"`
 	Address       sql.NullString `json:"address"`
@@ -24,12 +89,55 @@ type Destination struct {
 	Boundary      interface{}    `json:"boundary"`
 }
 
+type Extra struct {
+	ExtraID     uuid.UUID      `json:"extra_id"`
+	HotelID     uuid.NullUUID  `json:"hotel_id"`
+	Code        sql.NullString `json:"code"`
+	Name        sql.NullString `json:"name"`
+	Description sql.NullString `json:"description"`
+	Pricing     sql.NullString `json:"pricing"`
+	Price       sql.NullInt32  `json:"price"`
+	IsActive    sql.NullBool   `json:"is_active"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	CreatedBy   uuid.NullUUID  `json:"created_by"`
+	UpdateAt    sql.NullTime   `json:"update_at"`
+	UpdateBy    uuid.NullUUID  `json:"update_by"`
+}
+
 type Hotel struct {
-	HotelID       uuid.UUID       `json:"hotel_id"`
-	DestinationID uuid.NullUUID   `json:"destination_id"`
-	TypeID        sql.NullString  `json:"type_id"`
-	TotalRoom     sql.NullInt32   `json:"total_room"`
-	Rating        sql.NullFloat64 `json:"rating"`
+	HotelID             uuid.UUID       `json:"hotel_id"`
+	DestinationID       uuid.NullUUID   `json:"destination_id"`
+	TypeID              sql.NullString  `json:"type_id"`
+	TotalRoom           sql.NullInt32   `json:"total_room"`
+	Rating              sql.NullFloat64 `json:"rating"`
+	TimeZone            sql.NullString  `json:"time_zone"`
+	CheckInTime         sql.NullString  `json:"check_in_time"`
+	CheckOutTime        sql.NullString  `json:"check_out_time"`
+	DayUseStartTime     sql.NullString  `json:"day_use_start_time"`
+	DayUseEndTime       sql.NullString  `json:"day_use_end_time"`
+	DayUseSlotMinutes   sql.NullInt32   `json:"day_use_slot_minutes"`
+	DayUseBufferMinutes sql.NullInt32   `json:"day_use_buffer_minutes"`
+}
+
+type IcalExport struct {
+	RoomID    uuid.UUID `json:"room_id"`
+	TokenHash string    `json:"token_hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type IcalFeed struct {
+	FeedID        uuid.UUID      `json:"feed_id"`
+	RoomID        uuid.UUID      `json:"room_id"`
+	Url           string         `json:"url"`
+	Name          sql.NullString `json:"name"`
+	IsActive      bool           `json:"is_active"`
+	LastAttemptAt sql.NullTime   `json:"last_attempt_at"`
+	LastSyncedAt  sql.NullTime   `json:"last_synced_at"`
+	LastError     sql.NullString `json:"last_error"`
+	CreatedAt     sql.NullTime   `json:"created_at"`
+	CreatedBy     uuid.NullUUID  `json:"created_by"`
+	UpdateAt      sql.NullTime   `json:"update_at"`
+	UpdateBy      uuid.NullUUID  `json:"update_by"`
 }
 
 type Medium struct {
@@ -41,6 +149,85 @@ type Medium struct {
 	IsPrimary   sql.NullBool   `json:"is_primary"`
 }
 
+type Notification struct {
+	NotificationID uuid.UUID      `json:"notification_id"`
+	ReservationID  uuid.UUID      `json:"reservation_id"`
+	Kind           string         `json:"kind"`
+	Locale         string         `json:"locale"`
+	Recipient      string         `json:"recipient"`
+	Subject        sql.NullString `json:"subject"`
+	Status         string         `json:"status"`
+	LastError      sql.NullString `json:"last_error"`
+	CreatedAt      time.Time      `json:"created_at"`
+	SentAt         sql.NullTime   `json:"sent_at"`
+	ClaimedAt      time.Time      `json:"claimed_at"`
+}
+
+type OutboxDelivery struct {
+	EventID     uuid.UUID `json:"event_id"`
+	Sink        string    `json:"sink"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+type OutboxEvent struct {
+	EventID       uuid.UUID       `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	HotelID       uuid.NullUUID   `json:"hotel_id"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Attempts      int32           `json:"attempts"`
+	NextAttemptAt sql.NullTime    `json:"next_attempt_at"`
+	LastError     sql.NullString  `json:"last_error"`
+	DispatchedAt  sql.NullTime    `json:"dispatched_at"`
+	FailedAt      sql.NullTime    `json:"failed_at"`
+}
+
+type OverbookingAllowance struct {
+	AllowanceID     uuid.UUID      `json:"allowance_id"`
+	HotelID         uuid.NullUUID  `json:"hotel_id"`
+	TypeID          sql.NullString `json:"type_id"`
+	StartDate       sql.NullTime   `json:"start_date"`
+	EndDate         sql.NullTime   `json:"end_date"`
+	OverbookPercent sql.NullInt32  `json:"overbook_percent"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+	CreatedBy       uuid.NullUUID  `json:"created_by"`
+	UpdateAt        sql.NullTime   `json:"update_at"`
+	UpdateBy        uuid.NullUUID  `json:"update_by"`
+}
+
+type PromoCode struct {
+	Code                  string         `json:"code"`
+	Description           sql.NullString `json:"description"`
+	DiscountType          sql.NullString `json:"discount_type"`
+	DiscountValue         sql.NullInt32  `json:"discount_value"`
+	ValidFrom             sql.NullTime   `json:"valid_from"`
+	ValidTo               sql.NullTime   `json:"valid_to"`
+	StayFrom              sql.NullTime   `json:"stay_from"`
+	StayTo                sql.NullTime   `json:"stay_to"`
+	MinNights             sql.NullInt32  `json:"min_nights"`
+	HotelID               uuid.NullUUID  `json:"hotel_id"`
+	TypeID                sql.NullString `json:"type_id"`
+	MaxRedemptions        sql.NullInt32  `json:"max_redemptions"`
+	MaxRedemptionsPerUser sql.NullInt32  `json:"max_redemptions_per_user"`
+	RedemptionCount       sql.NullInt32  `json:"redemption_count"`
+	IsActive              sql.NullBool   `json:"is_active"`
+	CreatedAt             sql.NullTime   `json:"created_at"`
+	CreatedBy             uuid.NullUUID  `json:"created_by"`
+	UpdateAt              sql.NullTime   `json:"update_at"`
+	UpdateBy              uuid.NullUUID  `json:"update_by"`
+}
+
+type PromoRedemption struct {
+	RedemptionID   uuid.UUID      `json:"redemption_id"`
+	Code           sql.NullString `json:"code"`
+	ReservationID  uuid.NullUUID  `json:"reservation_id"`
+	UserID         sql.NullString `json:"user_id"`
+	DiscountAmount sql.NullInt32  `json:"discount_amount"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+}
+
 type Rate struct {
 	RoomID  uuid.UUID       `json:"room_id"`
 	UserID  string          `json:"user_id"`
@@ -49,16 +236,88 @@ type Rate struct {
 }
 
 type Reservation struct {
+	ReservationID     uuid.UUID      `json:"reservation_id"`
+	RoomID            uuid.NullUUID  `json:"room_id"`
+	UserID            sql.NullString `json:"user_id"`
+	StartDate         sql.NullTime   `json:"start_date"`
+	EndDate           sql.NullTime   `json:"end_date"`
+	Status            sql.NullString `json:"status"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	CreatedBy         uuid.NullUUID  `json:"created_by"`
+	UpdateAt          sql.NullTime   `json:"update_at"`
+	UpdateBy          uuid.NullUUID  `json:"update_by"`
+	TotalPrice        sql.NullInt32  `json:"total_price"`
+	PromoCode         sql.NullString `json:"promo_code"`
+	DiscountAmount    sql.NullInt32  `json:"discount_amount"`
+	HotelID           uuid.NullUUID  `json:"hotel_id"`
+	TypeID            sql.NullString `json:"type_id"`
+	GroupID           uuid.NullUUID  `json:"group_id"`
+	StayType          sql.NullString `json:"stay_type"`
+	HoldExpiresAt     sql.NullTime   `json:"hold_expires_at"`
+	BlockCode         sql.NullString `json:"block_code"`
+	Adults            sql.NullInt32  `json:"adults"`
+	Children          sql.NullInt32  `json:"children"`
+	ChildAges         []int32        `json:"child_ages"`
+	ExtraPersonCharge sql.NullInt32  `json:"extra_person_charge"`
+	ExtrasAmount      sql.NullInt32  `json:"extras_amount"`
+	SpecialRequests   sql.NullString `json:"special_requests"`
+	ConfirmationCode  sql.NullString `json:"confirmation_code"`
+	Source            sql.NullString `json:"source"`
+	ExternalID        sql.NullString `json:"external_id"`
+}
+
+type ReservationExtra struct {
+	ReservationExtraID uuid.UUID      `json:"reservation_extra_id"`
+	ReservationID      uuid.UUID      `json:"reservation_id"`
+	ExtraID            uuid.UUID      `json:"extra_id"`
+	Pricing            sql.NullString `json:"pricing"`
+	Quantity           int32          `json:"quantity"`
+	UnitPrice          sql.NullInt32  `json:"unit_price"`
+	Amount             sql.NullInt32  `json:"amount"`
+	CreatedAt          sql.NullTime   `json:"created_at"`
+}
+
+type ReservationGuest struct {
+	GuestID       uuid.UUID      `json:"guest_id"`
 	ReservationID uuid.UUID      `json:"reservation_id"`
-	RoomID        uuid.NullUUID  `json:"room_id"`
-	UserID        sql.NullString `json:"user_id"`
-	StartDate     sql.NullTime   `json:"start_date"`
-	EndDate       sql.NullTime   `json:"end_date"`
-	Status        sql.NullString `json:"status"`
+	FirstName     sql.NullString `json:"first_name"`
+	LastName      sql.NullString `json:"last_name"`
+	Age           sql.NullInt32  `json:"age"`
+	IsChild       sql.NullBool   `json:"is_child"`
+	IsPrimary     sql.NullBool   `json:"is_primary"`
+	Email         sql.NullString `json:"email"`
+	Phone         sql.NullString `json:"phone"`
 	CreatedAt     sql.NullTime   `json:"created_at"`
-	CreatedBy     uuid.NullUUID  `json:"created_by"`
-	UpdateAt      sql.NullTime   `json:"update_at"`
-	UpdateBy      uuid.NullUUID  `json:"update_by"`
+	Locale        sql.NullString `json:"locale"`
+}
+
+type ReservationModification struct {
+	ModificationID uuid.UUID      `json:"modification_id"`
+	ReservationID  uuid.NullUUID  `json:"reservation_id"`
+	ModifiedBy     sql.NullString `json:"modified_by"`
+	ModifiedByRole sql.NullString `json:"modified_by_role"`
+	Reason         sql.NullString `json:"reason"`
+	OldRoomID      uuid.NullUUID  `json:"old_room_id"`
+	NewRoomID      uuid.NullUUID  `json:"new_room_id"`
+	OldUserID      sql.NullString `json:"old_user_id"`
+	NewUserID      sql.NullString `json:"new_user_id"`
+	OldStartDate   sql.NullTime   `json:"old_start_date"`
+	NewStartDate   sql.NullTime   `json:"new_start_date"`
+	OldEndDate     sql.NullTime   `json:"old_end_date"`
+	NewEndDate     sql.NullTime   `json:"new_end_date"`
+	OldTotalPrice  sql.NullInt32  `json:"old_total_price"`
+	NewTotalPrice  sql.NullInt32  `json:"new_total_price"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+}
+
+type ReservationSegment struct {
+	SegmentID     uuid.UUID     `json:"segment_id"`
+	ReservationID uuid.UUID     `json:"reservation_id"`
+	RoomID        uuid.UUID     `json:"room_id"`
+	StartDate     time.Time     `json:"start_date"`
+	EndDate       time.Time     `json:"end_date"`
+	CreatedAt     sql.NullTime  `json:"created_at"`
+	CreatedBy     uuid.NullUUID `json:"created_by"`
 }
 
 type Role struct {
@@ -67,19 +326,23 @@ type Role struct {
 }
 
 type Room struct {
-	RoomID      uuid.UUID       `json:"room_id"`
-	RoomName    sql.NullString  `json:"room_name"`
-	HotelID     uuid.NullUUID   `json:"hotel_id"`
-	Floor       sql.NullInt32   `json:"floor"`
-	TypeID      sql.NullString  `json:"type_id"`
-	MaxCapacity sql.NullInt32   `json:"max_capacity"`
-	Rate        sql.NullFloat64 `json:"rate"`
-	Description sql.NullString  `json:"description"`
-	Price       sql.NullInt32   `json:"price"`
-	CreatedAt   sql.NullTime    `json:"created_at"`
-	CreatedBy   uuid.NullUUID   `json:"created_by"`
-	UpdateAt    sql.NullTime    `json:"update_at"`
-	UpdateBy    uuid.NullUUID   `json:"update_by"`
+	RoomID                uuid.UUID       `json:"room_id"`
+	RoomName              sql.NullString  `json:"room_name"`
+	HotelID               uuid.NullUUID   `json:"hotel_id"`
+	Floor                 sql.NullInt32   `json:"floor"`
+	TypeID                sql.NullString  `json:"type_id"`
+	MaxCapacity           sql.NullInt32   `json:"max_capacity"`
+	Rate                  sql.NullFloat64 `json:"rate"`
+	Description           sql.NullString  `json:"description"`
+	Price                 sql.NullInt32   `json:"price"`
+	CreatedAt             sql.NullTime    `json:"created_at"`
+	CreatedBy             uuid.NullUUID   `json:"created_by"`
+	UpdateAt              sql.NullTime    `json:"update_at"`
+	UpdateBy              uuid.NullUUID   `json:"update_by"`
+	HousekeepingStatus    sql.NullString  `json:"housekeeping_status"`
+	HousekeepingUpdatedAt sql.NullTime    `json:"housekeeping_updated_at"`
+	DayUse                sql.NullBool    `json:"day_use"`
+	DayUsePrice           sql.NullInt32   `json:"day_use_price"`
 }
 
 type RoomAmenity struct {
@@ -87,16 +350,108 @@ type RoomAmenity struct {
 	AmenityCode string    `json:"amenity_code"`
 }
 
-type Type struct {
-	TypeCode    string         `json:"type_code"`
-	Description sql.NullString `json:"description"`
+type RoomBlock struct {
+	BlockID     uuid.UUID      `json:"block_id"`
+	RoomID      uuid.NullUUID  `json:"room_id"`
+	BlockType   sql.NullString `json:"block_type"`
+	Reason      sql.NullString `json:"reason"`
+	StartDate   sql.NullTime   `json:"start_date"`
+	EndDate     sql.NullTime   `json:"end_date"`
 	CreatedAt   sql.NullTime   `json:"created_at"`
 	CreatedBy   uuid.NullUUID  `json:"created_by"`
 	UpdateAt    sql.NullTime   `json:"update_at"`
 	UpdateBy    uuid.NullUUID  `json:"update_by"`
+	FeedID      uuid.NullUUID  `json:"feed_id"`
+	ExternalUid sql.NullString `json:"external_uid"`
+}
+
+type StayRestriction struct {
+	RestrictionID     uuid.UUID      `json:"restriction_id"`
+	HotelID           uuid.NullUUID  `json:"hotel_id"`
+	TypeID            sql.NullString `json:"type_id"`
+	StartDate         sql.NullTime   `json:"start_date"`
+	EndDate           sql.NullTime   `json:"end_date"`
+	MinNights         sql.NullInt32  `json:"min_nights"`
+	MaxNights         sql.NullInt32  `json:"max_nights"`
+	ClosedToArrival   sql.NullBool   `json:"closed_to_arrival"`
+	ClosedToDeparture sql.NullBool   `json:"closed_to_departure"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	CreatedBy         uuid.NullUUID  `json:"created_by"`
+	UpdateAt          sql.NullTime   `json:"update_at"`
+	UpdateBy          uuid.NullUUID  `json:"update_by"`
+}
+
+type Type struct {
+	TypeCode         string         `json:"type_code"`
+	Description      sql.NullString `json:"description"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	CreatedBy        uuid.NullUUID  `json:"created_by"`
+	UpdateAt         sql.NullTime   `json:"update_at"`
+	UpdateBy         uuid.NullUUID  `json:"update_by"`
+	Name             sql.NullString `json:"name"`
+	BedConfiguration sql.NullString `json:"bed_configuration"`
+	SizeSqm          sql.NullInt32  `json:"size_sqm"`
+	DefaultCapacity  sql.NullInt32  `json:"default_capacity"`
+	BaseOccupancy    sql.NullInt32  `json:"base_occupancy"`
+	ExtraAdultPrice  sql.NullInt32  `json:"extra_adult_price"`
+	ExtraChildPrice  sql.NullInt32  `json:"extra_child_price"`
 }
 
 type User struct {
 	Username string         `json:"username"`
 	Role     sql.NullString `json:"role"`
 }
+
+type WaitlistEntry struct {
+	EntryID        uuid.UUID      `json:"entry_id"`
+	HotelID        uuid.NullUUID  `json:"hotel_id"`
+	TypeID         sql.NullString `json:"type_id"`
+	RoomID         uuid.NullUUID  `json:"room_id"`
+	UserID         sql.NullString `json:"user_id"`
+	StartDate      sql.NullTime   `json:"start_date"`
+	EndDate        sql.NullTime   `json:"end_date"`
+	Guests         sql.NullInt32  `json:"guests"`
+	ContactName    sql.NullString `json:"contact_name"`
+	ContactEmail   sql.NullString `json:"contact_email"`
+	ContactPhone   sql.NullString `json:"contact_phone"`
+	Status         sql.NullString `json:"status"`
+	ReservationID  uuid.NullUUID  `json:"reservation_id"`
+	OfferExpiresAt sql.NullTime   `json:"offer_expires_at"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+	CreatedBy      uuid.NullUUID  `json:"created_by"`
+	UpdateAt       sql.NullTime   `json:"update_at"`
+	UpdateBy       uuid.NullUUID  `json:"update_by"`
+}
+
+type WebhookDelivery struct {
+	DeliveryID     uuid.UUID       `json:"delivery_id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  sql.NullTime    `json:"next_attempt_at"`
+	LastAttemptAt  sql.NullTime    `json:"last_attempt_at"`
+	ResponseStatus sql.NullInt32   `json:"response_status"`
+	LastError      sql.NullString  `json:"last_error"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    sql.NullTime    `json:"delivered_at"`
+}
+
+type WebhookSubscription struct {
+	SubscriptionID      uuid.UUID      `json:"subscription_id"`
+	Url                 string         `json:"url"`
+	EventTypes          []string       `json:"event_types"`
+	HotelID             uuid.NullUUID  `json:"hotel_id"`
+	Secret              string         `json:"secret"`
+	Description         sql.NullString `json:"description"`
+	IsActive            sql.NullBool   `json:"is_active"`
+	ConsecutiveFailures int32          `json:"consecutive_failures"`
+	DisabledAt          sql.NullTime   `json:"disabled_at"`
+	DisabledReason      sql.NullString `json:"disabled_reason"`
+	CreatedAt           sql.NullTime   `json:"created_at"`
+	CreatedBy           uuid.NullUUID  `json:"created_by"`
+	UpdateAt            sql.NullTime   `json:"update_at"`
+	UpdateBy            uuid.NullUUID  `json:"update_by"`
+}
@@ -6,33 +6,182 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AssignReservationRoom(ctx context.Context, arg AssignReservationRoomParams) (Reservation, error)
+	ClaimNotification(ctx context.Context, arg ClaimNotificationParams) (Notification, error)
+	ClearReservationHold(ctx context.Context, reservationID uuid.UUID) error
+	CountAllotmentReservations(ctx context.Context, blockCode sql.NullString) (int64, error)
+	CountOverlappingRoomBlocks(ctx context.Context, arg CountOverlappingRoomBlocksParams) (int64, error)
+	// Stays hold their rooms segment by segment over the half-open interval [start_date, end_date).
+	// Day-use stays also hold the room for the hotel's cleaning buffer after they end.
+	CountOverlappingRoomReservations(ctx context.Context, arg CountOverlappingRoomReservationsParams) (int64, error)
+	CountPromoRedemptionsByUser(ctx context.Context, arg CountPromoRedemptionsByUserParams) (int64, error)
+	CountReservationsByConfirmationCode(ctx context.Context, confirmationCode sql.NullString) (int64, error)
+	CreateAllotment(ctx context.Context, arg CreateAllotmentParams) (Allotment, error)
+	CreateBookingGroup(ctx context.Context, arg CreateBookingGroupParams) (BookingGroup, error)
 	CreateHotel(ctx context.Context, arg CreateHotelParams) (Hotel, error)
+	CreateOutboxDelivery(ctx context.Context, arg CreateOutboxDeliveryParams) error
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
+	CreatePromoCode(ctx context.Context, arg CreatePromoCodeParams) (PromoCode, error)
+	CreatePromoRedemption(ctx context.Context, arg CreatePromoRedemptionParams) (PromoRedemption, error)
 	CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error)
+	CreateReservationExtra(ctx context.Context, arg CreateReservationExtraParams) (ReservationExtra, error)
+	CreateReservationGuest(ctx context.Context, arg CreateReservationGuestParams) (ReservationGuest, error)
+	CreateReservationModification(ctx context.Context, arg CreateReservationModificationParams) (ReservationModification, error)
+	CreateReservationSegment(ctx context.Context, arg CreateReservationSegmentParams) (ReservationSegment, error)
 	CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, error)
+	CreateRoomBlock(ctx context.Context, arg CreateRoomBlockParams) (RoomBlock, error)
+	CreateType(ctx context.Context, arg CreateTypeParams) (Type, error)
+	CreateWaitlistEntry(ctx context.Context, arg CreateWaitlistEntryParams) (WaitlistEntry, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
+	DecrementPromoCodeRedemptions(ctx context.Context, code string) (PromoCode, error)
 	DeleteHotel(ctx context.Context, hotelID uuid.UUID) error
+	DeletePromoCode(ctx context.Context, code string) error
+	DeletePromoRedemptionByReservation(ctx context.Context, reservationID uuid.NullUUID) (int64, error)
 	DeleteReservation(ctx context.Context, reservationID uuid.UUID) error
+	DeleteReservationExtras(ctx context.Context, reservationID uuid.UUID) error
+	DeleteReservationGuests(ctx context.Context, reservationID uuid.UUID) error
+	DeleteReservationSegments(ctx context.Context, reservationID uuid.UUID) error
 	DeleteRoom(ctx context.Context, roomID uuid.UUID) error
-	GetAvailableRooms(ctx context.Context, arg GetAvailableRoomsParams) ([]Room, error)
+	DeleteStaleICalBlocks(ctx context.Context, arg DeleteStaleICalBlocksParams) (int64, error)
+	DeleteType(ctx context.Context, typeCode string) error
+	DisableWebhookSubscription(ctx context.Context, arg DisableWebhookSubscriptionParams) error
+	ExpireStaleWaitlistEntries(ctx context.Context, now time.Time) (int64, error)
+	GetAllotmentByCodeForUpdate(ctx context.Context, blockCode string) (Allotment, error)
+	GetAllotmentForUpdate(ctx context.Context, allotmentID uuid.UUID) (Allotment, error)
+	GetBookingGroup(ctx context.Context, groupID uuid.UUID) (BookingGroup, error)
+	GetBookingGroupForUpdate(ctx context.Context, groupID uuid.UUID) (BookingGroup, error)
+	GetChannelReservationForUpdate(ctx context.Context, arg GetChannelReservationForUpdateParams) (Reservation, error)
+	GetExtra(ctx context.Context, extraID uuid.UUID) (Extra, error)
 	GetHotel(ctx context.Context, hotelID uuid.UUID) (Hotel, error)
+	GetICalExport(ctx context.Context, roomID uuid.UUID) (IcalExport, error)
+	GetICalFeedForUpdate(ctx context.Context, feedID uuid.UUID) (IcalFeed, error)
+	// Bookings made against the block code on the busiest night of the range
+	GetMaxNightlyAllotmentPickup(ctx context.Context, arg GetMaxNightlyAllotmentPickupParams) (int32, error)
+	// Rooms of the type still free on the fullest night of the range. A night's capacity is the
+	// type's rooms plus the overbooking allowance covering it. Stays with rooms count against the
+	// type of the room each segment uses; rooms taken out of service by a block
+	// and rooms an active allotment holds but has not had picked up count as booked for the nights
+	// they cover. The allotment named by block_code does not hold rooms away from its own bookings.
+	GetMinNightlyTypeAvailability(ctx context.Context, arg GetMinNightlyTypeAvailabilityParams) (int32, error)
+	GetPromoCode(ctx context.Context, code string) (PromoCode, error)
+	GetPromoCodeForUpdate(ctx context.Context, code string) (PromoCode, error)
 	GetReservation(ctx context.Context, reservationID uuid.UUID) (Reservation, error)
+	GetReservationForUpdate(ctx context.Context, reservationID uuid.UUID) (Reservation, error)
 	GetReservationsByDateRange(ctx context.Context, arg GetReservationsByDateRangeParams) ([]Reservation, error)
 	GetRoom(ctx context.Context, roomID uuid.UUID) (Room, error)
+	GetRoomForUpdate(ctx context.Context, roomID uuid.UUID) (Room, error)
+	GetType(ctx context.Context, typeCode string) (Type, error)
+	GetUser(ctx context.Context, username string) (User, error)
+	GetWaitlistEntryForUpdate(ctx context.Context, entryID uuid.UUID) (WaitlistEntry, error)
+	GetWebhookDeliveryForUpdate(ctx context.Context, deliveryID uuid.UUID) (WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, subscriptionID uuid.UUID) (WebhookSubscription, error)
+	GetWebhookSubscriptionForUpdate(ctx context.Context, subscriptionID uuid.UUID) (WebhookSubscription, error)
+	IncrementPromoCodeRedemptions(ctx context.Context, code string) (PromoCode, error)
+	// Holds back a claimed event from other dispatchers until next_attempt_at, while it is delivered
+	// outside the claiming transaction
+	LeaseOutboxEvent(ctx context.Context, arg LeaseOutboxEventParams) error
+	// Holds back a claimed delivery from other senders until next_attempt_at, while it is sent
+	// outside the claiming transaction
+	LeaseWebhookDelivery(ctx context.Context, arg LeaseWebhookDeliveryParams) error
+	ListActiveWebhookSubscriptionsForHotel(ctx context.Context, hotelID uuid.NullUUID) ([]WebhookSubscription, error)
+	// Restrictions covering either the arrival or the departure date of a stay.
+	// A restriction without a room type applies to every room type of the hotel.
+	ListApplicableStayRestrictions(ctx context.Context, arg ListApplicableStayRestrictionsParams) ([]StayRestriction, error)
+	ListChannelMappingsByConnection(ctx context.Context, connectionID uuid.UUID) ([]ChannelMapping, error)
+	// Active allotments whose release date has passed, oldest cutoff first
+	ListDueAllotments(ctx context.Context, now time.Time) ([]Allotment, error)
+	ListDueChannelPulls(ctx context.Context, arg ListDueChannelPullsParams) ([]ChannelConnection, error)
+	// Connections due a full push: never pushed, last pushed before pushed_before, marked due since
+	// their last push, or whose last push failed
+	ListDueChannelPushes(ctx context.Context, arg ListDueChannelPushesParams) ([]ChannelConnection, error)
+	ListDueICalFeeds(ctx context.Context, arg ListDueICalFeedsParams) ([]IcalFeed, error)
+	ListDueWebhookDeliveries(ctx context.Context, arg ListDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListExpiredHolds(ctx context.Context, now time.Time) ([]Reservation, error)
 	ListHotels(ctx context.Context, arg ListHotelsParams) ([]Hotel, error)
 	ListHotelsByDestination(ctx context.Context, arg ListHotelsByDestinationParams) ([]Hotel, error)
+	ListICalRoomBlocks(ctx context.Context, roomID uuid.NullUUID) ([]RoomBlock, error)
+	ListICalRoomSegments(ctx context.Context, roomID uuid.UUID) ([]ListICalRoomSegmentsRow, error)
+	// Whether each of the hotel's rooms is taken by a stay that is not cancelled or by a block on
+	// every night from start_date until end_date
+	ListNightlyRoomOccupancy(ctx context.Context, arg ListNightlyRoomOccupancyParams) ([]ListNightlyRoomOccupancyRow, error)
+	// Rooms of each of the hotel's room types still free on every night from start_date until
+	// end_date, counted like GetMinNightlyTypeAvailability, with the price of the type's cheapest room
+	ListNightlyTypeAvailability(ctx context.Context, arg ListNightlyTypeAvailabilityParams) ([]ListNightlyTypeAvailabilityRow, error)
+	// Lists the sinks that already accepted an event, so a retry skips them
+	ListOutboxEventSinks(ctx context.Context, eventID uuid.UUID) ([]string, error)
+	ListOverlappingRoomReservations(ctx context.Context, arg ListOverlappingRoomReservationsParams) ([]Reservation, error)
+	ListPendingOutboxEvents(ctx context.Context, arg ListPendingOutboxEventsParams) ([]OutboxEvent, error)
+	ListPromoCodes(ctx context.Context, arg ListPromoCodesParams) ([]PromoCode, error)
+	ListReservationExtrasForUpdate(ctx context.Context, reservationID uuid.UUID) ([]ReservationExtra, error)
+	ListReservationGuests(ctx context.Context, reservationID uuid.UUID) ([]ReservationGuest, error)
+	ListReservationSegments(ctx context.Context, reservationID uuid.UUID) ([]ReservationSegment, error)
+	ListReservationSegmentsForUpdate(ctx context.Context, reservationID uuid.UUID) ([]ReservationSegment, error)
 	ListReservations(ctx context.Context, arg ListReservationsParams) ([]Reservation, error)
+	ListReservationsByGroupForUpdate(ctx context.Context, groupID uuid.NullUUID) ([]Reservation, error)
 	ListReservationsByRoom(ctx context.Context, arg ListReservationsByRoomParams) ([]Reservation, error)
 	ListReservationsByUser(ctx context.Context, arg ListReservationsByUserParams) ([]Reservation, error)
+	ListReservationsDueForReminder(ctx context.Context, arg ListReservationsDueForReminderParams) ([]Reservation, error)
 	ListRooms(ctx context.Context, arg ListRoomsParams) ([]Room, error)
 	ListRoomsByHotel(ctx context.Context, arg ListRoomsByHotelParams) ([]Room, error)
+	// Restrictions of the hotel, for any room type, covering part of [start_date, end_date)
+	ListStayRestrictionsInRange(ctx context.Context, arg ListStayRestrictionsInRangeParams) ([]StayRestriction, error)
+	ListTypes(ctx context.Context, arg ListTypesParams) ([]Type, error)
+	// Entries of the hotel still waiting for dates that overlap the released stay, oldest first
+	ListWaitingEntriesForRelease(ctx context.Context, arg ListWaitingEntriesForReleaseParams) ([]WaitlistEntry, error)
+	LockRoomsByHotelAndType(ctx context.Context, arg LockRoomsByHotelAndTypeParams) ([]Room, error)
+	MarkChannelPullFailed(ctx context.Context, arg MarkChannelPullFailedParams) error
+	MarkChannelPulled(ctx context.Context, arg MarkChannelPulledParams) error
+	MarkChannelPushDue(ctx context.Context, arg MarkChannelPushDueParams) error
+	MarkChannelPushFailed(ctx context.Context, arg MarkChannelPushFailedParams) error
+	// Clears the push due mark only if it is still the one read before the push, so changes marked
+	// while the push ran are pushed again
+	MarkChannelPushed(ctx context.Context, arg MarkChannelPushedParams) error
+	// Makes every active connection of a hotel due a full push on the next pass
+	MarkHotelChannelsDue(ctx context.Context, arg MarkHotelChannelsDueParams) error
+	MarkICalFeedFailed(ctx context.Context, arg MarkICalFeedFailedParams) error
+	MarkICalFeedSynced(ctx context.Context, arg MarkICalFeedSyncedParams) error
+	MarkNotificationFailed(ctx context.Context, arg MarkNotificationFailedParams) error
+	MarkNotificationSent(ctx context.Context, arg MarkNotificationSentParams) error
+	MarkOutboxEventDispatched(ctx context.Context, arg MarkOutboxEventDispatchedParams) error
+	// A failed_at set gives up on the event, so it is no longer claimed
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error
+	MarkWebhookDeliverySucceeded(ctx context.Context, arg MarkWebhookDeliverySucceededParams) error
+	ModifyReservation(ctx context.Context, arg ModifyReservationParams) (Reservation, error)
+	NotifyAvailabilityChange(ctx context.Context, payload string) error
+	RecordWebhookSubscriptionFailure(ctx context.Context, subscriptionID uuid.UUID) (WebhookSubscription, error)
+	ReplayWebhookDelivery(ctx context.Context, arg ReplayWebhookDeliveryParams) error
+	ResetWebhookSubscriptionFailures(ctx context.Context, subscriptionID uuid.UUID) error
+	// Closes the open offer backed by a hold once the hold is confirmed, cancelled or expires
+	ResolveWaitlistOffer(ctx context.Context, arg ResolveWaitlistOfferParams) error
+	UpdateAllotment(ctx context.Context, arg UpdateAllotmentParams) (Allotment, error)
+	UpdateAllotmentStatus(ctx context.Context, arg UpdateAllotmentStatusParams) (Allotment, error)
+	UpdateBookingGroupStatus(ctx context.Context, arg UpdateBookingGroupStatusParams) (BookingGroup, error)
+	UpdateBookingGroupTotalPrice(ctx context.Context, arg UpdateBookingGroupTotalPriceParams) (BookingGroup, error)
 	UpdateHotel(ctx context.Context, arg UpdateHotelParams) (Hotel, error)
+	UpdatePromoCode(ctx context.Context, arg UpdatePromoCodeParams) (PromoCode, error)
+	UpdatePromoRedemptionDiscount(ctx context.Context, arg UpdatePromoRedemptionDiscountParams) error
 	UpdateReservation(ctx context.Context, arg UpdateReservationParams) (Reservation, error)
+	UpdateReservationExtraAmount(ctx context.Context, arg UpdateReservationExtraAmountParams) error
+	// Special requests are kept when none are given
+	UpdateReservationExtras(ctx context.Context, arg UpdateReservationExtrasParams) (Reservation, error)
+	UpdateReservationSegmentEnd(ctx context.Context, arg UpdateReservationSegmentEndParams) (ReservationSegment, error)
+	UpdateReservationSegmentRoom(ctx context.Context, arg UpdateReservationSegmentRoomParams) (ReservationSegment, error)
 	UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) (Reservation, error)
 	UpdateRoom(ctx context.Context, arg UpdateRoomParams) (Room, error)
+	UpdateRoomHousekeepingStatus(ctx context.Context, arg UpdateRoomHousekeepingStatusParams) (Room, error)
+	UpdateType(ctx context.Context, arg UpdateTypeParams) (Type, error)
+	UpdateWaitlistEntryOffer(ctx context.Context, arg UpdateWaitlistEntryOfferParams) (WaitlistEntry, error)
+	UpdateWaitlistEntryStatus(ctx context.Context, arg UpdateWaitlistEntryStatusParams) (WaitlistEntry, error)
+	UpsertICalBlock(ctx context.Context, arg UpsertICalBlockParams) (RoomBlock, error)
+	UpsertICalExport(ctx context.Context, arg UpsertICalExportParams) (IcalExport, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -8,10 +8,126 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const assignReservationRoom = `-- name: AssignReservationRoom :one
+UPDATE reservation
+SET
+  room_id = $2,
+  update_at = $3,
+  update_by = $4
+WHERE reservation_id = $1
+RETURNING reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id
+`
+
+type AssignReservationRoomParams struct {
+	ReservationID uuid.UUID     `json:"reservation_id"`
+	RoomID        uuid.NullUUID `json:"room_id"`
+	UpdateAt      sql.NullTime  `json:"update_at"`
+	UpdateBy      uuid.NullUUID `json:"update_by"`
+}
+
+func (q *Queries) AssignReservationRoom(ctx context.Context, arg AssignReservationRoomParams) (Reservation, error) {
+	row := q.queryRow(ctx, q.assignReservationRoomStmt, assignReservationRoom,
+		arg.ReservationID,
+		arg.RoomID,
+		arg.UpdateAt,
+		arg.UpdateBy,
+	)
+	var i Reservation
+	err := row.Scan(
+		&i.ReservationID,
+		&i.RoomID,
+		&i.UserID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdateAt,
+		&i.UpdateBy,
+		&i.TotalPrice,
+		&i.PromoCode,
+		&i.DiscountAmount,
+		&i.HotelID,
+		&i.TypeID,
+		&i.GroupID,
+		&i.StayType,
+		&i.HoldExpiresAt,
+		&i.BlockCode,
+		&i.Adults,
+		&i.Children,
+		pq.Array(&i.ChildAges),
+		&i.ExtraPersonCharge,
+		&i.ExtrasAmount,
+		&i.SpecialRequests,
+		&i.ConfirmationCode,
+		&i.Source,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const clearReservationHold = `-- name: ClearReservationHold :exec
+UPDATE reservation
+SET hold_expires_at = NULL
+WHERE reservation_id = $1
+`
+
+func (q *Queries) ClearReservationHold(ctx context.Context, reservationID uuid.UUID) error {
+	_, err := q.exec(ctx, q.clearReservationHoldStmt, clearReservationHold, reservationID)
+	return err
+}
+
+const countOverlappingRoomReservations = `-- name: CountOverlappingRoomReservations :one
+SELECT COUNT(*) FROM reservation_segment seg
+JOIN reservation res ON res.reservation_id = seg.reservation_id
+WHERE seg.room_id = $1
+  AND seg.reservation_id != $2
+  AND res.status != 'CANCELLED'
+  AND seg.start_date < $3::timestamptz
+  AND seg.end_date + CASE WHEN res.stay_type = 'DAY_USE' THEN make_interval(mins => $4::int) ELSE INTERVAL '0 minutes' END > $5::timestamptz
+`
+
+type CountOverlappingRoomReservationsParams struct {
+	RoomID               uuid.UUID `json:"room_id"`
+	ExcludeReservationID uuid.UUID `json:"exclude_reservation_id"`
+	EndDate              time.Time `json:"end_date"`
+	BufferMinutes        int32     `json:"buffer_minutes"`
+	StartDate            time.Time `json:"start_date"`
+}
+
+// Stays hold their rooms segment by segment over the half-open interval [start_date, end_date).
+// Day-use stays also hold the room for the hotel's cleaning buffer after they end.
+func (q *Queries) CountOverlappingRoomReservations(ctx context.Context, arg CountOverlappingRoomReservationsParams) (int64, error) {
+	row := q.queryRow(ctx, q.countOverlappingRoomReservationsStmt, countOverlappingRoomReservations,
+		arg.RoomID,
+		arg.ExcludeReservationID,
+		arg.EndDate,
+		arg.BufferMinutes,
+		arg.StartDate,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countReservationsByConfirmationCode = `-- name: CountReservationsByConfirmationCode :one
+SELECT COUNT(*) FROM reservation
+WHERE confirmation_code = $1
+`
+
+func (q *Queries) CountReservationsByConfirmationCode(ctx context.Context, confirmationCode sql.NullString) (int64, error) {
+	row := q.queryRow(ctx, q.countReservationsByConfirmationCodeStmt, countReservationsByConfirmationCode, confirmationCode)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createReservation = `-- name: CreateReservation :one
 INSERT INTO reservation (
   reservation_id,
@@ -23,23 +139,59 @@ INSERT INTO reservation (
   created_at,
   created_by,
   update_at,
-  update_by
+  update_by,
+  total_price,
+  promo_code,
+  discount_amount,
+  hotel_id,
+  type_id,
+  group_id,
+  stay_type,
+  hold_expires_at,
+  block_code,
+  adults,
+  children,
+  child_ages,
+  extra_person_charge,
+  extras_amount,
+  special_requests,
+  confirmation_code,
+  source,
+  external_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28
+) RETURNING reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id
 `
 
 type CreateReservationParams struct {
-	ReservationID uuid.UUID      `json:"reservation_id"`
-	RoomID        uuid.NullUUID  `json:"room_id"`
-	UserID        sql.NullString `json:"user_id"`
-	StartDate     sql.NullTime   `json:"start_date"`
-	EndDate       sql.NullTime   `json:"end_date"`
-	Status        sql.NullString `json:"status"`
-	CreatedAt     sql.NullTime   `json:"created_at"`
-	CreatedBy     uuid.NullUUID  `json:"created_by"`
-	UpdateAt      sql.NullTime   `json:"update_at"`
-	UpdateBy      uuid.NullUUID  `json:"update_by"`
+	ReservationID     uuid.UUID      `json:"reservation_id"`
+	RoomID            uuid.NullUUID  `json:"room_id"`
+	UserID            sql.NullString `json:"user_id"`
+	StartDate         sql.NullTime   `json:"start_date"`
+	EndDate           sql.NullTime   `json:"end_date"`
+	Status            sql.NullString `json:"status"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	CreatedBy         uuid.NullUUID  `json:"created_by"`
+	UpdateAt          sql.NullTime   `json:"update_at"`
+	UpdateBy          uuid.NullUUID  `json:"update_by"`
+	TotalPrice        sql.NullInt32  `json:"total_price"`
+	PromoCode         sql.NullString `json:"promo_code"`
+	DiscountAmount    sql.NullInt32  `json:"discount_amount"`
+	HotelID           uuid.NullUUID  `json:"hotel_id"`
+	TypeID            sql.NullString `json:"type_id"`
+	GroupID           uuid.NullUUID  `json:"group_id"`
+	StayType          sql.NullString `json:"stay_type"`
+	HoldExpiresAt     sql.NullTime   `json:"hold_expires_at"`
+	BlockCode         sql.NullString `json:"block_code"`
+	Adults            sql.NullInt32  `json:"adults"`
+	Children          sql.NullInt32  `json:"children"`
+	ChildAges         []int32        `json:"child_ages"`
+	ExtraPersonCharge sql.NullInt32  `json:"extra_person_charge"`
+	ExtrasAmount      sql.NullInt32  `json:"extras_amount"`
+	SpecialRequests   sql.NullString `json:"special_requests"`
+	ConfirmationCode  sql.NullString `json:"confirmation_code"`
+	Source            sql.NullString `json:"source"`
+	ExternalID        sql.NullString `json:"external_id"`
 }
 
 func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error) {
@@ -54,6 +206,24 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		arg.CreatedBy,
 		arg.UpdateAt,
 		arg.UpdateBy,
+		arg.TotalPrice,
+		arg.PromoCode,
+		arg.DiscountAmount,
+		arg.HotelID,
+		arg.TypeID,
+		arg.GroupID,
+		arg.StayType,
+		arg.HoldExpiresAt,
+		arg.BlockCode,
+		arg.Adults,
+		arg.Children,
+		pq.Array(arg.ChildAges),
+		arg.ExtraPersonCharge,
+		arg.ExtrasAmount,
+		arg.SpecialRequests,
+		arg.ConfirmationCode,
+		arg.Source,
+		arg.ExternalID,
 	)
 	var i Reservation
 	err := row.Scan(
@@ -67,6 +237,107 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		&i.CreatedBy,
 		&i.UpdateAt,
 		&i.UpdateBy,
+		&i.TotalPrice,
+		&i.PromoCode,
+		&i.DiscountAmount,
+		&i.HotelID,
+		&i.TypeID,
+		&i.GroupID,
+		&i.StayType,
+		&i.HoldExpiresAt,
+		&i.BlockCode,
+		&i.Adults,
+		&i.Children,
+		pq.Array(&i.ChildAges),
+		&i.ExtraPersonCharge,
+		&i.ExtrasAmount,
+		&i.SpecialRequests,
+		&i.ConfirmationCode,
+		&i.Source,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const createReservationModification = `-- name: CreateReservationModification :one
+INSERT INTO reservation_modification (
+  modification_id,
+  reservation_id,
+  modified_by,
+  modified_by_role,
+  reason,
+  old_room_id,
+  new_room_id,
+  old_user_id,
+  new_user_id,
+  old_start_date,
+  new_start_date,
+  old_end_date,
+  new_end_date,
+  old_total_price,
+  new_total_price,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+) RETURNING modification_id, reservation_id, modified_by, modified_by_role, reason, old_room_id, new_room_id, old_user_id, new_user_id, old_start_date, new_start_date, old_end_date, new_end_date, old_total_price, new_total_price, created_at
+`
+
+type CreateReservationModificationParams struct {
+	ModificationID uuid.UUID      `json:"modification_id"`
+	ReservationID  uuid.NullUUID  `json:"reservation_id"`
+	ModifiedBy     sql.NullString `json:"modified_by"`
+	ModifiedByRole sql.NullString `json:"modified_by_role"`
+	Reason         sql.NullString `json:"reason"`
+	OldRoomID      uuid.NullUUID  `json:"old_room_id"`
+	NewRoomID      uuid.NullUUID  `json:"new_room_id"`
+	OldUserID      sql.NullString `json:"old_user_id"`
+	NewUserID      sql.NullString `json:"new_user_id"`
+	OldStartDate   sql.NullTime   `json:"old_start_date"`
+	NewStartDate   sql.NullTime   `json:"new_start_date"`
+	OldEndDate     sql.NullTime   `json:"old_end_date"`
+	NewEndDate     sql.NullTime   `json:"new_end_date"`
+	OldTotalPrice  sql.NullInt32  `json:"old_total_price"`
+	NewTotalPrice  sql.NullInt32  `json:"new_total_price"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+}
+
+func (q *Queries) CreateReservationModification(ctx context.Context, arg CreateReservationModificationParams) (ReservationModification, error) {
+	row := q.queryRow(ctx, q.createReservationModificationStmt, createReservationModification,
+		arg.ModificationID,
+		arg.ReservationID,
+		arg.ModifiedBy,
+		arg.ModifiedByRole,
+		arg.Reason,
+		arg.OldRoomID,
+		arg.NewRoomID,
+		arg.OldUserID,
+		arg.NewUserID,
+		arg.OldStartDate,
+		arg.NewStartDate,
+		arg.OldEndDate,
+		arg.NewEndDate,
+		arg.OldTotalPrice,
+		arg.NewTotalPrice,
+		arg.CreatedAt,
+	)
+	var i ReservationModification
+	err := row.Scan(
+		&i.ModificationID,
+		&i.ReservationID,
+		&i.ModifiedBy,
+		&i.ModifiedByRole,
+		&i.Reason,
+		&i.OldRoomID,
+		&i.NewRoomID,
+		&i.OldUserID,
+		&i.NewUserID,
+		&i.OldStartDate,
+		&i.NewStartDate,
+		&i.OldEndDate,
+		&i.NewEndDate,
+		&i.OldTotalPrice,
+		&i.NewTotalPrice,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -81,8 +352,90 @@ func (q *Queries) DeleteReservation(ctx context.Context, reservationID uuid.UUID
 	return err
 }
 
+const getMinNightlyTypeAvailability = `-- name: GetMinNightlyTypeAvailability :one
+SELECT COALESCE(MIN(n.capacity - n.booked), 0)::int AS min_available
+FROM (
+  SELECT d.night,
+    $1::int + FLOOR($1::int * COALESCE((
+      SELECT MAX(a.overbook_percent)
+      FROM overbooking_allowance a
+      WHERE a.hotel_id = $2
+        AND a.type_id = $3
+        AND a.start_date <= d.night
+        AND a.end_date > d.night
+    ), 0) / 100.0)::int AS capacity,
+    (
+      SELECT COUNT(DISTINCT COALESCE(seg.room_id, res.reservation_id))
+      FROM reservation res
+      LEFT JOIN reservation_segment seg ON seg.reservation_id = res.reservation_id
+      LEFT JOIN room sr ON sr.room_id = seg.room_id
+      WHERE res.hotel_id = $2
+        AND COALESCE(sr.type_id, res.type_id) = $3
+        AND res.reservation_id != $4
+        AND res.status != 'CANCELLED'
+        AND COALESCE(seg.start_date, res.start_date) < LEAST(d.night + INTERVAL '1 day', $5::timestamptz)
+        AND COALESCE(seg.end_date, res.end_date) > d.night
+    ) + (
+      SELECT COUNT(b.block_id)
+      FROM room_block b
+      JOIN room r ON r.room_id = b.room_id
+      WHERE r.hotel_id = $2
+        AND r.type_id = $3
+        AND b.start_date < LEAST(d.night + INTERVAL '1 day', $5::timestamptz)
+        AND b.end_date > d.night
+    ) + (
+      SELECT COALESCE(SUM(GREATEST(al.quantity - (
+        SELECT COUNT(*)
+        FROM reservation ar
+        WHERE ar.block_code = al.block_code
+          AND ar.status != 'CANCELLED'
+          AND ar.start_date < LEAST(d.night + INTERVAL '1 day', $5::timestamptz)
+          AND ar.end_date > d.night
+      ), 0)), 0)
+      FROM allotment al
+      WHERE al.hotel_id = $2
+        AND al.type_id = $3
+        AND al.status = 'ACTIVE'
+        AND ($6::varchar IS NULL OR al.block_code != $6::varchar)
+        AND al.start_date < LEAST(d.night + INTERVAL '1 day', $5::timestamptz)
+        AND al.end_date > d.night
+    ) AS booked
+  FROM generate_series($7::timestamptz, $5::timestamptz - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+) n
+`
+
+type GetMinNightlyTypeAvailabilityParams struct {
+	TotalRooms           int32          `json:"total_rooms"`
+	HotelID              uuid.NullUUID  `json:"hotel_id"`
+	TypeID               sql.NullString `json:"type_id"`
+	ExcludeReservationID uuid.UUID      `json:"exclude_reservation_id"`
+	EndDate              time.Time      `json:"end_date"`
+	BlockCode            sql.NullString `json:"block_code"`
+	StartDate            time.Time      `json:"start_date"`
+}
+
+// Rooms of the type still free on the fullest night of the range. A night's capacity is the
+// type's rooms plus the overbooking allowance covering it. Stays with rooms count against the
+// type of the room each segment uses; rooms taken out of service by a block
+// and rooms an active allotment holds but has not had picked up count as booked for the nights
+// they cover. The allotment named by block_code does not hold rooms away from its own bookings.
+func (q *Queries) GetMinNightlyTypeAvailability(ctx context.Context, arg GetMinNightlyTypeAvailabilityParams) (int32, error) {
+	row := q.queryRow(ctx, q.getMinNightlyTypeAvailabilityStmt, getMinNightlyTypeAvailability,
+		arg.TotalRooms,
+		arg.HotelID,
+		arg.TypeID,
+		arg.ExcludeReservationID,
+		arg.EndDate,
+		arg.BlockCode,
+		arg.StartDate,
+	)
+	var min_available int32
+	err := row.Scan(&min_available)
+	return min_available, err
+}
+
 const getReservation = `-- name: GetReservation :one
-SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by FROM reservation
+SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id FROM reservation
 WHERE reservation_id = $1 LIMIT 1
 `
 
@@ -100,35 +453,96 @@ func (q *Queries) GetReservation(ctx context.Context, reservationID uuid.UUID) (
 		&i.CreatedBy,
 		&i.UpdateAt,
 		&i.UpdateBy,
+		&i.TotalPrice,
+		&i.PromoCode,
+		&i.DiscountAmount,
+		&i.HotelID,
+		&i.TypeID,
+		&i.GroupID,
+		&i.StayType,
+		&i.HoldExpiresAt,
+		&i.BlockCode,
+		&i.Adults,
+		&i.Children,
+		pq.Array(&i.ChildAges),
+		&i.ExtraPersonCharge,
+		&i.ExtrasAmount,
+		&i.SpecialRequests,
+		&i.ConfirmationCode,
+		&i.Source,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const getReservationForUpdate = `-- name: GetReservationForUpdate :one
+SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id FROM reservation
+WHERE reservation_id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetReservationForUpdate(ctx context.Context, reservationID uuid.UUID) (Reservation, error) {
+	row := q.queryRow(ctx, q.getReservationForUpdateStmt, getReservationForUpdate, reservationID)
+	var i Reservation
+	err := row.Scan(
+		&i.ReservationID,
+		&i.RoomID,
+		&i.UserID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdateAt,
+		&i.UpdateBy,
+		&i.TotalPrice,
+		&i.PromoCode,
+		&i.DiscountAmount,
+		&i.HotelID,
+		&i.TypeID,
+		&i.GroupID,
+		&i.StayType,
+		&i.HoldExpiresAt,
+		&i.BlockCode,
+		&i.Adults,
+		&i.Children,
+		pq.Array(&i.ChildAges),
+		&i.ExtraPersonCharge,
+		&i.ExtrasAmount,
+		&i.SpecialRequests,
+		&i.ConfirmationCode,
+		&i.Source,
+		&i.ExternalID,
 	)
 	return i, err
 }
 
 const getReservationsByDateRange = `-- name: GetReservationsByDateRange :many
-SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by FROM reservation
-WHERE room_id = $1
-  AND status = $2
-  AND (
-    (start_date <= $3 AND end_date > $3) OR
-    (start_date < $4 AND end_date >= $4) OR
-    (start_date >= $3 AND end_date <= $4)
+SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id FROM reservation res
+WHERE res.status = $1
+  AND EXISTS (
+    SELECT 1 FROM reservation_segment seg
+    WHERE seg.reservation_id = res.reservation_id
+      AND seg.room_id = $2
+      AND seg.start_date < $3::timestamptz
+      AND seg.end_date > $4::timestamptz
   )
 ORDER BY start_date
 `
 
 type GetReservationsByDateRangeParams struct {
-	RoomID      uuid.NullUUID  `json:"room_id"`
-	Status      sql.NullString `json:"status"`
-	StartDate   sql.NullTime   `json:"start_date"`
-	StartDate_2 sql.NullTime   `json:"start_date_2"`
+	Status    sql.NullString `json:"status"`
+	RoomID    uuid.UUID      `json:"room_id"`
+	EndDate   time.Time      `json:"end_date"`
+	StartDate time.Time      `json:"start_date"`
 }
 
 func (q *Queries) GetReservationsByDateRange(ctx context.Context, arg GetReservationsByDateRangeParams) ([]Reservation, error) {
 	rows, err := q.query(ctx, q.getReservationsByDateRangeStmt, getReservationsByDateRange,
-		arg.RoomID,
 		arg.Status,
+		arg.RoomID,
+		arg.EndDate,
 		arg.StartDate,
-		arg.StartDate_2,
 	)
 	if err != nil {
 		return nil, err
@@ -148,6 +562,83 @@ func (q *Queries) GetReservationsByDateRange(ctx context.Context, arg GetReserva
 			&i.CreatedBy,
 			&i.UpdateAt,
 			&i.UpdateBy,
+			&i.TotalPrice,
+			&i.PromoCode,
+			&i.DiscountAmount,
+			&i.HotelID,
+			&i.TypeID,
+			&i.GroupID,
+			&i.StayType,
+			&i.HoldExpiresAt,
+			&i.BlockCode,
+			&i.Adults,
+			&i.Children,
+			pq.Array(&i.ChildAges),
+			&i.ExtraPersonCharge,
+			&i.ExtrasAmount,
+			&i.SpecialRequests,
+			&i.ConfirmationCode,
+			&i.Source,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpiredHolds = `-- name: ListExpiredHolds :many
+SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id FROM reservation
+WHERE status = 'PENDING'
+  AND hold_expires_at < $1::timestamptz
+ORDER BY hold_expires_at
+`
+
+func (q *Queries) ListExpiredHolds(ctx context.Context, now time.Time) ([]Reservation, error) {
+	rows, err := q.query(ctx, q.listExpiredHoldsStmt, listExpiredHolds, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Reservation{}
+	for rows.Next() {
+		var i Reservation
+		if err := rows.Scan(
+			&i.ReservationID,
+			&i.RoomID,
+			&i.UserID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Status,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdateAt,
+			&i.UpdateBy,
+			&i.TotalPrice,
+			&i.PromoCode,
+			&i.DiscountAmount,
+			&i.HotelID,
+			&i.TypeID,
+			&i.GroupID,
+			&i.StayType,
+			&i.HoldExpiresAt,
+			&i.BlockCode,
+			&i.Adults,
+			&i.Children,
+			pq.Array(&i.ChildAges),
+			&i.ExtraPersonCharge,
+			&i.ExtrasAmount,
+			&i.SpecialRequests,
+			&i.ConfirmationCode,
+			&i.Source,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -163,7 +654,7 @@ func (q *Queries) GetReservationsByDateRange(ctx context.Context, arg GetReserva
 }
 
 const listReservations = `-- name: ListReservations :many
-SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by FROM reservation
+SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id FROM reservation
 ORDER BY created_at DESC
 LIMIT $1
 OFFSET $2
@@ -194,6 +685,24 @@ func (q *Queries) ListReservations(ctx context.Context, arg ListReservationsPara
 			&i.CreatedBy,
 			&i.UpdateAt,
 			&i.UpdateBy,
+			&i.TotalPrice,
+			&i.PromoCode,
+			&i.DiscountAmount,
+			&i.HotelID,
+			&i.TypeID,
+			&i.GroupID,
+			&i.StayType,
+			&i.HoldExpiresAt,
+			&i.BlockCode,
+			&i.Adults,
+			&i.Children,
+			pq.Array(&i.ChildAges),
+			&i.ExtraPersonCharge,
+			&i.ExtrasAmount,
+			&i.SpecialRequests,
+			&i.ConfirmationCode,
+			&i.Source,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -209,17 +718,21 @@ func (q *Queries) ListReservations(ctx context.Context, arg ListReservationsPara
 }
 
 const listReservationsByRoom = `-- name: ListReservationsByRoom :many
-SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by FROM reservation
-WHERE room_id = $1
+SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id FROM reservation res
+WHERE EXISTS (
+  SELECT 1 FROM reservation_segment seg
+  WHERE seg.reservation_id = res.reservation_id
+    AND seg.room_id = $1
+)
 ORDER BY start_date
 LIMIT $2
 OFFSET $3
 `
 
 type ListReservationsByRoomParams struct {
-	RoomID uuid.NullUUID `json:"room_id"`
-	Limit  int32         `json:"limit"`
-	Offset int32         `json:"offset"`
+	RoomID uuid.UUID `json:"room_id"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
 }
 
 func (q *Queries) ListReservationsByRoom(ctx context.Context, arg ListReservationsByRoomParams) ([]Reservation, error) {
@@ -242,6 +755,24 @@ func (q *Queries) ListReservationsByRoom(ctx context.Context, arg ListReservatio
 			&i.CreatedBy,
 			&i.UpdateAt,
 			&i.UpdateBy,
+			&i.TotalPrice,
+			&i.PromoCode,
+			&i.DiscountAmount,
+			&i.HotelID,
+			&i.TypeID,
+			&i.GroupID,
+			&i.StayType,
+			&i.HoldExpiresAt,
+			&i.BlockCode,
+			&i.Adults,
+			&i.Children,
+			pq.Array(&i.ChildAges),
+			&i.ExtraPersonCharge,
+			&i.ExtrasAmount,
+			&i.SpecialRequests,
+			&i.ConfirmationCode,
+			&i.Source,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -257,7 +788,7 @@ func (q *Queries) ListReservationsByRoom(ctx context.Context, arg ListReservatio
 }
 
 const listReservationsByUser = `-- name: ListReservationsByUser :many
-SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by FROM reservation
+SELECT reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id FROM reservation
 WHERE user_id = $1
 ORDER BY start_date DESC
 LIMIT $2
@@ -290,6 +821,24 @@ func (q *Queries) ListReservationsByUser(ctx context.Context, arg ListReservatio
 			&i.CreatedBy,
 			&i.UpdateAt,
 			&i.UpdateBy,
+			&i.TotalPrice,
+			&i.PromoCode,
+			&i.DiscountAmount,
+			&i.HotelID,
+			&i.TypeID,
+			&i.GroupID,
+			&i.StayType,
+			&i.HoldExpiresAt,
+			&i.BlockCode,
+			&i.Adults,
+			&i.Children,
+			pq.Array(&i.ChildAges),
+			&i.ExtraPersonCharge,
+			&i.ExtrasAmount,
+			&i.SpecialRequests,
+			&i.ConfirmationCode,
+			&i.Source,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -304,6 +853,97 @@ func (q *Queries) ListReservationsByUser(ctx context.Context, arg ListReservatio
 	return items, nil
 }
 
+const modifyReservation = `-- name: ModifyReservation :one
+UPDATE reservation
+SET
+  room_id = $2,
+  user_id = $3,
+  start_date = $4,
+  end_date = $5,
+  hotel_id = $6,
+  type_id = $7,
+  total_price = $8,
+  discount_amount = $9,
+  update_at = $10,
+  adults = $11,
+  children = $12,
+  child_ages = $13,
+  extra_person_charge = $14,
+  extras_amount = $15
+WHERE reservation_id = $1
+RETURNING reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id
+`
+
+type ModifyReservationParams struct {
+	ReservationID     uuid.UUID      `json:"reservation_id"`
+	RoomID            uuid.NullUUID  `json:"room_id"`
+	UserID            sql.NullString `json:"user_id"`
+	StartDate         sql.NullTime   `json:"start_date"`
+	EndDate           sql.NullTime   `json:"end_date"`
+	HotelID           uuid.NullUUID  `json:"hotel_id"`
+	TypeID            sql.NullString `json:"type_id"`
+	TotalPrice        sql.NullInt32  `json:"total_price"`
+	DiscountAmount    sql.NullInt32  `json:"discount_amount"`
+	UpdateAt          sql.NullTime   `json:"update_at"`
+	Adults            sql.NullInt32  `json:"adults"`
+	Children          sql.NullInt32  `json:"children"`
+	ChildAges         []int32        `json:"child_ages"`
+	ExtraPersonCharge sql.NullInt32  `json:"extra_person_charge"`
+	ExtrasAmount      sql.NullInt32  `json:"extras_amount"`
+}
+
+func (q *Queries) ModifyReservation(ctx context.Context, arg ModifyReservationParams) (Reservation, error) {
+	row := q.queryRow(ctx, q.modifyReservationStmt, modifyReservation,
+		arg.ReservationID,
+		arg.RoomID,
+		arg.UserID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.HotelID,
+		arg.TypeID,
+		arg.TotalPrice,
+		arg.DiscountAmount,
+		arg.UpdateAt,
+		arg.Adults,
+		arg.Children,
+		pq.Array(arg.ChildAges),
+		arg.ExtraPersonCharge,
+		arg.ExtrasAmount,
+	)
+	var i Reservation
+	err := row.Scan(
+		&i.ReservationID,
+		&i.RoomID,
+		&i.UserID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdateAt,
+		&i.UpdateBy,
+		&i.TotalPrice,
+		&i.PromoCode,
+		&i.DiscountAmount,
+		&i.HotelID,
+		&i.TypeID,
+		&i.GroupID,
+		&i.StayType,
+		&i.HoldExpiresAt,
+		&i.BlockCode,
+		&i.Adults,
+		&i.Children,
+		pq.Array(&i.ChildAges),
+		&i.ExtraPersonCharge,
+		&i.ExtrasAmount,
+		&i.SpecialRequests,
+		&i.ConfirmationCode,
+		&i.Source,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
 const updateReservation = `-- name: UpdateReservation :one
 UPDATE reservation
 SET 
@@ -315,7 +955,7 @@ SET
   update_at = $7,
   update_by = $8
 WHERE reservation_id = $1
-RETURNING reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by
+RETURNING reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id
 `
 
 type UpdateReservationParams struct {
@@ -352,6 +992,24 @@ func (q *Queries) UpdateReservation(ctx context.Context, arg UpdateReservationPa
 		&i.CreatedBy,
 		&i.UpdateAt,
 		&i.UpdateBy,
+		&i.TotalPrice,
+		&i.PromoCode,
+		&i.DiscountAmount,
+		&i.HotelID,
+		&i.TypeID,
+		&i.GroupID,
+		&i.StayType,
+		&i.HoldExpiresAt,
+		&i.BlockCode,
+		&i.Adults,
+		&i.Children,
+		pq.Array(&i.ChildAges),
+		&i.ExtraPersonCharge,
+		&i.ExtrasAmount,
+		&i.SpecialRequests,
+		&i.ConfirmationCode,
+		&i.Source,
+		&i.ExternalID,
 	)
 	return i, err
 }
@@ -363,7 +1021,7 @@ SET
   update_at = $3,
   update_by = $4
 WHERE reservation_id = $1
-RETURNING reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by
+RETURNING reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by, update_at, update_by, total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code, adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code, source, external_id
 `
 
 type UpdateReservationStatusParams struct {
@@ -392,6 +1050,24 @@ func (q *Queries) UpdateReservationStatus(ctx context.Context, arg UpdateReserva
 		&i.CreatedBy,
 		&i.UpdateAt,
 		&i.UpdateBy,
+		&i.TotalPrice,
+		&i.PromoCode,
+		&i.DiscountAmount,
+		&i.HotelID,
+		&i.TypeID,
+		&i.GroupID,
+		&i.StayType,
+		&i.HoldExpiresAt,
+		&i.BlockCode,
+		&i.Adults,
+		&i.Children,
+		pq.Array(&i.ChildAges),
+		&i.ExtraPersonCharge,
+		&i.ExtrasAmount,
+		&i.SpecialRequests,
+		&i.ConfirmationCode,
+		&i.Source,
+		&i.ExternalID,
 	)
 	return i, err
 }
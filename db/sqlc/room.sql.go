@@ -26,10 +26,12 @@ INSERT INTO room (
   created_at,
   created_by,
   update_at,
-  update_by
+  update_by,
+  day_use,
+  day_use_price
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
-) RETURNING room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+) RETURNING room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price
 `
 
 type CreateRoomParams struct {
@@ -46,6 +48,8 @@ type CreateRoomParams struct {
 	CreatedBy   uuid.NullUUID   `json:"created_by"`
 	UpdateAt    sql.NullTime    `json:"update_at"`
 	UpdateBy    uuid.NullUUID   `json:"update_by"`
+	DayUse      sql.NullBool    `json:"day_use"`
+	DayUsePrice sql.NullInt32   `json:"day_use_price"`
 }
 
 func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, error) {
@@ -63,6 +67,8 @@ func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, e
 		arg.CreatedBy,
 		arg.UpdateAt,
 		arg.UpdateBy,
+		arg.DayUse,
+		arg.DayUsePrice,
 	)
 	var i Room
 	err := row.Scan(
@@ -79,6 +85,10 @@ func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, e
 		&i.CreatedBy,
 		&i.UpdateAt,
 		&i.UpdateBy,
+		&i.HousekeepingStatus,
+		&i.HousekeepingUpdatedAt,
+		&i.DayUse,
+		&i.DayUsePrice,
 	)
 	return i, err
 }
@@ -93,82 +103,44 @@ func (q *Queries) DeleteRoom(ctx context.Context, roomID uuid.UUID) error {
 	return err
 }
 
-const getAvailableRooms = `-- name: GetAvailableRooms :many
-SELECT r.room_id, r.room_name, r.hotel_id, r.floor, r.type_id, r.max_capacity, r.rate, r.description, r.price, r.created_at, r.created_by, r.update_at, r.update_by FROM room r
-WHERE r.hotel_id = $1
-  AND r.room_id NOT IN (
-    SELECT res.room_id FROM reservation res
-    WHERE res.room_id = r.room_id
-      AND res.status = 'confirmed'
-      AND (
-        (res.start_date <= $2 AND res.end_date > $2) OR
-        (res.start_date < $3 AND res.end_date >= $3) OR
-        (res.start_date >= $2 AND res.end_date <= $3)
-      )
-  )
-ORDER BY r.price
-LIMIT $4
-OFFSET $5
+const getRoom = `-- name: GetRoom :one
+SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price FROM room
+WHERE room_id = $1 LIMIT 1
 `
 
-type GetAvailableRoomsParams struct {
-	HotelID     uuid.NullUUID `json:"hotel_id"`
-	StartDate   sql.NullTime  `json:"start_date"`
-	StartDate_2 sql.NullTime  `json:"start_date_2"`
-	Limit       int32         `json:"limit"`
-	Offset      int32         `json:"offset"`
-}
-
-func (q *Queries) GetAvailableRooms(ctx context.Context, arg GetAvailableRoomsParams) ([]Room, error) {
-	rows, err := q.query(ctx, q.getAvailableRoomsStmt, getAvailableRooms,
-		arg.HotelID,
-		arg.StartDate,
-		arg.StartDate_2,
-		arg.Limit,
-		arg.Offset,
+func (q *Queries) GetRoom(ctx context.Context, roomID uuid.UUID) (Room, error) {
+	row := q.queryRow(ctx, q.getRoomStmt, getRoom, roomID)
+	var i Room
+	err := row.Scan(
+		&i.RoomID,
+		&i.RoomName,
+		&i.HotelID,
+		&i.Floor,
+		&i.TypeID,
+		&i.MaxCapacity,
+		&i.Rate,
+		&i.Description,
+		&i.Price,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdateAt,
+		&i.UpdateBy,
+		&i.HousekeepingStatus,
+		&i.HousekeepingUpdatedAt,
+		&i.DayUse,
+		&i.DayUsePrice,
 	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []Room{}
-	for rows.Next() {
-		var i Room
-		if err := rows.Scan(
-			&i.RoomID,
-			&i.RoomName,
-			&i.HotelID,
-			&i.Floor,
-			&i.TypeID,
-			&i.MaxCapacity,
-			&i.Rate,
-			&i.Description,
-			&i.Price,
-			&i.CreatedAt,
-			&i.CreatedBy,
-			&i.UpdateAt,
-			&i.UpdateBy,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	return i, err
 }
 
-const getRoom = `-- name: GetRoom :one
-SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by FROM room
+const getRoomForUpdate = `-- name: GetRoomForUpdate :one
+SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price FROM room
 WHERE room_id = $1 LIMIT 1
+FOR UPDATE
 `
 
-func (q *Queries) GetRoom(ctx context.Context, roomID uuid.UUID) (Room, error) {
-	row := q.queryRow(ctx, q.getRoomStmt, getRoom, roomID)
+func (q *Queries) GetRoomForUpdate(ctx context.Context, roomID uuid.UUID) (Room, error) {
+	row := q.queryRow(ctx, q.getRoomForUpdateStmt, getRoomForUpdate, roomID)
 	var i Room
 	err := row.Scan(
 		&i.RoomID,
@@ -184,12 +156,16 @@ func (q *Queries) GetRoom(ctx context.Context, roomID uuid.UUID) (Room, error) {
 		&i.CreatedBy,
 		&i.UpdateAt,
 		&i.UpdateBy,
+		&i.HousekeepingStatus,
+		&i.HousekeepingUpdatedAt,
+		&i.DayUse,
+		&i.DayUsePrice,
 	)
 	return i, err
 }
 
 const listRooms = `-- name: ListRooms :many
-SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by FROM room
+SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price FROM room
 ORDER BY room_id
 LIMIT $1
 OFFSET $2
@@ -223,6 +199,10 @@ func (q *Queries) ListRooms(ctx context.Context, arg ListRoomsParams) ([]Room, e
 			&i.CreatedBy,
 			&i.UpdateAt,
 			&i.UpdateBy,
+			&i.HousekeepingStatus,
+			&i.HousekeepingUpdatedAt,
+			&i.DayUse,
+			&i.DayUsePrice,
 		); err != nil {
 			return nil, err
 		}
@@ -238,7 +218,7 @@ func (q *Queries) ListRooms(ctx context.Context, arg ListRoomsParams) ([]Room, e
 }
 
 const listRoomsByHotel = `-- name: ListRoomsByHotel :many
-SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by FROM room
+SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price FROM room
 WHERE hotel_id = $1
 ORDER BY floor, room_name
 LIMIT $2
@@ -274,6 +254,63 @@ func (q *Queries) ListRoomsByHotel(ctx context.Context, arg ListRoomsByHotelPara
 			&i.CreatedBy,
 			&i.UpdateAt,
 			&i.UpdateBy,
+			&i.HousekeepingStatus,
+			&i.HousekeepingUpdatedAt,
+			&i.DayUse,
+			&i.DayUsePrice,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockRoomsByHotelAndType = `-- name: LockRoomsByHotelAndType :many
+SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price FROM room
+WHERE hotel_id = $1 AND type_id = $2
+ORDER BY room_id
+FOR UPDATE
+`
+
+type LockRoomsByHotelAndTypeParams struct {
+	HotelID uuid.NullUUID  `json:"hotel_id"`
+	TypeID  sql.NullString `json:"type_id"`
+}
+
+func (q *Queries) LockRoomsByHotelAndType(ctx context.Context, arg LockRoomsByHotelAndTypeParams) ([]Room, error) {
+	rows, err := q.query(ctx, q.lockRoomsByHotelAndTypeStmt, lockRoomsByHotelAndType, arg.HotelID, arg.TypeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Room{}
+	for rows.Next() {
+		var i Room
+		if err := rows.Scan(
+			&i.RoomID,
+			&i.RoomName,
+			&i.HotelID,
+			&i.Floor,
+			&i.TypeID,
+			&i.MaxCapacity,
+			&i.Rate,
+			&i.Description,
+			&i.Price,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdateAt,
+			&i.UpdateBy,
+			&i.HousekeepingStatus,
+			&i.HousekeepingUpdatedAt,
+			&i.DayUse,
+			&i.DayUsePrice,
 		); err != nil {
 			return nil, err
 		}
@@ -300,9 +337,11 @@ SET
   description = $8,
   price = $9,
   update_at = $10,
-  update_by = $11
+  update_by = $11,
+  day_use = $12,
+  day_use_price = $13
 WHERE room_id = $1
-RETURNING room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by
+RETURNING room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price
 `
 
 type UpdateRoomParams struct {
@@ -317,6 +356,8 @@ type UpdateRoomParams struct {
 	Price       sql.NullInt32   `json:"price"`
 	UpdateAt    sql.NullTime    `json:"update_at"`
 	UpdateBy    uuid.NullUUID   `json:"update_by"`
+	DayUse      sql.NullBool    `json:"day_use"`
+	DayUsePrice sql.NullInt32   `json:"day_use_price"`
 }
 
 func (q *Queries) UpdateRoom(ctx context.Context, arg UpdateRoomParams) (Room, error) {
@@ -332,6 +373,8 @@ func (q *Queries) UpdateRoom(ctx context.Context, arg UpdateRoomParams) (Room, e
 		arg.Price,
 		arg.UpdateAt,
 		arg.UpdateBy,
+		arg.DayUse,
+		arg.DayUsePrice,
 	)
 	var i Room
 	err := row.Scan(
@@ -348,6 +391,50 @@ func (q *Queries) UpdateRoom(ctx context.Context, arg UpdateRoomParams) (Room, e
 		&i.CreatedBy,
 		&i.UpdateAt,
 		&i.UpdateBy,
+		&i.HousekeepingStatus,
+		&i.HousekeepingUpdatedAt,
+		&i.DayUse,
+		&i.DayUsePrice,
+	)
+	return i, err
+}
+
+const updateRoomHousekeepingStatus = `-- name: UpdateRoomHousekeepingStatus :one
+UPDATE room
+SET
+  housekeeping_status = $2,
+  housekeeping_updated_at = $3
+WHERE room_id = $1
+RETURNING room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price
+`
+
+type UpdateRoomHousekeepingStatusParams struct {
+	RoomID                uuid.UUID      `json:"room_id"`
+	HousekeepingStatus    sql.NullString `json:"housekeeping_status"`
+	HousekeepingUpdatedAt sql.NullTime   `json:"housekeeping_updated_at"`
+}
+
+func (q *Queries) UpdateRoomHousekeepingStatus(ctx context.Context, arg UpdateRoomHousekeepingStatusParams) (Room, error) {
+	row := q.queryRow(ctx, q.updateRoomHousekeepingStatusStmt, updateRoomHousekeepingStatus, arg.RoomID, arg.HousekeepingStatus, arg.HousekeepingUpdatedAt)
+	var i Room
+	err := row.Scan(
+		&i.RoomID,
+		&i.RoomName,
+		&i.HotelID,
+		&i.Floor,
+		&i.TypeID,
+		&i.MaxCapacity,
+		&i.Rate,
+		&i.Description,
+		&i.Price,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdateAt,
+		&i.UpdateBy,
+		&i.HousekeepingStatus,
+		&i.HousekeepingUpdatedAt,
+		&i.DayUse,
+		&i.DayUsePrice,
 	)
 	return i, err
 }
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AllotmentHandler struct {
+	allotmentService service.AllotmentService
+}
+
+func NewAllotmentHandler(allotmentService service.AllotmentService) *AllotmentHandler {
+	return &AllotmentHandler{
+		allotmentService: allotmentService,
+	}
+}
+
+func (h *AllotmentHandler) CreateAllotment(c *gin.Context) {
+	var allotment model.Allotment
+	if err := c.ShouldBindJSON(&allotment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.allotmentService.CreateAllotment(c.Request.Context(), &allotment); err != nil {
+		if err.Error() == "hotel not found" || err.Error() == "room type not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, allotment)
+}
+
+func (h *AllotmentHandler) GetAllotment(c *gin.Context) {
+	allotmentIDStr := c.Param("id")
+	allotmentID, err := uuid.Parse(allotmentIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid allotment ID"})
+		return
+	}
+
+	allotment, err := h.allotmentService.GetAllotmentByID(c.Request.Context(), allotmentID)
+	if err != nil {
+		if err.Error() == "allotment not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, allotment)
+}
+
+func (h *AllotmentHandler) GetAllotmentByCode(c *gin.Context) {
+	code := c.Param("code")
+
+	allotment, err := h.allotmentService.GetAllotmentByCode(c.Request.Context(), code)
+	if err != nil {
+		if err.Error() == "allotment not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, allotment)
+}
+
+func (h *AllotmentHandler) ListAllotmentsByHotel(c *gin.Context) {
+	hotelIDStr := c.Param("hotel_id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	allotments, err := h.allotmentService.ListAllotmentsByHotel(c.Request.Context(), hotelID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      allotments,
+		"hotel_id":  hotelID,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *AllotmentHandler) UpdateAllotment(c *gin.Context) {
+	allotmentIDStr := c.Param("id")
+	allotmentID, err := uuid.Parse(allotmentIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid allotment ID"})
+		return
+	}
+
+	var allotment model.Allotment
+	if err := c.ShouldBindJSON(&allotment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	allotment.AllotmentID = allotmentID
+
+	if err := h.allotmentService.UpdateAllotment(c.Request.Context(), &allotment); err != nil {
+		if err.Error() == "allotment not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, allotment)
+}
+
+func (h *AllotmentHandler) ReleaseAllotment(c *gin.Context) {
+	allotmentIDStr := c.Param("id")
+	allotmentID, err := uuid.Parse(allotmentIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid allotment ID"})
+		return
+	}
+
+	if err := h.allotmentService.ReleaseAllotment(c.Request.Context(), allotmentID); err != nil {
+		if err.Error() == "allotment not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "allotment released successfully"})
+}
+
+func (h *AllotmentHandler) CancelAllotment(c *gin.Context) {
+	allotmentIDStr := c.Param("id")
+	allotmentID, err := uuid.Parse(allotmentIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid allotment ID"})
+		return
+	}
+
+	if err := h.allotmentService.CancelAllotment(c.Request.Context(), allotmentID); err != nil {
+		if err.Error() == "allotment not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "allotment cancelled successfully"})
+}
\ No newline at end of file
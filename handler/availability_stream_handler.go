@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// availabilityHeartbeat is how often an idle stream sends a ping, so proxies keep it open
+const availabilityHeartbeat = 25 * time.Second
+
+type AvailabilityStreamHandler struct {
+	hotelService service.HotelService
+	stream       service.AvailabilityStream
+}
+
+func NewAvailabilityStreamHandler(hotelService service.HotelService, stream service.AvailabilityStream) *AvailabilityStreamHandler {
+	return &AvailabilityStreamHandler{
+		hotelService: hotelService,
+		stream:       stream,
+	}
+}
+
+// StreamAvailability sends a Server-Sent Event named after the event type whenever a
+// reservation changes the hotel's inventory, until the client disconnects
+func (h *AvailabilityStreamHandler) StreamAvailability(c *gin.Context) {
+	hotelIDStr := c.Param("id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	if _, err := h.hotelService.GetHotelByID(c.Request.Context(), hotelID); err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes, unsubscribe := h.stream.Subscribe(hotelID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent("ready", gin.H{"hotel_id": hotelID})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(availabilityHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case change := <-changes:
+			c.SSEvent(change.Type, change)
+			return true
+		case now := <-heartbeat.C:
+			c.SSEvent("ping", gin.H{"time": now.UTC()})
+			return true
+		}
+	})
+}
\ No newline at end of file
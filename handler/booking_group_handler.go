@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BookingGroupHandler struct {
+	bookingGroupService service.BookingGroupService
+}
+
+func NewBookingGroupHandler(bookingGroupService service.BookingGroupService) *BookingGroupHandler {
+	return &BookingGroupHandler{
+		bookingGroupService: bookingGroupService,
+	}
+}
+
+func (h *BookingGroupHandler) CreateBookingGroup(c *gin.Context) {
+	var group model.BookingGroup
+	if err := c.ShouldBindJSON(&group); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.bookingGroupService.CreateBookingGroup(c.Request.Context(), &group); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+func (h *BookingGroupHandler) GetBookingGroup(c *gin.Context) {
+	groupIDStr := c.Param("id")
+	groupID, err := uuid.Parse(groupIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking group ID"})
+		return
+	}
+
+	group, err := h.bookingGroupService.GetBookingGroupByID(c.Request.Context(), groupID)
+	if err != nil {
+		if err.Error() == "booking group not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (h *BookingGroupHandler) ListBookingGroupsByUser(c *gin.Context) {
+	userID := c.Param("user_id")
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	groups, err := h.bookingGroupService.ListBookingGroupsByUser(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      groups,
+		"user_id":   userID,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *BookingGroupHandler) UpdateBookingGroupStatus(c *gin.Context) {
+	groupIDStr := c.Param("id")
+	groupID, err := uuid.Parse(groupIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking group ID"})
+		return
+	}
+
+	var statusUpdate struct {
+		Status string `json:"status" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&statusUpdate); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if statusUpdate.Status == "CANCELLED" {
+		err = h.bookingGroupService.CancelBookingGroup(c.Request.Context(), groupID)
+	} else if statusUpdate.Status == "CONFIRMED" {
+		err = h.bookingGroupService.ConfirmBookingGroup(c.Request.Context(), groupID)
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status. Use CANCELLED or CONFIRMED"})
+		return
+	}
+
+	if err != nil {
+		if err.Error() == "booking group not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "booking group status updated successfully"})
+}
+
+func (h *BookingGroupHandler) CancelBookingGroup(c *gin.Context) {
+	groupIDStr := c.Param("id")
+	groupID, err := uuid.Parse(groupIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking group ID"})
+		return
+	}
+
+	if err := h.bookingGroupService.CancelBookingGroup(c.Request.Context(), groupID); err != nil {
+		if err.Error() == "booking group not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "booking group cancelled successfully"})
+}
\ No newline at end of file
@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ChannelHandler struct {
+	channelService service.ChannelService
+}
+
+func NewChannelHandler(channelService service.ChannelService) *ChannelHandler {
+	return &ChannelHandler{
+		channelService: channelService,
+	}
+}
+
+// ListChannels lists the channels hotels can be connected to
+func (h *ChannelHandler) ListChannels(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": h.channelService.ListChannels()})
+}
+
+func (h *ChannelHandler) CreateChannelConnection(c *gin.Context) {
+	var connection model.ChannelConnection
+	if err := c.ShouldBindJSON(&connection); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.channelService.CreateChannelConnection(c.Request.Context(), &connection); err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "hotel code is already connected to the channel" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, connection)
+}
+
+func (h *ChannelHandler) GetChannelConnection(c *gin.Context) {
+	connectionIDStr := c.Param("id")
+	connectionID, err := uuid.Parse(connectionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel connection ID"})
+		return
+	}
+
+	connection, err := h.channelService.GetChannelConnectionByID(c.Request.Context(), connectionID)
+	if err != nil {
+		if err.Error() == "channel connection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, connection)
+}
+
+func (h *ChannelHandler) ListChannelConnectionsByHotel(c *gin.Context) {
+	hotelIDStr := c.Param("hotel_id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	connections, err := h.channelService.ListChannelConnectionsByHotel(c.Request.Context(), hotelID, page, pageSize)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      connections,
+		"hotel_id":  hotelID,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *ChannelHandler) UpdateChannelConnection(c *gin.Context) {
+	connectionIDStr := c.Param("id")
+	connectionID, err := uuid.Parse(connectionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel connection ID"})
+		return
+	}
+
+	var connection model.ChannelConnection
+	if err := c.ShouldBindJSON(&connection); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	connection.ConnectionID = connectionID
+
+	if err := h.channelService.UpdateChannelConnection(c.Request.Context(), &connection); err != nil {
+		if err.Error() == "channel connection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "hotel code is already connected to the channel" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "channel connection updated successfully"})
+}
+
+func (h *ChannelHandler) DeleteChannelConnection(c *gin.Context) {
+	connectionIDStr := c.Param("id")
+	connectionID, err := uuid.Parse(connectionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel connection ID"})
+		return
+	}
+
+	if err := h.channelService.DeleteChannelConnection(c.Request.Context(), connectionID); err != nil {
+		if err.Error() == "channel connection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "channel connection deleted successfully"})
+}
+
+func (h *ChannelHandler) CreateChannelMapping(c *gin.Context) {
+	connectionIDStr := c.Param("id")
+	connectionID, err := uuid.Parse(connectionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel connection ID"})
+		return
+	}
+
+	var mapping model.ChannelMapping
+	if err := c.ShouldBindJSON(&mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	mapping.ConnectionID = connectionID
+
+	if err := h.channelService.CreateChannelMapping(c.Request.Context(), &mapping); err != nil {
+		switch err.Error() {
+		case "channel connection not found", "room not found", "room type not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case "room code is already mapped", "room is already mapped", "room type is already mapped", "a room and its room type cannot both be mapped":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapping)
+}
+
+func (h *ChannelHandler) ListChannelMappings(c *gin.Context) {
+	connectionIDStr := c.Param("id")
+	connectionID, err := uuid.Parse(connectionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel connection ID"})
+		return
+	}
+
+	mappings, err := h.channelService.ListChannelMappings(c.Request.Context(), connectionID)
+	if err != nil {
+		if err.Error() == "channel connection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":          mappings,
+		"connection_id": connectionID,
+	})
+}
+
+func (h *ChannelHandler) DeleteChannelMapping(c *gin.Context) {
+	mappingIDStr := c.Param("id")
+	mappingID, err := uuid.Parse(mappingIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel mapping ID"})
+		return
+	}
+
+	if err := h.channelService.DeleteChannelMapping(c.Request.Context(), mappingID); err != nil {
+		if err.Error() == "channel mapping not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "channel mapping deleted successfully"})
+}
+
+// PushChannelARI sends a connection its full availability, rates and restrictions immediately
+// instead of waiting for its next scheduled push
+func (h *ChannelHandler) PushChannelARI(c *gin.Context) {
+	connectionIDStr := c.Param("id")
+	connectionID, err := uuid.Parse(connectionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel connection ID"})
+		return
+	}
+
+	result, err := h.channelService.PushChannelARI(c.Request.Context(), connectionID, time.Now())
+	if err != nil {
+		if err.Error() == "channel connection not found" || err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// PullChannelReservations imports a connection's reservations immediately instead of waiting for
+// its next scheduled pull
+func (h *ChannelHandler) PullChannelReservations(c *gin.Context) {
+	connectionIDStr := c.Param("id")
+	connectionID, err := uuid.Parse(connectionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel connection ID"})
+		return
+	}
+
+	result, err := h.channelService.PullChannelReservations(c.Request.Context(), connectionID, time.Now())
+	if err != nil {
+		if err.Error() == "channel connection not found" || err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
\ No newline at end of file
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ExtraHandler struct {
+	extraService service.ExtraService
+}
+
+func NewExtraHandler(extraService service.ExtraService) *ExtraHandler {
+	return &ExtraHandler{
+		extraService: extraService,
+	}
+}
+
+func (h *ExtraHandler) CreateExtra(c *gin.Context) {
+	var extra model.Extra
+	if err := c.ShouldBindJSON(&extra); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.extraService.CreateExtra(c.Request.Context(), &extra); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, extra)
+}
+
+func (h *ExtraHandler) GetExtra(c *gin.Context) {
+	extraIDStr := c.Param("id")
+	extraID, err := uuid.Parse(extraIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid extra ID"})
+		return
+	}
+
+	extra, err := h.extraService.GetExtraByID(c.Request.Context(), extraID)
+	if err != nil {
+		if err.Error() == "extra not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, extra)
+}
+
+func (h *ExtraHandler) ListExtrasByHotel(c *gin.Context) {
+	hotelIDStr := c.Param("hotel_id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	extras, err := h.extraService.ListExtrasByHotel(c.Request.Context(), hotelID, page, pageSize)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      extras,
+		"hotel_id":  hotelID,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *ExtraHandler) UpdateExtra(c *gin.Context) {
+	extraIDStr := c.Param("id")
+	extraID, err := uuid.Parse(extraIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid extra ID"})
+		return
+	}
+
+	var extra model.Extra
+	if err := c.ShouldBindJSON(&extra); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	extra.ExtraID = extraID
+
+	if err := h.extraService.UpdateExtra(c.Request.Context(), &extra); err != nil {
+		if err.Error() == "extra not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "extra updated successfully"})
+}
+
+func (h *ExtraHandler) DeleteExtra(c *gin.Context) {
+	extraIDStr := c.Param("id")
+	extraID, err := uuid.Parse(extraIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid extra ID"})
+		return
+	}
+
+	if err := h.extraService.DeleteExtra(c.Request.Context(), extraID); err != nil {
+		if err.Error() == "extra not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "extra is still in use" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "extra deleted successfully"})
+}
+
+func (h *ExtraHandler) GetExtrasReport(c *gin.Context) {
+	hotelIDStr := c.Param("id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	fromStr := c.Query("from")
+	toStr := c.Query("to")
+
+	if fromStr == "" || toStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to dates are required"})
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	report, err := h.extraService.GetExtrasReport(c.Request.Context(), hotelID, from, to)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
\ No newline at end of file
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type HousekeepingHandler struct {
+	housekeepingService service.HousekeepingService
+}
+
+func NewHousekeepingHandler(housekeepingService service.HousekeepingService) *HousekeepingHandler {
+	return &HousekeepingHandler{
+		housekeepingService: housekeepingService,
+	}
+}
+
+func (h *HousekeepingHandler) UpdateHousekeepingStatus(c *gin.Context) {
+	roomIDStr := c.Param("id")
+	roomID, err := uuid.Parse(roomIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room ID"})
+		return
+	}
+
+	var statusUpdate struct {
+		Status string `json:"status" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&statusUpdate); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.housekeepingService.UpdateHousekeepingStatus(c.Request.Context(), roomID, statusUpdate.Status); err != nil {
+		if err.Error() == "room not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "housekeeping status updated successfully"})
+}
+
+func (h *HousekeepingHandler) GetHousekeepingBoard(c *gin.Context) {
+	hotelIDStr := c.Param("id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	// Left zero when no date is given, so the service uses today in the hotel's time zone
+	var date time.Time
+	if dateStr := c.Query("date"); dateStr != "" {
+		date, err = time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format (use YYYY-MM-DD)"})
+			return
+		}
+	}
+
+	board, err := h.housekeepingService.GetHousekeepingBoard(c.Request.Context(), hotelID, date)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
\ No newline at end of file
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ICalHandler struct {
+	icalService service.ICalService
+}
+
+func NewICalHandler(icalService service.ICalService) *ICalHandler {
+	return &ICalHandler{
+		icalService: icalService,
+	}
+}
+
+func (h *ICalHandler) IssueExportToken(c *gin.Context) {
+	roomIDStr := c.Param("id")
+	roomID, err := uuid.Parse(roomIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room ID"})
+		return
+	}
+
+	export, err := h.icalService.IssueExportToken(c.Request.Context(), roomID)
+	if err != nil {
+		if err.Error() == "room not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, export)
+}
+
+// ExportRoomCalendar serves a room's calendar to booking platforms and calendar apps, which
+// pass the export token in the query string
+func (h *ICalHandler) ExportRoomCalendar(c *gin.Context) {
+	roomIDStr := c.Param("id")
+	roomID, err := uuid.Parse(roomIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room ID"})
+		return
+	}
+
+	data, err := h.icalService.ExportRoomCalendar(c.Request.Context(), roomID, c.Query("token"))
+	if err != nil {
+		switch err.Error() {
+		case "room not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "invalid calendar token":
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Content-Disposition", `inline; filename="`+roomID.String()+`.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+func (h *ICalHandler) CreateICalFeed(c *gin.Context) {
+	var feed model.ICalFeed
+	if err := c.ShouldBindJSON(&feed); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.icalService.CreateICalFeed(c.Request.Context(), &feed); err != nil {
+		if err.Error() == "room not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, feed)
+}
+
+func (h *ICalHandler) GetICalFeed(c *gin.Context) {
+	feedIDStr := c.Param("id")
+	feedID, err := uuid.Parse(feedIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ical feed ID"})
+		return
+	}
+
+	feed, err := h.icalService.GetICalFeedByID(c.Request.Context(), feedID)
+	if err != nil {
+		if err.Error() == "ical feed not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+func (h *ICalHandler) ListICalFeedsByRoom(c *gin.Context) {
+	roomIDStr := c.Param("room_id")
+	roomID, err := uuid.Parse(roomIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room ID"})
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	feeds, err := h.icalService.ListICalFeedsByRoom(c.Request.Context(), roomID, page, pageSize)
+	if err != nil {
+		if err.Error() == "room not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      feeds,
+		"room_id":   roomID,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *ICalHandler) UpdateICalFeed(c *gin.Context) {
+	feedIDStr := c.Param("id")
+	feedID, err := uuid.Parse(feedIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ical feed ID"})
+		return
+	}
+
+	var feed model.ICalFeed
+	if err := c.ShouldBindJSON(&feed); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	feed.FeedID = feedID
+
+	if err := h.icalService.UpdateICalFeed(c.Request.Context(), &feed); err != nil {
+		if err.Error() == "ical feed not found" || err.Error() == "room not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ical feed updated successfully"})
+}
+
+func (h *ICalHandler) DeleteICalFeed(c *gin.Context) {
+	feedIDStr := c.Param("id")
+	feedID, err := uuid.Parse(feedIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ical feed ID"})
+		return
+	}
+
+	if err := h.icalService.DeleteICalFeed(c.Request.Context(), feedID); err != nil {
+		if err.Error() == "ical feed not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ical feed deleted successfully"})
+}
+
+// SyncICalFeed fetches a feed immediately instead of waiting for its next scheduled sync
+func (h *ICalHandler) SyncICalFeed(c *gin.Context) {
+	feedIDStr := c.Param("id")
+	feedID, err := uuid.Parse(feedIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ical feed ID"})
+		return
+	}
+
+	result, err := h.icalService.SyncICalFeed(c.Request.Context(), feedID, time.Now())
+	if err != nil {
+		if err.Error() == "ical feed not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
\ No newline at end of file
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OverbookingHandler struct {
+	overbookingService service.OverbookingService
+}
+
+func NewOverbookingHandler(overbookingService service.OverbookingService) *OverbookingHandler {
+	return &OverbookingHandler{
+		overbookingService: overbookingService,
+	}
+}
+
+func (h *OverbookingHandler) CreateOverbookingAllowance(c *gin.Context) {
+	var allowance model.OverbookingAllowance
+	if err := c.ShouldBindJSON(&allowance); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.overbookingService.CreateOverbookingAllowance(c.Request.Context(), &allowance); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, allowance)
+}
+
+func (h *OverbookingHandler) GetOverbookingAllowance(c *gin.Context) {
+	allowanceIDStr := c.Param("id")
+	allowanceID, err := uuid.Parse(allowanceIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid overbooking allowance ID"})
+		return
+	}
+
+	allowance, err := h.overbookingService.GetOverbookingAllowanceByID(c.Request.Context(), allowanceID)
+	if err != nil {
+		if err.Error() == "overbooking allowance not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, allowance)
+}
+
+func (h *OverbookingHandler) ListOverbookingAllowancesByHotel(c *gin.Context) {
+	hotelIDStr := c.Param("hotel_id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	allowances, err := h.overbookingService.ListOverbookingAllowancesByHotel(c.Request.Context(), hotelID, page, pageSize)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      allowances,
+		"hotel_id":  hotelID,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *OverbookingHandler) UpdateOverbookingAllowance(c *gin.Context) {
+	allowanceIDStr := c.Param("id")
+	allowanceID, err := uuid.Parse(allowanceIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid overbooking allowance ID"})
+		return
+	}
+
+	var allowance model.OverbookingAllowance
+	if err := c.ShouldBindJSON(&allowance); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	allowance.AllowanceID = allowanceID
+
+	if err := h.overbookingService.UpdateOverbookingAllowance(c.Request.Context(), &allowance); err != nil {
+		if err.Error() == "overbooking allowance not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "overbooking allowance updated successfully"})
+}
+
+func (h *OverbookingHandler) DeleteOverbookingAllowance(c *gin.Context) {
+	allowanceIDStr := c.Param("id")
+	allowanceID, err := uuid.Parse(allowanceIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid overbooking allowance ID"})
+		return
+	}
+
+	if err := h.overbookingService.DeleteOverbookingAllowance(c.Request.Context(), allowanceID); err != nil {
+		if err.Error() == "overbooking allowance not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "overbooking allowance deleted successfully"})
+}
+
+func (h *OverbookingHandler) GetOverbookingReport(c *gin.Context) {
+	hotelIDStr := c.Param("id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	fromStr := c.Query("from")
+	toStr := c.Query("to")
+
+	if fromStr == "" || toStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to dates are required"})
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	report, err := h.overbookingService.GetOverbookingReport(c.Request.Context(), hotelID, from, to)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
\ No newline at end of file
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+)
+
+type PromoCodeHandler struct {
+	promoCodeService service.PromoCodeService
+}
+
+func NewPromoCodeHandler(promoCodeService service.PromoCodeService) *PromoCodeHandler {
+	return &PromoCodeHandler{
+		promoCodeService: promoCodeService,
+	}
+}
+
+func (h *PromoCodeHandler) CreatePromoCode(c *gin.Context) {
+	var promo model.PromoCode
+	if err := c.ShouldBindJSON(&promo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.promoCodeService.CreatePromoCode(c.Request.Context(), &promo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, promo)
+}
+
+func (h *PromoCodeHandler) GetPromoCode(c *gin.Context) {
+	code := c.Param("code")
+
+	promo, err := h.promoCodeService.GetPromoCode(c.Request.Context(), code)
+	if err != nil {
+		if err.Error() == "promo code not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, promo)
+}
+
+func (h *PromoCodeHandler) ListPromoCodes(c *gin.Context) {
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	promos, err := h.promoCodeService.ListPromoCodes(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      promos,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *PromoCodeHandler) UpdatePromoCode(c *gin.Context) {
+	var promo model.PromoCode
+	if err := c.ShouldBindJSON(&promo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promo.Code = c.Param("code")
+
+	if err := h.promoCodeService.UpdatePromoCode(c.Request.Context(), &promo); err != nil {
+		if err.Error() == "promo code not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "promo code updated successfully"})
+}
+
+func (h *PromoCodeHandler) DeletePromoCode(c *gin.Context) {
+	code := c.Param("code")
+
+	if err := h.promoCodeService.DeletePromoCode(c.Request.Context(), code); err != nil {
+		if err.Error() == "promo code not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "promo code deleted successfully"})
+}
\ No newline at end of file
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/devsirose/hotel-reservation/middleware"
 	"github.com/devsirose/hotel-reservation/model"
 	"github.com/devsirose/hotel-reservation/service"
 	"github.com/gin-gonic/gin"
@@ -28,7 +29,13 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 	}
 
 	if err := h.reservationService.CreateReservation(c.Request.Context(), &reservation); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "room type is not available for the selected dates", "room is not available for the selected dates":
+			// Sold-out stays can be queued on the waitlist instead
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "waitlist_available": true})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -137,20 +144,73 @@ func (h *ReservationHandler) UpdateReservation(c *gin.Context) {
 		return
 	}
 
-	var reservation model.Reservation
-	if err := c.ShouldBindJSON(&reservation); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	// A magic link stands in for the user, and limits the change to what a guest may change
+	if c.GetString(middleware.MagicLinkActionKey) == model.MagicLinkModify {
+		var change model.GuestReservationChange
+		if err := c.ShouldBindJSON(&change); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		reservation, err := h.reservationService.UpdateReservationAsGuest(c.Request.Context(), reservationID, &change)
+		if err != nil {
+			if err.Error() == "reservation not found" {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, reservation)
 		return
 	}
 
-	reservation.ReservationID = reservationID
+	var change model.ReservationChange
+	if err := c.ShouldBindJSON(&change); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	if err := h.reservationService.UpdateReservation(c.Request.Context(), &reservation); err != nil {
+	reservation, err := h.reservationService.UpdateReservation(c.Request.Context(), reservationID, &change)
+	if err != nil {
+		if err.Error() == "authentication required" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "reservation not found" || err.Error() == "room not found" || err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "reservation updated successfully"})
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) ListReservationModifications(c *gin.Context) {
+	reservationIDStr := c.Param("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation ID"})
+		return
+	}
+
+	modifications, err := h.reservationService.ListReservationModifications(c.Request.Context(), reservationID)
+	if err != nil {
+		if err.Error() == "reservation not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":           modifications,
+		"reservation_id": reservationID,
+	})
 }
 
 func (h *ReservationHandler) CancelReservation(c *gin.Context) {
@@ -185,6 +245,140 @@ func (h *ReservationHandler) ConfirmReservation(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "reservation confirmed successfully"})
 }
 
+func (h *ReservationHandler) CheckOutReservation(c *gin.Context) {
+	reservationIDStr := c.Param("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation ID"})
+		return
+	}
+
+	if err := h.reservationService.CheckOutReservation(c.Request.Context(), reservationID); err != nil {
+		if err.Error() == "reservation not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "reservation checked out successfully"})
+}
+
+func (h *ReservationHandler) AssignRoom(c *gin.Context) {
+	reservationIDStr := c.Param("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation ID"})
+		return
+	}
+
+	var assignment struct {
+		RoomID string `json:"room_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	roomID, err := uuid.Parse(assignment.RoomID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room ID"})
+		return
+	}
+
+	if err := h.reservationService.AssignRoom(c.Request.Context(), reservationID, roomID); err != nil {
+		if err.Error() == "reservation not found" || err.Error() == "room not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "room assigned successfully"})
+}
+
+func (h *ReservationHandler) MoveGuest(c *gin.Context) {
+	reservationIDStr := c.Param("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation ID"})
+		return
+	}
+
+	var move model.RoomMove
+	if err := c.ShouldBindJSON(&move); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := h.reservationService.MoveGuest(c.Request.Context(), reservationID, &move)
+	if err != nil {
+		if err.Error() == "authentication required" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "reservation not found" || err.Error() == "room not found" || err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) UpdateReservationExtras(c *gin.Context) {
+	reservationIDStr := c.Param("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation ID"})
+		return
+	}
+
+	var change model.ReservationExtrasChange
+	if err := c.ShouldBindJSON(&change); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := h.reservationService.UpdateReservationExtras(c.Request.Context(), reservationID, &change)
+	if err != nil {
+		if err.Error() == "reservation not found" || err.Error() == "extra not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) GetReservationFolio(c *gin.Context) {
+	reservationIDStr := c.Param("id")
+	reservationID, err := uuid.Parse(reservationIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation ID"})
+		return
+	}
+
+	folio, err := h.reservationService.GetReservationFolio(c.Request.Context(), reservationID)
+	if err != nil {
+		if err.Error() == "reservation not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, folio)
+}
+
 func (h *ReservationHandler) ListReservations(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, gin.H{
 		"error": "ListReservations not yet implemented",
@@ -219,8 +413,13 @@ func (h *ReservationHandler) UpdateReservationStatus(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+	} else if statusUpdate.Status == "COMPLETED" {
+		if err := h.reservationService.CheckOutReservation(c.Request.Context(), reservationID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status. Use CANCELLED or CONFIRMED"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status. Use CANCELLED, CONFIRMED or COMPLETED"})
 		return
 	}
 
@@ -241,4 +440,55 @@ func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "reservation deleted (cancelled) successfully"})
+}
+
+func (h *ReservationHandler) LookupReservation(c *gin.Context) {
+	reservation, err := h.reservationService.LookupReservation(c.Request.Context(), c.Query("code"), c.Query("last_name"))
+	if err != nil {
+		if err.Error() == "reservation not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "confirmation code and last name are required" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) UpdateReservationByLookup(c *gin.Context) {
+	var change model.GuestReservationChange
+	if err := c.ShouldBindJSON(&change); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := h.reservationService.UpdateReservationByLookup(c.Request.Context(), c.Query("code"), c.Query("last_name"), &change)
+	if err != nil {
+		if err.Error() == "reservation not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) CancelReservationByLookup(c *gin.Context) {
+	if err := h.reservationService.CancelReservationByLookup(c.Request.Context(), c.Query("code"), c.Query("last_name")); err != nil {
+		if err.Error() == "reservation not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "reservation cancelled successfully"})
 }
\ No newline at end of file
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RoomBlockHandler struct {
+	roomBlockService service.RoomBlockService
+}
+
+func NewRoomBlockHandler(roomBlockService service.RoomBlockService) *RoomBlockHandler {
+	return &RoomBlockHandler{
+		roomBlockService: roomBlockService,
+	}
+}
+
+func (h *RoomBlockHandler) CreateRoomBlock(c *gin.Context) {
+	var block model.RoomBlock
+	if err := c.ShouldBindJSON(&block); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conflicts, err := h.roomBlockService.CreateRoomBlock(c.Request.Context(), &block)
+	if err != nil {
+		if len(conflicts) > 0 {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":     err.Error(),
+				"conflicts": conflicts,
+			})
+			return
+		}
+		if err.Error() == "room not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, block)
+}
+
+func (h *RoomBlockHandler) GetRoomBlock(c *gin.Context) {
+	blockIDStr := c.Param("id")
+	blockID, err := uuid.Parse(blockIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room block ID"})
+		return
+	}
+
+	block, err := h.roomBlockService.GetRoomBlockByID(c.Request.Context(), blockID)
+	if err != nil {
+		if err.Error() == "room block not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, block)
+}
+
+func (h *RoomBlockHandler) ListRoomBlocksByRoom(c *gin.Context) {
+	roomIDStr := c.Param("room_id")
+	roomID, err := uuid.Parse(roomIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room ID"})
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	blocks, err := h.roomBlockService.ListRoomBlocksByRoom(c.Request.Context(), roomID, page, pageSize)
+	if err != nil {
+		if err.Error() == "room not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      blocks,
+		"room_id":   roomID,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *RoomBlockHandler) DeleteRoomBlock(c *gin.Context) {
+	blockIDStr := c.Param("id")
+	blockID, err := uuid.Parse(blockIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room block ID"})
+		return
+	}
+
+	if err := h.roomBlockService.DeleteRoomBlock(c.Request.Context(), blockID); err != nil {
+		if err.Error() == "room block not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "room block is managed by an ical feed" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "room block deleted successfully"})
+}
\ No newline at end of file
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"encoding/csv"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/devsirose/hotel-reservation/model"
@@ -193,9 +195,162 @@ func (h *RoomHandler) GetAvailableRooms(c *gin.Context) {
 	})
 }
 
+func (h *RoomHandler) GetRoomTypeAvailability(c *gin.Context) {
+	hotelIDStr := c.Query("hotel_id")
+	if hotelIDStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "hotel_id query parameter is required"})
+		return
+	}
+
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	checkInStr := c.Query("check_in")
+	checkOutStr := c.Query("check_out")
+
+	if checkInStr == "" || checkOutStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "check_in and check_out dates are required"})
+		return
+	}
+
+	checkIn, err := time.Parse("2006-01-02", checkInStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid check_in date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	checkOut, err := time.Parse("2006-01-02", checkOutStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid check_out date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	availability, err := h.roomService.GetRoomTypeAvailability(c.Request.Context(), hotelID, checkIn, checkOut)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      availability,
+		"hotel_id":  hotelID,
+		"check_in":  checkInStr,
+		"check_out": checkOutStr,
+	})
+}
+
 func (h *RoomHandler) ListRooms(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, gin.H{
 		"error": "ListRooms not yet implemented",
 		"message": "Please use /hotels/:hotel_id/rooms to list rooms by hotel",
 	})
+}
+
+func (h *RoomHandler) GetHotelCalendar(c *gin.Context) {
+	hotelIDStr := c.Param("id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	fromStr := c.Query("from")
+	toStr := c.Query("to")
+
+	if fromStr == "" || toStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to dates are required"})
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	calendar, err := h.roomService.GetHotelCalendar(c.Request.Context(), hotelID, from, to)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "csv" || strings.Contains(c.GetHeader("Accept"), "text/csv") {
+		writeCalendarCSV(c, calendar)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+func (h *RoomHandler) GetDayUseSlots(c *gin.Context) {
+	hotelIDStr := c.Param("id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	// Left zero when no date is given, so the service uses today in the hotel's time zone
+	var date time.Time
+	if dateStr := c.Query("date"); dateStr != "" {
+		date, err = time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format (use YYYY-MM-DD)"})
+			return
+		}
+	}
+
+	slots, err := h.roomService.GetDayUseSlots(c.Request.Context(), hotelID, date)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, slots)
+}
+
+// writeCalendarCSV writes one row per room and one column per night. Occupied nights are
+// written as STATUS:reservation_id (or BLOCKED:block_id) so the spreadsheet still links back.
+func writeCalendarCSV(c *gin.Context, calendar *model.HotelCalendar) {
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename=calendar-"+calendar.From+"-"+calendar.To+".csv")
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(append([]string{"room_id", "room_name", "type_id"}, calendar.Dates...))
+	for _, room := range calendar.Rooms {
+		record := []string{room.RoomID.String(), room.RoomName, room.TypeID}
+		for _, night := range room.Nights {
+			cell := night.Status
+			if night.ReservationID != nil {
+				cell += ":" + night.ReservationID.String()
+			} else if night.BlockID != nil {
+				cell += ":" + night.BlockID.String()
+			}
+			record = append(record, cell)
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
 }
\ No newline at end of file
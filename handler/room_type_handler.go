@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+)
+
+type RoomTypeHandler struct {
+	roomTypeService service.RoomTypeService
+}
+
+func NewRoomTypeHandler(roomTypeService service.RoomTypeService) *RoomTypeHandler {
+	return &RoomTypeHandler{
+		roomTypeService: roomTypeService,
+	}
+}
+
+func (h *RoomTypeHandler) CreateRoomType(c *gin.Context) {
+	var roomType model.RoomType
+	if err := c.ShouldBindJSON(&roomType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.roomTypeService.CreateRoomType(c.Request.Context(), &roomType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, roomType)
+}
+
+func (h *RoomTypeHandler) GetRoomType(c *gin.Context) {
+	code := c.Param("code")
+
+	roomType, err := h.roomTypeService.GetRoomType(c.Request.Context(), code)
+	if err != nil {
+		if err.Error() == "room type not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, roomType)
+}
+
+func (h *RoomTypeHandler) ListRoomTypes(c *gin.Context) {
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	roomTypes, err := h.roomTypeService.ListRoomTypes(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      roomTypes,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *RoomTypeHandler) UpdateRoomType(c *gin.Context) {
+	var roomType model.RoomType
+	if err := c.ShouldBindJSON(&roomType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	roomType.TypeCode = c.Param("code")
+
+	if err := h.roomTypeService.UpdateRoomType(c.Request.Context(), &roomType); err != nil {
+		if err.Error() == "room type not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "room type updated successfully"})
+}
+
+func (h *RoomTypeHandler) DeleteRoomType(c *gin.Context) {
+	code := c.Param("code")
+
+	if err := h.roomTypeService.DeleteRoomType(c.Request.Context(), code); err != nil {
+		if err.Error() == "room type not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "room type deleted successfully"})
+}
\ No newline at end of file
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StayRestrictionHandler struct {
+	restrictionService service.StayRestrictionService
+}
+
+func NewStayRestrictionHandler(restrictionService service.StayRestrictionService) *StayRestrictionHandler {
+	return &StayRestrictionHandler{
+		restrictionService: restrictionService,
+	}
+}
+
+func (h *StayRestrictionHandler) CreateStayRestriction(c *gin.Context) {
+	var restriction model.StayRestriction
+	if err := c.ShouldBindJSON(&restriction); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.restrictionService.CreateStayRestriction(c.Request.Context(), &restriction); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, restriction)
+}
+
+func (h *StayRestrictionHandler) GetStayRestriction(c *gin.Context) {
+	restrictionIDStr := c.Param("id")
+	restrictionID, err := uuid.Parse(restrictionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid stay restriction ID"})
+		return
+	}
+
+	restriction, err := h.restrictionService.GetStayRestrictionByID(c.Request.Context(), restrictionID)
+	if err != nil {
+		if err.Error() == "stay restriction not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, restriction)
+}
+
+func (h *StayRestrictionHandler) ListStayRestrictionsByHotel(c *gin.Context) {
+	hotelIDStr := c.Param("hotel_id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	restrictions, err := h.restrictionService.ListStayRestrictionsByHotel(c.Request.Context(), hotelID, page, pageSize)
+	if err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      restrictions,
+		"hotel_id":  hotelID,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *StayRestrictionHandler) UpdateStayRestriction(c *gin.Context) {
+	restrictionIDStr := c.Param("id")
+	restrictionID, err := uuid.Parse(restrictionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid stay restriction ID"})
+		return
+	}
+
+	var restriction model.StayRestriction
+	if err := c.ShouldBindJSON(&restriction); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	restriction.RestrictionID = restrictionID
+
+	if err := h.restrictionService.UpdateStayRestriction(c.Request.Context(), &restriction); err != nil {
+		if err.Error() == "stay restriction not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "stay restriction updated successfully"})
+}
+
+func (h *StayRestrictionHandler) DeleteStayRestriction(c *gin.Context) {
+	restrictionIDStr := c.Param("id")
+	restrictionID, err := uuid.Parse(restrictionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid stay restriction ID"})
+		return
+	}
+
+	if err := h.restrictionService.DeleteStayRestriction(c.Request.Context(), restrictionID); err != nil {
+		if err.Error() == "stay restriction not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "stay restriction deleted successfully"})
+}
\ No newline at end of file
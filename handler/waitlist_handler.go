@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WaitlistHandler struct {
+	waitlistService service.WaitlistService
+}
+
+func NewWaitlistHandler(waitlistService service.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistService: waitlistService,
+	}
+}
+
+func (h *WaitlistHandler) JoinWaitlist(c *gin.Context) {
+	var entry model.WaitlistEntry
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.waitlistService.JoinWaitlist(c.Request.Context(), &entry); err != nil {
+		if err.Error() == "room not found" || err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *WaitlistHandler) GetWaitlistEntry(c *gin.Context) {
+	entryIDStr := c.Param("id")
+	entryID, err := uuid.Parse(entryIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid waitlist entry ID"})
+		return
+	}
+
+	entry, err := h.waitlistService.GetWaitlistEntryByID(c.Request.Context(), entryID)
+	if err != nil {
+		if err.Error() == "waitlist entry not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *WaitlistHandler) ListWaitlistEntriesByHotel(c *gin.Context) {
+	hotelIDStr := c.Param("hotel_id")
+	hotelID, err := uuid.Parse(hotelIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hotel ID"})
+		return
+	}
+
+	status := c.Query("status")
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	entries, err := h.waitlistService.ListWaitlistEntriesByHotel(c.Request.Context(), hotelID, status, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      entries,
+		"hotel_id":  hotelID,
+		"status":    status,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *WaitlistHandler) ListWaitlistEntriesByUser(c *gin.Context) {
+	userID := c.Param("user_id")
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	entries, err := h.waitlistService.ListWaitlistEntriesByUser(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      entries,
+		"user_id":   userID,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *WaitlistHandler) WithdrawWaitlistEntry(c *gin.Context) {
+	entryIDStr := c.Param("id")
+	entryID, err := uuid.Parse(entryIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid waitlist entry ID"})
+		return
+	}
+
+	if err := h.waitlistService.WithdrawWaitlistEntry(c.Request.Context(), entryID); err != nil {
+		if err.Error() == "waitlist entry not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "waitlist entry withdrawn successfully"})
+}
\ No newline at end of file
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h *WebhookHandler) CreateWebhookSubscription(c *gin.Context) {
+	var subscription model.WebhookSubscription
+	if err := c.ShouldBindJSON(&subscription); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.webhookService.CreateWebhookSubscription(c.Request.Context(), &subscription); err != nil {
+		if err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+func (h *WebhookHandler) GetWebhookSubscription(c *gin.Context) {
+	subscriptionIDStr := c.Param("id")
+	subscriptionID, err := uuid.Parse(subscriptionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook subscription ID"})
+		return
+	}
+
+	subscription, err := h.webhookService.GetWebhookSubscriptionByID(c.Request.Context(), subscriptionID)
+	if err != nil {
+		if err.Error() == "webhook subscription not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (h *WebhookHandler) ListWebhookSubscriptions(c *gin.Context) {
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	subscriptions, err := h.webhookService.ListWebhookSubscriptions(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      subscriptions,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *WebhookHandler) UpdateWebhookSubscription(c *gin.Context) {
+	subscriptionIDStr := c.Param("id")
+	subscriptionID, err := uuid.Parse(subscriptionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook subscription ID"})
+		return
+	}
+
+	var subscription model.WebhookSubscription
+	if err := c.ShouldBindJSON(&subscription); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription.SubscriptionID = subscriptionID
+
+	if err := h.webhookService.UpdateWebhookSubscription(c.Request.Context(), &subscription); err != nil {
+		if err.Error() == "webhook subscription not found" || err.Error() == "hotel not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook subscription updated successfully"})
+}
+
+func (h *WebhookHandler) DeleteWebhookSubscription(c *gin.Context) {
+	subscriptionIDStr := c.Param("id")
+	subscriptionID, err := uuid.Parse(subscriptionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook subscription ID"})
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhookSubscription(c.Request.Context(), subscriptionID); err != nil {
+		if err.Error() == "webhook subscription not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook subscription deleted successfully"})
+}
+
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
+	subscriptionIDStr := c.Param("id")
+	subscriptionID, err := uuid.Parse(subscriptionIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook subscription ID"})
+		return
+	}
+
+	page := 1
+	pageSize := 10
+
+	if p := c.Query("page"); p != "" {
+		if parsedPage, err := strconv.Atoi(p); err == nil {
+			page = parsedPage
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsedPageSize, err := strconv.Atoi(ps); err == nil {
+			pageSize = parsedPageSize
+		}
+	}
+
+	status := c.Query("status")
+
+	deliveries, err := h.webhookService.ListWebhookDeliveries(c.Request.Context(), subscriptionID, status, page, pageSize)
+	if err != nil {
+		if err.Error() == "webhook subscription not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":            deliveries,
+		"subscription_id": subscriptionID,
+		"page":            page,
+		"page_size":       pageSize,
+	})
+}
+
+func (h *WebhookHandler) GetWebhookDelivery(c *gin.Context) {
+	subscriptionID, deliveryID, ok := parseWebhookDeliveryIDs(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookService.GetWebhookDelivery(c.Request.Context(), subscriptionID, deliveryID)
+	if err != nil {
+		if err.Error() == "webhook delivery not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+func (h *WebhookHandler) ReplayWebhookDelivery(c *gin.Context) {
+	subscriptionID, deliveryID, ok := parseWebhookDeliveryIDs(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookService.ReplayWebhookDelivery(c.Request.Context(), subscriptionID, deliveryID)
+	if err != nil {
+		if err.Error() == "webhook delivery not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "webhook delivery is already pending" || err.Error() == "webhook subscription is disabled" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func parseWebhookDeliveryIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook subscription ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook delivery ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return subscriptionID, deliveryID, true
+}
\ No newline at end of file
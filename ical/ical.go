@@ -0,0 +1,71 @@
+// Package ical reads and writes the subset of iCalendar (RFC 5545) that booking platforms use
+// to exchange room availability: calendars of VEVENTs, all-day or timed, possibly recurring.
+package ical
+
+import (
+	"time"
+)
+
+// Event statuses
+const (
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Calendar is a VCALENDAR with its events
+type Calendar struct {
+	Name   string
+	Events []*Event
+}
+
+// Event is a VEVENT. All-day events start and end at midnight UTC of their dates, and End is
+// exclusive, as in DTEND. A recurring event has an RRULE and possibly exceptions; an event with
+// a RecurrenceID overrides one occurrence of the recurring event with the same UID.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Status       string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Transparent  bool
+	Stamp        time.Time
+	RRule        string
+	RDates       []time.Time
+	ExDates      []time.Time
+	RecurrenceID time.Time
+
+	// duration is the length given with DURATION instead of DTEND
+	duration *eventDuration
+}
+
+// Occurrence is one concrete period an event takes up
+type Occurrence struct {
+	UID string
+	// RecurrenceID is the start of the occurrence as scheduled by its recurrence rule, or zero
+	// for events that do not recur
+	RecurrenceID time.Time
+	Summary      string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+}
+
+// Key identifies the occurrence across repeated reads of the same calendar
+func (o *Occurrence) Key() string {
+	if o.RecurrenceID.IsZero() {
+		return o.UID
+	}
+	if o.AllDay {
+		return o.UID + "/" + o.RecurrenceID.Format(dateLayout)
+	}
+	return o.UID + "/" + o.RecurrenceID.UTC().Format(utcDateTimeLayout)
+}
+
+const (
+	dateLayout        = "20060102"
+	dateTimeLayout    = "20060102T150405"
+	utcDateTimeLayout = "20060102T150405Z"
+)
\ No newline at end of file
@@ -0,0 +1,233 @@
+package ical
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseFixture(t *testing.T, name string) *Calendar {
+	t.Helper()
+
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	cal, err := Parse(f)
+	if err != nil {
+		t.Fatalf("Parse(%s): %v", name, err)
+	}
+	return cal
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseAllDayEvents(t *testing.T) {
+	cal := parseFixture(t, "all_day.ics")
+
+	if cal.Name != "Sea View Loft" {
+		t.Errorf("Name = %q", cal.Name)
+	}
+	if len(cal.Events) != 3 {
+		t.Fatalf("got %d events, want 3", len(cal.Events))
+	}
+
+	reserved := cal.Events[0]
+	if !reserved.AllDay || !reserved.Start.Equal(date(2026, 11, 2)) || !reserved.End.Equal(date(2026, 11, 5)) {
+		t.Errorf("reserved = %v..%v all day %v", reserved.Start, reserved.End, reserved.AllDay)
+	}
+	if !strings.Contains(reserved.Description, "details/HMABCDEF12\nPhone") {
+		t.Errorf("folded description = %q", reserved.Description)
+	}
+
+	// An all-day event without DTEND lasts one day
+	single := cal.Events[2]
+	if !single.End.Equal(date(2026, 11, 21)) {
+		t.Errorf("single day end = %v", single.End)
+	}
+	if single.Summary != "Owner stay, one night" {
+		t.Errorf("summary = %q", single.Summary)
+	}
+}
+
+func TestExpandRecurringEvents(t *testing.T) {
+	cal := parseFixture(t, "recurring.ics")
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	occurrences, err := cal.Expand(date(2026, 11, 1), date(2027, 3, 1))
+	if err == nil || !strings.Contains(err.Error(), "unsupported@example.com") {
+		t.Errorf("err = %v, want the unsupported rule reported", err)
+	}
+
+	type want struct {
+		key        string
+		start, end time.Time
+	}
+	wants := []want{
+		{"first-monday@example.com/20261102", date(2026, 11, 2), date(2026, 11, 4)},
+		{"weekly-maintenance@example.com/20261103T020000Z", time.Date(2026, 11, 3, 9, 0, 0, 0, hcm), time.Date(2026, 11, 3, 12, 0, 0, 0, hcm)},
+		{"weekly-maintenance@example.com/20261110T020000Z", time.Date(2026, 11, 11, 14, 0, 0, 0, hcm), time.Date(2026, 11, 11, 17, 0, 0, 0, hcm)},
+		{"weekly-maintenance@example.com/20261117T020000Z", time.Date(2026, 11, 17, 9, 0, 0, 0, hcm), time.Date(2026, 11, 17, 12, 0, 0, 0, hcm)},
+		{"weekly-maintenance@example.com/20261119T020000Z", time.Date(2026, 11, 19, 9, 0, 0, 0, hcm), time.Date(2026, 11, 19, 12, 0, 0, 0, hcm)},
+		{"first-monday@example.com/20261207", date(2026, 12, 7), date(2026, 12, 9)},
+		{"new-year@example.com/20261231", date(2026, 12, 31), date(2027, 1, 2)},
+		{"first-monday@example.com/20270104", date(2027, 1, 4), date(2027, 1, 6)},
+		{"first-monday@example.com/20270201", date(2027, 2, 1), date(2027, 2, 3)},
+	}
+
+	if len(occurrences) != len(wants) {
+		for _, o := range occurrences {
+			t.Logf("%s %v..%v", o.Key(), o.Start, o.End)
+		}
+		t.Fatalf("got %d occurrences, want %d", len(occurrences), len(wants))
+	}
+	for i, w := range wants {
+		o := occurrences[i]
+		if o.Key() != w.key || !o.Start.Equal(w.start) || !o.End.Equal(w.end) {
+			t.Errorf("occurrence %d = %s %v..%v, want %s %v..%v", i, o.Key(), o.Start, o.End, w.key, w.start, w.end)
+		}
+	}
+}
+
+func TestExpandWindow(t *testing.T) {
+	cal := parseFixture(t, "all_day.ics")
+
+	// Only events overlapping the window are returned, including one that started before it
+	occurrences, err := cal.Expand(date(2026, 11, 4), date(2026, 11, 11))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occurrences) != 2 {
+		t.Fatalf("got %d occurrences, want 2", len(occurrences))
+	}
+	if occurrences[0].Key() != "1418fb94e984-0fa2a8a2ab6d5b0a9e3d4c5f0c4fa3b6@airbnb.com" || !occurrences[0].RecurrenceID.IsZero() {
+		t.Errorf("first occurrence = %+v", occurrences[0])
+	}
+}
+
+func TestExpandLongRunningRule(t *testing.T) {
+	// Far more than maxOccurrences days have passed since this open-ended rule started
+	cal := &Calendar{Events: []*Event{
+		{UID: "daily", Start: date(2020, 1, 1), End: date(2020, 1, 2), AllDay: true, RRule: "FREQ=DAILY"},
+		{UID: "weekly", Start: time.Date(2010, 1, 4, 9, 0, 0, 0, time.UTC), End: time.Date(2010, 1, 4, 17, 0, 0, 0, time.UTC), RRule: "FREQ=WEEKLY;BYDAY=MO,TH"},
+	}}
+
+	occurrences, err := cal.Expand(date(2026, 11, 1), date(2026, 11, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var daily, weekly int
+	for _, o := range occurrences {
+		switch o.UID {
+		case "daily":
+			daily++
+		case "weekly":
+			weekly++
+		}
+	}
+	// Oct 31 is left out: it ends as the window starts
+	if daily != 7 {
+		t.Errorf("got %d daily occurrences, want 7", daily)
+	}
+	// Monday the 2nd and Thursday the 5th
+	if weekly != 2 {
+		t.Errorf("got %d weekly occurrences, want 2", weekly)
+	}
+}
+
+func TestRuleMonthEnds(t *testing.T) {
+	r, err := parseRule("FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=4", date(2027, 1, 31), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := r.starts(date(2027, 1, 31), date(2027, 1, 31), date(2028, 1, 1))
+	wants := []time.Time{date(2027, 2, 28), date(2027, 3, 31), date(2027, 4, 30)}
+	if len(got) != len(wants) {
+		t.Fatalf("starts = %v, want %v", got, wants)
+	}
+	for i := range wants {
+		if !got[i].Equal(wants[i]) {
+			t.Errorf("start %d = %v, want %v", i, got[i], wants[i])
+		}
+	}
+
+	// A monthly rule on the 31st skips months without one
+	r, err = parseRule("FREQ=MONTHLY;INTERVAL=1", date(2027, 1, 31), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = r.starts(date(2027, 1, 31), date(2027, 1, 31), date(2027, 6, 1))
+	if len(got) != 2 || !got[0].Equal(date(2027, 3, 31)) || !got[1].Equal(date(2027, 5, 31)) {
+		t.Errorf("starts = %v", got)
+	}
+}
+
+func TestParseRejectsNonCalendar(t *testing.T) {
+	if _, err := Parse(strings.NewReader("<html>Not found</html>")); err == nil {
+		t.Error("Parse of HTML succeeded")
+	}
+	if _, err := Parse(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n")); err == nil {
+		t.Error("Parse of a truncated calendar succeeded")
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	stamp := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	cal := &Calendar{
+		Name: "Room 101; sea view",
+		Events: []*Event{
+			{
+				UID:     "stay@hotel",
+				Summary: "Reserved",
+				Start:   date(2026, 11, 2),
+				End:     date(2026, 11, 5),
+				AllDay:  true,
+				Stamp:   stamp,
+				Status:  StatusConfirmed,
+			},
+			{
+				UID:         "day-use@hotel",
+				Summary:     "Day use",
+				Description: strings.Repeat("Phòng nghỉ trong ngày, ", 6),
+				Start:       time.Date(2026, 11, 6, 9, 0, 0, 0, time.UTC),
+				End:         time.Date(2026, 11, 6, 13, 0, 0, 0, time.UTC),
+				Stamp:       stamp,
+			},
+		},
+	}
+
+	data := Marshal(cal)
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line longer than %d octets: %q", maxLineOctets, line)
+		}
+	}
+	if !bytes.Contains(data, []byte("DTSTART;VALUE=DATE:20261102\r\n")) {
+		t.Errorf("all-day start not written as a date:\n%s", data)
+	}
+
+	parsed, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if parsed.Name != cal.Name || len(parsed.Events) != 2 {
+		t.Fatalf("parsed = %+v", parsed)
+	}
+	for i, event := range parsed.Events {
+		want := cal.Events[i]
+		if event.UID != want.UID || event.Description != want.Description || event.AllDay != want.AllDay ||
+			!event.Start.Equal(want.Start) || !event.End.Equal(want.End) || !event.Stamp.Equal(stamp) {
+			t.Errorf("event %d = %+v, want %+v", i, event, want)
+		}
+	}
+}
\ No newline at end of file
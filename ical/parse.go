@@ -0,0 +1,379 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLineLength bounds a single unfolded content line, so a malformed feed cannot exhaust memory
+const maxLineLength = 1 << 20
+
+// property is one content line: NAME;PARAM=value:VALUE
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse reads a calendar. Events without a UID or start are skipped, as are components other
+// than VEVENT. Times with a TZID are read in that time zone when it is a known IANA zone, and in
+// UTC otherwise; floating times are read in UTC.
+func Parse(r io.Reader) (*Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &Calendar{}
+	var stack []string
+	var event *Event
+	var eventErr error
+	sawCalendar := false
+
+	for n, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			stack = append(stack, component)
+			if component == "VCALENDAR" {
+				sawCalendar = true
+			}
+			if component == "VEVENT" && len(stack) == 2 {
+				event = &Event{}
+				eventErr = nil
+			}
+			continue
+		case "END":
+			component := strings.ToUpper(prop.value)
+			if len(stack) == 0 || stack[len(stack)-1] != component {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", n+1, prop.value)
+			}
+			stack = stack[:len(stack)-1]
+			if component == "VEVENT" && event != nil && len(stack) == 1 {
+				if eventErr == nil && event.UID != "" && !event.Start.IsZero() {
+					cal.Events = append(cal.Events, event)
+				}
+				event = nil
+			}
+			continue
+		}
+
+		switch {
+		case len(stack) == 1 && stack[0] == "VCALENDAR":
+			if prop.name == "X-WR-CALNAME" {
+				cal.Name = unescapeText(prop.value)
+			}
+		case len(stack) == 2 && stack[1] == "VEVENT" && event != nil:
+			if err := event.setProperty(prop); err != nil && eventErr == nil {
+				eventErr = err
+			}
+		}
+	}
+
+	if !sawCalendar {
+		return nil, errors.New("not an iCalendar file")
+	}
+	if len(stack) != 0 {
+		return nil, errors.New("unterminated " + stack[len(stack)-1])
+	}
+
+	for _, event := range cal.Events {
+		event.fillEnd()
+	}
+	return cal, nil
+}
+
+// setProperty reads one property of a VEVENT
+func (e *Event) setProperty(prop property) error {
+	var err error
+	switch prop.name {
+	case "UID":
+		e.UID = prop.value
+	case "SUMMARY":
+		e.Summary = unescapeText(prop.value)
+	case "DESCRIPTION":
+		e.Description = unescapeText(prop.value)
+	case "STATUS":
+		e.Status = strings.ToUpper(prop.value)
+	case "TRANSP":
+		e.Transparent = strings.EqualFold(prop.value, "TRANSPARENT")
+	case "DTSTART":
+		e.Start, e.AllDay, err = parseTime(prop)
+	case "DTEND":
+		e.End, _, err = parseTime(prop)
+	case "DURATION":
+		var d time.Duration
+		var days int
+		days, d, err = parseDuration(prop.value)
+		if err == nil {
+			e.duration = &eventDuration{days: days, clock: d}
+		}
+	case "DTSTAMP":
+		e.Stamp, _, err = parseTime(prop)
+	case "RRULE":
+		e.RRule = prop.value
+	case "RDATE":
+		var dates []time.Time
+		dates, err = parseTimeList(prop)
+		e.RDates = append(e.RDates, dates...)
+	case "EXDATE":
+		var dates []time.Time
+		dates, err = parseTimeList(prop)
+		e.ExDates = append(e.ExDates, dates...)
+	case "RECURRENCE-ID":
+		e.RecurrenceID, _, err = parseTime(prop)
+	}
+	return err
+}
+
+// fillEnd works out the end of an event without DTEND: from its DURATION, or else one day after
+// an all-day start and at the start of a timed one
+func (e *Event) fillEnd() {
+	if !e.End.IsZero() {
+		return
+	}
+	switch {
+	case e.duration != nil:
+		e.End = e.duration.after(e.Start)
+	case e.AllDay:
+		e.End = e.Start.AddDate(0, 0, 1)
+	default:
+		e.End = e.Start
+	}
+}
+
+// eventDuration keeps the days of a DURATION apart from its clock time, because a day is not
+// always 24 hours in a time zone with daylight saving
+type eventDuration struct {
+	days  int
+	clock time.Duration
+}
+
+func (d *eventDuration) after(t time.Time) time.Time {
+	return t.AddDate(0, 0, d.days).Add(d.clock)
+}
+
+// unfold splits a calendar into content lines, joining lines continued with leading whitespace
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseProperty splits a content line into its name, parameters and value. Parameter values
+// may be quoted to contain ':', ';' or ','.
+func parseProperty(line string) (property, error) {
+	prop := property{params: make(map[string]string)}
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return prop, errors.New("invalid content line")
+	}
+	prop.name = strings.ToUpper(line[:i])
+
+	for line[i] == ';' {
+		rest := line[i+1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return prop, errors.New("invalid parameter in " + prop.name)
+		}
+		name := strings.ToUpper(rest[:eq])
+
+		j := eq + 1
+		var value strings.Builder
+		inQuotes := false
+		for ; j < len(rest); j++ {
+			c := rest[j]
+			if c == '"' {
+				inQuotes = !inQuotes
+				continue
+			}
+			if !inQuotes && (c == ';' || c == ':') {
+				break
+			}
+			value.WriteByte(c)
+		}
+		if j >= len(rest) {
+			return prop, errors.New("missing value in " + prop.name)
+		}
+
+		prop.params[name] = value.String()
+		i += 1 + j
+	}
+
+	prop.value = line[i+1:]
+	return prop, nil
+}
+
+// parseTime reads a DATE or DATE-TIME value and reports whether it was a date
+func parseTime(prop property) (time.Time, bool, error) {
+	return parseTimeValue(prop.value, prop.params)
+}
+
+// parseTimeList reads the comma-separated values of RDATE or EXDATE. Of a PERIOD value only the
+// start is kept, which is all a recurrence needs of it.
+func parseTimeList(prop property) ([]time.Time, error) {
+	var times []time.Time
+	for _, value := range strings.Split(prop.value, ",") {
+		start, _, _ := strings.Cut(value, "/")
+		t, _, err := parseTimeValue(start, prop.params)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+func parseTimeValue(value string, params map[string]string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.EqualFold(params["VALUE"], "DATE") || (len(value) == len(dateLayout) && !strings.Contains(value, "T")) {
+		t, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcDateTimeLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid time %q", value)
+		}
+		return t, false, nil
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value, timeZone(params["TZID"]))
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid time %q", value)
+	}
+	return t, false, nil
+}
+
+// timeZone looks up a TZID, which some producers prefix with a slash
+func timeZone(tzid string) *time.Location {
+	tzid = strings.TrimPrefix(strings.Trim(tzid, `"`), "/")
+	if tzid == "" {
+		return time.UTC
+	}
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// parseDuration reads a DURATION such as P1D, PT2H30M or P2W into whole days and clock time
+func parseDuration(value string) (int, time.Duration, error) {
+	invalid := fmt.Errorf("invalid duration %q", value)
+
+	sign := 1
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return 0, 0, invalid
+	}
+	value = value[1:]
+
+	var days int
+	var clock time.Duration
+	inTime := false
+	number := ""
+	for _, c := range value {
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+			continue
+		case c == 'T':
+			if inTime || number != "" {
+				return 0, 0, invalid
+			}
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, 0, invalid
+		}
+		number = ""
+
+		switch {
+		case c == 'W' && !inTime:
+			days += 7 * n
+		case c == 'D' && !inTime:
+			days += n
+		case c == 'H' && inTime:
+			clock += time.Duration(n) * time.Hour
+		case c == 'M' && inTime:
+			clock += time.Duration(n) * time.Minute
+		case c == 'S' && inTime:
+			clock += time.Duration(n) * time.Second
+		default:
+			return 0, 0, invalid
+		}
+	}
+	if number != "" {
+		return 0, 0, invalid
+	}
+
+	return sign * days, time.Duration(sign) * clock, nil
+}
+
+// unescapeText decodes the backslash escapes of a TEXT value
+func unescapeText(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
\ No newline at end of file
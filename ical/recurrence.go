@@ -0,0 +1,491 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxOccurrences bounds how many occurrences within the window one recurring event expands to
+	maxOccurrences = 1000
+	// maxPeriods bounds how many frequency periods are searched for occurrences, so a rule whose
+	// filters never match ends rather than searching forever
+	maxPeriods = 50000
+)
+
+// Expand returns the occurrences of the calendar's events that overlap [from, to), ordered by
+// start. Recurring events are expanded, with their exceptions and overridden occurrences
+// applied. Cancelled and transparent events take up no time and are left out. Events whose
+// recurrence rule cannot be read are left out too, and reported in the error alongside the
+// occurrences of the other events.
+func (c *Calendar) Expand(from, to time.Time) ([]*Occurrence, error) {
+	overrides := make(map[string]map[string]*Event)
+	for _, event := range c.Events {
+		if event.RecurrenceID.IsZero() {
+			continue
+		}
+		if overrides[event.UID] == nil {
+			overrides[event.UID] = make(map[string]*Event)
+		}
+		overrides[event.UID][instantKey(event.RecurrenceID, event.AllDay)] = event
+	}
+
+	var occurrences []*Occurrence
+	var errs []error
+	for _, event := range c.Events {
+		if !event.RecurrenceID.IsZero() {
+			if event.blocksTime() && event.overlaps(event.Start, event.End, from, to) {
+				occurrences = append(occurrences, event.occurrence(event.RecurrenceID, event.Start, event.End))
+			}
+			continue
+		}
+		if !event.blocksTime() {
+			continue
+		}
+
+		starts, err := event.starts(from, to)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("event %s: %w", event.UID, err))
+			continue
+		}
+
+		recurring := len(starts) > 1 || event.RRule != "" || len(event.RDates) > 0
+		for _, start := range starts {
+			if _, ok := overrides[event.UID][instantKey(start, event.AllDay)]; ok {
+				continue
+			}
+			end := event.endFrom(start)
+			if !event.overlaps(start, end, from, to) {
+				continue
+			}
+
+			var recurrenceID time.Time
+			if recurring {
+				recurrenceID = start
+			}
+			occurrences = append(occurrences, event.occurrence(recurrenceID, start, end))
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+	return occurrences, errors.Join(errs...)
+}
+
+func (e *Event) blocksTime() bool {
+	return e.Status != StatusCancelled && !e.Transparent
+}
+
+func (e *Event) overlaps(start, end, from, to time.Time) bool {
+	return end.After(start) && start.Before(to) && end.After(from)
+}
+
+func (e *Event) occurrence(recurrenceID, start, end time.Time) *Occurrence {
+	return &Occurrence{
+		UID:          e.UID,
+		RecurrenceID: recurrenceID,
+		Summary:      e.Summary,
+		Start:        start,
+		End:          end,
+		AllDay:       e.AllDay,
+	}
+}
+
+// endFrom gives an occurrence starting at start the length of the event, counted in days for
+// all-day events so that every occurrence spans the same dates
+func (e *Event) endFrom(start time.Time) time.Time {
+	if e.AllDay {
+		days := int(e.End.Sub(e.Start).Round(24*time.Hour) / (24 * time.Hour))
+		return start.AddDate(0, 0, days)
+	}
+	return start.Add(e.End.Sub(e.Start))
+}
+
+// starts lists the starts of the event's occurrences before until: DTSTART, the starts its
+// RRULE generates and its RDATEs, without its EXDATEs. Occurrences of the rule that end before
+// from are left out.
+func (e *Event) starts(from, until time.Time) ([]time.Time, error) {
+	starts := []time.Time{e.Start}
+	if e.RRule != "" {
+		r, err := parseRule(e.RRule, e.Start, e.AllDay)
+		if err != nil {
+			return nil, err
+		}
+		// An hour of slack covers all-day occurrences lengthened by a daylight saving change
+		after := from.Add(-e.End.Sub(e.Start) - time.Hour)
+		starts = append(starts, r.starts(e.Start, after, until)...)
+	}
+	starts = append(starts, e.RDates...)
+
+	excluded := make(map[string]bool, len(e.ExDates))
+	for _, exdate := range e.ExDates {
+		excluded[instantKey(exdate, e.AllDay)] = true
+	}
+
+	seen := make(map[string]bool, len(starts))
+	var result []time.Time
+	for _, start := range starts {
+		key := instantKey(start, e.AllDay)
+		if excluded[key] || seen[key] || !start.Before(until) {
+			continue
+		}
+		seen[key] = true
+		result = append(result, start)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Before(result[j])
+	})
+	return result, nil
+}
+
+// instantKey compares starts by date for all-day events and by instant otherwise
+func instantKey(t time.Time, allDay bool) string {
+	if allDay {
+		return t.Format(dateLayout)
+	}
+	return t.UTC().Format(utcDateTimeLayout)
+}
+
+// weekdayNum is a BYDAY entry such as MO, 2TU or -1FR. N is zero for every such weekday.
+type weekdayNum struct {
+	n   int
+	day time.Weekday
+}
+
+// rule is a parsed RRULE. Only the parts booking calendars use are supported: FREQ of DAILY,
+// WEEKLY, MONTHLY or YEARLY, with INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH and WKST.
+type rule struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	byDay      []weekdayNum
+	byMonthDay []int
+	byMonth    []time.Month
+	weekStart  time.Weekday
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func parseRule(value string, dtstart time.Time, allDay bool) (*rule, error) {
+	r := &rule{interval: 1, weekStart: time.Monday}
+
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		name, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		name = strings.ToUpper(name)
+		val = strings.ToUpper(val)
+
+		var err error
+		switch name {
+		case "FREQ":
+			switch val {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				r.freq = val
+			default:
+				return nil, fmt.Errorf("unsupported recurrence frequency %s", val)
+			}
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(val)
+			if err == nil && r.interval < 1 {
+				err = errors.New("interval must be positive")
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(val)
+			if err == nil && r.count < 1 {
+				err = errors.New("count must be positive")
+			}
+		case "UNTIL":
+			r.until, err = parseUntil(val, dtstart, allDay)
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				if len(day) < 2 {
+					return nil, fmt.Errorf("invalid BYDAY %q", day)
+				}
+				weekday, ok := weekdays[day[len(day)-2:]]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %q", day)
+				}
+				entry := weekdayNum{day: weekday}
+				if ordinal := day[:len(day)-2]; ordinal != "" {
+					entry.n, err = strconv.Atoi(ordinal)
+					if err != nil || entry.n == 0 {
+						return nil, fmt.Errorf("invalid BYDAY %q", day)
+					}
+				}
+				r.byDay = append(r.byDay, entry)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q", day)
+				}
+				r.byMonthDay = append(r.byMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(month)
+				if err != nil || n < 1 || n > 12 {
+					return nil, fmt.Errorf("invalid BYMONTH %q", month)
+				}
+				r.byMonth = append(r.byMonth, time.Month(n))
+			}
+		case "WKST":
+			weekday, ok := weekdays[val]
+			if !ok {
+				return nil, fmt.Errorf("invalid WKST %q", val)
+			}
+			r.weekStart = weekday
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	if r.freq == "" {
+		return nil, errors.New("recurrence rule has no FREQ")
+	}
+	return r, nil
+}
+
+// parseUntil reads UNTIL, which is inclusive. A date UNTIL on a timed event covers that whole
+// date, and a floating one is read in the time zone of DTSTART.
+func parseUntil(value string, dtstart time.Time, allDay bool) (time.Time, error) {
+	if len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, dtstart.Location())
+		if err != nil {
+			return time.Time{}, err
+		}
+		if allDay {
+			return t, nil
+		}
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(utcDateTimeLayout, value)
+	}
+	return time.ParseInLocation(dateTimeLayout, value, dtstart.Location())
+}
+
+// starts lists the starts the rule generates after both dtstart and after, and before horizon.
+// DTSTART itself counts as the first occurrence towards COUNT, and starts up to after still
+// count, so only they are generated for rules with a COUNT; other rules skip the periods that
+// end before after.
+func (r *rule) starts(dtstart, after, horizon time.Time) []time.Time {
+	var starts []time.Time
+	count := 1
+
+	first := 0
+	if r.count == 0 {
+		first = r.periodsBefore(dtstart, after)
+	}
+
+	for period := first; period < first+maxPeriods; period++ {
+		candidates := r.periodCandidates(dtstart, period)
+		for _, candidate := range candidates {
+			if !candidate.After(dtstart) {
+				continue
+			}
+			if !r.until.IsZero() && candidate.After(r.until) {
+				return starts
+			}
+			if r.count > 0 && count >= r.count {
+				return starts
+			}
+			if !candidate.Before(horizon) || len(starts) >= maxOccurrences {
+				return starts
+			}
+			count++
+			if candidate.After(after) {
+				starts = append(starts, candidate)
+			}
+		}
+	}
+	return starts
+}
+
+// periodsBefore returns how many periods from the one containing dtstart have no candidate
+// after t. It errs low, taking each period as long as its frequency's longest.
+func (r *rule) periodsBefore(dtstart, t time.Time) int {
+	var longest time.Duration
+	switch r.freq {
+	case "DAILY":
+		longest = 25 * time.Hour
+	case "WEEKLY":
+		longest = 7*24*time.Hour + time.Hour
+	case "MONTHLY":
+		longest = 32 * 24 * time.Hour
+	case "YEARLY":
+		longest = 367 * 24 * time.Hour
+	default:
+		return 0
+	}
+
+	// The candidates of a period all start before the end of the next one
+	periods := (int(t.Sub(dtstart)/longest) - 1) / r.interval
+	if periods < 0 {
+		return 0
+	}
+	return periods
+}
+
+// periodCandidates lists, in order, the starts the rule allows within the period-th period of
+// its frequency, counted from the period containing dtstart
+func (r *rule) periodCandidates(dtstart time.Time, period int) []time.Time {
+	step := period * r.interval
+	hour, minute, second := dtstart.Clock()
+	loc := dtstart.Location()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, loc)
+	}
+
+	var candidates []time.Time
+	switch r.freq {
+	case "DAILY":
+		day := dtstart.AddDate(0, 0, step)
+		if r.matchesDay(day) {
+			candidates = append(candidates, day)
+		}
+	case "WEEKLY":
+		base := dtstart.AddDate(0, 0, 7*step)
+		if len(r.byDay) == 0 {
+			candidates = append(candidates, base)
+			break
+		}
+		offset := (int(base.Weekday()) - int(r.weekStart) + 7) % 7
+		weekStart := base.AddDate(0, 0, -offset)
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i)
+			if r.matchesWeekday(day) && r.matchesMonth(day.Month()) {
+				candidates = append(candidates, day)
+			}
+		}
+	case "MONTHLY":
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, 0, 0, 0, 0, loc)
+		if r.matchesMonth(first.Month()) {
+			candidates = r.monthCandidates(first.Year(), first.Month(), dtstart.Day(), at)
+		}
+	case "YEARLY":
+		year := dtstart.Year() + step
+		months := r.byMonth
+		if len(months) == 0 {
+			months = []time.Month{dtstart.Month()}
+		}
+		for _, month := range months {
+			candidates = append(candidates, r.monthCandidates(year, month, dtstart.Day(), at)...)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Before(candidates[j])
+	})
+	return candidates
+}
+
+// monthCandidates lists the days of a month the rule's BYDAY and BYMONTHDAY select, or the day
+// of the month of DTSTART when neither is given. Days the month does not have are skipped.
+func (r *rule) monthCandidates(year int, month time.Month, dtstartDay int, at func(int, time.Month, int) time.Time) []time.Time {
+	daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	if len(r.byDay) == 0 && len(r.byMonthDay) == 0 {
+		if dtstartDay > daysInMonth {
+			return nil
+		}
+		return []time.Time{at(year, month, dtstartDay)}
+	}
+
+	var candidates []time.Time
+	for day := 1; day <= daysInMonth; day++ {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if len(r.byMonthDay) > 0 && !r.matchesMonthDay(day, daysInMonth) {
+			continue
+		}
+		if len(r.byDay) > 0 && !r.matchesWeekdayInMonth(date, daysInMonth) {
+			continue
+		}
+		candidates = append(candidates, at(year, month, day))
+	}
+	return candidates
+}
+
+// matchesDay applies BYDAY, BYMONTHDAY and BYMONTH as filters on a daily rule
+func (r *rule) matchesDay(day time.Time) bool {
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return r.matchesWeekday(day) &&
+		r.matchesMonth(day.Month()) &&
+		(len(r.byMonthDay) == 0 || r.matchesMonthDay(day.Day(), daysInMonth))
+}
+
+func (r *rule) matchesWeekday(day time.Time) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	for _, entry := range r.byDay {
+		if entry.day == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekdayInMonth applies BYDAY within a month, where 2TU is the second Tuesday and -1FR
+// the last Friday
+func (r *rule) matchesWeekdayInMonth(date time.Time, daysInMonth int) bool {
+	for _, entry := range r.byDay {
+		if entry.day != date.Weekday() {
+			continue
+		}
+		switch {
+		case entry.n == 0:
+			return true
+		case entry.n > 0 && (date.Day()-1)/7+1 == entry.n:
+			return true
+		case entry.n < 0 && (daysInMonth-date.Day())/7+1 == -entry.n:
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rule) matchesMonthDay(day, daysInMonth int) bool {
+	for _, n := range r.byMonthDay {
+		if n == day || (n < 0 && daysInMonth+n+1 == day) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rule) matchesMonth(month time.Month) bool {
+	if len(r.byMonth) == 0 {
+		return true
+	}
+	for _, m := range r.byMonth {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
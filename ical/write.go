@@ -0,0 +1,94 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProdID identifies this application as the producer of calendars it writes
+const ProdID = "-//devsirose//Hotel Reservation//EN"
+
+// maxLineOctets is the longest content line RFC 5545 allows before it must be folded
+const maxLineOctets = 75
+
+// Marshal writes a calendar. All-day events are written as dates and timed events in UTC. An
+// event without a stamp is stamped with the current time.
+func Marshal(cal *Calendar) []byte {
+	var b bytes.Buffer
+	w := &lineWriter{buf: &b}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + ProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if cal.Name != "" {
+		w.line("X-WR-CALNAME:" + escapeText(cal.Name))
+	}
+
+	for _, event := range cal.Events {
+		stamp := event.Stamp
+		if stamp.IsZero() {
+			stamp = time.Now()
+		}
+
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + event.UID)
+		w.line("DTSTAMP:" + stamp.UTC().Format(utcDateTimeLayout))
+		if event.AllDay {
+			w.line("DTSTART;VALUE=DATE:" + event.Start.Format(dateLayout))
+			w.line("DTEND;VALUE=DATE:" + event.End.Format(dateLayout))
+		} else {
+			w.line("DTSTART:" + event.Start.UTC().Format(utcDateTimeLayout))
+			w.line("DTEND:" + event.End.UTC().Format(utcDateTimeLayout))
+		}
+		if event.Summary != "" {
+			w.line("SUMMARY:" + escapeText(event.Summary))
+		}
+		if event.Description != "" {
+			w.line("DESCRIPTION:" + escapeText(event.Description))
+		}
+		if event.Status != "" {
+			w.line("STATUS:" + event.Status)
+		}
+		if event.Transparent {
+			w.line("TRANSP:TRANSPARENT")
+		}
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+	return b.Bytes()
+}
+
+// lineWriter writes CRLF-terminated content lines, folding long lines without splitting a
+// UTF-8 character
+type lineWriter struct {
+	buf *bytes.Buffer
+}
+
+func (w *lineWriter) line(s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts towards their length
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(s)
+	w.buf.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeText encodes a TEXT value
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
\ No newline at end of file
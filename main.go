@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"os"
+	_ "time/tzdata"
 
 	"github.com/devsirose/hotel-reservation/api"
 	"github.com/devsirose/hotel-reservation/config"
@@ -41,7 +43,11 @@ func main() {
 	store := db.NewStore(dbSQL)
 
 	// Create API server
-	server := api.NewServer(store, dbSQL)
+	server := api.NewServer(cfg, store, dbSQL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server.StartJobs(ctx)
 
 	serverAddr := cfg.ServerHost + ":" + cfg.HTTPServerPort
 	logger.Log.Info("Starting Hotel Reservation System",
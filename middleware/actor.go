@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/devsirose/hotel-reservation/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ActorMiddleware takes the username of the caller from header. The API does not authenticate
+// callers itself: header must be set by an authenticating proxy in front of it, which also has to
+// drop the header from the requests it passes on unauthenticated. Without a header name no request
+// is authenticated.
+func ActorMiddleware(header string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		if username := strings.TrimSpace(c.GetHeader(header)); username != "" {
+			c.Request = c.Request.WithContext(service.WithActor(c.Request.Context(), username))
+		}
+		c.Next()
+	}
+}
\ No newline at end of file
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"database/sql"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// Allotment statuses
+const (
+	AllotmentActive    = "ACTIVE"
+	AllotmentReleased  = "RELEASED"
+	AllotmentCancelled = "CANCELLED"
+)
+
+// Allotment holds Quantity rooms of a type away from public sale for an event or corporate
+// group. Attendees book the held rooms at Rate per night by quoting BlockCode. Rooms not picked
+// up by ReleaseDate return to general inventory.
+type Allotment struct {
+	AllotmentID uuid.UUID      `json:"allotment_id"`
+	BlockCode   string         `json:"block_code"`
+	Name        sql.NullString `json:"name"`
+	HotelID     uuid.NullUUID  `json:"hotel_id"`
+	TypeID      sql.NullString `json:"type_id"`
+	StartDate   sql.NullTime   `json:"start_date"`
+	EndDate     sql.NullTime   `json:"end_date"`
+	Quantity    sql.NullInt32  `json:"quantity"`
+	Rate        sql.NullInt32  `json:"rate"`
+	ReleaseDate sql.NullTime   `json:"release_date"`
+	Status      sql.NullString `json:"status"`
+	ReleasedAt  sql.NullTime   `json:"released_at"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	CreatedBy   uuid.NullUUID  `json:"created_by"`
+	UpdateAt    sql.NullTime   `json:"update_at"`
+	UpdateBy    uuid.NullUUID  `json:"update_by"`
+}
+
+// ToDBModel converts model.Allotment to db.Allotment
+func (a *Allotment) ToDBModel() *db.Allotment {
+	return &db.Allotment{
+		AllotmentID: a.AllotmentID,
+		BlockCode:   a.BlockCode,
+		Name:        a.Name,
+		HotelID:     a.HotelID,
+		TypeID:      a.TypeID,
+		StartDate:   a.StartDate,
+		EndDate:     a.EndDate,
+		Quantity:    a.Quantity,
+		Rate:        a.Rate,
+		ReleaseDate: a.ReleaseDate,
+		Status:      a.Status,
+		ReleasedAt:  a.ReleasedAt,
+		CreatedAt:   a.CreatedAt,
+		CreatedBy:   a.CreatedBy,
+		UpdateAt:    a.UpdateAt,
+		UpdateBy:    a.UpdateBy,
+	}
+}
+
+// FromDBAllotment converts db.Allotment to model.Allotment
+func FromDBAllotment(dbAllotment *db.Allotment) *Allotment {
+	return &Allotment{
+		AllotmentID: dbAllotment.AllotmentID,
+		BlockCode:   dbAllotment.BlockCode,
+		Name:        dbAllotment.Name,
+		HotelID:     dbAllotment.HotelID,
+		TypeID:      dbAllotment.TypeID,
+		StartDate:   dbAllotment.StartDate,
+		EndDate:     dbAllotment.EndDate,
+		Quantity:    dbAllotment.Quantity,
+		Rate:        dbAllotment.Rate,
+		ReleaseDate: dbAllotment.ReleaseDate,
+		Status:      dbAllotment.Status,
+		ReleasedAt:  dbAllotment.ReleasedAt,
+		CreatedAt:   dbAllotment.CreatedAt,
+		CreatedBy:   dbAllotment.CreatedBy,
+		UpdateAt:    dbAllotment.UpdateAt,
+		UpdateBy:    dbAllotment.UpdateBy,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoomTypeAvailability describes how many rooms of a type can still be sold for a date range.
+// Booked is the highest number of overlapping reservations on any single night of the range,
+// with rooms blocked for maintenance and rooms held by allotments counted as booked. OverbookAllowance is the number of rooms
+// the type may be oversold by on every night of the range, and Available includes it.
+type RoomTypeAvailability struct {
+	TypeID            string `json:"type_id"`
+	TotalRooms        int32  `json:"total_rooms"`
+	Booked            int32  `json:"booked"`
+	OverbookAllowance int32  `json:"overbook_allowance"`
+	Available         int32  `json:"available"`
+	MinPrice          int32  `json:"min_price"`
+}
+
+// AvailabilityChange tells availability stream clients that a reservation changed the inventory
+// of a hotel between StartDate and EndDate. Clients re-query availability for the dates they show.
+type AvailabilityChange struct {
+	EventID       uuid.UUID     `json:"event_id"`
+	Type          string        `json:"type"`
+	HotelID       uuid.UUID     `json:"hotel_id"`
+	ReservationID uuid.UUID     `json:"reservation_id"`
+	RoomID        uuid.NullUUID `json:"room_id"`
+	TypeID        string        `json:"type_id,omitempty"`
+	StartDate     time.Time     `json:"start_date"`
+	EndDate       time.Time     `json:"end_date"`
+	OccurredAt    time.Time     `json:"occurred_at"`
+}
\ No newline at end of file
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"database/sql"
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+type BookingGroup struct {
+	GroupID      uuid.UUID      `json:"group_id"`
+	UserID       sql.NullString `json:"user_id"`
+	ContactName  sql.NullString `json:"contact_name"`
+	ContactEmail sql.NullString `json:"contact_email"`
+	ContactPhone sql.NullString `json:"contact_phone"`
+	StartDate    sql.NullTime   `json:"start_date"`
+	EndDate      sql.NullTime   `json:"end_date"`
+	Status       sql.NullString `json:"status"`
+	TotalPrice   sql.NullInt32  `json:"total_price"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	CreatedBy    uuid.NullUUID  `json:"created_by"`
+	UpdateAt     sql.NullTime   `json:"update_at"`
+	UpdateBy     uuid.NullUUID  `json:"update_by"`
+	Reservations []*Reservation `json:"reservations"`
+}
+
+// ToDBModel converts model.BookingGroup to db.BookingGroup
+func (g *BookingGroup) ToDBModel() *db.BookingGroup {
+	return &db.BookingGroup{
+		GroupID:      g.GroupID,
+		UserID:       g.UserID,
+		ContactName:  g.ContactName,
+		ContactEmail: g.ContactEmail,
+		ContactPhone: g.ContactPhone,
+		StartDate:    g.StartDate,
+		EndDate:      g.EndDate,
+		Status:       g.Status,
+		TotalPrice:   g.TotalPrice,
+		CreatedAt:    g.CreatedAt,
+		CreatedBy:    g.CreatedBy,
+		UpdateAt:     g.UpdateAt,
+		UpdateBy:     g.UpdateBy,
+	}
+}
+
+// FromDBBookingGroup converts db.BookingGroup to model.BookingGroup
+func FromDBBookingGroup(dbGroup *db.BookingGroup) *BookingGroup {
+	return &BookingGroup{
+		GroupID:      dbGroup.GroupID,
+		UserID:       dbGroup.UserID,
+		ContactName:  dbGroup.ContactName,
+		ContactEmail: dbGroup.ContactEmail,
+		ContactPhone: dbGroup.ContactPhone,
+		StartDate:    dbGroup.StartDate,
+		EndDate:      dbGroup.EndDate,
+		Status:       dbGroup.Status,
+		TotalPrice:   dbGroup.TotalPrice,
+		CreatedAt:    dbGroup.CreatedAt,
+		CreatedBy:    dbGroup.CreatedBy,
+		UpdateAt:     dbGroup.UpdateAt,
+		UpdateBy:     dbGroup.UpdateBy,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Calendar night statuses
+const (
+	CalendarFree    = "FREE"
+	CalendarPending = "PENDING"
+	CalendarBooked  = "BOOKED"
+	CalendarBlocked = "BLOCKED"
+)
+
+// CalendarCell is one room-night of the hotel calendar as read from the database.
+// ReservationID, Status and BlockID are null when nothing occupies the room that night.
+type CalendarCell struct {
+	RoomID        uuid.UUID
+	RoomName      sql.NullString
+	TypeID        sql.NullString
+	Night         time.Time
+	ReservationID uuid.NullUUID
+	Status        sql.NullString
+	BlockID       uuid.NullUUID
+}
+
+type CalendarNight struct {
+	Date          string     `json:"date"`
+	Status        string     `json:"status"`
+	ReservationID *uuid.UUID `json:"reservation_id,omitempty"`
+	BlockID       *uuid.UUID `json:"block_id,omitempty"`
+}
+
+type RoomCalendar struct {
+	RoomID   uuid.UUID        `json:"room_id"`
+	RoomName string           `json:"room_name"`
+	TypeID   string           `json:"type_id"`
+	Nights   []*CalendarNight `json:"nights"`
+}
+
+// HotelCalendar is a per-room, per-night status matrix. Every room has one entry per date in Dates.
+type HotelCalendar struct {
+	HotelID uuid.UUID       `json:"hotel_id"`
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Dates   []string        `json:"dates"`
+	Rooms   []*RoomCalendar `json:"rooms"`
+}
\ No newline at end of file
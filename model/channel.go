@@ -0,0 +1,124 @@
+package model
+
+import (
+	"database/sql"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// ReservationSourceDirect is the source of reservations made with the hotel itself. Reservations
+// imported from a distribution channel have the channel's code as their source.
+const ReservationSourceDirect = "DIRECT"
+
+// ChannelConnection lists a hotel on a distribution channel, under the code the channel knows the
+// hotel by. Availability, rates and restrictions are pushed to the channel as inventory changes
+// and in full on a schedule, and the channel's reservations are pulled on a schedule. PushError
+// and PullError hold the reason the latest push or pull failed, and are cleared by the next
+// successful one. PushDueAt is when a change still waiting to be pushed was made.
+type ChannelConnection struct {
+	ConnectionID uuid.UUID      `json:"connection_id"`
+	HotelID      uuid.UUID      `json:"hotel_id" binding:"required"`
+	Channel      string         `json:"channel" binding:"required"`
+	HotelCode    string         `json:"hotel_code" binding:"required"`
+	IsActive     sql.NullBool   `json:"is_active"`
+	LastPushedAt sql.NullTime   `json:"last_pushed_at"`
+	PushError    sql.NullString `json:"push_error"`
+	LastPulledAt sql.NullTime   `json:"last_pulled_at"`
+	PullError    sql.NullString `json:"pull_error"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	CreatedBy    uuid.NullUUID  `json:"created_by"`
+	UpdateAt     sql.NullTime   `json:"update_at"`
+	UpdateBy     uuid.NullUUID  `json:"update_by"`
+	PushDueAt    sql.NullTime   `json:"push_due_at"`
+}
+
+// ToDBModel converts model.ChannelConnection to db.ChannelConnection
+func (c *ChannelConnection) ToDBModel() *db.ChannelConnection {
+	return &db.ChannelConnection{
+		ConnectionID: c.ConnectionID,
+		HotelID:      c.HotelID,
+		Channel:      c.Channel,
+		HotelCode:    c.HotelCode,
+		IsActive:     c.IsActive.Bool,
+		LastPushedAt: c.LastPushedAt,
+		PushError:    c.PushError,
+		LastPulledAt: c.LastPulledAt,
+		PullError:    c.PullError,
+		CreatedAt:    c.CreatedAt,
+		CreatedBy:    c.CreatedBy,
+		UpdateAt:     c.UpdateAt,
+		UpdateBy:     c.UpdateBy,
+		PushDueAt:    c.PushDueAt,
+	}
+}
+
+// FromDBChannelConnection converts db.ChannelConnection to model.ChannelConnection
+func FromDBChannelConnection(dbConnection *db.ChannelConnection) *ChannelConnection {
+	return &ChannelConnection{
+		ConnectionID: dbConnection.ConnectionID,
+		HotelID:      dbConnection.HotelID,
+		Channel:      dbConnection.Channel,
+		HotelCode:    dbConnection.HotelCode,
+		IsActive:     sql.NullBool{Bool: dbConnection.IsActive, Valid: true},
+		LastPushedAt: dbConnection.LastPushedAt,
+		PushError:    dbConnection.PushError,
+		LastPulledAt: dbConnection.LastPulledAt,
+		PullError:    dbConnection.PullError,
+		CreatedAt:    dbConnection.CreatedAt,
+		CreatedBy:    dbConnection.CreatedBy,
+		UpdateAt:     dbConnection.UpdateAt,
+		UpdateBy:     dbConnection.UpdateBy,
+		PushDueAt:    dbConnection.PushDueAt,
+	}
+}
+
+// ChannelMapping maps a room type, or a single room, of the connection's hotel to the room code
+// the channel sells it under. A type is sold as the rooms of the type still free; a single room
+// is sold on its own.
+type ChannelMapping struct {
+	MappingID    uuid.UUID      `json:"mapping_id"`
+	ConnectionID uuid.UUID      `json:"connection_id"`
+	TypeID       sql.NullString `json:"type_id"`
+	RoomID       uuid.NullUUID  `json:"room_id"`
+	RoomCode     string         `json:"room_code" binding:"required"`
+	RateCode     sql.NullString `json:"rate_code"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	CreatedBy    uuid.NullUUID  `json:"created_by"`
+}
+
+// FromDBChannelMapping converts db.ChannelMapping to model.ChannelMapping
+func FromDBChannelMapping(dbMapping *db.ChannelMapping) *ChannelMapping {
+	return &ChannelMapping{
+		MappingID:    dbMapping.MappingID,
+		ConnectionID: dbMapping.ConnectionID,
+		TypeID:       dbMapping.TypeID,
+		RoomID:       dbMapping.RoomID,
+		RoomCode:     dbMapping.RoomCode,
+		RateCode:     dbMapping.RateCode,
+		CreatedAt:    dbMapping.CreatedAt,
+		CreatedBy:    dbMapping.CreatedBy,
+	}
+}
+
+// ChannelPushResult reports a push of availability, rates and restrictions to a channel
+type ChannelPushResult struct {
+	ConnectionID uuid.UUID `json:"connection_id"`
+	From         string    `json:"from"`
+	To           string    `json:"to"`
+	Items        int       `json:"items"`
+}
+
+// ChannelPullResult reports what one pull of a channel's reservations changed. Skipped explains
+// the reservations that were not imported. Conflicts lists imported reservations the hotel had
+// no room left for, which staff need to relocate or resolve with the channel.
+type ChannelPullResult struct {
+	ConnectionID uuid.UUID   `json:"connection_id"`
+	Received     int         `json:"received"`
+	Created      int         `json:"created"`
+	Modified     int         `json:"modified"`
+	Cancelled    int         `json:"cancelled"`
+	Unchanged    int         `json:"unchanged"`
+	Skipped      []string    `json:"skipped,omitempty"`
+	Conflicts    []uuid.UUID `json:"conflicts,omitempty"`
+}
\ No newline at end of file
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stay types of a reservation
+const (
+	StayTypeOvernight = "OVERNIGHT"
+	StayTypeDayUse    = "DAY_USE"
+)
+
+// RoomOccupancy is an interval during which a room is held by a reservation or a block.
+// StayType is BLOCK for room blocks.
+type RoomOccupancy struct {
+	RoomID    uuid.UUID
+	StartDate time.Time
+	EndDate   time.Time
+	StayType  string
+}
+
+type DayUseSlot struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Available bool      `json:"available"`
+}
+
+type RoomDayUseSlots struct {
+	RoomID   uuid.UUID     `json:"room_id"`
+	RoomName string        `json:"room_name"`
+	TypeID   string        `json:"type_id"`
+	Price    *int32        `json:"price,omitempty"`
+	Slots    []*DayUseSlot `json:"slots"`
+}
+
+// HotelDayUseSlots lists the day-use slots of one local day for every day-use room of a hotel
+type HotelDayUseSlots struct {
+	HotelID       uuid.UUID          `json:"hotel_id"`
+	Date          string             `json:"date"`
+	SlotMinutes   int32              `json:"slot_minutes"`
+	BufferMinutes int32              `json:"buffer_minutes"`
+	Rooms         []*RoomDayUseSlots `json:"rooms"`
+}
\ No newline at end of file
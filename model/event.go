@@ -0,0 +1,80 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// Event types
+const (
+	EventWaitlistOfferCreated = "waitlist.offer_created"
+
+	EventReservationCreated   = "reservation.created"
+	EventReservationConfirmed = "reservation.confirmed"
+	EventReservationModified  = "reservation.modified"
+	EventReservationCancelled = "reservation.cancelled"
+	EventReservationExpired   = "reservation.expired"
+	EventReservationCompleted = "reservation.completed"
+
+	EventRoomCreated = "room.created"
+	EventRoomUpdated = "room.updated"
+	EventRoomDeleted = "room.deleted"
+
+	EventHotelCreated = "hotel.created"
+	EventHotelUpdated = "hotel.updated"
+	EventHotelDeleted = "hotel.deleted"
+)
+
+// EventTypes lists every event type, for validating subscriptions to events
+var EventTypes = []string{
+	EventWaitlistOfferCreated,
+	EventReservationCreated,
+	EventReservationConfirmed,
+	EventReservationModified,
+	EventReservationCancelled,
+	EventReservationExpired,
+	EventReservationCompleted,
+	EventRoomCreated,
+	EventRoomUpdated,
+	EventRoomDeleted,
+	EventHotelCreated,
+	EventHotelUpdated,
+	EventHotelDeleted,
+}
+
+// Kinds of record an event can be about
+const (
+	AggregateReservation   = "reservation"
+	AggregateRoom          = "room"
+	AggregateHotel         = "hotel"
+	AggregateWaitlistEntry = "waitlist_entry"
+)
+
+// Event is a domain event handed to an event publisher. AggregateID identifies the record the
+// event is about, such as a waitlist entry, and HotelID the hotel it belongs to, if any.
+type Event struct {
+	EventID       uuid.UUID     `json:"event_id"`
+	Type          string        `json:"type"`
+	AggregateType string        `json:"aggregate_type"`
+	AggregateID   uuid.UUID     `json:"aggregate_id"`
+	HotelID       uuid.NullUUID `json:"hotel_id"`
+	Payload       interface{}   `json:"payload"`
+	OccurredAt    time.Time     `json:"occurred_at"`
+}
+
+// FromDBOutboxEvent converts db.OutboxEvent to model.Event. The payload is kept as the JSON it
+// was stored as.
+func FromDBOutboxEvent(dbEvent *db.OutboxEvent) *Event {
+	return &Event{
+		EventID:       dbEvent.EventID,
+		Type:          dbEvent.EventType,
+		AggregateType: dbEvent.AggregateType,
+		AggregateID:   dbEvent.AggregateID,
+		HotelID:       dbEvent.HotelID,
+		Payload:       json.RawMessage(dbEvent.Payload),
+		OccurredAt:    dbEvent.OccurredAt,
+	}
+}
\ No newline at end of file
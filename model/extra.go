@@ -0,0 +1,126 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// How the price of an extra is multiplied over a stay
+const (
+	ExtraPerStay        = "PER_STAY"
+	ExtraPerNight       = "PER_NIGHT"
+	ExtraPerPerson      = "PER_PERSON"
+	ExtraPerPersonNight = "PER_PERSON_NIGHT"
+)
+
+// Extra is an add-on a hotel sells with its rooms, such as breakfast, parking or a crib
+type Extra struct {
+	ExtraID     uuid.UUID      `json:"extra_id"`
+	HotelID     uuid.NullUUID  `json:"hotel_id"`
+	Code        sql.NullString `json:"code"`
+	Name        sql.NullString `json:"name"`
+	Description sql.NullString `json:"description"`
+	Pricing     sql.NullString `json:"pricing"`
+	Price       sql.NullInt32  `json:"price"`
+	IsActive    sql.NullBool   `json:"is_active"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	CreatedBy   uuid.NullUUID  `json:"created_by"`
+	UpdateAt    sql.NullTime   `json:"update_at"`
+	UpdateBy    uuid.NullUUID  `json:"update_by"`
+}
+
+// ToDBModel converts model.Extra to db.Extra
+func (e *Extra) ToDBModel() *db.Extra {
+	return &db.Extra{
+		ExtraID:     e.ExtraID,
+		HotelID:     e.HotelID,
+		Code:        e.Code,
+		Name:        e.Name,
+		Description: e.Description,
+		Pricing:     e.Pricing,
+		Price:       e.Price,
+		IsActive:    e.IsActive,
+		CreatedAt:   e.CreatedAt,
+		CreatedBy:   e.CreatedBy,
+		UpdateAt:    e.UpdateAt,
+		UpdateBy:    e.UpdateBy,
+	}
+}
+
+// FromDBExtra converts db.Extra to model.Extra
+func FromDBExtra(dbExtra *db.Extra) *Extra {
+	return &Extra{
+		ExtraID:     dbExtra.ExtraID,
+		HotelID:     dbExtra.HotelID,
+		Code:        dbExtra.Code,
+		Name:        dbExtra.Name,
+		Description: dbExtra.Description,
+		Pricing:     dbExtra.Pricing,
+		Price:       dbExtra.Price,
+		IsActive:    dbExtra.IsActive,
+		CreatedAt:   dbExtra.CreatedAt,
+		CreatedBy:   dbExtra.CreatedBy,
+		UpdateAt:    dbExtra.UpdateAt,
+		UpdateBy:    dbExtra.UpdateBy,
+	}
+}
+
+// ReservationExtra is an extra attached to a reservation. The pricing and unit price are copied
+// from the catalog when the extra is added, so later catalog changes do not reprice the stay.
+// Code and Name are read from the catalog for display.
+type ReservationExtra struct {
+	ReservationExtraID uuid.UUID      `json:"reservation_extra_id"`
+	ReservationID      uuid.UUID      `json:"reservation_id"`
+	ExtraID            uuid.UUID      `json:"extra_id" binding:"required"`
+	Code               sql.NullString `json:"code"`
+	Name               sql.NullString `json:"name"`
+	Pricing            sql.NullString `json:"pricing"`
+	Quantity           int32          `json:"quantity"`
+	UnitPrice          sql.NullInt32  `json:"unit_price"`
+	Amount             sql.NullInt32  `json:"amount"`
+	CreatedAt          sql.NullTime   `json:"created_at"`
+}
+
+// ReservationExtrasChange replaces the extras of a reservation, and its special requests when given
+type ReservationExtrasChange struct {
+	Extras          []*ReservationExtra `json:"extras"`
+	SpecialRequests sql.NullString      `json:"special_requests"`
+}
+
+// FolioLine is one charge on a reservation's folio. Discounts are negative.
+type FolioLine struct {
+	Description string `json:"description"`
+	Quantity    int32  `json:"quantity"`
+	UnitPrice   int32  `json:"unit_price"`
+	Amount      int32  `json:"amount"`
+}
+
+// Folio itemizes what a reservation is charged: the room, extra guests, discounts and extras
+type Folio struct {
+	ReservationID   uuid.UUID      `json:"reservation_id"`
+	Status          sql.NullString `json:"status"`
+	SpecialRequests sql.NullString `json:"special_requests"`
+	Lines           []*FolioLine   `json:"lines"`
+	Total           int32          `json:"total"`
+}
+
+// ExtraDailyCount is how many units of an extra a hotel has to provide on one date
+type ExtraDailyCount struct {
+	Night    time.Time `json:"-"`
+	Date     string    `json:"date"`
+	ExtraID  uuid.UUID `json:"extra_id"`
+	Code     string    `json:"code"`
+	Name     string    `json:"name"`
+	Quantity int32     `json:"quantity"`
+}
+
+// ExtrasReport lists the daily counts of a hotel's extras in [From, To)
+type ExtrasReport struct {
+	HotelID uuid.UUID          `json:"hotel_id"`
+	From    string             `json:"from"`
+	To      string             `json:"to"`
+	Counts  []*ExtraDailyCount `json:"counts"`
+}
\ No newline at end of file
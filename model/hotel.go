@@ -7,31 +7,52 @@ import (
 )
 
 type Hotel struct {
-	HotelID       uuid.UUID       `json:"hotel_id"`
-	DestinationID uuid.NullUUID   `json:"destination_id"`
-	TypeID        sql.NullString  `json:"type_id"`
-	TotalRoom     sql.NullInt32   `json:"total_room"`
-	Rating        sql.NullFloat64 `json:"rating"`
+	HotelID             uuid.UUID       `json:"hotel_id"`
+	DestinationID       uuid.NullUUID   `json:"destination_id"`
+	TypeID              sql.NullString  `json:"type_id"`
+	TotalRoom           sql.NullInt32   `json:"total_room"`
+	Rating              sql.NullFloat64 `json:"rating"`
+	TimeZone            sql.NullString  `json:"time_zone"`
+	CheckInTime         sql.NullString  `json:"check_in_time"`
+	CheckOutTime        sql.NullString  `json:"check_out_time"`
+	DayUseStartTime     sql.NullString  `json:"day_use_start_time"`
+	DayUseEndTime       sql.NullString  `json:"day_use_end_time"`
+	DayUseSlotMinutes   sql.NullInt32   `json:"day_use_slot_minutes"`
+	DayUseBufferMinutes sql.NullInt32   `json:"day_use_buffer_minutes"`
 }
 
 // ToDBModel converts model.Hotel to db.Hotel
 func (h *Hotel) ToDBModel() *db.Hotel {
 	return &db.Hotel{
-		HotelID:       h.HotelID,
-		DestinationID: h.DestinationID,
-		TypeID:        h.TypeID,
-		TotalRoom:     h.TotalRoom,
-		Rating:        h.Rating,
+		HotelID:             h.HotelID,
+		DestinationID:       h.DestinationID,
+		TypeID:              h.TypeID,
+		TotalRoom:           h.TotalRoom,
+		Rating:              h.Rating,
+		TimeZone:            h.TimeZone,
+		CheckInTime:         h.CheckInTime,
+		CheckOutTime:        h.CheckOutTime,
+		DayUseStartTime:     h.DayUseStartTime,
+		DayUseEndTime:       h.DayUseEndTime,
+		DayUseSlotMinutes:   h.DayUseSlotMinutes,
+		DayUseBufferMinutes: h.DayUseBufferMinutes,
 	}
 }
 
 // FromDBModel converts db.Hotel to model.Hotel
 func FromDBHotel(dbHotel *db.Hotel) *Hotel {
 	return &Hotel{
-		HotelID:       dbHotel.HotelID,
-		DestinationID: dbHotel.DestinationID,
-		TypeID:        dbHotel.TypeID,
-		TotalRoom:     dbHotel.TotalRoom,
-		Rating:        dbHotel.Rating,
+		HotelID:             dbHotel.HotelID,
+		DestinationID:       dbHotel.DestinationID,
+		TypeID:              dbHotel.TypeID,
+		TotalRoom:           dbHotel.TotalRoom,
+		Rating:              dbHotel.Rating,
+		TimeZone:            dbHotel.TimeZone,
+		CheckInTime:         dbHotel.CheckInTime,
+		CheckOutTime:        dbHotel.CheckOutTime,
+		DayUseStartTime:     dbHotel.DayUseStartTime,
+		DayUseEndTime:       dbHotel.DayUseEndTime,
+		DayUseSlotMinutes:   dbHotel.DayUseSlotMinutes,
+		DayUseBufferMinutes: dbHotel.DayUseBufferMinutes,
 	}
 }
\ No newline at end of file
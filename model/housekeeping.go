@@ -0,0 +1,37 @@
+package model
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// Housekeeping statuses of a room
+const (
+	HousekeepingClean      = "CLEAN"
+	HousekeepingDirty      = "DIRTY"
+	HousekeepingInspected  = "INSPECTED"
+	HousekeepingOutOfOrder = "OUT_OF_ORDER"
+)
+
+type HousekeepingRoom struct {
+	RoomID          uuid.UUID      `json:"room_id"`
+	RoomName        sql.NullString `json:"room_name"`
+	Floor           sql.NullInt32  `json:"floor"`
+	TypeID          sql.NullString `json:"type_id"`
+	Status          string         `json:"status"`
+	UpdatedAt       sql.NullTime   `json:"updated_at"`
+	OccupiedTonight bool           `json:"occupied_tonight"`
+	ArrivalID       *uuid.UUID     `json:"arrival_reservation_id,omitempty"`
+	DepartureID     *uuid.UUID     `json:"departure_reservation_id,omitempty"`
+}
+
+// HousekeepingBoard groups the rooms of a hotel by housekeeping status for one day,
+// together with the reservations arriving and departing that day.
+type HousekeepingBoard struct {
+	HotelID       uuid.UUID                      `json:"hotel_id"`
+	Date          string                         `json:"date"`
+	RoomsByStatus map[string][]*HousekeepingRoom `json:"rooms_by_status"`
+	Arrivals      []*Reservation                 `json:"arrivals"`
+	Departures    []*Reservation                 `json:"departures"`
+}
\ No newline at end of file
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"database/sql"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// RoomBlockExternal is the block type of blocks imported from an iCal feed
+const RoomBlockExternal = "EXTERNAL"
+
+// ICalFeed is an external calendar, such as a room's listing on another booking platform, whose
+// events block the room here. Feeds are fetched on a schedule; LastError holds the reason the
+// latest attempt failed, and is cleared by the next successful one.
+type ICalFeed struct {
+	FeedID        uuid.UUID      `json:"feed_id"`
+	RoomID        uuid.UUID      `json:"room_id"`
+	URL           string         `json:"url" binding:"required"`
+	Name          sql.NullString `json:"name"`
+	IsActive      sql.NullBool   `json:"is_active"`
+	LastAttemptAt sql.NullTime   `json:"last_attempt_at"`
+	LastSyncedAt  sql.NullTime   `json:"last_synced_at"`
+	LastError     sql.NullString `json:"last_error"`
+	CreatedAt     sql.NullTime   `json:"created_at"`
+	CreatedBy     uuid.NullUUID  `json:"created_by"`
+	UpdateAt      sql.NullTime   `json:"update_at"`
+	UpdateBy      uuid.NullUUID  `json:"update_by"`
+}
+
+// ToDBModel converts model.ICalFeed to db.IcalFeed
+func (f *ICalFeed) ToDBModel() *db.IcalFeed {
+	return &db.IcalFeed{
+		FeedID:        f.FeedID,
+		RoomID:        f.RoomID,
+		Url:           f.URL,
+		Name:          f.Name,
+		IsActive:      f.IsActive.Bool,
+		LastAttemptAt: f.LastAttemptAt,
+		LastSyncedAt:  f.LastSyncedAt,
+		LastError:     f.LastError,
+		CreatedAt:     f.CreatedAt,
+		CreatedBy:     f.CreatedBy,
+		UpdateAt:      f.UpdateAt,
+		UpdateBy:      f.UpdateBy,
+	}
+}
+
+// FromDBICalFeed converts db.IcalFeed to model.ICalFeed
+func FromDBICalFeed(dbFeed *db.IcalFeed) *ICalFeed {
+	return &ICalFeed{
+		FeedID:        dbFeed.FeedID,
+		RoomID:        dbFeed.RoomID,
+		URL:           dbFeed.Url,
+		Name:          dbFeed.Name,
+		IsActive:      sql.NullBool{Bool: dbFeed.IsActive, Valid: true},
+		LastAttemptAt: dbFeed.LastAttemptAt,
+		LastSyncedAt:  dbFeed.LastSyncedAt,
+		LastError:     dbFeed.LastError,
+		CreatedAt:     dbFeed.CreatedAt,
+		CreatedBy:     dbFeed.CreatedBy,
+		UpdateAt:      dbFeed.UpdateAt,
+		UpdateBy:      dbFeed.UpdateBy,
+	}
+}
+
+// ICalExport is the token that opens a room's calendar feed. The token is only returned when it
+// is issued; issuing a new one revokes the old.
+type ICalExport struct {
+	RoomID uuid.UUID `json:"room_id"`
+	Token  string    `json:"token"`
+	Path   string    `json:"path"`
+}
+
+// ICalSyncResult reports what one synchronization of a feed changed. Conflicts lists our
+// reservations that overlap blocks the feed added or moved, which staff need to relocate.
+type ICalSyncResult struct {
+	FeedID    uuid.UUID   `json:"feed_id"`
+	Events    int         `json:"events"`
+	Upserted  int         `json:"upserted"`
+	Removed   int64       `json:"removed"`
+	Skipped   []string    `json:"skipped,omitempty"`
+	Conflicts []uuid.UUID `json:"conflicts,omitempty"`
+}
\ No newline at end of file
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions a magic link can grant on a reservation
+const (
+	MagicLinkView   = "VIEW"
+	MagicLinkCancel = "CANCEL"
+	MagicLinkModify = "MODIFY"
+)
+
+// MagicLinkRequest asks for a link granting Action on a reservation
+type MagicLinkRequest struct {
+	Action string `json:"action" binding:"required"`
+}
+
+// MagicLink is a signed token that lets whoever holds it perform one action on one reservation
+// until it expires or the reservation changes status
+type MagicLink struct {
+	ReservationID uuid.UUID `json:"reservation_id"`
+	Action        string    `json:"action"`
+	Token         string    `json:"token"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
\ No newline at end of file
@@ -0,0 +1,17 @@
+package model
+
+// Notification kinds, one email of each kind at most is sent per reservation
+const (
+	NotificationBookingReceived     = "BOOKING_RECEIVED"
+	NotificationBookingConfirmation = "BOOKING_CONFIRMATION"
+	NotificationArrivalReminder     = "ARRIVAL_REMINDER"
+	NotificationCancellation        = "CANCELLATION"
+	NotificationReviewInvitation    = "REVIEW_INVITATION"
+)
+
+// Notification statuses
+const (
+	NotificationPending = "PENDING"
+	NotificationSent    = "SENT"
+	NotificationFailed  = "FAILED"
+)
\ No newline at end of file
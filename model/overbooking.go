@@ -0,0 +1,78 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// OverbookingAllowance lets a room type be sold beyond its physical rooms on the nights within
+// [StartDate, EndDate), by OverbookPercent of its rooms rounded down. When allowances overlap,
+// the highest percentage applies.
+type OverbookingAllowance struct {
+	AllowanceID     uuid.UUID      `json:"allowance_id"`
+	HotelID         uuid.NullUUID  `json:"hotel_id"`
+	TypeID          sql.NullString `json:"type_id"`
+	StartDate       sql.NullTime   `json:"start_date"`
+	EndDate         sql.NullTime   `json:"end_date"`
+	OverbookPercent sql.NullInt32  `json:"overbook_percent"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+	CreatedBy       uuid.NullUUID  `json:"created_by"`
+	UpdateAt        sql.NullTime   `json:"update_at"`
+	UpdateBy        uuid.NullUUID  `json:"update_by"`
+}
+
+// ToDBModel converts model.OverbookingAllowance to db.OverbookingAllowance
+func (a *OverbookingAllowance) ToDBModel() *db.OverbookingAllowance {
+	return &db.OverbookingAllowance{
+		AllowanceID:     a.AllowanceID,
+		HotelID:         a.HotelID,
+		TypeID:          a.TypeID,
+		StartDate:       a.StartDate,
+		EndDate:         a.EndDate,
+		OverbookPercent: a.OverbookPercent,
+		CreatedAt:       a.CreatedAt,
+		CreatedBy:       a.CreatedBy,
+		UpdateAt:        a.UpdateAt,
+		UpdateBy:        a.UpdateBy,
+	}
+}
+
+// FromDBOverbookingAllowance converts db.OverbookingAllowance to model.OverbookingAllowance
+func FromDBOverbookingAllowance(dbAllowance *db.OverbookingAllowance) *OverbookingAllowance {
+	return &OverbookingAllowance{
+		AllowanceID:     dbAllowance.AllowanceID,
+		HotelID:         dbAllowance.HotelID,
+		TypeID:          dbAllowance.TypeID,
+		StartDate:       dbAllowance.StartDate,
+		EndDate:         dbAllowance.EndDate,
+		OverbookPercent: dbAllowance.OverbookPercent,
+		CreatedAt:       dbAllowance.CreatedAt,
+		CreatedBy:       dbAllowance.CreatedBy,
+		UpdateAt:        dbAllowance.UpdateAt,
+		UpdateBy:        dbAllowance.UpdateBy,
+	}
+}
+
+// OverbookedNight is a night on which a room type has more confirmed bookings than rooms in
+// service, i.e. its physical rooms less those blocked for maintenance. Overbooked is the number
+// of guests that need relocating.
+type OverbookedNight struct {
+	Night             time.Time `json:"-"`
+	Date              string    `json:"date"`
+	TypeID            string    `json:"type_id"`
+	PhysicalRooms     int32     `json:"physical_rooms"`
+	BlockedRooms      int32     `json:"blocked_rooms"`
+	ConfirmedBookings int32     `json:"confirmed_bookings"`
+	Overbooked        int32     `json:"overbooked"`
+}
+
+// OverbookingReport lists a hotel's overbooked nights in [From, To)
+type OverbookingReport struct {
+	HotelID uuid.UUID          `json:"hotel_id"`
+	From    string             `json:"from"`
+	To      string             `json:"to"`
+	Nights  []*OverbookedNight `json:"nights"`
+}
\ No newline at end of file
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"database/sql"
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+type PromoCode struct {
+	Code                  string         `json:"code"`
+	Description           sql.NullString `json:"description"`
+	DiscountType          sql.NullString `json:"discount_type"`
+	DiscountValue         sql.NullInt32  `json:"discount_value"`
+	ValidFrom             sql.NullTime   `json:"valid_from"`
+	ValidTo               sql.NullTime   `json:"valid_to"`
+	StayFrom              sql.NullTime   `json:"stay_from"`
+	StayTo                sql.NullTime   `json:"stay_to"`
+	MinNights             sql.NullInt32  `json:"min_nights"`
+	HotelID               uuid.NullUUID  `json:"hotel_id"`
+	TypeID                sql.NullString `json:"type_id"`
+	MaxRedemptions        sql.NullInt32  `json:"max_redemptions"`
+	MaxRedemptionsPerUser sql.NullInt32  `json:"max_redemptions_per_user"`
+	RedemptionCount       sql.NullInt32  `json:"redemption_count"`
+	IsActive              sql.NullBool   `json:"is_active"`
+	CreatedAt             sql.NullTime   `json:"created_at"`
+	CreatedBy             uuid.NullUUID  `json:"created_by"`
+	UpdateAt              sql.NullTime   `json:"update_at"`
+	UpdateBy              uuid.NullUUID  `json:"update_by"`
+}
+
+// ToDBModel converts model.PromoCode to db.PromoCode
+func (p *PromoCode) ToDBModel() *db.PromoCode {
+	return &db.PromoCode{
+		Code:                  p.Code,
+		Description:           p.Description,
+		DiscountType:          p.DiscountType,
+		DiscountValue:         p.DiscountValue,
+		ValidFrom:             p.ValidFrom,
+		ValidTo:               p.ValidTo,
+		StayFrom:              p.StayFrom,
+		StayTo:                p.StayTo,
+		MinNights:             p.MinNights,
+		HotelID:               p.HotelID,
+		TypeID:                p.TypeID,
+		MaxRedemptions:        p.MaxRedemptions,
+		MaxRedemptionsPerUser: p.MaxRedemptionsPerUser,
+		RedemptionCount:       p.RedemptionCount,
+		IsActive:              p.IsActive,
+		CreatedAt:             p.CreatedAt,
+		CreatedBy:             p.CreatedBy,
+		UpdateAt:              p.UpdateAt,
+		UpdateBy:              p.UpdateBy,
+	}
+}
+
+// FromDBPromoCode converts db.PromoCode to model.PromoCode
+func FromDBPromoCode(dbPromoCode *db.PromoCode) *PromoCode {
+	return &PromoCode{
+		Code:                  dbPromoCode.Code,
+		Description:           dbPromoCode.Description,
+		DiscountType:          dbPromoCode.DiscountType,
+		DiscountValue:         dbPromoCode.DiscountValue,
+		ValidFrom:             dbPromoCode.ValidFrom,
+		ValidTo:               dbPromoCode.ValidTo,
+		StayFrom:              dbPromoCode.StayFrom,
+		StayTo:                dbPromoCode.StayTo,
+		MinNights:             dbPromoCode.MinNights,
+		HotelID:               dbPromoCode.HotelID,
+		TypeID:                dbPromoCode.TypeID,
+		MaxRedemptions:        dbPromoCode.MaxRedemptions,
+		MaxRedemptionsPerUser: dbPromoCode.MaxRedemptionsPerUser,
+		RedemptionCount:       dbPromoCode.RedemptionCount,
+		IsActive:              dbPromoCode.IsActive,
+		CreatedAt:             dbPromoCode.CreatedAt,
+		CreatedBy:             dbPromoCode.CreatedBy,
+		UpdateAt:              dbPromoCode.UpdateAt,
+		UpdateBy:              dbPromoCode.UpdateBy,
+	}
+}
\ No newline at end of file
@@ -7,46 +7,104 @@ import (
 )
 
 type Reservation struct {
-	ReservationID uuid.UUID      `json:"reservation_id"`
-	RoomID        uuid.NullUUID  `json:"room_id"`
-	UserID        sql.NullString `json:"user_id"`
-	StartDate     sql.NullTime   `json:"start_date"`
-	EndDate       sql.NullTime   `json:"end_date"`
-	Status        sql.NullString `json:"status"`
-	CreatedAt     sql.NullTime   `json:"created_at"`
-	CreatedBy     uuid.NullUUID  `json:"created_by"`
-	UpdateAt      sql.NullTime   `json:"update_at"`
-	UpdateBy      uuid.NullUUID  `json:"update_by"`
+	ReservationID     uuid.UUID      `json:"reservation_id"`
+	RoomID            uuid.NullUUID  `json:"room_id"`
+	UserID            sql.NullString `json:"user_id"`
+	StartDate         sql.NullTime   `json:"start_date"`
+	EndDate           sql.NullTime   `json:"end_date"`
+	Status            sql.NullString `json:"status"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	CreatedBy         uuid.NullUUID  `json:"created_by"`
+	UpdateAt          sql.NullTime   `json:"update_at"`
+	UpdateBy          uuid.NullUUID  `json:"update_by"`
+	TotalPrice        sql.NullInt32  `json:"total_price"`
+	PromoCode         sql.NullString `json:"promo_code"`
+	DiscountAmount    sql.NullInt32  `json:"discount_amount"`
+	HotelID           uuid.NullUUID  `json:"hotel_id"`
+	TypeID            sql.NullString `json:"type_id"`
+	GroupID           uuid.NullUUID  `json:"group_id"`
+	StayType          sql.NullString `json:"stay_type"`
+	HoldExpiresAt     sql.NullTime   `json:"hold_expires_at"`
+	BlockCode         sql.NullString `json:"block_code"`
+	Adults            sql.NullInt32  `json:"adults"`
+	Children          sql.NullInt32  `json:"children"`
+	ChildAges         []int32        `json:"child_ages"`
+	ExtraPersonCharge sql.NullInt32  `json:"extra_person_charge"`
+	ExtrasAmount      sql.NullInt32  `json:"extras_amount"`
+	SpecialRequests   sql.NullString `json:"special_requests"`
+	ConfirmationCode  sql.NullString `json:"confirmation_code"`
+	Source            sql.NullString `json:"source"`
+	ExternalID        sql.NullString `json:"external_id"`
+
+	Extras   []*ReservationExtra   `json:"extras,omitempty"`
+	Guests   []*ReservationGuest   `json:"guests,omitempty"`
+	Segments []*ReservationSegment `json:"segments,omitempty"`
 }
 
 // ToDBModel converts model.Reservation to db.Reservation
 func (r *Reservation) ToDBModel() *db.Reservation {
 	return &db.Reservation{
-		ReservationID: r.ReservationID,
-		RoomID:        r.RoomID,
-		UserID:        r.UserID,
-		StartDate:     r.StartDate,
-		EndDate:       r.EndDate,
-		Status:        r.Status,
-		CreatedAt:     r.CreatedAt,
-		CreatedBy:     r.CreatedBy,
-		UpdateAt:      r.UpdateAt,
-		UpdateBy:      r.UpdateBy,
+		ReservationID:     r.ReservationID,
+		RoomID:            r.RoomID,
+		UserID:            r.UserID,
+		StartDate:         r.StartDate,
+		EndDate:           r.EndDate,
+		Status:            r.Status,
+		CreatedAt:         r.CreatedAt,
+		CreatedBy:         r.CreatedBy,
+		UpdateAt:          r.UpdateAt,
+		UpdateBy:          r.UpdateBy,
+		TotalPrice:        r.TotalPrice,
+		PromoCode:         r.PromoCode,
+		DiscountAmount:    r.DiscountAmount,
+		HotelID:           r.HotelID,
+		TypeID:            r.TypeID,
+		GroupID:           r.GroupID,
+		StayType:          r.StayType,
+		HoldExpiresAt:     r.HoldExpiresAt,
+		BlockCode:         r.BlockCode,
+		Adults:            r.Adults,
+		Children:          r.Children,
+		ChildAges:         r.ChildAges,
+		ExtraPersonCharge: r.ExtraPersonCharge,
+		ExtrasAmount:      r.ExtrasAmount,
+		SpecialRequests:   r.SpecialRequests,
+		ConfirmationCode:  r.ConfirmationCode,
+		Source:            r.Source,
+		ExternalID:        r.ExternalID,
 	}
 }
 
 // FromDBReservation converts db.Reservation to model.Reservation
 func FromDBReservation(dbReservation *db.Reservation) *Reservation {
 	return &Reservation{
-		ReservationID: dbReservation.ReservationID,
-		RoomID:        dbReservation.RoomID,
-		UserID:        dbReservation.UserID,
-		StartDate:     dbReservation.StartDate,
-		EndDate:       dbReservation.EndDate,
-		Status:        dbReservation.Status,
-		CreatedAt:     dbReservation.CreatedAt,
-		CreatedBy:     dbReservation.CreatedBy,
-		UpdateAt:      dbReservation.UpdateAt,
-		UpdateBy:      dbReservation.UpdateBy,
+		ReservationID:     dbReservation.ReservationID,
+		RoomID:            dbReservation.RoomID,
+		UserID:            dbReservation.UserID,
+		StartDate:         dbReservation.StartDate,
+		EndDate:           dbReservation.EndDate,
+		Status:            dbReservation.Status,
+		CreatedAt:         dbReservation.CreatedAt,
+		CreatedBy:         dbReservation.CreatedBy,
+		UpdateAt:          dbReservation.UpdateAt,
+		UpdateBy:          dbReservation.UpdateBy,
+		TotalPrice:        dbReservation.TotalPrice,
+		PromoCode:         dbReservation.PromoCode,
+		DiscountAmount:    dbReservation.DiscountAmount,
+		HotelID:           dbReservation.HotelID,
+		TypeID:            dbReservation.TypeID,
+		GroupID:           dbReservation.GroupID,
+		StayType:          dbReservation.StayType,
+		HoldExpiresAt:     dbReservation.HoldExpiresAt,
+		BlockCode:         dbReservation.BlockCode,
+		Adults:            dbReservation.Adults,
+		Children:          dbReservation.Children,
+		ChildAges:         dbReservation.ChildAges,
+		ExtraPersonCharge: dbReservation.ExtraPersonCharge,
+		ExtrasAmount:      dbReservation.ExtrasAmount,
+		SpecialRequests:   dbReservation.SpecialRequests,
+		ConfirmationCode:  dbReservation.ConfirmationCode,
+		Source:            dbReservation.Source,
+		ExternalID:        dbReservation.ExternalID,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"database/sql"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// ReservationGuest is a named member of the party staying on a reservation. The primary guest
+// is the contact for the stay and carries an email address or phone number.
+type ReservationGuest struct {
+	GuestID       uuid.UUID      `json:"guest_id"`
+	ReservationID uuid.UUID      `json:"reservation_id"`
+	FirstName     sql.NullString `json:"first_name"`
+	LastName      sql.NullString `json:"last_name"`
+	Age           sql.NullInt32  `json:"age"`
+	IsChild       sql.NullBool   `json:"is_child"`
+	IsPrimary     sql.NullBool   `json:"is_primary"`
+	Email         sql.NullString `json:"email"`
+	Phone         sql.NullString `json:"phone"`
+	CreatedAt     sql.NullTime   `json:"created_at"`
+	Locale        sql.NullString `json:"locale"`
+}
+
+// FromDBReservationGuest converts db.ReservationGuest to model.ReservationGuest
+func FromDBReservationGuest(dbGuest *db.ReservationGuest) *ReservationGuest {
+	return &ReservationGuest{
+		GuestID:       dbGuest.GuestID,
+		ReservationID: dbGuest.ReservationID,
+		FirstName:     dbGuest.FirstName,
+		LastName:      dbGuest.LastName,
+		Age:           dbGuest.Age,
+		IsChild:       dbGuest.IsChild,
+		IsPrimary:     dbGuest.IsPrimary,
+		Email:         dbGuest.Email,
+		Phone:         dbGuest.Phone,
+		CreatedAt:     dbGuest.CreatedAt,
+		Locale:        dbGuest.Locale,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"database/sql"
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// ReservationChange is a request to modify an existing reservation.
+// Fields left unset keep their current value.
+type ReservationChange struct {
+	RoomID    uuid.NullUUID       `json:"room_id"`
+	UserID    sql.NullString      `json:"user_id"`
+	StartDate sql.NullTime        `json:"start_date"`
+	EndDate   sql.NullTime        `json:"end_date"`
+	Adults    sql.NullInt32       `json:"adults"`
+	Children  sql.NullInt32       `json:"children"`
+	ChildAges []int32             `json:"child_ages"`
+	Guests    []*ReservationGuest `json:"guests"`
+	Reason    sql.NullString      `json:"reason"`
+}
+
+// GuestReservationChange is a change a guest makes to a booking found by its confirmation code.
+// Guests without an account may only change the dates and the party.
+type GuestReservationChange struct {
+	StartDate sql.NullTime        `json:"start_date"`
+	EndDate   sql.NullTime        `json:"end_date"`
+	Adults    sql.NullInt32       `json:"adults"`
+	Children  sql.NullInt32       `json:"children"`
+	ChildAges []int32             `json:"child_ages"`
+	Guests    []*ReservationGuest `json:"guests"`
+	Reason    sql.NullString      `json:"reason"`
+}
+
+type ReservationModification struct {
+	ModificationID uuid.UUID      `json:"modification_id"`
+	ReservationID  uuid.NullUUID  `json:"reservation_id"`
+	ModifiedBy     sql.NullString `json:"modified_by"`
+	ModifiedByRole sql.NullString `json:"modified_by_role"`
+	Reason         sql.NullString `json:"reason"`
+	OldRoomID      uuid.NullUUID  `json:"old_room_id"`
+	NewRoomID      uuid.NullUUID  `json:"new_room_id"`
+	OldUserID      sql.NullString `json:"old_user_id"`
+	NewUserID      sql.NullString `json:"new_user_id"`
+	OldStartDate   sql.NullTime   `json:"old_start_date"`
+	NewStartDate   sql.NullTime   `json:"new_start_date"`
+	OldEndDate     sql.NullTime   `json:"old_end_date"`
+	NewEndDate     sql.NullTime   `json:"new_end_date"`
+	OldTotalPrice  sql.NullInt32  `json:"old_total_price"`
+	NewTotalPrice  sql.NullInt32  `json:"new_total_price"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+}
+
+// FromDBReservationModification converts db.ReservationModification to model.ReservationModification
+func FromDBReservationModification(dbModification *db.ReservationModification) *ReservationModification {
+	return &ReservationModification{
+		ModificationID: dbModification.ModificationID,
+		ReservationID:  dbModification.ReservationID,
+		ModifiedBy:     dbModification.ModifiedBy,
+		ModifiedByRole: dbModification.ModifiedByRole,
+		Reason:         dbModification.Reason,
+		OldRoomID:      dbModification.OldRoomID,
+		NewRoomID:      dbModification.NewRoomID,
+		OldUserID:      dbModification.OldUserID,
+		NewUserID:      dbModification.NewUserID,
+		OldStartDate:   dbModification.OldStartDate,
+		NewStartDate:   dbModification.NewStartDate,
+		OldEndDate:     dbModification.OldEndDate,
+		NewEndDate:     dbModification.NewEndDate,
+		OldTotalPrice:  dbModification.OldTotalPrice,
+		NewTotalPrice:  dbModification.NewTotalPrice,
+		CreatedAt:      dbModification.CreatedAt,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// ReservationSegment is the part of a stay spent in one room. A reservation with a room has one
+// segment covering the whole stay until the guest is moved, which splits it at the move.
+type ReservationSegment struct {
+	SegmentID     uuid.UUID     `json:"segment_id"`
+	ReservationID uuid.UUID     `json:"reservation_id"`
+	RoomID        uuid.UUID     `json:"room_id"`
+	StartDate     time.Time     `json:"start_date"`
+	EndDate       time.Time     `json:"end_date"`
+	CreatedAt     sql.NullTime  `json:"created_at"`
+	CreatedBy     uuid.NullUUID `json:"created_by"`
+}
+
+// RoomMove is a request to move a guest to another room from Date, the calendar date of the
+// first night in the new room. Date defaults to today, or to the arrival date for future stays.
+type RoomMove struct {
+	RoomID uuid.UUID      `json:"room_id" binding:"required"`
+	Date   sql.NullTime   `json:"date"`
+	Reason sql.NullString `json:"reason"`
+}
+
+// FromDBReservationSegment converts db.ReservationSegment to model.ReservationSegment
+func FromDBReservationSegment(dbSegment *db.ReservationSegment) *ReservationSegment {
+	return &ReservationSegment{
+		SegmentID:     dbSegment.SegmentID,
+		ReservationID: dbSegment.ReservationID,
+		RoomID:        dbSegment.RoomID,
+		StartDate:     dbSegment.StartDate,
+		EndDate:       dbSegment.EndDate,
+		CreatedAt:     dbSegment.CreatedAt,
+		CreatedBy:     dbSegment.CreatedBy,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"database/sql"
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+type RoomBlock struct {
+	BlockID   uuid.UUID      `json:"block_id"`
+	RoomID    uuid.NullUUID  `json:"room_id"`
+	BlockType sql.NullString `json:"block_type"`
+	Reason    sql.NullString `json:"reason"`
+	StartDate sql.NullTime   `json:"start_date"`
+	EndDate   sql.NullTime   `json:"end_date"`
+	CreatedAt sql.NullTime   `json:"created_at"`
+	CreatedBy uuid.NullUUID  `json:"created_by"`
+	UpdateAt  sql.NullTime   `json:"update_at"`
+	UpdateBy  uuid.NullUUID  `json:"update_by"`
+	// FeedID and ExternalUID are set on blocks imported from an iCal feed, which the feed's
+	// synchronization manages
+	FeedID      uuid.NullUUID  `json:"feed_id"`
+	ExternalUID sql.NullString `json:"external_uid"`
+}
+
+// ToDBModel converts model.RoomBlock to db.RoomBlock
+func (b *RoomBlock) ToDBModel() *db.RoomBlock {
+	return &db.RoomBlock{
+		BlockID:     b.BlockID,
+		RoomID:      b.RoomID,
+		BlockType:   b.BlockType,
+		Reason:      b.Reason,
+		StartDate:   b.StartDate,
+		EndDate:     b.EndDate,
+		CreatedAt:   b.CreatedAt,
+		CreatedBy:   b.CreatedBy,
+		UpdateAt:    b.UpdateAt,
+		UpdateBy:    b.UpdateBy,
+		FeedID:      b.FeedID,
+		ExternalUid: b.ExternalUID,
+	}
+}
+
+// FromDBRoomBlock converts db.RoomBlock to model.RoomBlock
+func FromDBRoomBlock(dbBlock *db.RoomBlock) *RoomBlock {
+	return &RoomBlock{
+		BlockID:     dbBlock.BlockID,
+		RoomID:      dbBlock.RoomID,
+		BlockType:   dbBlock.BlockType,
+		Reason:      dbBlock.Reason,
+		StartDate:   dbBlock.StartDate,
+		EndDate:     dbBlock.EndDate,
+		CreatedAt:   dbBlock.CreatedAt,
+		CreatedBy:   dbBlock.CreatedBy,
+		UpdateAt:    dbBlock.UpdateAt,
+		UpdateBy:    dbBlock.UpdateBy,
+		FeedID:      dbBlock.FeedID,
+		ExternalUID: dbBlock.ExternalUid,
+	}
+}
\ No newline at end of file
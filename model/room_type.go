@@ -0,0 +1,61 @@
+package model
+
+import (
+	"database/sql"
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+type RoomType struct {
+	TypeCode         string         `json:"type_code"`
+	Name             sql.NullString `json:"name"`
+	Description      sql.NullString `json:"description"`
+	BedConfiguration sql.NullString `json:"bed_configuration"`
+	SizeSqm          sql.NullInt32  `json:"size_sqm"`
+	DefaultCapacity  sql.NullInt32  `json:"default_capacity"`
+	BaseOccupancy    sql.NullInt32  `json:"base_occupancy"`
+	ExtraAdultPrice  sql.NullInt32  `json:"extra_adult_price"`
+	ExtraChildPrice  sql.NullInt32  `json:"extra_child_price"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	CreatedBy        uuid.NullUUID  `json:"created_by"`
+	UpdateAt         sql.NullTime   `json:"update_at"`
+	UpdateBy         uuid.NullUUID  `json:"update_by"`
+}
+
+// ToDBModel converts model.RoomType to db.Type
+func (t *RoomType) ToDBModel() *db.Type {
+	return &db.Type{
+		TypeCode:         t.TypeCode,
+		Name:             t.Name,
+		Description:      t.Description,
+		BedConfiguration: t.BedConfiguration,
+		SizeSqm:          t.SizeSqm,
+		DefaultCapacity:  t.DefaultCapacity,
+		BaseOccupancy:    t.BaseOccupancy,
+		ExtraAdultPrice:  t.ExtraAdultPrice,
+		ExtraChildPrice:  t.ExtraChildPrice,
+		CreatedAt:        t.CreatedAt,
+		CreatedBy:        t.CreatedBy,
+		UpdateAt:         t.UpdateAt,
+		UpdateBy:         t.UpdateBy,
+	}
+}
+
+// FromDBRoomType converts db.Type to model.RoomType
+func FromDBRoomType(dbType *db.Type) *RoomType {
+	return &RoomType{
+		TypeCode:         dbType.TypeCode,
+		Name:             dbType.Name,
+		Description:      dbType.Description,
+		BedConfiguration: dbType.BedConfiguration,
+		SizeSqm:          dbType.SizeSqm,
+		DefaultCapacity:  dbType.DefaultCapacity,
+		BaseOccupancy:    dbType.BaseOccupancy,
+		ExtraAdultPrice:  dbType.ExtraAdultPrice,
+		ExtraChildPrice:  dbType.ExtraChildPrice,
+		CreatedAt:        dbType.CreatedAt,
+		CreatedBy:        dbType.CreatedBy,
+		UpdateAt:         dbType.UpdateAt,
+		UpdateBy:         dbType.UpdateBy,
+	}
+}
\ No newline at end of file
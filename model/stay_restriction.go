@@ -0,0 +1,64 @@
+package model
+
+import (
+	"database/sql"
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// StayRestriction limits which stays can be booked at a hotel. Minimum/maximum nights and
+// closed to arrival apply to stays arriving within [StartDate, EndDate); closed to departure
+// applies to stays departing within it. A restriction without TypeID covers every room type.
+type StayRestriction struct {
+	RestrictionID     uuid.UUID      `json:"restriction_id"`
+	HotelID           uuid.NullUUID  `json:"hotel_id"`
+	TypeID            sql.NullString `json:"type_id"`
+	StartDate         sql.NullTime   `json:"start_date"`
+	EndDate           sql.NullTime   `json:"end_date"`
+	MinNights         sql.NullInt32  `json:"min_nights"`
+	MaxNights         sql.NullInt32  `json:"max_nights"`
+	ClosedToArrival   sql.NullBool   `json:"closed_to_arrival"`
+	ClosedToDeparture sql.NullBool   `json:"closed_to_departure"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	CreatedBy         uuid.NullUUID  `json:"created_by"`
+	UpdateAt          sql.NullTime   `json:"update_at"`
+	UpdateBy          uuid.NullUUID  `json:"update_by"`
+}
+
+// ToDBModel converts model.StayRestriction to db.StayRestriction
+func (r *StayRestriction) ToDBModel() *db.StayRestriction {
+	return &db.StayRestriction{
+		RestrictionID:     r.RestrictionID,
+		HotelID:           r.HotelID,
+		TypeID:            r.TypeID,
+		StartDate:         r.StartDate,
+		EndDate:           r.EndDate,
+		MinNights:         r.MinNights,
+		MaxNights:         r.MaxNights,
+		ClosedToArrival:   r.ClosedToArrival,
+		ClosedToDeparture: r.ClosedToDeparture,
+		CreatedAt:         r.CreatedAt,
+		CreatedBy:         r.CreatedBy,
+		UpdateAt:          r.UpdateAt,
+		UpdateBy:          r.UpdateBy,
+	}
+}
+
+// FromDBStayRestriction converts db.StayRestriction to model.StayRestriction
+func FromDBStayRestriction(dbRestriction *db.StayRestriction) *StayRestriction {
+	return &StayRestriction{
+		RestrictionID:     dbRestriction.RestrictionID,
+		HotelID:           dbRestriction.HotelID,
+		TypeID:            dbRestriction.TypeID,
+		StartDate:         dbRestriction.StartDate,
+		EndDate:           dbRestriction.EndDate,
+		MinNights:         dbRestriction.MinNights,
+		MaxNights:         dbRestriction.MaxNights,
+		ClosedToArrival:   dbRestriction.ClosedToArrival,
+		ClosedToDeparture: dbRestriction.ClosedToDeparture,
+		CreatedAt:         dbRestriction.CreatedAt,
+		CreatedBy:         dbRestriction.CreatedBy,
+		UpdateAt:          dbRestriction.UpdateAt,
+		UpdateBy:          dbRestriction.UpdateBy,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"database/sql"
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// Waitlist entry statuses
+const (
+	WaitlistWaiting   = "WAITING"
+	WaitlistOffered   = "OFFERED"
+	WaitlistBooked    = "BOOKED"
+	WaitlistDeclined  = "DECLINED"
+	WaitlistExpired   = "EXPIRED"
+	WaitlistWithdrawn = "WITHDRAWN"
+)
+
+type WaitlistEntry struct {
+	EntryID        uuid.UUID      `json:"entry_id"`
+	HotelID        uuid.NullUUID  `json:"hotel_id"`
+	TypeID         sql.NullString `json:"type_id"`
+	RoomID         uuid.NullUUID  `json:"room_id"`
+	UserID         sql.NullString `json:"user_id"`
+	StartDate      sql.NullTime   `json:"start_date"`
+	EndDate        sql.NullTime   `json:"end_date"`
+	Guests         sql.NullInt32  `json:"guests"`
+	ContactName    sql.NullString `json:"contact_name"`
+	ContactEmail   sql.NullString `json:"contact_email"`
+	ContactPhone   sql.NullString `json:"contact_phone"`
+	Status         sql.NullString `json:"status"`
+	ReservationID  uuid.NullUUID  `json:"reservation_id"`
+	OfferExpiresAt sql.NullTime   `json:"offer_expires_at"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+	CreatedBy      uuid.NullUUID  `json:"created_by"`
+	UpdateAt       sql.NullTime   `json:"update_at"`
+	UpdateBy       uuid.NullUUID  `json:"update_by"`
+}
+
+// ToDBModel converts model.WaitlistEntry to db.WaitlistEntry
+func (w *WaitlistEntry) ToDBModel() *db.WaitlistEntry {
+	return &db.WaitlistEntry{
+		EntryID:        w.EntryID,
+		HotelID:        w.HotelID,
+		TypeID:         w.TypeID,
+		RoomID:         w.RoomID,
+		UserID:         w.UserID,
+		StartDate:      w.StartDate,
+		EndDate:        w.EndDate,
+		Guests:         w.Guests,
+		ContactName:    w.ContactName,
+		ContactEmail:   w.ContactEmail,
+		ContactPhone:   w.ContactPhone,
+		Status:         w.Status,
+		ReservationID:  w.ReservationID,
+		OfferExpiresAt: w.OfferExpiresAt,
+		CreatedAt:      w.CreatedAt,
+		CreatedBy:      w.CreatedBy,
+		UpdateAt:       w.UpdateAt,
+		UpdateBy:       w.UpdateBy,
+	}
+}
+
+// FromDBWaitlistEntry converts db.WaitlistEntry to model.WaitlistEntry
+func FromDBWaitlistEntry(dbEntry *db.WaitlistEntry) *WaitlistEntry {
+	return &WaitlistEntry{
+		EntryID:        dbEntry.EntryID,
+		HotelID:        dbEntry.HotelID,
+		TypeID:         dbEntry.TypeID,
+		RoomID:         dbEntry.RoomID,
+		UserID:         dbEntry.UserID,
+		StartDate:      dbEntry.StartDate,
+		EndDate:        dbEntry.EndDate,
+		Guests:         dbEntry.Guests,
+		ContactName:    dbEntry.ContactName,
+		ContactEmail:   dbEntry.ContactEmail,
+		ContactPhone:   dbEntry.ContactPhone,
+		Status:         dbEntry.Status,
+		ReservationID:  dbEntry.ReservationID,
+		OfferExpiresAt: dbEntry.OfferExpiresAt,
+		CreatedAt:      dbEntry.CreatedAt,
+		CreatedBy:      dbEntry.CreatedBy,
+		UpdateAt:       dbEntry.UpdateAt,
+		UpdateBy:       dbEntry.UpdateBy,
+	}
+}
\ No newline at end of file
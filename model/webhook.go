@@ -0,0 +1,114 @@
+package model
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/google/uuid"
+)
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "PENDING"
+	WebhookDeliverySucceeded = "SUCCEEDED"
+	WebhookDeliveryFailed    = "FAILED"
+)
+
+// WebhookSubscription is a partner endpoint that is sent events as they happen. An empty
+// EventTypes subscribes to every event type, and a missing HotelID to events of every hotel. The
+// secret signs each delivery; it is only returned when the subscription is created.
+type WebhookSubscription struct {
+	SubscriptionID      uuid.UUID      `json:"subscription_id"`
+	URL                 string         `json:"url" binding:"required"`
+	EventTypes          []string       `json:"event_types"`
+	HotelID             uuid.NullUUID  `json:"hotel_id"`
+	Secret              string         `json:"secret,omitempty"`
+	Description         sql.NullString `json:"description"`
+	IsActive            sql.NullBool   `json:"is_active"`
+	ConsecutiveFailures int32          `json:"consecutive_failures"`
+	DisabledAt          sql.NullTime   `json:"disabled_at"`
+	DisabledReason      sql.NullString `json:"disabled_reason"`
+	CreatedAt           sql.NullTime   `json:"created_at"`
+	CreatedBy           uuid.NullUUID  `json:"created_by"`
+	UpdateAt            sql.NullTime   `json:"update_at"`
+	UpdateBy            uuid.NullUUID  `json:"update_by"`
+}
+
+// ToDBModel converts model.WebhookSubscription to db.WebhookSubscription
+func (w *WebhookSubscription) ToDBModel() *db.WebhookSubscription {
+	return &db.WebhookSubscription{
+		SubscriptionID:      w.SubscriptionID,
+		Url:                 w.URL,
+		EventTypes:          w.EventTypes,
+		HotelID:             w.HotelID,
+		Secret:              w.Secret,
+		Description:         w.Description,
+		IsActive:            w.IsActive,
+		ConsecutiveFailures: w.ConsecutiveFailures,
+		DisabledAt:          w.DisabledAt,
+		DisabledReason:      w.DisabledReason,
+		CreatedAt:           w.CreatedAt,
+		CreatedBy:           w.CreatedBy,
+		UpdateAt:            w.UpdateAt,
+		UpdateBy:            w.UpdateBy,
+	}
+}
+
+// FromDBWebhookSubscription converts db.WebhookSubscription to model.WebhookSubscription
+func FromDBWebhookSubscription(dbSubscription *db.WebhookSubscription) *WebhookSubscription {
+	return &WebhookSubscription{
+		SubscriptionID:      dbSubscription.SubscriptionID,
+		URL:                 dbSubscription.Url,
+		EventTypes:          dbSubscription.EventTypes,
+		HotelID:             dbSubscription.HotelID,
+		Secret:              dbSubscription.Secret,
+		Description:         dbSubscription.Description,
+		IsActive:            dbSubscription.IsActive,
+		ConsecutiveFailures: dbSubscription.ConsecutiveFailures,
+		DisabledAt:          dbSubscription.DisabledAt,
+		DisabledReason:      dbSubscription.DisabledReason,
+		CreatedAt:           dbSubscription.CreatedAt,
+		CreatedBy:           dbSubscription.CreatedBy,
+		UpdateAt:            dbSubscription.UpdateAt,
+		UpdateBy:            dbSubscription.UpdateBy,
+	}
+}
+
+// WebhookDelivery is the log entry of one event sent to one subscription. Payload is the exact
+// body that is posted, so a replay sends the same bytes as the original delivery.
+type WebhookDelivery struct {
+	DeliveryID     uuid.UUID       `json:"delivery_id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  sql.NullTime    `json:"next_attempt_at"`
+	LastAttemptAt  sql.NullTime    `json:"last_attempt_at"`
+	ResponseStatus sql.NullInt32   `json:"response_status"`
+	LastError      sql.NullString  `json:"last_error"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    sql.NullTime    `json:"delivered_at"`
+}
+
+// FromDBWebhookDelivery converts db.WebhookDelivery to model.WebhookDelivery
+func FromDBWebhookDelivery(dbDelivery *db.WebhookDelivery) *WebhookDelivery {
+	return &WebhookDelivery{
+		DeliveryID:     dbDelivery.DeliveryID,
+		SubscriptionID: dbDelivery.SubscriptionID,
+		EventID:        dbDelivery.EventID,
+		EventType:      dbDelivery.EventType,
+		Payload:        dbDelivery.Payload,
+		Status:         dbDelivery.Status,
+		Attempts:       dbDelivery.Attempts,
+		NextAttemptAt:  dbDelivery.NextAttemptAt,
+		LastAttemptAt:  dbDelivery.LastAttemptAt,
+		ResponseStatus: dbDelivery.ResponseStatus,
+		LastError:      dbDelivery.LastError,
+		CreatedAt:      dbDelivery.CreatedAt,
+		DeliveredAt:    dbDelivery.DeliveredAt,
+	}
+}
\ No newline at end of file
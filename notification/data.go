@@ -0,0 +1,17 @@
+package notification
+
+// Booking is the data every notification template is rendered with
+type Booking struct {
+	GuestName        string
+	ConfirmationCode string
+	CheckIn          string
+	CheckOut         string
+	CheckInTime      string
+	CheckOutTime     string
+	RoomType         string
+	Adults           int32
+	Children         int32
+	TotalPrice       int32
+	SpecialRequests  string
+	ReviewURL        string
+}
\ No newline at end of file
@@ -0,0 +1,192 @@
+package notification
+
+import (
+	"context"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testBooking = &Booking{
+	GuestName:        "Jane Doe",
+	ConfirmationCode: "ABC123",
+	CheckIn:          "2026-11-01",
+	CheckOut:         "2026-11-03",
+	CheckInTime:      "14:00",
+	RoomType:         "DELUXE",
+	Adults:           2,
+	TotalPrice:       240,
+	ReviewURL:        "https://example.com/review?code=ABC123&lang=en",
+}
+
+var kinds = []string{
+	"BOOKING_RECEIVED",
+	"BOOKING_CONFIRMATION",
+	"ARRIVAL_REMINDER",
+	"CANCELLATION",
+	"REVIEW_INVITATION",
+}
+
+func TestRenderEveryKindInEveryLocale(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	for _, locale := range []string{"en", "vi"} {
+		for _, kind := range kinds {
+			msg, err := r.Render(kind, locale, testBooking)
+			if err != nil {
+				t.Fatalf("Render(%s, %s): %v", kind, locale, err)
+			}
+			if msg.Subject == "" || strings.Contains(msg.Subject, "\n") {
+				t.Errorf("Render(%s, %s) subject = %q", kind, locale, msg.Subject)
+			}
+			if !strings.Contains(msg.Text, "Jane Doe") {
+				t.Errorf("Render(%s, %s) text does not greet the guest:\n%s", kind, locale, msg.Text)
+			}
+			if !strings.Contains(msg.HTML, "<html>") || !strings.Contains(msg.HTML, "Jane Doe") {
+				t.Errorf("Render(%s, %s) HTML is not laid out:\n%s", kind, locale, msg.HTML)
+			}
+		}
+	}
+}
+
+func TestRenderEscapesHTML(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	booking := *testBooking
+	booking.GuestName = "<script>alert(1)</script>"
+
+	msg, err := r.Render("BOOKING_CONFIRMATION", "en", &booking)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if strings.Contains(msg.HTML, "<script>") {
+		t.Errorf("HTML contains unescaped guest name:\n%s", msg.HTML)
+	}
+	if !strings.Contains(msg.Text, "<script>") {
+		t.Errorf("text escaped the guest name:\n%s", msg.Text)
+	}
+}
+
+func TestRenderLocaleFallback(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	vi, err := r.Render("CANCELLATION", "vi", testBooking)
+	if err != nil {
+		t.Fatalf("Render vi: %v", err)
+	}
+	en, err := r.Render("CANCELLATION", "en", testBooking)
+	if err != nil {
+		t.Fatalf("Render en: %v", err)
+	}
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"vi-VN", vi.Subject},
+		{"VI_vn", vi.Subject},
+		{"fr", en.Subject},
+		{"", en.Subject},
+	}
+	for _, tt := range tests {
+		msg, err := r.Render("CANCELLATION", tt.locale, testBooking)
+		if err != nil {
+			t.Fatalf("Render(%q): %v", tt.locale, err)
+		}
+		if msg.Subject != tt.want {
+			t.Errorf("Render(%q) subject = %q, want %q", tt.locale, msg.Subject, tt.want)
+		}
+	}
+
+	if locale := r.ResolveLocale("vi-VN"); locale != "vi" {
+		t.Errorf("ResolveLocale(vi-VN) = %q, want vi", locale)
+	}
+	if locale := r.ResolveLocale("de"); locale != DefaultLocale {
+		t.Errorf("ResolveLocale(de) = %q, want %q", locale, DefaultLocale)
+	}
+
+	if _, err := r.Render("UNKNOWN", "en", testBooking); err == nil {
+		t.Error("Render of an unknown kind succeeded")
+	}
+}
+
+func TestMemorySender(t *testing.T) {
+	s := NewMemorySender()
+	for _, to := range []string{"a@example.com", "b@example.com"} {
+		if err := s.Send(context.Background(), &Message{To: to, Subject: "Hi"}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	messages := s.Messages()
+	if len(messages) != 2 || messages[0].To != "a@example.com" || messages[1].To != "b@example.com" {
+		t.Fatalf("Messages = %+v", messages)
+	}
+}
+
+func TestFileSenderWritesMIME(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	s := NewFileSender(dir, "Hotel <bookings@example.com>")
+
+	msg := &Message{
+		To:      "jane@example.com",
+		Subject: "Đặt phòng ABC123 đã được xác nhận",
+		Text:    "Xin chào Jane\n",
+		HTML:    "<p>Xin chào Jane</p>",
+	}
+	if err := s.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("eml files = %v, %v", files, err)
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	email, err := mail.ReadMessage(f)
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if to := email.Header.Get("To"); to != msg.To {
+		t.Errorf("To = %q, want %q", to, msg.To)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(email.Header.Get("Subject"))
+	if err != nil || subject != msg.Subject {
+		t.Errorf("Subject = %q, %v, want %q", subject, err, msg.Subject)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(email.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", mediaType, err)
+	}
+	parts := multipart.NewReader(email.Body, params["boundary"])
+	var contentTypes []string
+	for {
+		part, err := parts.NextPart()
+		if err != nil {
+			break
+		}
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+	}
+	if len(contentTypes) != 2 || !strings.HasPrefix(contentTypes[0], "text/plain") || !strings.HasPrefix(contentTypes[1], "text/html") {
+		t.Errorf("parts = %v, want text then HTML", contentTypes)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPSender sends emails through an SMTP server. Credentials are optional; when given, the
+// server must offer STARTTLS, since net/smtp refuses to send them over a plain connection.
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPSender{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+		auth: auth,
+	}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	data, err := buildMIME(s.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// The envelope sender is the bare address, without the display name of the From header
+	sender := s.from
+	if address, err := mail.ParseAddress(s.from); err == nil {
+		sender = address.Address
+	}
+
+	return smtp.SendMail(s.addr, s.auth, sender, []string{msg.To}, data)
+}
\ No newline at end of file
@@ -0,0 +1,141 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+// DefaultLocale is used for guests without a locale, or whose locale has no templates
+const DefaultLocale = "en"
+
+// Templates live in templates/<locale>/<kind>.html and <kind>.txt, where kind is the notification
+// kind in lower case. The text template also defines the "subject" of the email, and HTML
+// templates define the "content" of templates/layout.html.
+//
+//go:embed templates
+var templateFS embed.FS
+
+// Renderer renders notification emails from the embedded templates
+type Renderer struct {
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+}
+
+// NewRenderer parses every embedded template up front, so a broken template fails at startup
+// rather than when the first guest is notified
+func NewRenderer() (*Renderer, error) {
+	r := &Renderer{
+		html: make(map[string]*htmltemplate.Template),
+		text: make(map[string]*texttemplate.Template),
+	}
+
+	textFiles, err := fs.Glob(templateFS, "templates/*/*.txt")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range textFiles {
+		tmpl, err := texttemplate.ParseFS(templateFS, file)
+		if err != nil {
+			return nil, err
+		}
+		if tmpl.Lookup("subject") == nil {
+			return nil, errors.New("notification template " + file + " does not define a subject")
+		}
+		r.text[templateKey(file)] = tmpl
+	}
+
+	htmlFiles, err := fs.Glob(templateFS, "templates/*/*.html")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range htmlFiles {
+		tmpl, err := htmltemplate.ParseFS(templateFS, "templates/layout.html", file)
+		if err != nil {
+			return nil, err
+		}
+		r.html[templateKey(file)] = tmpl
+	}
+
+	return r, nil
+}
+
+// Render renders the email for a notification kind in the guest's locale. A regional locale
+// such as vi-VN falls back to its language, and a language without templates to DefaultLocale.
+func (r *Renderer) Render(kind, locale string, data interface{}) (*Message, error) {
+	name := strings.ToLower(kind)
+
+	var text *texttemplate.Template
+	var html *htmltemplate.Template
+	for _, candidate := range localeCandidates(locale) {
+		if tmpl, ok := r.text[candidate+"/"+name]; ok {
+			text = tmpl
+			html = r.html[candidate+"/"+name]
+			break
+		}
+	}
+	if text == nil {
+		return nil, errors.New("no notification template for " + name)
+	}
+
+	var subject, body bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := text.Execute(&body, data); err != nil {
+		return nil, err
+	}
+
+	msg := &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(body.String()) + "\n",
+	}
+
+	if html != nil {
+		var content bytes.Buffer
+		if err := html.ExecuteTemplate(&content, "layout", data); err != nil {
+			return nil, err
+		}
+		msg.HTML = content.String()
+	}
+
+	return msg, nil
+}
+
+// ResolveLocale returns the locale Render will use for locale
+func (r *Renderer) ResolveLocale(locale string) string {
+	for _, candidate := range localeCandidates(locale) {
+		for key := range r.text {
+			if strings.HasPrefix(key, candidate+"/") {
+				return candidate
+			}
+		}
+	}
+	return DefaultLocale
+}
+
+// localeCandidates lists the locales to try for locale, most specific first
+func localeCandidates(locale string) []string {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+
+	var candidates []string
+	if locale != "" {
+		candidates = append(candidates, locale)
+		if language, _, ok := strings.Cut(locale, "-"); ok {
+			candidates = append(candidates, language)
+		}
+	}
+	return append(candidates, DefaultLocale)
+}
+
+// templateKey turns templates/<locale>/<kind>.<ext> into <locale>/<kind>
+func templateKey(file string) string {
+	locale := path.Base(path.Dir(file))
+	kind := strings.TrimSuffix(path.Base(file), path.Ext(file))
+	return locale + "/" + kind
+}
\ No newline at end of file
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+type AllotmentRepository interface {
+	GetAllotmentByID(ctx context.Context, allotmentID uuid.UUID) (*model.Allotment, error)
+	GetAllotmentByCode(ctx context.Context, blockCode string) (*model.Allotment, error)
+	ListAllotmentsByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.Allotment, error)
+}
+
+type allotmentRepository struct {
+	db *sql.DB
+}
+
+func NewAllotmentRepository(db *sql.DB) AllotmentRepository {
+	return &allotmentRepository{db: db}
+}
+
+func (r *allotmentRepository) GetAllotmentByID(ctx context.Context, allotmentID uuid.UUID) (*model.Allotment, error) {
+	var allotment model.Allotment
+	query := `
+		SELECT allotment_id, block_code, name, hotel_id, type_id, start_date, end_date, quantity, rate,
+		       release_date, status, released_at, created_at, created_by, update_at, update_by
+		FROM allotment
+		WHERE allotment_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, allotmentID).Scan(
+		&allotment.AllotmentID,
+		&allotment.BlockCode,
+		&allotment.Name,
+		&allotment.HotelID,
+		&allotment.TypeID,
+		&allotment.StartDate,
+		&allotment.EndDate,
+		&allotment.Quantity,
+		&allotment.Rate,
+		&allotment.ReleaseDate,
+		&allotment.Status,
+		&allotment.ReleasedAt,
+		&allotment.CreatedAt,
+		&allotment.CreatedBy,
+		&allotment.UpdateAt,
+		&allotment.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &allotment, nil
+}
+
+func (r *allotmentRepository) GetAllotmentByCode(ctx context.Context, blockCode string) (*model.Allotment, error) {
+	var allotment model.Allotment
+	query := `
+		SELECT allotment_id, block_code, name, hotel_id, type_id, start_date, end_date, quantity, rate,
+		       release_date, status, released_at, created_at, created_by, update_at, update_by
+		FROM allotment
+		WHERE block_code = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, blockCode).Scan(
+		&allotment.AllotmentID,
+		&allotment.BlockCode,
+		&allotment.Name,
+		&allotment.HotelID,
+		&allotment.TypeID,
+		&allotment.StartDate,
+		&allotment.EndDate,
+		&allotment.Quantity,
+		&allotment.Rate,
+		&allotment.ReleaseDate,
+		&allotment.Status,
+		&allotment.ReleasedAt,
+		&allotment.CreatedAt,
+		&allotment.CreatedBy,
+		&allotment.UpdateAt,
+		&allotment.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &allotment, nil
+}
+
+func (r *allotmentRepository) ListAllotmentsByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.Allotment, error) {
+	query := `
+		SELECT allotment_id, block_code, name, hotel_id, type_id, start_date, end_date, quantity, rate,
+		       release_date, status, released_at, created_at, created_by, update_at, update_by
+		FROM allotment
+		WHERE hotel_id = $1
+		ORDER BY start_date, block_code
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var allotments []*model.Allotment
+	for rows.Next() {
+		var allotment model.Allotment
+		err := rows.Scan(
+			&allotment.AllotmentID,
+			&allotment.BlockCode,
+			&allotment.Name,
+			&allotment.HotelID,
+			&allotment.TypeID,
+			&allotment.StartDate,
+			&allotment.EndDate,
+			&allotment.Quantity,
+			&allotment.Rate,
+			&allotment.ReleaseDate,
+			&allotment.Status,
+			&allotment.ReleasedAt,
+			&allotment.CreatedAt,
+			&allotment.CreatedBy,
+			&allotment.UpdateAt,
+			&allotment.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		allotments = append(allotments, &allotment)
+	}
+	return allotments, nil
+}
\ No newline at end of file
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+type BookingGroupRepository interface {
+	GetBookingGroupByID(ctx context.Context, groupID uuid.UUID) (*model.BookingGroup, error)
+	ListBookingGroupsByUser(ctx context.Context, userID string, limit, offset int) ([]*model.BookingGroup, error)
+}
+
+type bookingGroupRepository struct {
+	db *sql.DB
+}
+
+func NewBookingGroupRepository(db *sql.DB) BookingGroupRepository {
+	return &bookingGroupRepository{db: db}
+}
+
+func (r *bookingGroupRepository) GetBookingGroupByID(ctx context.Context, groupID uuid.UUID) (*model.BookingGroup, error) {
+	var group model.BookingGroup
+	query := `
+		SELECT group_id, user_id, contact_name, contact_email, contact_phone, start_date, end_date,
+		       status, total_price, created_at, created_by, update_at, update_by
+		FROM booking_group
+		WHERE group_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, groupID).Scan(
+		&group.GroupID,
+		&group.UserID,
+		&group.ContactName,
+		&group.ContactEmail,
+		&group.ContactPhone,
+		&group.StartDate,
+		&group.EndDate,
+		&group.Status,
+		&group.TotalPrice,
+		&group.CreatedAt,
+		&group.CreatedBy,
+		&group.UpdateAt,
+		&group.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *bookingGroupRepository) ListBookingGroupsByUser(ctx context.Context, userID string, limit, offset int) ([]*model.BookingGroup, error) {
+	query := `
+		SELECT group_id, user_id, contact_name, contact_email, contact_phone, start_date, end_date,
+		       status, total_price, created_at, created_by, update_at, update_by
+		FROM booking_group
+		WHERE user_id = $1
+		ORDER BY start_date DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*model.BookingGroup
+	for rows.Next() {
+		var group model.BookingGroup
+		err := rows.Scan(
+			&group.GroupID,
+			&group.UserID,
+			&group.ContactName,
+			&group.ContactEmail,
+			&group.ContactPhone,
+			&group.StartDate,
+			&group.EndDate,
+			&group.Status,
+			&group.TotalPrice,
+			&group.CreatedAt,
+			&group.CreatedBy,
+			&group.UpdateAt,
+			&group.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, &group)
+	}
+	return groups, nil
+}
\ No newline at end of file
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+type ChannelRepository interface {
+	CreateChannelConnection(ctx context.Context, connection *model.ChannelConnection) error
+	GetChannelConnectionByID(ctx context.Context, connectionID uuid.UUID) (*model.ChannelConnection, error)
+	GetChannelConnectionByCode(ctx context.Context, channel, hotelCode string) (*model.ChannelConnection, error)
+	ListChannelConnectionsByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.ChannelConnection, error)
+	UpdateChannelConnection(ctx context.Context, connection *model.ChannelConnection) error
+	DeleteChannelConnection(ctx context.Context, connectionID uuid.UUID) error
+	CreateChannelMapping(ctx context.Context, mapping *model.ChannelMapping) error
+	GetChannelMappingByID(ctx context.Context, mappingID uuid.UUID) (*model.ChannelMapping, error)
+	ListChannelMappingsByConnection(ctx context.Context, connectionID uuid.UUID) ([]*model.ChannelMapping, error)
+	DeleteChannelMapping(ctx context.Context, mappingID uuid.UUID) error
+}
+
+type channelRepository struct {
+	db *sql.DB
+}
+
+func NewChannelRepository(db *sql.DB) ChannelRepository {
+	return &channelRepository{db: db}
+}
+
+func (r *channelRepository) CreateChannelConnection(ctx context.Context, connection *model.ChannelConnection) error {
+	query := `
+		INSERT INTO channel_connection (connection_id, hotel_id, channel, hotel_code, is_active, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		connection.ConnectionID,
+		connection.HotelID,
+		connection.Channel,
+		connection.HotelCode,
+		connection.IsActive.Bool,
+		connection.CreatedAt,
+		connection.CreatedBy,
+	)
+	return err
+}
+
+func (r *channelRepository) GetChannelConnectionByID(ctx context.Context, connectionID uuid.UUID) (*model.ChannelConnection, error) {
+	query := `
+		SELECT connection_id, hotel_id, channel, hotel_code, is_active, last_pushed_at, push_error,
+		       last_pulled_at, pull_error, created_at, created_by, update_at, update_by, push_due_at
+		FROM channel_connection
+		WHERE connection_id = $1
+	`
+	return r.getChannelConnection(ctx, query, connectionID)
+}
+
+func (r *channelRepository) GetChannelConnectionByCode(ctx context.Context, channel, hotelCode string) (*model.ChannelConnection, error) {
+	query := `
+		SELECT connection_id, hotel_id, channel, hotel_code, is_active, last_pushed_at, push_error,
+		       last_pulled_at, pull_error, created_at, created_by, update_at, update_by, push_due_at
+		FROM channel_connection
+		WHERE channel = $1 AND hotel_code = $2
+	`
+	return r.getChannelConnection(ctx, query, channel, hotelCode)
+}
+
+func (r *channelRepository) getChannelConnection(ctx context.Context, query string, args ...interface{}) (*model.ChannelConnection, error) {
+	var connection model.ChannelConnection
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&connection.ConnectionID,
+		&connection.HotelID,
+		&connection.Channel,
+		&connection.HotelCode,
+		&connection.IsActive,
+		&connection.LastPushedAt,
+		&connection.PushError,
+		&connection.LastPulledAt,
+		&connection.PullError,
+		&connection.CreatedAt,
+		&connection.CreatedBy,
+		&connection.UpdateAt,
+		&connection.UpdateBy,
+		&connection.PushDueAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &connection, nil
+}
+
+func (r *channelRepository) ListChannelConnectionsByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.ChannelConnection, error) {
+	query := `
+		SELECT connection_id, hotel_id, channel, hotel_code, is_active, last_pushed_at, push_error,
+		       last_pulled_at, pull_error, created_at, created_by, update_at, update_by, push_due_at
+		FROM channel_connection
+		WHERE hotel_id = $1
+		ORDER BY channel, connection_id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []*model.ChannelConnection
+	for rows.Next() {
+		var connection model.ChannelConnection
+		err := rows.Scan(
+			&connection.ConnectionID,
+			&connection.HotelID,
+			&connection.Channel,
+			&connection.HotelCode,
+			&connection.IsActive,
+			&connection.LastPushedAt,
+			&connection.PushError,
+			&connection.LastPulledAt,
+			&connection.PullError,
+			&connection.CreatedAt,
+			&connection.CreatedBy,
+			&connection.UpdateAt,
+			&connection.UpdateBy,
+			&connection.PushDueAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		connections = append(connections, &connection)
+	}
+	return connections, nil
+}
+
+func (r *channelRepository) UpdateChannelConnection(ctx context.Context, connection *model.ChannelConnection) error {
+	query := `
+		UPDATE channel_connection
+		SET hotel_code = $2, is_active = $3, update_at = $4, update_by = $5
+		WHERE connection_id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		connection.ConnectionID,
+		connection.HotelCode,
+		connection.IsActive.Bool,
+		connection.UpdateAt,
+		connection.UpdateBy,
+	)
+	return err
+}
+
+// DeleteChannelConnection deletes a connection together with its mappings. Reservations imported
+// through it are kept.
+func (r *channelRepository) DeleteChannelConnection(ctx context.Context, connectionID uuid.UUID) error {
+	query := `DELETE FROM channel_connection WHERE connection_id = $1`
+	_, err := r.db.ExecContext(ctx, query, connectionID)
+	return err
+}
+
+func (r *channelRepository) CreateChannelMapping(ctx context.Context, mapping *model.ChannelMapping) error {
+	query := `
+		INSERT INTO channel_mapping (mapping_id, connection_id, type_id, room_id, room_code, rate_code, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		mapping.MappingID,
+		mapping.ConnectionID,
+		mapping.TypeID,
+		mapping.RoomID,
+		mapping.RoomCode,
+		mapping.RateCode,
+		mapping.CreatedAt,
+		mapping.CreatedBy,
+	)
+	return err
+}
+
+func (r *channelRepository) GetChannelMappingByID(ctx context.Context, mappingID uuid.UUID) (*model.ChannelMapping, error) {
+	var mapping model.ChannelMapping
+	query := `
+		SELECT mapping_id, connection_id, type_id, room_id, room_code, rate_code, created_at, created_by
+		FROM channel_mapping
+		WHERE mapping_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, mappingID).Scan(
+		&mapping.MappingID,
+		&mapping.ConnectionID,
+		&mapping.TypeID,
+		&mapping.RoomID,
+		&mapping.RoomCode,
+		&mapping.RateCode,
+		&mapping.CreatedAt,
+		&mapping.CreatedBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &mapping, nil
+}
+
+func (r *channelRepository) ListChannelMappingsByConnection(ctx context.Context, connectionID uuid.UUID) ([]*model.ChannelMapping, error) {
+	query := `
+		SELECT mapping_id, connection_id, type_id, room_id, room_code, rate_code, created_at, created_by
+		FROM channel_mapping
+		WHERE connection_id = $1
+		ORDER BY room_code
+	`
+	rows, err := r.db.QueryContext(ctx, query, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mappings []*model.ChannelMapping
+	for rows.Next() {
+		var mapping model.ChannelMapping
+		err := rows.Scan(
+			&mapping.MappingID,
+			&mapping.ConnectionID,
+			&mapping.TypeID,
+			&mapping.RoomID,
+			&mapping.RoomCode,
+			&mapping.RateCode,
+			&mapping.CreatedAt,
+			&mapping.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, &mapping)
+	}
+	return mappings, nil
+}
+
+func (r *channelRepository) DeleteChannelMapping(ctx context.Context, mappingID uuid.UUID) error {
+	query := `DELETE FROM channel_mapping WHERE mapping_id = $1`
+	_, err := r.db.ExecContext(ctx, query, mappingID)
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+type ExtraRepository interface {
+	CreateExtra(ctx context.Context, extra *model.Extra) error
+	GetExtraByID(ctx context.Context, extraID uuid.UUID) (*model.Extra, error)
+	GetExtraByCode(ctx context.Context, hotelID uuid.UUID, code string) (*model.Extra, error)
+	ListExtrasByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.Extra, error)
+	UpdateExtra(ctx context.Context, extra *model.Extra) error
+	DeleteExtra(ctx context.Context, extraID uuid.UUID) error
+	CountExtraUsage(ctx context.Context, extraID uuid.UUID) (int64, error)
+	ListExtraDailyCounts(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.ExtraDailyCount, error)
+}
+
+type extraRepository struct {
+	db *sql.DB
+}
+
+func NewExtraRepository(db *sql.DB) ExtraRepository {
+	return &extraRepository{db: db}
+}
+
+func (r *extraRepository) CreateExtra(ctx context.Context, extra *model.Extra) error {
+	query := `
+		INSERT INTO extra (extra_id, hotel_id, code, name, description, pricing, price, is_active,
+		                   created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		extra.ExtraID,
+		extra.HotelID,
+		extra.Code,
+		extra.Name,
+		extra.Description,
+		extra.Pricing,
+		extra.Price,
+		extra.IsActive,
+		extra.CreatedAt,
+		extra.CreatedBy,
+	)
+	return err
+}
+
+func (r *extraRepository) GetExtraByID(ctx context.Context, extraID uuid.UUID) (*model.Extra, error) {
+	var extra model.Extra
+	query := `
+		SELECT extra_id, hotel_id, code, name, description, pricing, price, is_active,
+		       created_at, created_by, update_at, update_by
+		FROM extra
+		WHERE extra_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, extraID).Scan(
+		&extra.ExtraID,
+		&extra.HotelID,
+		&extra.Code,
+		&extra.Name,
+		&extra.Description,
+		&extra.Pricing,
+		&extra.Price,
+		&extra.IsActive,
+		&extra.CreatedAt,
+		&extra.CreatedBy,
+		&extra.UpdateAt,
+		&extra.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &extra, nil
+}
+
+func (r *extraRepository) GetExtraByCode(ctx context.Context, hotelID uuid.UUID, code string) (*model.Extra, error) {
+	var extra model.Extra
+	query := `
+		SELECT extra_id, hotel_id, code, name, description, pricing, price, is_active,
+		       created_at, created_by, update_at, update_by
+		FROM extra
+		WHERE hotel_id = $1 AND code = $2
+	`
+	err := r.db.QueryRowContext(ctx, query, hotelID, code).Scan(
+		&extra.ExtraID,
+		&extra.HotelID,
+		&extra.Code,
+		&extra.Name,
+		&extra.Description,
+		&extra.Pricing,
+		&extra.Price,
+		&extra.IsActive,
+		&extra.CreatedAt,
+		&extra.CreatedBy,
+		&extra.UpdateAt,
+		&extra.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &extra, nil
+}
+
+func (r *extraRepository) ListExtrasByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.Extra, error) {
+	query := `
+		SELECT extra_id, hotel_id, code, name, description, pricing, price, is_active,
+		       created_at, created_by, update_at, update_by
+		FROM extra
+		WHERE hotel_id = $1
+		ORDER BY code
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var extras []*model.Extra
+	for rows.Next() {
+		var extra model.Extra
+		err := rows.Scan(
+			&extra.ExtraID,
+			&extra.HotelID,
+			&extra.Code,
+			&extra.Name,
+			&extra.Description,
+			&extra.Pricing,
+			&extra.Price,
+			&extra.IsActive,
+			&extra.CreatedAt,
+			&extra.CreatedBy,
+			&extra.UpdateAt,
+			&extra.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		extras = append(extras, &extra)
+	}
+	return extras, nil
+}
+
+func (r *extraRepository) UpdateExtra(ctx context.Context, extra *model.Extra) error {
+	query := `
+		UPDATE extra
+		SET name = $2, description = $3, pricing = $4, price = $5, is_active = $6,
+		    update_at = $7, update_by = $8
+		WHERE extra_id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		extra.ExtraID,
+		extra.Name,
+		extra.Description,
+		extra.Pricing,
+		extra.Price,
+		extra.IsActive,
+		extra.UpdateAt,
+		extra.UpdateBy,
+	)
+	return err
+}
+
+func (r *extraRepository) DeleteExtra(ctx context.Context, extraID uuid.UUID) error {
+	query := `DELETE FROM extra WHERE extra_id = $1`
+	_, err := r.db.ExecContext(ctx, query, extraID)
+	return err
+}
+
+func (r *extraRepository) CountExtraUsage(ctx context.Context, extraID uuid.UUID) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM reservation_extra WHERE extra_id = $1`
+	err := r.db.QueryRowContext(ctx, query, extraID).Scan(&count)
+	return count, err
+}
+
+// ListExtraDailyCounts returns, per night of [startDate, endDate) and extra, the units of the
+// extra that confirmed stays booked. Nightly extras count on every night of the stay, per-stay
+// extras on the night of arrival, and per-person extras once for every guest in the party.
+func (r *extraRepository) ListExtraDailyCounts(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.ExtraDailyCount, error) {
+	query := `
+		SELECT d.night, e.extra_id, COALESCE(e.code, ''), COALESCE(e.name, ''),
+		       SUM(re.quantity * CASE WHEN re.pricing IN ('PER_PERSON', 'PER_PERSON_NIGHT')
+		           THEN COALESCE(res.adults, 1) + COALESCE(res.children, 0) ELSE 1 END)
+		FROM generate_series($2::TIMESTAMPTZ, $3::TIMESTAMPTZ - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+		JOIN reservation res ON res.hotel_id = $1
+		JOIN reservation_extra re ON re.reservation_id = res.reservation_id
+		JOIN extra e ON e.extra_id = re.extra_id
+		WHERE res.status IN ('CONFIRMED', 'COMPLETED')
+		AND (
+			(re.pricing IN ('PER_NIGHT', 'PER_PERSON_NIGHT') AND res.start_date < d.night + INTERVAL '1 day' AND res.end_date > d.night) OR
+			(re.pricing NOT IN ('PER_NIGHT', 'PER_PERSON_NIGHT') AND res.start_date >= d.night AND res.start_date < d.night + INTERVAL '1 day')
+		)
+		GROUP BY d.night, e.extra_id, e.code, e.name
+		ORDER BY d.night, e.code
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []*model.ExtraDailyCount
+	for rows.Next() {
+		var count model.ExtraDailyCount
+		err := rows.Scan(
+			&count.Night,
+			&count.ExtraID,
+			&count.Code,
+			&count.Name,
+			&count.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, &count)
+	}
+	return counts, nil
+}
\ No newline at end of file
@@ -26,8 +26,9 @@ func NewHotelRepository(db *sql.DB) HotelRepository {
 
 func (r *hotelRepository) CreateHotel(ctx context.Context, hotel *model.Hotel) error {
 	query := `
-		INSERT INTO hotel (hotel_id, destination_id, type_id, total_room, rating)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO hotel (hotel_id, destination_id, type_id, total_room, rating, time_zone, check_in_time, check_out_time,
+		                   day_use_start_time, day_use_end_time, day_use_slot_minutes, day_use_buffer_minutes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		hotel.HotelID,
@@ -35,6 +36,13 @@ func (r *hotelRepository) CreateHotel(ctx context.Context, hotel *model.Hotel) e
 		hotel.TypeID,
 		hotel.TotalRoom,
 		hotel.Rating,
+		hotel.TimeZone,
+		hotel.CheckInTime,
+		hotel.CheckOutTime,
+		hotel.DayUseStartTime,
+		hotel.DayUseEndTime,
+		hotel.DayUseSlotMinutes,
+		hotel.DayUseBufferMinutes,
 	)
 	return err
 }
@@ -42,7 +50,8 @@ func (r *hotelRepository) CreateHotel(ctx context.Context, hotel *model.Hotel) e
 func (r *hotelRepository) GetHotelByID(ctx context.Context, hotelID uuid.UUID) (*model.Hotel, error) {
 	var hotel model.Hotel
 	query := `
-		SELECT hotel_id, destination_id, type_id, total_room, rating
+		SELECT hotel_id, destination_id, type_id, total_room, rating, time_zone, check_in_time, check_out_time,
+		       day_use_start_time, day_use_end_time, day_use_slot_minutes, day_use_buffer_minutes
 		FROM hotel
 		WHERE hotel_id = $1
 	`
@@ -52,6 +61,13 @@ func (r *hotelRepository) GetHotelByID(ctx context.Context, hotelID uuid.UUID) (
 		&hotel.TypeID,
 		&hotel.TotalRoom,
 		&hotel.Rating,
+		&hotel.TimeZone,
+		&hotel.CheckInTime,
+		&hotel.CheckOutTime,
+		&hotel.DayUseStartTime,
+		&hotel.DayUseEndTime,
+		&hotel.DayUseSlotMinutes,
+		&hotel.DayUseBufferMinutes,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -64,7 +80,8 @@ func (r *hotelRepository) GetHotelByID(ctx context.Context, hotelID uuid.UUID) (
 
 func (r *hotelRepository) ListHotels(ctx context.Context, limit, offset int) ([]*model.Hotel, error) {
 	query := `
-		SELECT hotel_id, destination_id, type_id, total_room, rating
+		SELECT hotel_id, destination_id, type_id, total_room, rating, time_zone, check_in_time, check_out_time,
+		       day_use_start_time, day_use_end_time, day_use_slot_minutes, day_use_buffer_minutes
 		FROM hotel
 		ORDER BY hotel_id
 		LIMIT $1 OFFSET $2
@@ -84,6 +101,13 @@ func (r *hotelRepository) ListHotels(ctx context.Context, limit, offset int) ([]
 			&hotel.TypeID,
 			&hotel.TotalRoom,
 			&hotel.Rating,
+			&hotel.TimeZone,
+			&hotel.CheckInTime,
+			&hotel.CheckOutTime,
+			&hotel.DayUseStartTime,
+			&hotel.DayUseEndTime,
+			&hotel.DayUseSlotMinutes,
+			&hotel.DayUseBufferMinutes,
 		)
 		if err != nil {
 			return nil, err
@@ -96,7 +120,9 @@ func (r *hotelRepository) ListHotels(ctx context.Context, limit, offset int) ([]
 func (r *hotelRepository) UpdateHotel(ctx context.Context, hotel *model.Hotel) error {
 	query := `
 		UPDATE hotel
-		SET destination_id = $2, type_id = $3, total_room = $4, rating = $5
+		SET destination_id = $2, type_id = $3, total_room = $4, rating = $5,
+		    time_zone = $6, check_in_time = $7, check_out_time = $8,
+		    day_use_start_time = $9, day_use_end_time = $10, day_use_slot_minutes = $11, day_use_buffer_minutes = $12
 		WHERE hotel_id = $1
 	`
 	_, err := r.db.ExecContext(ctx, query,
@@ -105,6 +131,13 @@ func (r *hotelRepository) UpdateHotel(ctx context.Context, hotel *model.Hotel) e
 		hotel.TypeID,
 		hotel.TotalRoom,
 		hotel.Rating,
+		hotel.TimeZone,
+		hotel.CheckInTime,
+		hotel.CheckOutTime,
+		hotel.DayUseStartTime,
+		hotel.DayUseEndTime,
+		hotel.DayUseSlotMinutes,
+		hotel.DayUseBufferMinutes,
 	)
 	return err
 }
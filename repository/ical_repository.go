@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+type ICalRepository interface {
+	CreateICalFeed(ctx context.Context, feed *model.ICalFeed) error
+	GetICalFeedByID(ctx context.Context, feedID uuid.UUID) (*model.ICalFeed, error)
+	ListICalFeedsByRoom(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*model.ICalFeed, error)
+	UpdateICalFeed(ctx context.Context, feed *model.ICalFeed) error
+	DeleteICalFeed(ctx context.Context, feedID uuid.UUID) error
+}
+
+type icalRepository struct {
+	db *sql.DB
+}
+
+func NewICalRepository(db *sql.DB) ICalRepository {
+	return &icalRepository{db: db}
+}
+
+func (r *icalRepository) CreateICalFeed(ctx context.Context, feed *model.ICalFeed) error {
+	query := `
+		INSERT INTO ical_feed (feed_id, room_id, url, name, is_active, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		feed.FeedID,
+		feed.RoomID,
+		feed.URL,
+		feed.Name,
+		feed.IsActive.Bool,
+		feed.CreatedAt,
+		feed.CreatedBy,
+	)
+	return err
+}
+
+func (r *icalRepository) GetICalFeedByID(ctx context.Context, feedID uuid.UUID) (*model.ICalFeed, error) {
+	var feed model.ICalFeed
+	query := `
+		SELECT feed_id, room_id, url, name, is_active, last_attempt_at, last_synced_at, last_error,
+		       created_at, created_by, update_at, update_by
+		FROM ical_feed
+		WHERE feed_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, feedID).Scan(
+		&feed.FeedID,
+		&feed.RoomID,
+		&feed.URL,
+		&feed.Name,
+		&feed.IsActive,
+		&feed.LastAttemptAt,
+		&feed.LastSyncedAt,
+		&feed.LastError,
+		&feed.CreatedAt,
+		&feed.CreatedBy,
+		&feed.UpdateAt,
+		&feed.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *icalRepository) ListICalFeedsByRoom(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*model.ICalFeed, error) {
+	query := `
+		SELECT feed_id, room_id, url, name, is_active, last_attempt_at, last_synced_at, last_error,
+		       created_at, created_by, update_at, update_by
+		FROM ical_feed
+		WHERE room_id = $1
+		ORDER BY created_at, feed_id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, roomID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var feeds []*model.ICalFeed
+	for rows.Next() {
+		var feed model.ICalFeed
+		err := rows.Scan(
+			&feed.FeedID,
+			&feed.RoomID,
+			&feed.URL,
+			&feed.Name,
+			&feed.IsActive,
+			&feed.LastAttemptAt,
+			&feed.LastSyncedAt,
+			&feed.LastError,
+			&feed.CreatedAt,
+			&feed.CreatedBy,
+			&feed.UpdateAt,
+			&feed.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, &feed)
+	}
+	return feeds, nil
+}
+
+func (r *icalRepository) UpdateICalFeed(ctx context.Context, feed *model.ICalFeed) error {
+	query := `
+		UPDATE ical_feed
+		SET url = $2, name = $3, is_active = $4, update_at = $5, update_by = $6
+		WHERE feed_id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		feed.FeedID,
+		feed.URL,
+		feed.Name,
+		feed.IsActive.Bool,
+		feed.UpdateAt,
+		feed.UpdateBy,
+	)
+	return err
+}
+
+// DeleteICalFeed deletes a feed together with the blocks imported from it
+func (r *icalRepository) DeleteICalFeed(ctx context.Context, feedID uuid.UUID) error {
+	query := `DELETE FROM ical_feed WHERE feed_id = $1`
+	_, err := r.db.ExecContext(ctx, query, feedID)
+	return err
+}
\ No newline at end of file
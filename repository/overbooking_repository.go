@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+type OverbookingRepository interface {
+	CreateOverbookingAllowance(ctx context.Context, allowance *model.OverbookingAllowance) error
+	GetOverbookingAllowanceByID(ctx context.Context, allowanceID uuid.UUID) (*model.OverbookingAllowance, error)
+	ListOverbookingAllowancesByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.OverbookingAllowance, error)
+	UpdateOverbookingAllowance(ctx context.Context, allowance *model.OverbookingAllowance) error
+	DeleteOverbookingAllowance(ctx context.Context, allowanceID uuid.UUID) error
+	ListOverbookedNights(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.OverbookedNight, error)
+}
+
+type overbookingRepository struct {
+	db *sql.DB
+}
+
+func NewOverbookingRepository(db *sql.DB) OverbookingRepository {
+	return &overbookingRepository{db: db}
+}
+
+func (r *overbookingRepository) CreateOverbookingAllowance(ctx context.Context, allowance *model.OverbookingAllowance) error {
+	query := `
+		INSERT INTO overbooking_allowance (allowance_id, hotel_id, type_id, start_date, end_date, overbook_percent,
+		                                   created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		allowance.AllowanceID,
+		allowance.HotelID,
+		allowance.TypeID,
+		allowance.StartDate,
+		allowance.EndDate,
+		allowance.OverbookPercent,
+		allowance.CreatedAt,
+		allowance.CreatedBy,
+	)
+	return err
+}
+
+func (r *overbookingRepository) GetOverbookingAllowanceByID(ctx context.Context, allowanceID uuid.UUID) (*model.OverbookingAllowance, error) {
+	var allowance model.OverbookingAllowance
+	query := `
+		SELECT allowance_id, hotel_id, type_id, start_date, end_date, overbook_percent,
+		       created_at, created_by, update_at, update_by
+		FROM overbooking_allowance
+		WHERE allowance_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, allowanceID).Scan(
+		&allowance.AllowanceID,
+		&allowance.HotelID,
+		&allowance.TypeID,
+		&allowance.StartDate,
+		&allowance.EndDate,
+		&allowance.OverbookPercent,
+		&allowance.CreatedAt,
+		&allowance.CreatedBy,
+		&allowance.UpdateAt,
+		&allowance.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &allowance, nil
+}
+
+func (r *overbookingRepository) ListOverbookingAllowancesByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.OverbookingAllowance, error) {
+	query := `
+		SELECT allowance_id, hotel_id, type_id, start_date, end_date, overbook_percent,
+		       created_at, created_by, update_at, update_by
+		FROM overbooking_allowance
+		WHERE hotel_id = $1
+		ORDER BY start_date, type_id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var allowances []*model.OverbookingAllowance
+	for rows.Next() {
+		var allowance model.OverbookingAllowance
+		err := rows.Scan(
+			&allowance.AllowanceID,
+			&allowance.HotelID,
+			&allowance.TypeID,
+			&allowance.StartDate,
+			&allowance.EndDate,
+			&allowance.OverbookPercent,
+			&allowance.CreatedAt,
+			&allowance.CreatedBy,
+			&allowance.UpdateAt,
+			&allowance.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		allowances = append(allowances, &allowance)
+	}
+	return allowances, nil
+}
+
+func (r *overbookingRepository) UpdateOverbookingAllowance(ctx context.Context, allowance *model.OverbookingAllowance) error {
+	query := `
+		UPDATE overbooking_allowance
+		SET type_id = $2, start_date = $3, end_date = $4, overbook_percent = $5, update_at = $6, update_by = $7
+		WHERE allowance_id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		allowance.AllowanceID,
+		allowance.TypeID,
+		allowance.StartDate,
+		allowance.EndDate,
+		allowance.OverbookPercent,
+		allowance.UpdateAt,
+		allowance.UpdateBy,
+	)
+	return err
+}
+
+func (r *overbookingRepository) DeleteOverbookingAllowance(ctx context.Context, allowanceID uuid.UUID) error {
+	query := `DELETE FROM overbooking_allowance WHERE allowance_id = $1`
+	_, err := r.db.ExecContext(ctx, query, allowanceID)
+	return err
+}
+
+// ListOverbookedNights returns, per room type and night of [startDate, endDate), the nights on
+// which confirmed reservations outnumber the type's rooms that are not blocked for maintenance
+func (r *overbookingRepository) ListOverbookedNights(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.OverbookedNight, error) {
+	query := `
+		SELECT n.night, n.type_id, n.total_rooms, n.blocked, n.confirmed
+		FROM (
+			SELECT d.night, rt.type_id, rt.total_rooms,
+				(
+					SELECT COUNT(b.block_id)
+					FROM room_block b
+					JOIN room br ON br.room_id = b.room_id
+					WHERE br.hotel_id = $1
+					AND br.type_id = rt.type_id
+					AND b.start_date < d.night + INTERVAL '1 day'
+					AND b.end_date > d.night
+				) AS blocked,
+				(
+					SELECT COUNT(DISTINCT COALESCE(seg.room_id, res.reservation_id))
+					FROM reservation res
+					LEFT JOIN reservation_segment seg ON seg.reservation_id = res.reservation_id
+					LEFT JOIN room sr ON sr.room_id = seg.room_id
+					WHERE res.hotel_id = $1
+					AND COALESCE(sr.type_id, res.type_id) = rt.type_id
+					AND res.status = 'CONFIRMED'
+					AND COALESCE(seg.start_date, res.start_date) < d.night + INTERVAL '1 day'
+					AND COALESCE(seg.end_date, res.end_date) > d.night
+				) AS confirmed
+			FROM (
+				SELECT type_id, COUNT(*) AS total_rooms
+				FROM room
+				WHERE hotel_id = $1 AND type_id IS NOT NULL
+				GROUP BY type_id
+			) rt
+			CROSS JOIN generate_series($2::TIMESTAMPTZ, $3::TIMESTAMPTZ - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+		) n
+		WHERE n.confirmed > n.total_rooms - n.blocked
+		ORDER BY n.night, n.type_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nights []*model.OverbookedNight
+	for rows.Next() {
+		var night model.OverbookedNight
+		err := rows.Scan(
+			&night.Night,
+			&night.TypeID,
+			&night.PhysicalRooms,
+			&night.BlockedRooms,
+			&night.ConfirmedBookings,
+		)
+		if err != nil {
+			return nil, err
+		}
+		night.Overbooked = night.ConfirmedBookings - (night.PhysicalRooms - night.BlockedRooms)
+		nights = append(nights, &night)
+	}
+	return nights, nil
+}
\ No newline at end of file
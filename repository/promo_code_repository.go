@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+)
+
+type PromoCodeRepository interface {
+	CreatePromoCode(ctx context.Context, promo *model.PromoCode) error
+	GetPromoCodeByCode(ctx context.Context, code string) (*model.PromoCode, error)
+	ListPromoCodes(ctx context.Context, limit, offset int) ([]*model.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, promo *model.PromoCode) error
+	DeletePromoCode(ctx context.Context, code string) error
+}
+
+type promoCodeRepository struct {
+	db *sql.DB
+}
+
+func NewPromoCodeRepository(db *sql.DB) PromoCodeRepository {
+	return &promoCodeRepository{db: db}
+}
+
+func (r *promoCodeRepository) CreatePromoCode(ctx context.Context, promo *model.PromoCode) error {
+	query := `
+		INSERT INTO promo_code (code, description, discount_type, discount_value, valid_from, valid_to,
+		                        stay_from, stay_to, min_nights, hotel_id, type_id, max_redemptions,
+		                        max_redemptions_per_user, redemption_count, is_active, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		promo.Code,
+		promo.Description,
+		promo.DiscountType,
+		promo.DiscountValue,
+		promo.ValidFrom,
+		promo.ValidTo,
+		promo.StayFrom,
+		promo.StayTo,
+		promo.MinNights,
+		promo.HotelID,
+		promo.TypeID,
+		promo.MaxRedemptions,
+		promo.MaxRedemptionsPerUser,
+		promo.RedemptionCount,
+		promo.IsActive,
+		promo.CreatedAt,
+		promo.CreatedBy,
+	)
+	return err
+}
+
+func (r *promoCodeRepository) GetPromoCodeByCode(ctx context.Context, code string) (*model.PromoCode, error) {
+	var promo model.PromoCode
+	query := `
+		SELECT code, description, discount_type, discount_value, valid_from, valid_to, stay_from, stay_to,
+		       min_nights, hotel_id, type_id, max_redemptions, max_redemptions_per_user, redemption_count,
+		       is_active, created_at, created_by, update_at, update_by
+		FROM promo_code
+		WHERE code = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, code).Scan(
+		&promo.Code,
+		&promo.Description,
+		&promo.DiscountType,
+		&promo.DiscountValue,
+		&promo.ValidFrom,
+		&promo.ValidTo,
+		&promo.StayFrom,
+		&promo.StayTo,
+		&promo.MinNights,
+		&promo.HotelID,
+		&promo.TypeID,
+		&promo.MaxRedemptions,
+		&promo.MaxRedemptionsPerUser,
+		&promo.RedemptionCount,
+		&promo.IsActive,
+		&promo.CreatedAt,
+		&promo.CreatedBy,
+		&promo.UpdateAt,
+		&promo.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &promo, nil
+}
+
+func (r *promoCodeRepository) ListPromoCodes(ctx context.Context, limit, offset int) ([]*model.PromoCode, error) {
+	query := `
+		SELECT code, description, discount_type, discount_value, valid_from, valid_to, stay_from, stay_to,
+		       min_nights, hotel_id, type_id, max_redemptions, max_redemptions_per_user, redemption_count,
+		       is_active, created_at, created_by, update_at, update_by
+		FROM promo_code
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promos []*model.PromoCode
+	for rows.Next() {
+		var promo model.PromoCode
+		err := rows.Scan(
+			&promo.Code,
+			&promo.Description,
+			&promo.DiscountType,
+			&promo.DiscountValue,
+			&promo.ValidFrom,
+			&promo.ValidTo,
+			&promo.StayFrom,
+			&promo.StayTo,
+			&promo.MinNights,
+			&promo.HotelID,
+			&promo.TypeID,
+			&promo.MaxRedemptions,
+			&promo.MaxRedemptionsPerUser,
+			&promo.RedemptionCount,
+			&promo.IsActive,
+			&promo.CreatedAt,
+			&promo.CreatedBy,
+			&promo.UpdateAt,
+			&promo.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		promos = append(promos, &promo)
+	}
+	return promos, nil
+}
+
+func (r *promoCodeRepository) UpdatePromoCode(ctx context.Context, promo *model.PromoCode) error {
+	query := `
+		UPDATE promo_code
+		SET description = $2, discount_type = $3, discount_value = $4, valid_from = $5, valid_to = $6,
+		    stay_from = $7, stay_to = $8, min_nights = $9, hotel_id = $10, type_id = $11,
+		    max_redemptions = $12, max_redemptions_per_user = $13, is_active = $14,
+		    update_at = $15, update_by = $16
+		WHERE code = $1
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		promo.Code,
+		promo.Description,
+		promo.DiscountType,
+		promo.DiscountValue,
+		promo.ValidFrom,
+		promo.ValidTo,
+		promo.StayFrom,
+		promo.StayTo,
+		promo.MinNights,
+		promo.HotelID,
+		promo.TypeID,
+		promo.MaxRedemptions,
+		promo.MaxRedemptionsPerUser,
+		promo.IsActive,
+		promo.UpdateAt,
+		promo.UpdateBy,
+	)
+	return err
+}
+
+func (r *promoCodeRepository) DeletePromoCode(ctx context.Context, code string) error {
+	query := `DELETE FROM promo_code WHERE code = $1`
+	_, err := r.db.ExecContext(ctx, query, code)
+	return err
+}
\ No newline at end of file
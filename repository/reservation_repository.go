@@ -6,16 +6,24 @@ import (
 
 	"github.com/devsirose/hotel-reservation/model"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type ReservationRepository interface {
 	CreateReservation(ctx context.Context, reservation *model.Reservation) error
 	GetReservationByID(ctx context.Context, reservationID uuid.UUID) (*model.Reservation, error)
+	GetReservationByConfirmationCode(ctx context.Context, confirmationCode string) (*model.Reservation, error)
 	ListReservationsByUser(ctx context.Context, userID string, limit, offset int) ([]*model.Reservation, error)
 	ListReservationsByRoom(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*model.Reservation, error)
+	ListReservationsByGroup(ctx context.Context, groupID uuid.UUID) ([]*model.Reservation, error)
 	UpdateReservation(ctx context.Context, reservation *model.Reservation) error
 	DeleteReservation(ctx context.Context, reservationID uuid.UUID) error
 	UpdateReservationStatus(ctx context.Context, reservationID uuid.UUID, status string) error
+	ListReservationModifications(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationModification, error)
+	ListHotelArrivalsAndDepartures(ctx context.Context, hotelID uuid.UUID, dayStart, dayEnd string) ([]*model.Reservation, error)
+	ListReservationSegments(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationSegment, error)
+	ListReservationGuests(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationGuest, error)
+	ListReservationExtras(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationExtra, error)
 }
 
 type reservationRepository struct {
@@ -28,8 +36,9 @@ func NewReservationRepository(db *sql.DB) ReservationRepository {
 
 func (r *reservationRepository) CreateReservation(ctx context.Context, reservation *model.Reservation) error {
 	query := `
-		INSERT INTO reservation (reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO reservation (reservation_id, room_id, user_id, start_date, end_date, status, created_at, created_by,
+		                         total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		reservation.ReservationID,
@@ -40,6 +49,13 @@ func (r *reservationRepository) CreateReservation(ctx context.Context, reservati
 		reservation.Status,
 		reservation.CreatedAt,
 		reservation.CreatedBy,
+		reservation.TotalPrice,
+		reservation.PromoCode,
+		reservation.DiscountAmount,
+		reservation.HotelID,
+		reservation.TypeID,
+		reservation.GroupID,
+		reservation.StayType,
 	)
 	return err
 }
@@ -48,7 +64,10 @@ func (r *reservationRepository) GetReservationByID(ctx context.Context, reservat
 	var reservation model.Reservation
 	query := `
 		SELECT reservation_id, room_id, user_id, start_date, end_date, status, 
-		       created_at, created_by, update_at, update_by
+		       created_at, created_by, update_at, update_by,
+		       total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code,
+		       adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code,
+		       source, external_id
 		FROM reservation
 		WHERE reservation_id = $1
 	`
@@ -63,6 +82,74 @@ func (r *reservationRepository) GetReservationByID(ctx context.Context, reservat
 		&reservation.CreatedBy,
 		&reservation.UpdateAt,
 		&reservation.UpdateBy,
+		&reservation.TotalPrice,
+		&reservation.PromoCode,
+		&reservation.DiscountAmount,
+		&reservation.HotelID,
+		&reservation.TypeID,
+		&reservation.GroupID,
+		&reservation.StayType,
+		&reservation.HoldExpiresAt,
+		&reservation.BlockCode,
+		&reservation.Adults,
+		&reservation.Children,
+		pq.Array(&reservation.ChildAges),
+		&reservation.ExtraPersonCharge,
+		&reservation.ExtrasAmount,
+		&reservation.SpecialRequests,
+		&reservation.ConfirmationCode,
+		&reservation.Source,
+		&reservation.ExternalID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *reservationRepository) GetReservationByConfirmationCode(ctx context.Context, confirmationCode string) (*model.Reservation, error) {
+	var reservation model.Reservation
+	query := `
+		SELECT reservation_id, room_id, user_id, start_date, end_date, status, 
+		       created_at, created_by, update_at, update_by,
+		       total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code,
+		       adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code,
+		       source, external_id
+		FROM reservation
+		WHERE confirmation_code = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, confirmationCode).Scan(
+		&reservation.ReservationID,
+		&reservation.RoomID,
+		&reservation.UserID,
+		&reservation.StartDate,
+		&reservation.EndDate,
+		&reservation.Status,
+		&reservation.CreatedAt,
+		&reservation.CreatedBy,
+		&reservation.UpdateAt,
+		&reservation.UpdateBy,
+		&reservation.TotalPrice,
+		&reservation.PromoCode,
+		&reservation.DiscountAmount,
+		&reservation.HotelID,
+		&reservation.TypeID,
+		&reservation.GroupID,
+		&reservation.StayType,
+		&reservation.HoldExpiresAt,
+		&reservation.BlockCode,
+		&reservation.Adults,
+		&reservation.Children,
+		pq.Array(&reservation.ChildAges),
+		&reservation.ExtraPersonCharge,
+		&reservation.ExtrasAmount,
+		&reservation.SpecialRequests,
+		&reservation.ConfirmationCode,
+		&reservation.Source,
+		&reservation.ExternalID,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -76,7 +163,10 @@ func (r *reservationRepository) GetReservationByID(ctx context.Context, reservat
 func (r *reservationRepository) ListReservationsByUser(ctx context.Context, userID string, limit, offset int) ([]*model.Reservation, error) {
 	query := `
 		SELECT reservation_id, room_id, user_id, start_date, end_date, status,
-		       created_at, created_by, update_at, update_by
+		       created_at, created_by, update_at, update_by,
+		       total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code,
+		       adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code,
+		       source, external_id
 		FROM reservation
 		WHERE user_id = $1
 		ORDER BY start_date DESC
@@ -102,6 +192,24 @@ func (r *reservationRepository) ListReservationsByUser(ctx context.Context, user
 			&reservation.CreatedBy,
 			&reservation.UpdateAt,
 			&reservation.UpdateBy,
+			&reservation.TotalPrice,
+			&reservation.PromoCode,
+			&reservation.DiscountAmount,
+			&reservation.HotelID,
+			&reservation.TypeID,
+			&reservation.GroupID,
+			&reservation.StayType,
+			&reservation.HoldExpiresAt,
+			&reservation.BlockCode,
+			&reservation.Adults,
+			&reservation.Children,
+			pq.Array(&reservation.ChildAges),
+			&reservation.ExtraPersonCharge,
+			&reservation.ExtrasAmount,
+			&reservation.SpecialRequests,
+			&reservation.ConfirmationCode,
+			&reservation.Source,
+			&reservation.ExternalID,
 		)
 		if err != nil {
 			return nil, err
@@ -114,9 +222,16 @@ func (r *reservationRepository) ListReservationsByUser(ctx context.Context, user
 func (r *reservationRepository) ListReservationsByRoom(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*model.Reservation, error) {
 	query := `
 		SELECT reservation_id, room_id, user_id, start_date, end_date, status,
-		       created_at, created_by, update_at, update_by
-		FROM reservation
-		WHERE room_id = $1
+		       created_at, created_by, update_at, update_by,
+		       total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code,
+		       adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code,
+		       source, external_id
+		FROM reservation res
+		WHERE EXISTS (
+			SELECT 1 FROM reservation_segment seg
+			WHERE seg.reservation_id = res.reservation_id
+			AND seg.room_id = $1
+		)
 		ORDER BY start_date DESC
 		LIMIT $2 OFFSET $3
 	`
@@ -140,6 +255,146 @@ func (r *reservationRepository) ListReservationsByRoom(ctx context.Context, room
 			&reservation.CreatedBy,
 			&reservation.UpdateAt,
 			&reservation.UpdateBy,
+			&reservation.TotalPrice,
+			&reservation.PromoCode,
+			&reservation.DiscountAmount,
+			&reservation.HotelID,
+			&reservation.TypeID,
+			&reservation.GroupID,
+			&reservation.StayType,
+			&reservation.HoldExpiresAt,
+			&reservation.BlockCode,
+			&reservation.Adults,
+			&reservation.Children,
+			pq.Array(&reservation.ChildAges),
+			&reservation.ExtraPersonCharge,
+			&reservation.ExtrasAmount,
+			&reservation.SpecialRequests,
+			&reservation.ConfirmationCode,
+			&reservation.Source,
+			&reservation.ExternalID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, &reservation)
+	}
+	return reservations, nil
+}
+
+func (r *reservationRepository) ListReservationsByGroup(ctx context.Context, groupID uuid.UUID) ([]*model.Reservation, error) {
+	query := `
+		SELECT reservation_id, room_id, user_id, start_date, end_date, status,
+		       created_at, created_by, update_at, update_by,
+		       total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code,
+		       adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code,
+		       source, external_id
+		FROM reservation
+		WHERE group_id = $1
+		ORDER BY created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []*model.Reservation
+	for rows.Next() {
+		var reservation model.Reservation
+		err := rows.Scan(
+			&reservation.ReservationID,
+			&reservation.RoomID,
+			&reservation.UserID,
+			&reservation.StartDate,
+			&reservation.EndDate,
+			&reservation.Status,
+			&reservation.CreatedAt,
+			&reservation.CreatedBy,
+			&reservation.UpdateAt,
+			&reservation.UpdateBy,
+			&reservation.TotalPrice,
+			&reservation.PromoCode,
+			&reservation.DiscountAmount,
+			&reservation.HotelID,
+			&reservation.TypeID,
+			&reservation.GroupID,
+			&reservation.StayType,
+			&reservation.HoldExpiresAt,
+			&reservation.BlockCode,
+			&reservation.Adults,
+			&reservation.Children,
+			pq.Array(&reservation.ChildAges),
+			&reservation.ExtraPersonCharge,
+			&reservation.ExtrasAmount,
+			&reservation.SpecialRequests,
+			&reservation.ConfirmationCode,
+			&reservation.Source,
+			&reservation.ExternalID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, &reservation)
+	}
+	return reservations, nil
+}
+
+// ListHotelArrivalsAndDepartures returns the reservations of a hotel that start or end within [dayStart, dayEnd).
+func (r *reservationRepository) ListHotelArrivalsAndDepartures(ctx context.Context, hotelID uuid.UUID, dayStart, dayEnd string) ([]*model.Reservation, error) {
+	query := `
+		SELECT reservation_id, room_id, user_id, start_date, end_date, status,
+		       created_at, created_by, update_at, update_by,
+		       total_price, promo_code, discount_amount, hotel_id, type_id, group_id, stay_type, hold_expires_at, block_code,
+		       adults, children, child_ages, extra_person_charge, extras_amount, special_requests, confirmation_code,
+		       source, external_id
+		FROM reservation
+		WHERE hotel_id = $1
+		  AND status IN ('PENDING', 'CONFIRMED', 'COMPLETED')
+		  AND (
+		    (start_date >= $2::TIMESTAMPTZ AND start_date < $3::TIMESTAMPTZ) OR
+		    (end_date >= $2::TIMESTAMPTZ AND end_date < $3::TIMESTAMPTZ)
+		  )
+		ORDER BY start_date
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []*model.Reservation
+	for rows.Next() {
+		var reservation model.Reservation
+		err := rows.Scan(
+			&reservation.ReservationID,
+			&reservation.RoomID,
+			&reservation.UserID,
+			&reservation.StartDate,
+			&reservation.EndDate,
+			&reservation.Status,
+			&reservation.CreatedAt,
+			&reservation.CreatedBy,
+			&reservation.UpdateAt,
+			&reservation.UpdateBy,
+			&reservation.TotalPrice,
+			&reservation.PromoCode,
+			&reservation.DiscountAmount,
+			&reservation.HotelID,
+			&reservation.TypeID,
+			&reservation.GroupID,
+			&reservation.StayType,
+			&reservation.HoldExpiresAt,
+			&reservation.BlockCode,
+			&reservation.Adults,
+			&reservation.Children,
+			pq.Array(&reservation.ChildAges),
+			&reservation.ExtraPersonCharge,
+			&reservation.ExtrasAmount,
+			&reservation.SpecialRequests,
+			&reservation.ConfirmationCode,
+			&reservation.Source,
+			&reservation.ExternalID,
 		)
 		if err != nil {
 			return nil, err
@@ -183,4 +438,156 @@ func (r *reservationRepository) UpdateReservationStatus(ctx context.Context, res
 	`
 	_, err := r.db.ExecContext(ctx, query, reservationID, status)
 	return err
+}
+
+func (r *reservationRepository) ListReservationModifications(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationModification, error) {
+	query := `
+		SELECT modification_id, reservation_id, modified_by, modified_by_role, reason,
+		       old_room_id, new_room_id, old_user_id, new_user_id, old_start_date, new_start_date,
+		       old_end_date, new_end_date, old_total_price, new_total_price, created_at
+		FROM reservation_modification
+		WHERE reservation_id = $1
+		ORDER BY created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var modifications []*model.ReservationModification
+	for rows.Next() {
+		var modification model.ReservationModification
+		err := rows.Scan(
+			&modification.ModificationID,
+			&modification.ReservationID,
+			&modification.ModifiedBy,
+			&modification.ModifiedByRole,
+			&modification.Reason,
+			&modification.OldRoomID,
+			&modification.NewRoomID,
+			&modification.OldUserID,
+			&modification.NewUserID,
+			&modification.OldStartDate,
+			&modification.NewStartDate,
+			&modification.OldEndDate,
+			&modification.NewEndDate,
+			&modification.OldTotalPrice,
+			&modification.NewTotalPrice,
+			&modification.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		modifications = append(modifications, &modification)
+	}
+	return modifications, nil
+}
+
+func (r *reservationRepository) ListReservationSegments(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationSegment, error) {
+	query := `
+		SELECT segment_id, reservation_id, room_id, start_date, end_date, created_at, created_by
+		FROM reservation_segment
+		WHERE reservation_id = $1
+		ORDER BY start_date
+	`
+	rows, err := r.db.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var segments []*model.ReservationSegment
+	for rows.Next() {
+		var segment model.ReservationSegment
+		err := rows.Scan(
+			&segment.SegmentID,
+			&segment.ReservationID,
+			&segment.RoomID,
+			&segment.StartDate,
+			&segment.EndDate,
+			&segment.CreatedAt,
+			&segment.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, &segment)
+	}
+	return segments, nil
+}
+
+func (r *reservationRepository) ListReservationGuests(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationGuest, error) {
+	query := `
+		SELECT guest_id, reservation_id, first_name, last_name, age, is_child, is_primary, email, phone, created_at, locale
+		FROM reservation_guest
+		WHERE reservation_id = $1
+		ORDER BY is_primary DESC, created_at, guest_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var guests []*model.ReservationGuest
+	for rows.Next() {
+		var guest model.ReservationGuest
+		err := rows.Scan(
+			&guest.GuestID,
+			&guest.ReservationID,
+			&guest.FirstName,
+			&guest.LastName,
+			&guest.Age,
+			&guest.IsChild,
+			&guest.IsPrimary,
+			&guest.Email,
+			&guest.Phone,
+			&guest.CreatedAt,
+			&guest.Locale,
+		)
+		if err != nil {
+			return nil, err
+		}
+		guests = append(guests, &guest)
+	}
+	return guests, nil
+}
+
+func (r *reservationRepository) ListReservationExtras(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationExtra, error) {
+	query := `
+		SELECT re.reservation_extra_id, re.reservation_id, re.extra_id, e.code, e.name,
+		       re.pricing, re.quantity, re.unit_price, re.amount, re.created_at
+		FROM reservation_extra re
+		JOIN extra e ON e.extra_id = re.extra_id
+		WHERE re.reservation_id = $1
+		ORDER BY re.created_at, re.reservation_extra_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var extras []*model.ReservationExtra
+	for rows.Next() {
+		var extra model.ReservationExtra
+		err := rows.Scan(
+			&extra.ReservationExtraID,
+			&extra.ReservationID,
+			&extra.ExtraID,
+			&extra.Code,
+			&extra.Name,
+			&extra.Pricing,
+			&extra.Quantity,
+			&extra.UnitPrice,
+			&extra.Amount,
+			&extra.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		extras = append(extras, &extra)
+	}
+	return extras, nil
 }
\ No newline at end of file
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+type RoomBlockRepository interface {
+	GetRoomBlockByID(ctx context.Context, blockID uuid.UUID) (*model.RoomBlock, error)
+	ListRoomBlocksByRoom(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*model.RoomBlock, error)
+	DeleteRoomBlock(ctx context.Context, blockID uuid.UUID) error
+}
+
+type roomBlockRepository struct {
+	db *sql.DB
+}
+
+func NewRoomBlockRepository(db *sql.DB) RoomBlockRepository {
+	return &roomBlockRepository{db: db}
+}
+
+func (r *roomBlockRepository) GetRoomBlockByID(ctx context.Context, blockID uuid.UUID) (*model.RoomBlock, error) {
+	var block model.RoomBlock
+	query := `
+		SELECT block_id, room_id, block_type, reason, start_date, end_date,
+		       created_at, created_by, update_at, update_by, feed_id, external_uid
+		FROM room_block
+		WHERE block_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, blockID).Scan(
+		&block.BlockID,
+		&block.RoomID,
+		&block.BlockType,
+		&block.Reason,
+		&block.StartDate,
+		&block.EndDate,
+		&block.CreatedAt,
+		&block.CreatedBy,
+		&block.UpdateAt,
+		&block.UpdateBy,
+		&block.FeedID,
+		&block.ExternalUID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &block, nil
+}
+
+func (r *roomBlockRepository) ListRoomBlocksByRoom(ctx context.Context, roomID uuid.UUID, limit, offset int) ([]*model.RoomBlock, error) {
+	query := `
+		SELECT block_id, room_id, block_type, reason, start_date, end_date,
+		       created_at, created_by, update_at, update_by, feed_id, external_uid
+		FROM room_block
+		WHERE room_id = $1
+		ORDER BY start_date
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, roomID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocks []*model.RoomBlock
+	for rows.Next() {
+		var block model.RoomBlock
+		err := rows.Scan(
+			&block.BlockID,
+			&block.RoomID,
+			&block.BlockType,
+			&block.Reason,
+			&block.StartDate,
+			&block.EndDate,
+			&block.CreatedAt,
+			&block.CreatedBy,
+			&block.UpdateAt,
+			&block.UpdateBy,
+			&block.FeedID,
+			&block.ExternalUID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, &block)
+	}
+	return blocks, nil
+}
+
+func (r *roomBlockRepository) DeleteRoomBlock(ctx context.Context, blockID uuid.UUID) error {
+	query := `DELETE FROM room_block WHERE block_id = $1`
+	_, err := r.db.ExecContext(ctx, query, blockID)
+	return err
+}
\ No newline at end of file
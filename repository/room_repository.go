@@ -14,7 +14,13 @@ type RoomRepository interface {
 	ListRoomsByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.Room, error)
 	UpdateRoom(ctx context.Context, room *model.Room) error
 	DeleteRoom(ctx context.Context, roomID uuid.UUID) error
-	GetAvailableRooms(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.Room, error)
+	GetAvailableRooms(ctx context.Context, hotelID uuid.UUID, startDate, endDate string, nights int32) ([]*model.Room, error)
+	GetRoomTypeAvailability(ctx context.Context, hotelID uuid.UUID, startDate, endDate string, nights int32) ([]*model.RoomTypeAvailability, error)
+	GetHotelCalendar(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.CalendarCell, error)
+	UpdateHousekeepingStatus(ctx context.Context, roomID uuid.UUID, status string) error
+	ListHousekeepingRooms(ctx context.Context, hotelID uuid.UUID, dayStart, dayEnd string) ([]*model.HousekeepingRoom, error)
+	ListDayUseRooms(ctx context.Context, hotelID uuid.UUID) ([]*model.Room, error)
+	ListDayUseRoomOccupancy(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.RoomOccupancy, error)
 }
 
 type roomRepository struct {
@@ -27,8 +33,9 @@ func NewRoomRepository(db *sql.DB) RoomRepository {
 
 func (r *roomRepository) CreateRoom(ctx context.Context, room *model.Room) error {
 	query := `
-		INSERT INTO room (room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO room (room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, created_at, created_by,
+		                  day_use, day_use_price)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		room.RoomID,
@@ -42,6 +49,8 @@ func (r *roomRepository) CreateRoom(ctx context.Context, room *model.Room) error
 		room.Price,
 		room.CreatedAt,
 		room.CreatedBy,
+		room.DayUse,
+		room.DayUsePrice,
 	)
 	return err
 }
@@ -50,7 +59,7 @@ func (r *roomRepository) GetRoomByID(ctx context.Context, roomID uuid.UUID) (*mo
 	var room model.Room
 	query := `
 		SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price, 
-		       created_at, created_by, update_at, update_by
+		       created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price
 		FROM room
 		WHERE room_id = $1
 	`
@@ -68,6 +77,10 @@ func (r *roomRepository) GetRoomByID(ctx context.Context, roomID uuid.UUID) (*mo
 		&room.CreatedBy,
 		&room.UpdateAt,
 		&room.UpdateBy,
+		&room.HousekeepingStatus,
+		&room.HousekeepingUpdatedAt,
+		&room.DayUse,
+		&room.DayUsePrice,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -81,7 +94,7 @@ func (r *roomRepository) GetRoomByID(ctx context.Context, roomID uuid.UUID) (*mo
 func (r *roomRepository) ListRoomsByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.Room, error) {
 	query := `
 		SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price,
-		       created_at, created_by, update_at, update_by
+		       created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price
 		FROM room
 		WHERE hotel_id = $1
 		ORDER BY room_id
@@ -110,6 +123,10 @@ func (r *roomRepository) ListRoomsByHotel(ctx context.Context, hotelID uuid.UUID
 			&room.CreatedBy,
 			&room.UpdateAt,
 			&room.UpdateBy,
+			&room.HousekeepingStatus,
+			&room.HousekeepingUpdatedAt,
+			&room.DayUse,
+			&room.DayUsePrice,
 		)
 		if err != nil {
 			return nil, err
@@ -123,7 +140,8 @@ func (r *roomRepository) UpdateRoom(ctx context.Context, room *model.Room) error
 	query := `
 		UPDATE room
 		SET room_name = $2, floor = $3, type_id = $4, max_capacity = $5, 
-		    rate = $6, description = $7, price = $8, update_at = $9, update_by = $10
+		    rate = $6, description = $7, price = $8, update_at = $9, update_by = $10,
+		    day_use = $11, day_use_price = $12
 		WHERE room_id = $1
 	`
 	_, err := r.db.ExecContext(ctx, query,
@@ -137,6 +155,8 @@ func (r *roomRepository) UpdateRoom(ctx context.Context, room *model.Room) error
 		room.Price,
 		room.UpdateAt,
 		room.UpdateBy,
+		room.DayUse,
+		room.DayUsePrice,
 	)
 	return err
 }
@@ -147,30 +167,355 @@ func (r *roomRepository) DeleteRoom(ctx context.Context, roomID uuid.UUID) error
 	return err
 }
 
-func (r *roomRepository) GetAvailableRooms(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.Room, error) {
+func (r *roomRepository) GetAvailableRooms(ctx context.Context, hotelID uuid.UUID, startDate, endDate string, nights int32) ([]*model.Room, error) {
 	query := `
 		SELECT r.room_id, r.room_name, r.hotel_id, r.floor, r.type_id, r.max_capacity, 
-		       r.rate, r.description, r.price, r.created_at, r.created_by, r.update_at, r.update_by
+		       r.rate, r.description, r.price, r.created_at, r.created_by, r.update_at, r.update_by,
+		       r.housekeeping_status, r.housekeeping_updated_at, r.day_use, r.day_use_price
 		FROM room r
+		JOIN hotel h ON h.hotel_id = r.hotel_id
 		WHERE r.hotel_id = $1
-		AND r.room_id NOT IN (
-			SELECT res.room_id
-			FROM reservation res
-			WHERE res.status != 'CANCELLED'
+		AND NOT EXISTS (
+			SELECT 1
+			FROM reservation_segment seg
+			JOIN reservation res ON res.reservation_id = seg.reservation_id
+			WHERE seg.room_id = r.room_id
+			AND res.status != 'CANCELLED'
+			AND seg.start_date < $3::TIMESTAMPTZ
+			AND seg.end_date + CASE WHEN res.stay_type = 'DAY_USE'
+				THEN make_interval(mins => COALESCE(h.day_use_buffer_minutes, 0))
+				ELSE INTERVAL '0 minutes' END > $2::TIMESTAMPTZ
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM stay_restriction sr
+			WHERE sr.hotel_id = r.hotel_id
+			AND (sr.type_id IS NULL OR sr.type_id = r.type_id)
 			AND (
-				(res.start_date <= $2::TIMESTAMPTZ AND res.end_date >= $2::TIMESTAMPTZ) OR
-				(res.start_date <= $3::TIMESTAMPTZ AND res.end_date >= $3::TIMESTAMPTZ) OR
-				(res.start_date >= $2::TIMESTAMPTZ AND res.end_date <= $3::TIMESTAMPTZ)
+				(
+					sr.start_date <= $2::TIMESTAMPTZ AND sr.end_date > $2::TIMESTAMPTZ AND (
+						sr.closed_to_arrival OR
+						sr.min_nights > $4::INT OR
+						sr.max_nights < $4::INT
+					)
+				) OR
+				(sr.closed_to_departure AND sr.start_date <= $3::TIMESTAMPTZ AND sr.end_date > $3::TIMESTAMPTZ)
+			)
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM room_block b
+			WHERE b.room_id = r.room_id
+			AND b.start_date < $3::TIMESTAMPTZ
+			AND b.end_date > $2::TIMESTAMPTZ
+		)
+		AND (
+			r.type_id IS NULL OR
+			(SELECT COUNT(*) FROM room r2 WHERE r2.hotel_id = r.hotel_id AND r2.type_id = r.type_id) > (
+				SELECT COALESCE(MAX(n.booked), 0)
+				FROM (
+					SELECT (
+						SELECT COUNT(DISTINCT COALESCE(seg2.room_id, res2.reservation_id))
+						FROM reservation res2
+						LEFT JOIN reservation_segment seg2 ON seg2.reservation_id = res2.reservation_id
+						LEFT JOIN room sr2 ON sr2.room_id = seg2.room_id
+						WHERE res2.hotel_id = r.hotel_id
+						AND COALESCE(sr2.type_id, res2.type_id) = r.type_id
+						AND res2.status != 'CANCELLED'
+						AND COALESCE(seg2.start_date, res2.start_date) < LEAST(d.night + INTERVAL '1 day', $3::TIMESTAMPTZ)
+						AND COALESCE(seg2.end_date, res2.end_date) > d.night
+					) + (
+						SELECT COUNT(b2.block_id)
+						FROM room_block b2
+						JOIN room r3 ON r3.room_id = b2.room_id
+						WHERE r3.hotel_id = r.hotel_id
+						AND r3.type_id = r.type_id
+						AND b2.start_date < LEAST(d.night + INTERVAL '1 day', $3::TIMESTAMPTZ)
+						AND b2.end_date > d.night
+					) + (
+						SELECT COALESCE(SUM(GREATEST(al.quantity - (
+							SELECT COUNT(*)
+							FROM reservation ar
+							WHERE ar.block_code = al.block_code
+							AND ar.status != 'CANCELLED'
+							AND ar.start_date < LEAST(d.night + INTERVAL '1 day', $3::TIMESTAMPTZ)
+							AND ar.end_date > d.night
+						), 0)), 0)
+						FROM allotment al
+						WHERE al.hotel_id = r.hotel_id
+						AND al.type_id = r.type_id
+						AND al.status = 'ACTIVE'
+						AND al.start_date < LEAST(d.night + INTERVAL '1 day', $3::TIMESTAMPTZ)
+						AND al.end_date > d.night
+					) AS booked
+					FROM generate_series($2::TIMESTAMPTZ, $3::TIMESTAMPTZ - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+				) n
 			)
 		)
 		ORDER BY r.room_id
 	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, startDate, endDate, nights)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []*model.Room
+	for rows.Next() {
+		var room model.Room
+		err := rows.Scan(
+			&room.RoomID,
+			&room.RoomName,
+			&room.HotelID,
+			&room.Floor,
+			&room.TypeID,
+			&room.MaxCapacity,
+			&room.Rate,
+			&room.Description,
+			&room.Price,
+			&room.CreatedAt,
+			&room.CreatedBy,
+			&room.UpdateAt,
+			&room.UpdateBy,
+			&room.HousekeepingStatus,
+			&room.HousekeepingUpdatedAt,
+			&room.DayUse,
+			&room.DayUsePrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, &room)
+	}
+	return rooms, nil
+}
+
+func (r *roomRepository) GetRoomTypeAvailability(ctx context.Context, hotelID uuid.UUID, startDate, endDate string, nights int32) ([]*model.RoomTypeAvailability, error) {
+	query := `
+		SELECT rt.type_id, rt.total_rooms, COALESCE(MAX(n.booked), 0) AS booked,
+		       COALESCE(MIN(n.allowance), 0) AS allowance,
+		       COALESCE(MIN(rt.total_rooms + n.allowance - n.booked), 0) AS available, rt.min_price
+		FROM (
+			SELECT type_id, COUNT(*) AS total_rooms, COALESCE(MIN(price), 0) AS min_price
+			FROM room
+			WHERE hotel_id = $1 AND type_id IS NOT NULL
+			GROUP BY type_id
+		) rt
+		LEFT JOIN LATERAL (
+			SELECT (
+				SELECT COUNT(DISTINCT COALESCE(seg.room_id, res.reservation_id))
+				FROM reservation res
+				LEFT JOIN reservation_segment seg ON seg.reservation_id = res.reservation_id
+				LEFT JOIN room sr ON sr.room_id = seg.room_id
+				WHERE res.hotel_id = $1
+				AND COALESCE(sr.type_id, res.type_id) = rt.type_id
+				AND res.status != 'CANCELLED'
+				AND COALESCE(seg.start_date, res.start_date) < LEAST(d.night + INTERVAL '1 day', $3::TIMESTAMPTZ)
+				AND COALESCE(seg.end_date, res.end_date) > d.night
+			) + (
+				SELECT COUNT(b.block_id)
+				FROM room_block b
+				JOIN room br ON br.room_id = b.room_id
+				WHERE br.hotel_id = $1
+				AND br.type_id = rt.type_id
+				AND b.start_date < LEAST(d.night + INTERVAL '1 day', $3::TIMESTAMPTZ)
+				AND b.end_date > d.night
+			) + (
+				SELECT COALESCE(SUM(GREATEST(al.quantity - (
+					SELECT COUNT(*)
+					FROM reservation ar
+					WHERE ar.block_code = al.block_code
+					AND ar.status != 'CANCELLED'
+					AND ar.start_date < LEAST(d.night + INTERVAL '1 day', $3::TIMESTAMPTZ)
+					AND ar.end_date > d.night
+				), 0)), 0)
+				FROM allotment al
+				WHERE al.hotel_id = $1
+				AND al.type_id = rt.type_id
+				AND al.status = 'ACTIVE'
+				AND al.start_date < LEAST(d.night + INTERVAL '1 day', $3::TIMESTAMPTZ)
+				AND al.end_date > d.night
+			) AS booked, FLOOR(rt.total_rooms * COALESCE((
+				SELECT MAX(a.overbook_percent)
+				FROM overbooking_allowance a
+				WHERE a.hotel_id = $1
+				AND a.type_id = rt.type_id
+				AND a.start_date <= d.night
+				AND a.end_date > d.night
+			), 0) / 100.0)::INT AS allowance
+			FROM generate_series($2::TIMESTAMPTZ, $3::TIMESTAMPTZ - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+		) n ON TRUE
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM stay_restriction sr
+			WHERE sr.hotel_id = $1
+			AND (sr.type_id IS NULL OR sr.type_id = rt.type_id)
+			AND (
+				(
+					sr.start_date <= $2::TIMESTAMPTZ AND sr.end_date > $2::TIMESTAMPTZ AND (
+						sr.closed_to_arrival OR
+						sr.min_nights > $4::INT OR
+						sr.max_nights < $4::INT
+					)
+				) OR
+				(sr.closed_to_departure AND sr.start_date <= $3::TIMESTAMPTZ AND sr.end_date > $3::TIMESTAMPTZ)
+			)
+		)
+		GROUP BY rt.type_id, rt.total_rooms, rt.min_price
+		ORDER BY rt.type_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, startDate, endDate, nights)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var availability []*model.RoomTypeAvailability
+	for rows.Next() {
+		var item model.RoomTypeAvailability
+		err := rows.Scan(
+			&item.TypeID,
+			&item.TotalRooms,
+			&item.Booked,
+			&item.OverbookAllowance,
+			&item.Available,
+			&item.MinPrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if item.Available < 0 {
+			item.Available = 0
+		}
+		availability = append(availability, &item)
+	}
+	return availability, nil
+}
+
+// GetHotelCalendar returns one row per room and night of [startDate, endDate), together with the
+// reservation or block occupying the room that night. Confirmed stays win over pending ones.
+func (r *roomRepository) GetHotelCalendar(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.CalendarCell, error) {
+	query := `
+		SELECT r.room_id, r.room_name, r.type_id, d.night, occ.reservation_id, occ.status, blk.block_id
+		FROM room r
+		CROSS JOIN generate_series($2::TIMESTAMPTZ, $3::TIMESTAMPTZ - INTERVAL '1 second', INTERVAL '1 day') AS d(night)
+		LEFT JOIN LATERAL (
+			SELECT res.reservation_id, res.status
+			FROM reservation_segment seg
+			JOIN reservation res ON res.reservation_id = seg.reservation_id
+			WHERE seg.room_id = r.room_id
+			  AND res.status != 'CANCELLED'
+			  AND seg.start_date < d.night + INTERVAL '1 day'
+			  AND seg.end_date > d.night
+			ORDER BY res.status = 'PENDING', seg.start_date
+			LIMIT 1
+		) occ ON true
+		LEFT JOIN LATERAL (
+			SELECT b.block_id
+			FROM room_block b
+			WHERE b.room_id = r.room_id
+			  AND b.start_date < d.night + INTERVAL '1 day'
+			  AND b.end_date > d.night
+			ORDER BY b.start_date
+			LIMIT 1
+		) blk ON true
+		WHERE r.hotel_id = $1
+		ORDER BY r.room_name, r.room_id, d.night
+	`
 	rows, err := r.db.QueryContext(ctx, query, hotelID, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	var cells []*model.CalendarCell
+	for rows.Next() {
+		var cell model.CalendarCell
+		err := rows.Scan(
+			&cell.RoomID,
+			&cell.RoomName,
+			&cell.TypeID,
+			&cell.Night,
+			&cell.ReservationID,
+			&cell.Status,
+			&cell.BlockID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		cells = append(cells, &cell)
+	}
+	return cells, nil
+}
+
+func (r *roomRepository) UpdateHousekeepingStatus(ctx context.Context, roomID uuid.UUID, status string) error {
+	query := `
+		UPDATE room
+		SET housekeeping_status = $2, housekeeping_updated_at = NOW()
+		WHERE room_id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query, roomID, status)
+	return err
+}
+
+// ListHousekeepingRooms returns every room of a hotel with its housekeeping status and whether a
+// reservation occupies it on the night starting at dayStart.
+func (r *roomRepository) ListHousekeepingRooms(ctx context.Context, hotelID uuid.UUID, dayStart, dayEnd string) ([]*model.HousekeepingRoom, error) {
+	query := `
+		SELECT r.room_id, r.room_name, r.floor, r.type_id,
+		       COALESCE(r.housekeeping_status, 'CLEAN'), r.housekeeping_updated_at,
+		       EXISTS (
+		           SELECT 1 FROM reservation_segment seg
+		           JOIN reservation res ON res.reservation_id = seg.reservation_id
+		           WHERE seg.room_id = r.room_id
+		             AND res.status IN ('PENDING', 'CONFIRMED')
+		             AND seg.start_date < $3::TIMESTAMPTZ
+		             AND seg.end_date > $2::TIMESTAMPTZ
+		       ) AS occupied_tonight
+		FROM room r
+		WHERE r.hotel_id = $1
+		ORDER BY r.floor, r.room_name
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []*model.HousekeepingRoom
+	for rows.Next() {
+		var room model.HousekeepingRoom
+		err := rows.Scan(
+			&room.RoomID,
+			&room.RoomName,
+			&room.Floor,
+			&room.TypeID,
+			&room.Status,
+			&room.UpdatedAt,
+			&room.OccupiedTonight,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, &room)
+	}
+	return rooms, nil
+}
+
+func (r *roomRepository) ListDayUseRooms(ctx context.Context, hotelID uuid.UUID) ([]*model.Room, error) {
+	query := `
+		SELECT room_id, room_name, hotel_id, floor, type_id, max_capacity, rate, description, price,
+		       created_at, created_by, update_at, update_by, housekeeping_status, housekeeping_updated_at, day_use, day_use_price
+		FROM room
+		WHERE hotel_id = $1 AND day_use
+		ORDER BY room_name, room_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
 	var rooms []*model.Room
 	for rows.Next() {
 		var room model.Room
@@ -188,6 +533,10 @@ func (r *roomRepository) GetAvailableRooms(ctx context.Context, hotelID uuid.UUI
 			&room.CreatedBy,
 			&room.UpdateAt,
 			&room.UpdateBy,
+			&room.HousekeepingStatus,
+			&room.HousekeepingUpdatedAt,
+			&room.DayUse,
+			&room.DayUsePrice,
 		)
 		if err != nil {
 			return nil, err
@@ -195,4 +544,50 @@ func (r *roomRepository) GetAvailableRooms(ctx context.Context, hotelID uuid.UUI
 		rooms = append(rooms, &room)
 	}
 	return rooms, nil
+}
+
+// ListDayUseRoomOccupancy returns the reservations and blocks holding the day-use rooms of a hotel
+// at any time within [startDate, endDate).
+func (r *roomRepository) ListDayUseRoomOccupancy(ctx context.Context, hotelID uuid.UUID, startDate, endDate string) ([]*model.RoomOccupancy, error) {
+	query := `
+		SELECT seg.room_id, seg.start_date, seg.end_date, COALESCE(res.stay_type, 'OVERNIGHT')
+		FROM reservation_segment seg
+		JOIN reservation res ON res.reservation_id = seg.reservation_id
+		JOIN room r ON r.room_id = seg.room_id
+		WHERE r.hotel_id = $1
+		  AND r.day_use
+		  AND res.status != 'CANCELLED'
+		  AND seg.start_date < $3::TIMESTAMPTZ
+		  AND seg.end_date > $2::TIMESTAMPTZ
+		UNION ALL
+		SELECT b.room_id, b.start_date, b.end_date, 'BLOCK'
+		FROM room_block b
+		JOIN room r ON r.room_id = b.room_id
+		WHERE r.hotel_id = $1
+		  AND r.day_use
+		  AND b.start_date < $3::TIMESTAMPTZ
+		  AND b.end_date > $2::TIMESTAMPTZ
+		ORDER BY 1, 2
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var occupancy []*model.RoomOccupancy
+	for rows.Next() {
+		var item model.RoomOccupancy
+		err := rows.Scan(
+			&item.RoomID,
+			&item.StartDate,
+			&item.EndDate,
+			&item.StayType,
+		)
+		if err != nil {
+			return nil, err
+		}
+		occupancy = append(occupancy, &item)
+	}
+	return occupancy, nil
 }
\ No newline at end of file
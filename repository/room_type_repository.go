@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+)
+
+type RoomTypeRepository interface {
+	CreateRoomType(ctx context.Context, roomType *model.RoomType) error
+	GetRoomTypeByCode(ctx context.Context, typeCode string) (*model.RoomType, error)
+	ListRoomTypes(ctx context.Context, limit, offset int) ([]*model.RoomType, error)
+	UpdateRoomType(ctx context.Context, roomType *model.RoomType) error
+	DeleteRoomType(ctx context.Context, typeCode string) error
+	CountRoomTypeUsage(ctx context.Context, typeCode string) (int64, error)
+}
+
+type roomTypeRepository struct {
+	db *sql.DB
+}
+
+func NewRoomTypeRepository(db *sql.DB) RoomTypeRepository {
+	return &roomTypeRepository{db: db}
+}
+
+func (r *roomTypeRepository) CreateRoomType(ctx context.Context, roomType *model.RoomType) error {
+	query := `
+		INSERT INTO type (type_code, name, description, bed_configuration, size_sqm, default_capacity,
+		                  base_occupancy, extra_adult_price, extra_child_price, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		roomType.TypeCode,
+		roomType.Name,
+		roomType.Description,
+		roomType.BedConfiguration,
+		roomType.SizeSqm,
+		roomType.DefaultCapacity,
+		roomType.BaseOccupancy,
+		roomType.ExtraAdultPrice,
+		roomType.ExtraChildPrice,
+		roomType.CreatedAt,
+		roomType.CreatedBy,
+	)
+	return err
+}
+
+func (r *roomTypeRepository) GetRoomTypeByCode(ctx context.Context, typeCode string) (*model.RoomType, error) {
+	var roomType model.RoomType
+	query := `
+		SELECT type_code, name, description, bed_configuration, size_sqm, default_capacity, base_occupancy,
+		       extra_adult_price, extra_child_price, created_at, created_by, update_at, update_by
+		FROM type
+		WHERE type_code = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, typeCode).Scan(
+		&roomType.TypeCode,
+		&roomType.Name,
+		&roomType.Description,
+		&roomType.BedConfiguration,
+		&roomType.SizeSqm,
+		&roomType.DefaultCapacity,
+		&roomType.BaseOccupancy,
+		&roomType.ExtraAdultPrice,
+		&roomType.ExtraChildPrice,
+		&roomType.CreatedAt,
+		&roomType.CreatedBy,
+		&roomType.UpdateAt,
+		&roomType.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &roomType, nil
+}
+
+func (r *roomTypeRepository) ListRoomTypes(ctx context.Context, limit, offset int) ([]*model.RoomType, error) {
+	query := `
+		SELECT type_code, name, description, bed_configuration, size_sqm, default_capacity, base_occupancy,
+		       extra_adult_price, extra_child_price, created_at, created_by, update_at, update_by
+		FROM type
+		ORDER BY type_code
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roomTypes []*model.RoomType
+	for rows.Next() {
+		var roomType model.RoomType
+		err := rows.Scan(
+			&roomType.TypeCode,
+			&roomType.Name,
+			&roomType.Description,
+			&roomType.BedConfiguration,
+			&roomType.SizeSqm,
+			&roomType.DefaultCapacity,
+			&roomType.BaseOccupancy,
+			&roomType.ExtraAdultPrice,
+			&roomType.ExtraChildPrice,
+			&roomType.CreatedAt,
+			&roomType.CreatedBy,
+			&roomType.UpdateAt,
+			&roomType.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		roomTypes = append(roomTypes, &roomType)
+	}
+	return roomTypes, nil
+}
+
+func (r *roomTypeRepository) UpdateRoomType(ctx context.Context, roomType *model.RoomType) error {
+	query := `
+		UPDATE type
+		SET name = $2, description = $3, bed_configuration = $4, size_sqm = $5,
+		    default_capacity = $6, base_occupancy = $7, extra_adult_price = $8, extra_child_price = $9,
+		    update_at = $10, update_by = $11
+		WHERE type_code = $1
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		roomType.TypeCode,
+		roomType.Name,
+		roomType.Description,
+		roomType.BedConfiguration,
+		roomType.SizeSqm,
+		roomType.DefaultCapacity,
+		roomType.BaseOccupancy,
+		roomType.ExtraAdultPrice,
+		roomType.ExtraChildPrice,
+		roomType.UpdateAt,
+		roomType.UpdateBy,
+	)
+	return err
+}
+
+func (r *roomTypeRepository) DeleteRoomType(ctx context.Context, typeCode string) error {
+	query := `DELETE FROM type WHERE type_code = $1`
+	_, err := r.db.ExecContext(ctx, query, typeCode)
+	return err
+}
+
+func (r *roomTypeRepository) CountRoomTypeUsage(ctx context.Context, typeCode string) (int64, error) {
+	var count int64
+	query := `
+		SELECT (SELECT COUNT(*) FROM room WHERE type_id = $1)
+		     + (SELECT COUNT(*) FROM hotel WHERE type_id = $1)
+		     + (SELECT COUNT(*) FROM reservation WHERE type_id = $1)
+		     + (SELECT COUNT(*) FROM promo_code WHERE type_id = $1)
+		     + (SELECT COUNT(*) FROM stay_restriction WHERE type_id = $1)
+		     + (SELECT COUNT(*) FROM waitlist_entry WHERE type_id = $1)
+		     + (SELECT COUNT(*) FROM overbooking_allowance WHERE type_id = $1)
+		     + (SELECT COUNT(*) FROM allotment WHERE type_id = $1)
+		     + (SELECT COUNT(*) FROM channel_mapping WHERE type_id = $1)
+	`
+	err := r.db.QueryRowContext(ctx, query, typeCode).Scan(&count)
+	return count, err
+}
\ No newline at end of file
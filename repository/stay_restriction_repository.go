@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+type StayRestrictionRepository interface {
+	CreateStayRestriction(ctx context.Context, restriction *model.StayRestriction) error
+	GetStayRestrictionByID(ctx context.Context, restrictionID uuid.UUID) (*model.StayRestriction, error)
+	ListStayRestrictionsByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.StayRestriction, error)
+	UpdateStayRestriction(ctx context.Context, restriction *model.StayRestriction) error
+	DeleteStayRestriction(ctx context.Context, restrictionID uuid.UUID) error
+}
+
+type stayRestrictionRepository struct {
+	db *sql.DB
+}
+
+func NewStayRestrictionRepository(db *sql.DB) StayRestrictionRepository {
+	return &stayRestrictionRepository{db: db}
+}
+
+func (r *stayRestrictionRepository) CreateStayRestriction(ctx context.Context, restriction *model.StayRestriction) error {
+	query := `
+		INSERT INTO stay_restriction (restriction_id, hotel_id, type_id, start_date, end_date, min_nights, max_nights,
+		                              closed_to_arrival, closed_to_departure, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		restriction.RestrictionID,
+		restriction.HotelID,
+		restriction.TypeID,
+		restriction.StartDate,
+		restriction.EndDate,
+		restriction.MinNights,
+		restriction.MaxNights,
+		restriction.ClosedToArrival,
+		restriction.ClosedToDeparture,
+		restriction.CreatedAt,
+		restriction.CreatedBy,
+	)
+	return err
+}
+
+func (r *stayRestrictionRepository) GetStayRestrictionByID(ctx context.Context, restrictionID uuid.UUID) (*model.StayRestriction, error) {
+	var restriction model.StayRestriction
+	query := `
+		SELECT restriction_id, hotel_id, type_id, start_date, end_date, min_nights, max_nights,
+		       closed_to_arrival, closed_to_departure, created_at, created_by, update_at, update_by
+		FROM stay_restriction
+		WHERE restriction_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, restrictionID).Scan(
+		&restriction.RestrictionID,
+		&restriction.HotelID,
+		&restriction.TypeID,
+		&restriction.StartDate,
+		&restriction.EndDate,
+		&restriction.MinNights,
+		&restriction.MaxNights,
+		&restriction.ClosedToArrival,
+		&restriction.ClosedToDeparture,
+		&restriction.CreatedAt,
+		&restriction.CreatedBy,
+		&restriction.UpdateAt,
+		&restriction.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &restriction, nil
+}
+
+func (r *stayRestrictionRepository) ListStayRestrictionsByHotel(ctx context.Context, hotelID uuid.UUID, limit, offset int) ([]*model.StayRestriction, error) {
+	query := `
+		SELECT restriction_id, hotel_id, type_id, start_date, end_date, min_nights, max_nights,
+		       closed_to_arrival, closed_to_departure, created_at, created_by, update_at, update_by
+		FROM stay_restriction
+		WHERE hotel_id = $1
+		ORDER BY start_date
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restrictions []*model.StayRestriction
+	for rows.Next() {
+		var restriction model.StayRestriction
+		err := rows.Scan(
+			&restriction.RestrictionID,
+			&restriction.HotelID,
+			&restriction.TypeID,
+			&restriction.StartDate,
+			&restriction.EndDate,
+			&restriction.MinNights,
+			&restriction.MaxNights,
+			&restriction.ClosedToArrival,
+			&restriction.ClosedToDeparture,
+			&restriction.CreatedAt,
+			&restriction.CreatedBy,
+			&restriction.UpdateAt,
+			&restriction.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		restrictions = append(restrictions, &restriction)
+	}
+	return restrictions, nil
+}
+
+func (r *stayRestrictionRepository) UpdateStayRestriction(ctx context.Context, restriction *model.StayRestriction) error {
+	query := `
+		UPDATE stay_restriction
+		SET type_id = $2, start_date = $3, end_date = $4, min_nights = $5, max_nights = $6,
+		    closed_to_arrival = $7, closed_to_departure = $8, update_at = $9, update_by = $10
+		WHERE restriction_id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		restriction.RestrictionID,
+		restriction.TypeID,
+		restriction.StartDate,
+		restriction.EndDate,
+		restriction.MinNights,
+		restriction.MaxNights,
+		restriction.ClosedToArrival,
+		restriction.ClosedToDeparture,
+		restriction.UpdateAt,
+		restriction.UpdateBy,
+	)
+	return err
+}
+
+func (r *stayRestrictionRepository) DeleteStayRestriction(ctx context.Context, restrictionID uuid.UUID) error {
+	query := `DELETE FROM stay_restriction WHERE restriction_id = $1`
+	_, err := r.db.ExecContext(ctx, query, restrictionID)
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+type WaitlistRepository interface {
+	GetWaitlistEntryByID(ctx context.Context, entryID uuid.UUID) (*model.WaitlistEntry, error)
+	ListWaitlistEntriesByHotel(ctx context.Context, hotelID uuid.UUID, status string, limit, offset int) ([]*model.WaitlistEntry, error)
+	ListWaitlistEntriesByUser(ctx context.Context, userID string, limit, offset int) ([]*model.WaitlistEntry, error)
+}
+
+type waitlistRepository struct {
+	db *sql.DB
+}
+
+func NewWaitlistRepository(db *sql.DB) WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+func (r *waitlistRepository) GetWaitlistEntryByID(ctx context.Context, entryID uuid.UUID) (*model.WaitlistEntry, error) {
+	var entry model.WaitlistEntry
+	query := `
+		SELECT entry_id, hotel_id, type_id, room_id, user_id, start_date, end_date, guests,
+		       contact_name, contact_email, contact_phone, status, reservation_id, offer_expires_at,
+		       created_at, created_by, update_at, update_by
+		FROM waitlist_entry
+		WHERE entry_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, entryID).Scan(
+		&entry.EntryID,
+		&entry.HotelID,
+		&entry.TypeID,
+		&entry.RoomID,
+		&entry.UserID,
+		&entry.StartDate,
+		&entry.EndDate,
+		&entry.Guests,
+		&entry.ContactName,
+		&entry.ContactEmail,
+		&entry.ContactPhone,
+		&entry.Status,
+		&entry.ReservationID,
+		&entry.OfferExpiresAt,
+		&entry.CreatedAt,
+		&entry.CreatedBy,
+		&entry.UpdateAt,
+		&entry.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ListWaitlistEntriesByHotel lists a hotel's entries in queue order. An empty status lists every entry.
+func (r *waitlistRepository) ListWaitlistEntriesByHotel(ctx context.Context, hotelID uuid.UUID, status string, limit, offset int) ([]*model.WaitlistEntry, error) {
+	query := `
+		SELECT entry_id, hotel_id, type_id, room_id, user_id, start_date, end_date, guests,
+		       contact_name, contact_email, contact_phone, status, reservation_id, offer_expires_at,
+		       created_at, created_by, update_at, update_by
+		FROM waitlist_entry
+		WHERE hotel_id = $1 AND ($2::VARCHAR = '' OR status = $2)
+		ORDER BY created_at
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.QueryContext(ctx, query, hotelID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*model.WaitlistEntry
+	for rows.Next() {
+		var entry model.WaitlistEntry
+		err := rows.Scan(
+			&entry.EntryID,
+			&entry.HotelID,
+			&entry.TypeID,
+			&entry.RoomID,
+			&entry.UserID,
+			&entry.StartDate,
+			&entry.EndDate,
+			&entry.Guests,
+			&entry.ContactName,
+			&entry.ContactEmail,
+			&entry.ContactPhone,
+			&entry.Status,
+			&entry.ReservationID,
+			&entry.OfferExpiresAt,
+			&entry.CreatedAt,
+			&entry.CreatedBy,
+			&entry.UpdateAt,
+			&entry.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
+
+func (r *waitlistRepository) ListWaitlistEntriesByUser(ctx context.Context, userID string, limit, offset int) ([]*model.WaitlistEntry, error) {
+	query := `
+		SELECT entry_id, hotel_id, type_id, room_id, user_id, start_date, end_date, guests,
+		       contact_name, contact_email, contact_phone, status, reservation_id, offer_expires_at,
+		       created_at, created_by, update_at, update_by
+		FROM waitlist_entry
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*model.WaitlistEntry
+	for rows.Next() {
+		var entry model.WaitlistEntry
+		err := rows.Scan(
+			&entry.EntryID,
+			&entry.HotelID,
+			&entry.TypeID,
+			&entry.RoomID,
+			&entry.UserID,
+			&entry.StartDate,
+			&entry.EndDate,
+			&entry.Guests,
+			&entry.ContactName,
+			&entry.ContactEmail,
+			&entry.ContactPhone,
+			&entry.Status,
+			&entry.ReservationID,
+			&entry.OfferExpiresAt,
+			&entry.CreatedAt,
+			&entry.CreatedBy,
+			&entry.UpdateAt,
+			&entry.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
\ No newline at end of file
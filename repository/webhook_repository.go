@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type WebhookRepository interface {
+	CreateWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscription) error
+	GetWebhookSubscriptionByID(ctx context.Context, subscriptionID uuid.UUID) (*model.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context, limit, offset int) ([]*model.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscription) error
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID uuid.UUID) error
+	GetWebhookDeliveryByID(ctx context.Context, deliveryID uuid.UUID) (*model.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, status string, limit, offset int) ([]*model.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) CreateWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscription (subscription_id, url, event_types, hotel_id, secret, description, is_active,
+		                                  created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		subscription.SubscriptionID,
+		subscription.URL,
+		pq.Array(subscription.EventTypes),
+		subscription.HotelID,
+		subscription.Secret,
+		subscription.Description,
+		subscription.IsActive,
+		subscription.CreatedAt,
+		subscription.CreatedBy,
+	)
+	return err
+}
+
+func (r *webhookRepository) GetWebhookSubscriptionByID(ctx context.Context, subscriptionID uuid.UUID) (*model.WebhookSubscription, error) {
+	var subscription model.WebhookSubscription
+	query := `
+		SELECT subscription_id, url, event_types, hotel_id, secret, description, is_active, consecutive_failures,
+		       disabled_at, disabled_reason, created_at, created_by, update_at, update_by
+		FROM webhook_subscription
+		WHERE subscription_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, subscriptionID).Scan(
+		&subscription.SubscriptionID,
+		&subscription.URL,
+		pq.Array(&subscription.EventTypes),
+		&subscription.HotelID,
+		&subscription.Secret,
+		&subscription.Description,
+		&subscription.IsActive,
+		&subscription.ConsecutiveFailures,
+		&subscription.DisabledAt,
+		&subscription.DisabledReason,
+		&subscription.CreatedAt,
+		&subscription.CreatedBy,
+		&subscription.UpdateAt,
+		&subscription.UpdateBy,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *webhookRepository) ListWebhookSubscriptions(ctx context.Context, limit, offset int) ([]*model.WebhookSubscription, error) {
+	query := `
+		SELECT subscription_id, url, event_types, hotel_id, secret, description, is_active, consecutive_failures,
+		       disabled_at, disabled_reason, created_at, created_by, update_at, update_by
+		FROM webhook_subscription
+		ORDER BY created_at, subscription_id
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*model.WebhookSubscription
+	for rows.Next() {
+		var subscription model.WebhookSubscription
+		err := rows.Scan(
+			&subscription.SubscriptionID,
+			&subscription.URL,
+			pq.Array(&subscription.EventTypes),
+			&subscription.HotelID,
+			&subscription.Secret,
+			&subscription.Description,
+			&subscription.IsActive,
+			&subscription.ConsecutiveFailures,
+			&subscription.DisabledAt,
+			&subscription.DisabledReason,
+			&subscription.CreatedAt,
+			&subscription.CreatedBy,
+			&subscription.UpdateAt,
+			&subscription.UpdateBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, &subscription)
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) UpdateWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscription
+		SET url = $2, event_types = $3, hotel_id = $4, secret = $5, description = $6, is_active = $7,
+		    consecutive_failures = $8, disabled_at = $9, disabled_reason = $10, update_at = $11, update_by = $12
+		WHERE subscription_id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		subscription.SubscriptionID,
+		subscription.URL,
+		pq.Array(subscription.EventTypes),
+		subscription.HotelID,
+		subscription.Secret,
+		subscription.Description,
+		subscription.IsActive,
+		subscription.ConsecutiveFailures,
+		subscription.DisabledAt,
+		subscription.DisabledReason,
+		subscription.UpdateAt,
+		subscription.UpdateBy,
+	)
+	return err
+}
+
+func (r *webhookRepository) DeleteWebhookSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	query := `DELETE FROM webhook_subscription WHERE subscription_id = $1`
+	_, err := r.db.ExecContext(ctx, query, subscriptionID)
+	return err
+}
+
+func (r *webhookRepository) GetWebhookDeliveryByID(ctx context.Context, deliveryID uuid.UUID) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	query := `
+		SELECT delivery_id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+		       last_attempt_at, response_status, last_error, created_at, delivered_at
+		FROM webhook_delivery
+		WHERE delivery_id = $1
+	`
+	err := r.db.QueryRowContext(ctx, query, deliveryID).Scan(
+		&delivery.DeliveryID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.DeliveredAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListWebhookDeliveries returns the delivery log of a subscription, newest first, optionally
+// limited to one status
+func (r *webhookRepository) ListWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, status string, limit, offset int) ([]*model.WebhookDelivery, error) {
+	query := `
+		SELECT delivery_id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+		       last_attempt_at, response_status, last_error, created_at, delivered_at
+		FROM webhook_delivery
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, delivery_id
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		var delivery model.WebhookDelivery
+		err := rows.Scan(
+			&delivery.DeliveryID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastAttemptAt,
+			&delivery.ResponseStatus,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
\ No newline at end of file
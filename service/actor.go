@@ -0,0 +1,18 @@
+package service
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor returns a context carrying the username of the authenticated caller. Services take
+// the caller from the context rather than from request bodies, which any client can fill in.
+func WithActor(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, actorKey{}, username)
+}
+
+// ActorFromContext returns the username of the authenticated caller, or "" when the request was
+// not authenticated
+func ActorFromContext(ctx context.Context) string {
+	username, _ := ctx.Value(actorKey{}).(string)
+	return username
+}
\ No newline at end of file
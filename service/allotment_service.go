@@ -0,0 +1,478 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+)
+
+type AllotmentService interface {
+	CreateAllotment(ctx context.Context, allotment *model.Allotment) error
+	GetAllotmentByID(ctx context.Context, allotmentID uuid.UUID) (*model.Allotment, error)
+	GetAllotmentByCode(ctx context.Context, blockCode string) (*model.Allotment, error)
+	ListAllotmentsByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.Allotment, error)
+	UpdateAllotment(ctx context.Context, allotment *model.Allotment) error
+	ReleaseAllotment(ctx context.Context, allotmentID uuid.UUID) error
+	CancelAllotment(ctx context.Context, allotmentID uuid.UUID) error
+	ReleaseDueAllotments(ctx context.Context, now time.Time) error
+}
+
+type allotmentService struct {
+	store         db.Store
+	allotmentRepo repository.AllotmentRepository
+	roomTypeRepo  repository.RoomTypeRepository
+	waitlist      WaitlistMatcher
+}
+
+func NewAllotmentService(store db.Store, allotmentRepo repository.AllotmentRepository, roomTypeRepo repository.RoomTypeRepository, waitlist WaitlistMatcher) AllotmentService {
+	return &allotmentService{
+		store:         store,
+		allotmentRepo: allotmentRepo,
+		roomTypeRepo:  roomTypeRepo,
+		waitlist:      waitlist,
+	}
+}
+
+// CreateAllotment holds rooms of a type for a group. The rooms must be free on every night of
+// the allotment, as public bookings can no longer take them once it exists.
+func (s *allotmentService) CreateAllotment(ctx context.Context, allotment *model.Allotment) error {
+	if allotment.AllotmentID == uuid.Nil {
+		allotment.AllotmentID = uuid.New()
+	}
+
+	allotment.BlockCode = normalizeBlockCode(allotment.BlockCode)
+	if allotment.BlockCode == "" {
+		return errors.New("block code is required")
+	}
+
+	if !allotment.HotelID.Valid {
+		return errors.New("invalid hotel ID")
+	}
+
+	if !allotment.TypeID.Valid {
+		return errors.New("room type is required")
+	}
+
+	if !allotment.StartDate.Valid || !allotment.EndDate.Valid {
+		return errors.New("invalid allotment dates")
+	}
+
+	if !allotment.StartDate.Time.Before(allotment.EndDate.Time) {
+		return errors.New("invalid date range: start date must be before end date")
+	}
+
+	if err := validateAllotmentTerms(allotment); err != nil {
+		return err
+	}
+
+	if _, err := checkRoomTypeExists(ctx, s.roomTypeRepo, allotment.TypeID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	allotment.Status = sql.NullString{String: model.AllotmentActive, Valid: true}
+	allotment.CreatedAt = sql.NullTime{Time: now, Valid: true}
+
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		if _, err := q.GetAllotmentByCodeForUpdate(ctx, allotment.BlockCode); err == nil {
+			return errors.New("block code already exists")
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		if err := localizeAllotment(ctx, q, allotment, now); err != nil {
+			return err
+		}
+
+		rooms, err := q.LockRoomsByHotelAndType(ctx, db.LockRoomsByHotelAndTypeParams{
+			HotelID: allotment.HotelID,
+			TypeID:  allotment.TypeID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateAllotment(ctx, db.CreateAllotmentParams{
+			AllotmentID: allotment.AllotmentID,
+			BlockCode:   allotment.BlockCode,
+			Name:        allotment.Name,
+			HotelID:     allotment.HotelID,
+			TypeID:      allotment.TypeID,
+			StartDate:   allotment.StartDate,
+			EndDate:     allotment.EndDate,
+			Quantity:    allotment.Quantity,
+			Rate:        allotment.Rate,
+			ReleaseDate: allotment.ReleaseDate,
+			Status:      allotment.Status,
+			CreatedAt:   allotment.CreatedAt,
+			CreatedBy:   allotment.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := markChannelsDue(ctx, q, allotment.HotelID); err != nil {
+			return err
+		}
+
+		return checkAllotmentCapacity(ctx, q, allotment, len(rooms))
+	})
+}
+
+func (s *allotmentService) GetAllotmentByID(ctx context.Context, allotmentID uuid.UUID) (*model.Allotment, error) {
+	allotment, err := s.allotmentRepo.GetAllotmentByID(ctx, allotmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if allotment == nil {
+		return nil, errors.New("allotment not found")
+	}
+
+	return allotment, nil
+}
+
+func (s *allotmentService) GetAllotmentByCode(ctx context.Context, blockCode string) (*model.Allotment, error) {
+	allotment, err := s.allotmentRepo.GetAllotmentByCode(ctx, normalizeBlockCode(blockCode))
+	if err != nil {
+		return nil, err
+	}
+
+	if allotment == nil {
+		return nil, errors.New("allotment not found")
+	}
+
+	return allotment, nil
+}
+
+func (s *allotmentService) ListAllotmentsByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.Allotment, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.allotmentRepo.ListAllotmentsByHotel(ctx, hotelID, pageSize, offset)
+}
+
+// UpdateAllotment renegotiates an active allotment's name, quantity, rate or release date. Its
+// hotel, room type and dates are fixed once attendees can book against it.
+func (s *allotmentService) UpdateAllotment(ctx context.Context, allotment *model.Allotment) error {
+	now := time.Now()
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbAllotment, err := q.GetAllotmentForUpdate(ctx, allotment.AllotmentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("allotment not found")
+			}
+			return err
+		}
+		existing := model.FromDBAllotment(&dbAllotment)
+
+		if existing.Status.String != model.AllotmentActive {
+			return errors.New("only active allotments can be updated")
+		}
+
+		allotment.BlockCode = existing.BlockCode
+		allotment.HotelID = existing.HotelID
+		allotment.TypeID = existing.TypeID
+		allotment.StartDate = existing.StartDate
+		allotment.EndDate = existing.EndDate
+		allotment.Status = existing.Status
+		allotment.CreatedAt = existing.CreatedAt
+		allotment.CreatedBy = existing.CreatedBy
+		if !allotment.Name.Valid {
+			allotment.Name = existing.Name
+		}
+		if !allotment.Quantity.Valid {
+			allotment.Quantity = existing.Quantity
+		}
+		if !allotment.Rate.Valid {
+			allotment.Rate = existing.Rate
+		}
+
+		releaseChanged := allotment.ReleaseDate.Valid
+		if !releaseChanged {
+			allotment.ReleaseDate = existing.ReleaseDate
+		}
+
+		if err := validateAllotmentTerms(allotment); err != nil {
+			return err
+		}
+
+		if releaseChanged {
+			dbHotel, err := q.GetHotel(ctx, allotment.HotelID.UUID)
+			if err != nil {
+				return err
+			}
+			if err := localizeReleaseDate(model.FromDBHotel(&dbHotel), allotment, now); err != nil {
+				return err
+			}
+		}
+
+		pickedUp, err := q.GetMaxNightlyAllotmentPickup(ctx, db.GetMaxNightlyAllotmentPickupParams{
+			BlockCode: sql.NullString{String: allotment.BlockCode, Valid: true},
+			StartDate: allotment.StartDate.Time,
+			EndDate:   allotment.EndDate.Time,
+		})
+		if err != nil {
+			return err
+		}
+		if allotment.Quantity.Int32 < pickedUp {
+			return errors.New("quantity cannot be less than the rooms already booked against the block")
+		}
+
+		rooms, err := q.LockRoomsByHotelAndType(ctx, db.LockRoomsByHotelAndTypeParams{
+			HotelID: allotment.HotelID,
+			TypeID:  allotment.TypeID,
+		})
+		if err != nil {
+			return err
+		}
+
+		allotment.UpdateAt = sql.NullTime{Time: now, Valid: true}
+		_, err = q.UpdateAllotment(ctx, db.UpdateAllotmentParams{
+			AllotmentID: allotment.AllotmentID,
+			Name:        allotment.Name,
+			StartDate:   allotment.StartDate,
+			EndDate:     allotment.EndDate,
+			Quantity:    allotment.Quantity,
+			Rate:        allotment.Rate,
+			ReleaseDate: allotment.ReleaseDate,
+			UpdateAt:    allotment.UpdateAt,
+			UpdateBy:    allotment.UpdateBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := markChannelsDue(ctx, q, allotment.HotelID); err != nil {
+			return err
+		}
+
+		return checkAllotmentCapacity(ctx, q, allotment, len(rooms))
+	})
+}
+
+// ReleaseAllotment returns an allotment's unbooked rooms to general inventory ahead of its
+// release date. Bookings already made against it are kept.
+func (s *allotmentService) ReleaseAllotment(ctx context.Context, allotmentID uuid.UUID) error {
+	return s.closeAllotment(ctx, allotmentID, model.AllotmentReleased)
+}
+
+// CancelAllotment withdraws an allotment nobody has booked against yet
+func (s *allotmentService) CancelAllotment(ctx context.Context, allotmentID uuid.UUID) error {
+	return s.closeAllotment(ctx, allotmentID, model.AllotmentCancelled)
+}
+
+func (s *allotmentService) closeAllotment(ctx context.Context, allotmentID uuid.UUID, status string) error {
+	var released *model.Allotment
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbAllotment, err := q.GetAllotmentForUpdate(ctx, allotmentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("allotment not found")
+			}
+			return err
+		}
+
+		if dbAllotment.Status.String != model.AllotmentActive {
+			return errors.New("allotment is already " + strings.ToLower(dbAllotment.Status.String))
+		}
+
+		if status == model.AllotmentCancelled {
+			booked, err := q.CountAllotmentReservations(ctx, sql.NullString{String: dbAllotment.BlockCode, Valid: true})
+			if err != nil {
+				return err
+			}
+			if booked > 0 {
+				return errors.New("allotment has bookings; release it instead")
+			}
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		dbAllotment, err = q.UpdateAllotmentStatus(ctx, db.UpdateAllotmentStatusParams{
+			AllotmentID: allotmentID,
+			Status:      sql.NullString{String: status, Valid: true},
+			ReleasedAt:  now,
+			UpdateAt:    now,
+		})
+		if err != nil {
+			return err
+		}
+
+		released = model.FromDBAllotment(&dbAllotment)
+		return markChannelsDue(ctx, q, released.HotelID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.matchReleasedRooms(ctx, released)
+	return nil
+}
+
+// ReleaseDueAllotments releases every active allotment whose release date has passed
+func (s *allotmentService) ReleaseDueAllotments(ctx context.Context, now time.Time) error {
+	due, err := s.store.ListDueAllotments(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range due {
+		var released *model.Allotment
+		err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+			dbAllotment, err := q.GetAllotmentForUpdate(ctx, due[i].AllotmentID)
+			if err != nil {
+				return err
+			}
+			// Staff may have released or cancelled it since it was listed
+			if dbAllotment.Status.String != model.AllotmentActive {
+				return nil
+			}
+
+			releasedAt := sql.NullTime{Time: now, Valid: true}
+			dbAllotment, err = q.UpdateAllotmentStatus(ctx, db.UpdateAllotmentStatusParams{
+				AllotmentID: dbAllotment.AllotmentID,
+				Status:      sql.NullString{String: model.AllotmentReleased, Valid: true},
+				ReleasedAt:  releasedAt,
+				UpdateAt:    releasedAt,
+			})
+			if err != nil {
+				return err
+			}
+
+			released = model.FromDBAllotment(&dbAllotment)
+			return markChannelsDue(ctx, q, released.HotelID)
+		})
+		if err != nil {
+			return err
+		}
+
+		if released != nil {
+			s.matchReleasedRooms(ctx, released)
+		}
+	}
+
+	return nil
+}
+
+// matchReleasedRooms offers rooms returned by an allotment to the waitlist
+func (s *allotmentService) matchReleasedRooms(ctx context.Context, allotment *model.Allotment) {
+	s.waitlist.MatchReleasedInventory(ctx, &model.Reservation{
+		HotelID:   allotment.HotelID,
+		TypeID:    allotment.TypeID,
+		StartDate: allotment.StartDate,
+		EndDate:   allotment.EndDate,
+	})
+}
+
+func normalizeBlockCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validateAllotmentTerms(allotment *model.Allotment) error {
+	if !allotment.Quantity.Valid || allotment.Quantity.Int32 < 1 {
+		return errors.New("quantity must be at least 1")
+	}
+
+	if !allotment.Rate.Valid || allotment.Rate.Int32 < 0 {
+		return errors.New("rate is required and cannot be negative")
+	}
+
+	if !allotment.ReleaseDate.Valid {
+		return errors.New("release date is required")
+	}
+
+	return nil
+}
+
+// localizeAllotment holds the rooms from check-in on the first day to check-out on the last,
+// the window a stay over those dates would hold
+func localizeAllotment(ctx context.Context, q *db.Queries, allotment *model.Allotment, now time.Time) error {
+	dbHotel, err := q.GetHotel(ctx, allotment.HotelID.UUID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("hotel not found")
+		}
+		return err
+	}
+	hotel := model.FromDBHotel(&dbHotel)
+
+	start, end := stayWindow(hotel, allotment.StartDate.Time, allotment.EndDate.Time)
+	allotment.StartDate = sql.NullTime{Time: start, Valid: true}
+	allotment.EndDate = sql.NullTime{Time: end, Valid: true}
+
+	return localizeReleaseDate(hotel, allotment, now)
+}
+
+// localizeReleaseDate releases the rooms at the start of the release date in hotel-local time.
+// The release date must be in the future and no later than the first night.
+func localizeReleaseDate(hotel *model.Hotel, allotment *model.Allotment, now time.Time) error {
+	loc := hotelLocation(hotel)
+	release := localMidnight(allotment.ReleaseDate.Time, loc)
+	if !release.After(now) {
+		return errors.New("release date must be in the future")
+	}
+	if release.After(allotment.StartDate.Time) {
+		return errors.New("release date cannot be after the start date")
+	}
+
+	allotment.ReleaseDate = sql.NullTime{Time: release, Valid: true}
+	return nil
+}
+
+// checkAllotmentCapacity makes sure the type's rooms still cover every night once the
+// allotment's rooms are held
+func checkAllotmentCapacity(ctx context.Context, q *db.Queries, allotment *model.Allotment, totalRooms int) error {
+	if totalRooms == 0 {
+		return errors.New("hotel has no rooms of this type")
+	}
+
+	available, err := q.GetMinNightlyTypeAvailability(ctx, db.GetMinNightlyTypeAvailabilityParams{
+		TotalRooms: int32(totalRooms),
+		HotelID:    allotment.HotelID,
+		TypeID:     allotment.TypeID,
+		StartDate:  allotment.StartDate.Time,
+		EndDate:    allotment.EndDate.Time,
+	})
+	if err != nil {
+		return err
+	}
+	if available < 0 {
+		return errors.New("not enough rooms of this type are free to hold the allotment")
+	}
+
+	return nil
+}
+
+// checkBlockCodeOpen verifies that new bookings can still be made against a block code
+func checkBlockCodeOpen(ctx context.Context, q *db.Queries, blockCode string, now time.Time) error {
+	dbAllotment, err := q.GetAllotmentByCodeForUpdate(ctx, blockCode)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("block code not found")
+		}
+		return err
+	}
+
+	if dbAllotment.Status.String == model.AllotmentCancelled {
+		return errors.New("block code is no longer valid")
+	}
+
+	if dbAllotment.Status.String == model.AllotmentReleased || !now.Before(dbAllotment.ReleaseDate.Time) {
+		return errors.New("block code has been released")
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/logger"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const (
+	// availabilityChannel is the Postgres notification channel availability changes are sent on
+	availabilityChannel = "availability_change"
+	// availabilityBufferSize is how many changes a slow stream client may fall behind before
+	// further changes are dropped for it
+	availabilityBufferSize = 16
+	// availabilityListenerPing is how often an idle listener checks its connection is still alive
+	availabilityListenerPing = 90 * time.Second
+)
+
+// AvailabilityStream fans reservation changes out to clients watching a hotel's availability.
+// As an event publisher it announces each change with a Postgres NOTIFY, so every instance
+// listening on the database, including the one that published it, passes it on to its own
+// clients. Without a database source the stream works within a single instance.
+type AvailabilityStream interface {
+	EventPublisher
+	Subscribe(hotelID uuid.UUID) (<-chan *model.AvailabilityChange, func())
+	Listen(ctx context.Context) error
+}
+
+type availabilityStream struct {
+	store    db.Store
+	dbSource string
+
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[chan *model.AvailabilityChange]struct{}
+}
+
+func NewAvailabilityStream(store db.Store, dbSource string) AvailabilityStream {
+	return &availabilityStream{
+		store:       store,
+		dbSource:    dbSource,
+		subscribers: make(map[uuid.UUID]map[chan *model.AvailabilityChange]struct{}),
+	}
+}
+
+// Subscribe returns the changes to a hotel's availability from now on, and a function that
+// ends the subscription
+func (s *availabilityStream) Subscribe(hotelID uuid.UUID) (<-chan *model.AvailabilityChange, func()) {
+	changes := make(chan *model.AvailabilityChange, availabilityBufferSize)
+
+	s.mu.Lock()
+	if s.subscribers[hotelID] == nil {
+		s.subscribers[hotelID] = make(map[chan *model.AvailabilityChange]struct{})
+	}
+	s.subscribers[hotelID][changes] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.subscribers[hotelID], changes)
+			if len(s.subscribers[hotelID]) == 0 {
+				delete(s.subscribers, hotelID)
+			}
+		})
+	}
+	return changes, unsubscribe
+}
+
+// Publish announces reservation events that change inventory: reservations created, modified,
+// cancelled or expired. Other events are ignored.
+func (s *availabilityStream) Publish(ctx context.Context, event *model.Event) error {
+	change, err := availabilityChangeFromEvent(event)
+	if err != nil || change == nil {
+		return err
+	}
+
+	if s.dbSource == "" {
+		s.broadcast(change)
+		return nil
+	}
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	return s.store.NotifyAvailabilityChange(ctx, string(data))
+}
+
+// Listen passes changes announced by any instance on to this instance's clients until ctx is
+// cancelled. The listener reconnects by itself when its connection drops.
+func (s *availabilityStream) Listen(ctx context.Context) error {
+	if s.dbSource == "" {
+		<-ctx.Done()
+		return nil
+	}
+
+	listener := pq.NewListener(s.dbSource, 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil && logger.Log != nil {
+			logger.Log.Warn("Availability listener connection problem", zap.Error(err))
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(availabilityChannel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			// A nil notification means the connection was re-established and changes may have
+			// been missed; clients re-query availability on their next change
+			if notification == nil {
+				continue
+			}
+
+			var change model.AvailabilityChange
+			if err := json.Unmarshal([]byte(notification.Extra), &change); err != nil {
+				if logger.Log != nil {
+					logger.Log.Warn("Invalid availability notification", zap.Error(err))
+				}
+				continue
+			}
+			s.broadcast(&change)
+		case <-time.After(availabilityListenerPing):
+			go listener.Ping()
+		}
+	}
+}
+
+// broadcast hands a change to every client watching its hotel. A client whose buffer is full
+// misses the change rather than holding up the others.
+func (s *availabilityStream) broadcast(change *model.AvailabilityChange) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for changes := range s.subscribers[change.HotelID] {
+		select {
+		case changes <- change:
+		default:
+		}
+	}
+}
+
+// availabilityChangeFromEvent describes the inventory a reservation event touched, or returns
+// nil for events that do not change availability
+func availabilityChangeFromEvent(event *model.Event) (*model.AvailabilityChange, error) {
+	switch event.Type {
+	case model.EventReservationCreated, model.EventReservationModified, model.EventReservationCancelled, model.EventReservationExpired:
+	default:
+		return nil, nil
+	}
+
+	if !event.HotelID.Valid {
+		return nil, nil
+	}
+
+	var reservation model.Reservation
+	switch payload := event.Payload.(type) {
+	case json.RawMessage:
+		if err := json.Unmarshal(payload, &reservation); err != nil {
+			return nil, err
+		}
+	case *model.Reservation:
+		reservation = *payload
+	}
+
+	return &model.AvailabilityChange{
+		EventID:       event.EventID,
+		Type:          event.Type,
+		HotelID:       event.HotelID.UUID,
+		ReservationID: event.AggregateID,
+		RoomID:        reservation.RoomID,
+		TypeID:        reservation.TypeID.String,
+		StartDate:     reservation.StartDate.Time,
+		EndDate:       reservation.EndDate.Time,
+		OccurredAt:    event.OccurredAt,
+	}, nil
+}
\ No newline at end of file
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+)
+
+type BookingGroupService interface {
+	CreateBookingGroup(ctx context.Context, group *model.BookingGroup) error
+	GetBookingGroupByID(ctx context.Context, groupID uuid.UUID) (*model.BookingGroup, error)
+	ListBookingGroupsByUser(ctx context.Context, userID string, page, pageSize int) ([]*model.BookingGroup, error)
+	CancelBookingGroup(ctx context.Context, groupID uuid.UUID) error
+	ConfirmBookingGroup(ctx context.Context, groupID uuid.UUID) error
+}
+
+type bookingGroupService struct {
+	store            db.Store
+	bookingGroupRepo repository.BookingGroupRepository
+	reservationRepo  repository.ReservationRepository
+	waitlist         WaitlistMatcher
+}
+
+func NewBookingGroupService(store db.Store, bookingGroupRepo repository.BookingGroupRepository, reservationRepo repository.ReservationRepository, waitlist WaitlistMatcher) BookingGroupService {
+	return &bookingGroupService{
+		store:            store,
+		bookingGroupRepo: bookingGroupRepo,
+		reservationRepo:  reservationRepo,
+		waitlist:         waitlist,
+	}
+}
+
+func (s *bookingGroupService) CreateBookingGroup(ctx context.Context, group *model.BookingGroup) error {
+	if group.GroupID == uuid.Nil {
+		group.GroupID = uuid.New()
+	}
+
+	if len(group.Reservations) == 0 {
+		return errors.New("at least one room is required")
+	}
+
+	if !group.StartDate.Valid || !group.EndDate.Valid {
+		return errors.New("invalid reservation dates")
+	}
+
+	if !group.StartDate.Time.Before(group.EndDate.Time) {
+		return errors.New("invalid date range: start date must be before end date")
+	}
+
+	if !group.ContactName.Valid || strings.TrimSpace(group.ContactName.String) == "" {
+		return errors.New("contact name is required")
+	}
+
+	if !group.ContactEmail.Valid && !group.ContactPhone.Valid {
+		return errors.New("contact email or phone is required")
+	}
+
+	for _, reservation := range group.Reservations {
+		if !reservation.RoomID.Valid && (!reservation.HotelID.Valid || !reservation.TypeID.Valid) {
+			return errors.New("either room ID or hotel ID and room type are required")
+		}
+		if reservation.PromoCode.Valid {
+			return errors.New("promo codes are not supported for group bookings")
+		}
+		if isDayUse(reservation) {
+			return errors.New("day-use bookings are not supported for group bookings")
+		}
+		if len(reservation.Extras) > 0 {
+			return errors.New("extras are added to group reservations after the group is booked")
+		}
+		if err := normalizeOccupancy(reservation); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	group.Status = sql.NullString{String: "PENDING", Valid: true}
+	group.CreatedAt = sql.NullTime{Time: now, Valid: true}
+
+	// Every room is checked and inserted in the same transaction, so either the whole group
+	// is booked or none of it is. Rooms inserted earlier in the loop count against the
+	// inventory checks of later ones.
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var hotelID uuid.NullUUID
+		for _, reservation := range group.Reservations {
+			if reservation.RoomID.Valid {
+				dbRoom, err := q.GetRoom(ctx, reservation.RoomID.UUID)
+				if err != nil {
+					if err == sql.ErrNoRows {
+						return errors.New("room not found")
+					}
+					return err
+				}
+				reservation.HotelID = dbRoom.HotelID
+				reservation.TypeID = dbRoom.TypeID
+			}
+			if hotelID.Valid && reservation.HotelID != hotelID {
+				return errors.New("all rooms of a group booking must be in the same hotel")
+			}
+			hotelID = reservation.HotelID
+		}
+
+		// The group's dates are localized once, so every room shares the same stay window
+		stay := &model.Reservation{HotelID: hotelID, StartDate: group.StartDate, EndDate: group.EndDate}
+		hotel, err := localizeStay(ctx, q, stay)
+		if err != nil {
+			return err
+		}
+		if err := checkArrivalNotPast(hotel, stay.StartDate.Time); err != nil {
+			return err
+		}
+		group.StartDate = stay.StartDate
+		group.EndDate = stay.EndDate
+
+		_, err = q.CreateBookingGroup(ctx, db.CreateBookingGroupParams{
+			GroupID:      group.GroupID,
+			UserID:       group.UserID,
+			ContactName:  group.ContactName,
+			ContactEmail: group.ContactEmail,
+			ContactPhone: group.ContactPhone,
+			StartDate:    group.StartDate,
+			EndDate:      group.EndDate,
+			Status:       group.Status,
+			CreatedAt:    group.CreatedAt,
+			CreatedBy:    group.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		nights := stayNights(group.StartDate.Time, group.EndDate.Time)
+		var total int32
+		priced := true
+		for _, reservation := range group.Reservations {
+			reservation.ReservationID = uuid.New()
+			reservation.GroupID = uuid.NullUUID{UUID: group.GroupID, Valid: true}
+			reservation.UserID = group.UserID
+			reservation.StartDate = group.StartDate
+			reservation.EndDate = group.EndDate
+			reservation.Status = group.Status
+			reservation.CreatedAt = group.CreatedAt
+			reservation.CreatedBy = group.CreatedBy
+			reservation.DiscountAmount = sql.NullInt32{}
+			reservation.StayType = sql.NullString{String: model.StayTypeOvernight, Valid: true}
+			reservation.Source = sql.NullString{String: model.ReservationSourceDirect, Valid: true}
+			reservation.ExternalID = sql.NullString{}
+
+			room, err := reserveInventory(ctx, q, reservation)
+			if err != nil {
+				return err
+			}
+
+			extra, err := priceOccupancy(ctx, q, reservation, room)
+			if err != nil {
+				return err
+			}
+
+			reservation.ExtraPersonCharge = sql.NullInt32{Int32: extra * nights, Valid: true}
+			reservation.TotalPrice = sql.NullInt32{Int32: room.Price.Int32*nights + reservation.ExtraPersonCharge.Int32, Valid: room.Price.Valid}
+			total += reservation.TotalPrice.Int32
+			priced = priced && room.Price.Valid
+
+			reservation.ConfirmationCode, err = newConfirmationCode(ctx, q)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateReservation(ctx, db.CreateReservationParams{
+				ReservationID:     reservation.ReservationID,
+				RoomID:            reservation.RoomID,
+				UserID:            reservation.UserID,
+				StartDate:         reservation.StartDate,
+				EndDate:           reservation.EndDate,
+				Status:            reservation.Status,
+				CreatedAt:         reservation.CreatedAt,
+				CreatedBy:         reservation.CreatedBy,
+				TotalPrice:        reservation.TotalPrice,
+				HotelID:           reservation.HotelID,
+				TypeID:            reservation.TypeID,
+				GroupID:           reservation.GroupID,
+				StayType:          reservation.StayType,
+				Adults:            reservation.Adults,
+				Children:          reservation.Children,
+				ChildAges:         reservation.ChildAges,
+				ExtraPersonCharge: reservation.ExtraPersonCharge,
+				ConfirmationCode:  reservation.ConfirmationCode,
+				Source:            reservation.Source,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := createStaySegment(ctx, q, reservation); err != nil {
+				return err
+			}
+
+			if err := saveReservationGuests(ctx, q, reservation); err != nil {
+				return err
+			}
+
+			if err := recordReservationEvent(ctx, q, model.EventReservationCreated, reservation); err != nil {
+				return err
+			}
+		}
+
+		group.TotalPrice = sql.NullInt32{Int32: total, Valid: priced}
+		_, err = q.UpdateBookingGroupTotalPrice(ctx, db.UpdateBookingGroupTotalPriceParams{
+			GroupID:    group.GroupID,
+			TotalPrice: group.TotalPrice,
+		})
+		return err
+	})
+}
+
+func (s *bookingGroupService) GetBookingGroupByID(ctx context.Context, groupID uuid.UUID) (*model.BookingGroup, error) {
+	group, err := s.bookingGroupRepo.GetBookingGroupByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if group == nil {
+		return nil, errors.New("booking group not found")
+	}
+
+	group.Reservations, err = s.reservationRepo.ListReservationsByGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *bookingGroupService) ListBookingGroupsByUser(ctx context.Context, userID string, page, pageSize int) ([]*model.BookingGroup, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.bookingGroupRepo.ListBookingGroupsByUser(ctx, userID, pageSize, offset)
+}
+
+func (s *bookingGroupService) CancelBookingGroup(ctx context.Context, groupID uuid.UUID) error {
+	var released []*model.Reservation
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		group, err := q.GetBookingGroupForUpdate(ctx, groupID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("booking group not found")
+			}
+			return err
+		}
+
+		if group.Status.Valid && group.Status.String == "CANCELLED" {
+			return errors.New("booking group is already cancelled")
+		}
+
+		if group.Status.Valid && group.Status.String == "COMPLETED" {
+			return errors.New("cannot cancel completed booking group")
+		}
+
+		reservations, err := q.ListReservationsByGroupForUpdate(ctx, uuid.NullUUID{UUID: groupID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		for _, reservation := range reservations {
+			if reservation.Status.Valid && (reservation.Status.String == "CANCELLED" || reservation.Status.String == "COMPLETED") {
+				continue
+			}
+
+			dbCancelled, err := q.UpdateReservationStatus(ctx, db.UpdateReservationStatusParams{
+				ReservationID: reservation.ReservationID,
+				Status:        sql.NullString{String: "CANCELLED", Valid: true},
+				UpdateAt:      now,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := recordReservationEvent(ctx, q, model.EventReservationCancelled, model.FromDBReservation(&dbCancelled)); err != nil {
+				return err
+			}
+			released = append(released, model.FromDBReservation(&reservation))
+		}
+
+		_, err = q.UpdateBookingGroupStatus(ctx, db.UpdateBookingGroupStatusParams{
+			GroupID:  groupID,
+			Status:   sql.NullString{String: "CANCELLED", Valid: true},
+			UpdateAt: now,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range released {
+		s.waitlist.MatchReleasedInventory(ctx, reservation)
+	}
+	return nil
+}
+
+func (s *bookingGroupService) ConfirmBookingGroup(ctx context.Context, groupID uuid.UUID) error {
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		group, err := q.GetBookingGroupForUpdate(ctx, groupID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("booking group not found")
+			}
+			return err
+		}
+
+		if !group.Status.Valid || group.Status.String != "PENDING" {
+			return errors.New("only pending booking groups can be confirmed")
+		}
+
+		reservations, err := q.ListReservationsByGroupForUpdate(ctx, uuid.NullUUID{UUID: groupID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		for _, reservation := range reservations {
+			if !reservation.Status.Valid || reservation.Status.String != "PENDING" {
+				continue
+			}
+
+			dbConfirmed, err := q.UpdateReservationStatus(ctx, db.UpdateReservationStatusParams{
+				ReservationID: reservation.ReservationID,
+				Status:        sql.NullString{String: "CONFIRMED", Valid: true},
+				UpdateAt:      now,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := recordReservationEvent(ctx, q, model.EventReservationConfirmed, model.FromDBReservation(&dbConfirmed)); err != nil {
+				return err
+			}
+		}
+
+		_, err = q.UpdateBookingGroupStatus(ctx, db.UpdateBookingGroupStatusParams{
+			GroupID:  groupID,
+			Status:   sql.NullString{String: "CONFIRMED", Valid: true},
+			UpdateAt: now,
+		})
+		return err
+	})
+}
\ No newline at end of file
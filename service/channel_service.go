@@ -0,0 +1,1161 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/channel"
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/logger"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// channelPullInterval is how often the reservations of each active connection are pulled
+	channelPullInterval = 5 * time.Minute
+	// channelPullOverlap is how far before the previous pull each pull reaches back, so
+	// reservations a channel records late are not missed. Reservations pulled twice are
+	// recognized by their external ID.
+	channelPullOverlap = 10 * time.Minute
+	// channelRefreshInterval is how often each active connection is sent its full ARI. Refreshes
+	// also carry changes that raise no event, such as room blocks and stay restrictions.
+	channelRefreshInterval = 6 * time.Hour
+	// channelSyncBatchSize bounds how many connections one pass pulls from and pushes to
+	channelSyncBatchSize = 20
+	// channelHorizonDays is how many days ahead availability, rates and restrictions are pushed
+	channelHorizonDays = 365
+	// channelTimeout bounds one push to or pull from a channel
+	channelTimeout = time.Minute
+	// roleChannel is recorded as the role of whoever made modifications imported from a channel
+	roleChannel = "CHANNEL"
+)
+
+// ChannelService distributes the hotel's rooms through channels such as online travel agencies.
+// As an event publisher it has availability, rates and restrictions (ARI) pushed to a hotel's
+// channels whenever its inventory changes, and it pulls the reservations made on the channels
+// into the hotel's reservations. Channel reservations are accepted even when the hotel no longer
+// has room for them, because the guest has already booked; such reservations are reported as
+// conflicts for staff to resolve.
+type ChannelService interface {
+	EventPublisher
+	ListChannels() []string
+	CreateChannelConnection(ctx context.Context, connection *model.ChannelConnection) error
+	GetChannelConnectionByID(ctx context.Context, connectionID uuid.UUID) (*model.ChannelConnection, error)
+	ListChannelConnectionsByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.ChannelConnection, error)
+	UpdateChannelConnection(ctx context.Context, connection *model.ChannelConnection) error
+	DeleteChannelConnection(ctx context.Context, connectionID uuid.UUID) error
+	CreateChannelMapping(ctx context.Context, mapping *model.ChannelMapping) error
+	ListChannelMappings(ctx context.Context, connectionID uuid.UUID) ([]*model.ChannelMapping, error)
+	DeleteChannelMapping(ctx context.Context, mappingID uuid.UUID) error
+	PushChannelARI(ctx context.Context, connectionID uuid.UUID, now time.Time) (*model.ChannelPushResult, error)
+	PullChannelReservations(ctx context.Context, connectionID uuid.UUID, now time.Time) (*model.ChannelPullResult, error)
+	SyncDueChannels(ctx context.Context, now time.Time) (int, error)
+}
+
+type channelService struct {
+	store        db.Store
+	channelRepo  repository.ChannelRepository
+	hotelRepo    repository.HotelRepository
+	roomRepo     repository.RoomRepository
+	roomTypeRepo repository.RoomTypeRepository
+	channels     *channel.Registry
+	waitlist     WaitlistMatcher
+}
+
+func NewChannelService(store db.Store, channelRepo repository.ChannelRepository, hotelRepo repository.HotelRepository, roomRepo repository.RoomRepository, roomTypeRepo repository.RoomTypeRepository, channels *channel.Registry, waitlist WaitlistMatcher) ChannelService {
+	return &channelService{
+		store:        store,
+		channelRepo:  channelRepo,
+		hotelRepo:    hotelRepo,
+		roomRepo:     roomRepo,
+		roomTypeRepo: roomTypeRepo,
+		channels:     channels,
+		waitlist:     waitlist,
+	}
+}
+
+// ListChannels returns the codes of the channels hotels can be connected to
+func (s *channelService) ListChannels() []string {
+	return s.channels.Codes()
+}
+
+func (s *channelService) CreateChannelConnection(ctx context.Context, connection *model.ChannelConnection) error {
+	if connection.ConnectionID == uuid.Nil {
+		connection.ConnectionID = uuid.New()
+	}
+
+	connection.Channel = channel.NormalizeCode(connection.Channel)
+	if _, ok := s.channels.Provider(connection.Channel); !ok {
+		return errors.New("unknown channel")
+	}
+
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, connection.HotelID)
+	if err != nil {
+		return err
+	}
+	if hotel == nil {
+		return errors.New("hotel not found")
+	}
+
+	if err := s.checkHotelCode(ctx, connection); err != nil {
+		return err
+	}
+
+	if !connection.IsActive.Valid {
+		connection.IsActive = sql.NullBool{Bool: true, Valid: true}
+	}
+	connection.LastPushedAt = sql.NullTime{}
+	connection.PushError = sql.NullString{}
+	connection.PushDueAt = sql.NullTime{}
+	connection.LastPulledAt = sql.NullTime{}
+	connection.PullError = sql.NullString{}
+	connection.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return s.channelRepo.CreateChannelConnection(ctx, connection)
+}
+
+// checkHotelCode checks that no other connection lists a hotel under the same code on the channel
+func (s *channelService) checkHotelCode(ctx context.Context, connection *model.ChannelConnection) error {
+	connection.HotelCode = strings.TrimSpace(connection.HotelCode)
+	if connection.HotelCode == "" {
+		return errors.New("hotel code is required")
+	}
+
+	existing, err := s.channelRepo.GetChannelConnectionByCode(ctx, connection.Channel, connection.HotelCode)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ConnectionID != connection.ConnectionID {
+		return errors.New("hotel code is already connected to the channel")
+	}
+
+	return nil
+}
+
+func (s *channelService) GetChannelConnectionByID(ctx context.Context, connectionID uuid.UUID) (*model.ChannelConnection, error) {
+	connection, err := s.channelRepo.GetChannelConnectionByID(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if connection == nil {
+		return nil, errors.New("channel connection not found")
+	}
+
+	return connection, nil
+}
+
+func (s *channelService) ListChannelConnectionsByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.ChannelConnection, error) {
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.channelRepo.ListChannelConnectionsByHotel(ctx, hotelID, pageSize, offset)
+}
+
+// UpdateChannelConnection changes the code a connection lists its hotel under, or whether it is
+// synchronized. A connection stays with its hotel and channel. Changing the code sends the
+// listing its full ARI on the next pass.
+func (s *channelService) UpdateChannelConnection(ctx context.Context, connection *model.ChannelConnection) error {
+	existing, err := s.channelRepo.GetChannelConnectionByID(ctx, connection.ConnectionID)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		return errors.New("channel connection not found")
+	}
+
+	connection.HotelID = existing.HotelID
+	connection.Channel = existing.Channel
+	if err := s.checkHotelCode(ctx, connection); err != nil {
+		return err
+	}
+
+	if !connection.IsActive.Valid {
+		connection.IsActive = existing.IsActive
+	}
+	connection.LastPushedAt = existing.LastPushedAt
+	connection.PushError = existing.PushError
+	connection.PushDueAt = existing.PushDueAt
+	connection.LastPulledAt = existing.LastPulledAt
+	connection.PullError = existing.PullError
+	connection.CreatedAt = existing.CreatedAt
+	connection.CreatedBy = existing.CreatedBy
+	connection.UpdateAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.channelRepo.UpdateChannelConnection(ctx, connection); err != nil {
+		return err
+	}
+
+	if connection.HotelCode != existing.HotelCode {
+		connection.PushDueAt = sql.NullTime{Time: time.Now(), Valid: true}
+		return s.store.MarkChannelPushDue(ctx, db.MarkChannelPushDueParams{
+			ConnectionID: connection.ConnectionID,
+			PushDueAt:    connection.PushDueAt,
+		})
+	}
+	return nil
+}
+
+// DeleteChannelConnection deletes a connection and its mappings. Reservations imported through
+// the connection are kept.
+func (s *channelService) DeleteChannelConnection(ctx context.Context, connectionID uuid.UUID) error {
+	connection, err := s.channelRepo.GetChannelConnectionByID(ctx, connectionID)
+	if err != nil {
+		return err
+	}
+
+	if connection == nil {
+		return errors.New("channel connection not found")
+	}
+
+	return s.channelRepo.DeleteChannelConnection(ctx, connectionID)
+}
+
+// CreateChannelMapping maps a room type or a single room of the connection's hotel to a room
+// code on the channel. A room and its type cannot both be mapped on one connection, since the
+// channel would sell the room twice.
+func (s *channelService) CreateChannelMapping(ctx context.Context, mapping *model.ChannelMapping) error {
+	if mapping.MappingID == uuid.Nil {
+		mapping.MappingID = uuid.New()
+	}
+
+	connection, err := s.channelRepo.GetChannelConnectionByID(ctx, mapping.ConnectionID)
+	if err != nil {
+		return err
+	}
+	if connection == nil {
+		return errors.New("channel connection not found")
+	}
+
+	mapping.RoomCode = strings.TrimSpace(mapping.RoomCode)
+	if mapping.RoomCode == "" {
+		return errors.New("room code is required")
+	}
+	if mapping.RateCode.Valid {
+		mapping.RateCode.String = strings.TrimSpace(mapping.RateCode.String)
+		mapping.RateCode.Valid = mapping.RateCode.String != ""
+	}
+
+	if mapping.TypeID.Valid == mapping.RoomID.Valid {
+		return errors.New("either room type or room ID is required")
+	}
+
+	// The type a mapping sells, which for a single room is the room's type
+	typeID := mapping.TypeID
+	if mapping.RoomID.Valid {
+		room, err := s.roomRepo.GetRoomByID(ctx, mapping.RoomID.UUID)
+		if err != nil {
+			return err
+		}
+		if room == nil {
+			return errors.New("room not found")
+		}
+		if !room.HotelID.Valid || room.HotelID.UUID != connection.HotelID {
+			return errors.New("room does not belong to the connection's hotel")
+		}
+		typeID = room.TypeID
+	} else {
+		roomType, err := s.roomTypeRepo.GetRoomTypeByCode(ctx, mapping.TypeID.String)
+		if err != nil {
+			return err
+		}
+		if roomType == nil {
+			return errors.New("room type not found")
+		}
+	}
+
+	mappings, err := s.channelRepo.ListChannelMappingsByConnection(ctx, mapping.ConnectionID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range mappings {
+		if existing.RoomCode == mapping.RoomCode {
+			return errors.New("room code is already mapped")
+		}
+		if mapping.RoomID.Valid && existing.RoomID == mapping.RoomID {
+			return errors.New("room is already mapped")
+		}
+		if mapping.TypeID.Valid && existing.TypeID == mapping.TypeID {
+			return errors.New("room type is already mapped")
+		}
+	}
+
+	// Rooms of the mapped type and the type of a mapped room would be sold twice
+	for _, existing := range mappings {
+		if mapping.RoomID.Valid && existing.TypeID.Valid && existing.TypeID == typeID {
+			return errors.New("a room and its room type cannot both be mapped")
+		}
+		if mapping.TypeID.Valid && existing.RoomID.Valid {
+			room, err := s.roomRepo.GetRoomByID(ctx, existing.RoomID.UUID)
+			if err != nil {
+				return err
+			}
+			if room != nil && room.TypeID == mapping.TypeID {
+				return errors.New("a room and its room type cannot both be mapped")
+			}
+		}
+	}
+
+	mapping.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	if err := s.channelRepo.CreateChannelMapping(ctx, mapping); err != nil {
+		return err
+	}
+
+	// The new room code is sent its full ARI on the next pass
+	return s.store.MarkChannelPushDue(ctx, db.MarkChannelPushDueParams{
+		ConnectionID: connection.ConnectionID,
+		PushDueAt:    sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+func (s *channelService) ListChannelMappings(ctx context.Context, connectionID uuid.UUID) ([]*model.ChannelMapping, error) {
+	connection, err := s.channelRepo.GetChannelConnectionByID(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if connection == nil {
+		return nil, errors.New("channel connection not found")
+	}
+
+	return s.channelRepo.ListChannelMappingsByConnection(ctx, connectionID)
+}
+
+// DeleteChannelMapping stops selling a room code on the channel. What the channel last received
+// for the code is left to the channel.
+func (s *channelService) DeleteChannelMapping(ctx context.Context, mappingID uuid.UUID) error {
+	mapping, err := s.channelRepo.GetChannelMappingByID(ctx, mappingID)
+	if err != nil {
+		return err
+	}
+
+	if mapping == nil {
+		return errors.New("channel mapping not found")
+	}
+
+	return s.channelRepo.DeleteChannelMapping(ctx, mappingID)
+}
+
+// PushChannelARI sends a connection its full ARI now, whether or not it is due or active
+func (s *channelService) PushChannelARI(ctx context.Context, connectionID uuid.UUID, now time.Time) (*model.ChannelPushResult, error) {
+	connection, err := s.channelRepo.GetChannelConnectionByID(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if connection == nil {
+		return nil, errors.New("channel connection not found")
+	}
+
+	return s.pushFull(ctx, connection.ToDBModel(), now)
+}
+
+// PullChannelReservations pulls a connection's reservations now, whether or not it is due or active
+func (s *channelService) PullChannelReservations(ctx context.Context, connectionID uuid.UUID, now time.Time) (*model.ChannelPullResult, error) {
+	connection, err := s.channelRepo.GetChannelConnectionByID(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if connection == nil {
+		return nil, errors.New("channel connection not found")
+	}
+
+	return s.pull(ctx, connection.ToDBModel(), now)
+}
+
+// SyncDueChannels pulls the reservations of the active connections not pulled within the pull
+// interval, then sends their full ARI to the active connections that have not had it within the
+// refresh interval, that an event made due or whose last push failed. It returns how many pulls
+// and pushes succeeded.
+func (s *channelService) SyncDueChannels(ctx context.Context, now time.Time) (int, error) {
+	pulls, err := s.store.ListDueChannelPulls(ctx, db.ListDueChannelPullsParams{
+		PulledBefore:   sql.NullTime{Time: now.Add(-channelPullInterval), Valid: true},
+		MaxConnections: channelSyncBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var synced int
+	for i := range pulls {
+		if _, err := s.pull(ctx, &pulls[i], now); err != nil {
+			if logger.Log != nil {
+				logger.Log.Warn("Failed to pull channel reservations",
+					zap.String("connection_id", pulls[i].ConnectionID.String()),
+					zap.String("channel", pulls[i].Channel),
+					zap.Error(err),
+				)
+			}
+			continue
+		}
+		synced++
+	}
+
+	pushes, err := s.store.ListDueChannelPushes(ctx, db.ListDueChannelPushesParams{
+		PushedBefore:   sql.NullTime{Time: now.Add(-channelRefreshInterval), Valid: true},
+		MaxConnections: channelSyncBatchSize,
+	})
+	if err != nil {
+		return synced, err
+	}
+
+	for i := range pushes {
+		if _, err := s.pushFull(ctx, &pushes[i], now); err != nil {
+			if logger.Log != nil {
+				logger.Log.Warn("Failed to push ARI to channel",
+					zap.String("connection_id", pushes[i].ConnectionID.String()),
+					zap.String("channel", pushes[i].Channel),
+					zap.Error(err),
+				)
+			}
+			continue
+		}
+		synced++
+	}
+
+	return synced, nil
+}
+
+// Publish makes the active connections of a hotel due a push when a reservation, room or hotel
+// event changes its ARI. SyncDueChannels then sends them their full ARI, so a slow or down channel
+// never holds up the outbox, and the events of a busy minute are sent in one push.
+func (s *channelService) Publish(ctx context.Context, event *model.Event) error {
+	if !event.HotelID.Valid {
+		return nil
+	}
+
+	switch event.Type {
+	case model.EventReservationCreated, model.EventReservationCancelled, model.EventReservationExpired,
+		model.EventReservationModified, model.EventRoomCreated, model.EventRoomUpdated, model.EventRoomDeleted, model.EventHotelUpdated:
+	default:
+		return nil
+	}
+
+	return markChannelsDue(ctx, s.store, event.HotelID)
+}
+
+// markChannelsDue makes the active connections of a hotel due a push. Services changing ARI
+// without raising an event, such as room blocks and stay restrictions, call it themselves.
+func markChannelsDue(ctx context.Context, q db.Querier, hotelID uuid.NullUUID) error {
+	if !hotelID.Valid {
+		return nil
+	}
+
+	return q.MarkHotelChannelsDue(ctx, db.MarkHotelChannelsDueParams{
+		HotelID:   hotelID.UUID,
+		PushDueAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+// channelHorizon returns the dates ARI is pushed for: from the current day at the hotel for
+// channelHorizonDays days
+func channelHorizon(hotel *model.Hotel, now time.Time) (time.Time, time.Time) {
+	today := hotelToday(hotel, now)
+	return today, today.AddDate(0, 0, channelHorizonDays)
+}
+
+// pushFull sends a connection its ARI for the whole horizon and records the outcome
+func (s *channelService) pushFull(ctx context.Context, connection *db.ChannelConnection, now time.Time) (*model.ChannelPushResult, error) {
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, connection.HotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+
+	from, to := channelHorizon(hotel, now)
+	items, err := s.push(ctx, connection, hotel, from, to)
+	if err != nil {
+		s.recordPushFailure(ctx, connection, err)
+		return nil, err
+	}
+
+	// A change marked after the connection was read is not in this push, so its mark is kept
+	err = s.store.MarkChannelPushed(ctx, db.MarkChannelPushedParams{
+		LastPushedAt: sql.NullTime{Time: now, Valid: true},
+		DueSeen:      connection.PushDueAt,
+		ConnectionID: connection.ConnectionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ChannelPushResult{
+		ConnectionID: connection.ConnectionID,
+		From:         from.Format(channel.DateLayout),
+		To:           to.Format(channel.DateLayout),
+		Items:        items,
+	}, nil
+}
+
+func (s *channelService) recordPushFailure(ctx context.Context, connection *db.ChannelConnection, pushErr error) {
+	err := s.store.MarkChannelPushFailed(ctx, db.MarkChannelPushFailedParams{
+		ConnectionID: connection.ConnectionID,
+		PushError:    sql.NullString{String: pushErr.Error(), Valid: true},
+	})
+	if logger.Log == nil {
+		return
+	}
+	logger.Log.Warn("Failed to push ARI to channel",
+		zap.String("connection_id", connection.ConnectionID.String()),
+		zap.String("channel", connection.Channel),
+		zap.Error(pushErr),
+	)
+	if err != nil {
+		logger.Log.Error("Failed to record channel push failure", zap.Error(err))
+	}
+}
+
+// push sends the ARI of every room code mapped on a connection for the dates in [from, to) and
+// returns how many items it sent
+func (s *channelService) push(ctx context.Context, connection *db.ChannelConnection, hotel *model.Hotel, from, to time.Time) (int, error) {
+	provider, ok := s.channels.Provider(connection.Channel)
+	if !ok {
+		return 0, fmt.Errorf("channel %s is not available", connection.Channel)
+	}
+
+	mappings, err := s.store.ListChannelMappingsByConnection(ctx, connection.ConnectionID)
+	if err != nil {
+		return 0, err
+	}
+	if len(mappings) == 0 {
+		return 0, nil
+	}
+
+	// Nights run from check-in on one date to check-in on the next, like the nights of a stay
+	nightsStart, firstDeparture := stayWindow(hotel, from, from)
+	nightsEnd, _ := stayWindow(hotel, to, to)
+	hotelID := uuid.NullUUID{UUID: connection.HotelID, Valid: true}
+
+	typeNights, err := s.store.ListNightlyTypeAvailability(ctx, db.ListNightlyTypeAvailabilityParams{
+		HotelID:   hotelID,
+		StartDate: nightsStart,
+		EndDate:   nightsEnd,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rooms := make(map[uuid.UUID]*model.Room)
+	var roomNights []db.ListNightlyRoomOccupancyRow
+	for i := range mappings {
+		if !mappings[i].RoomID.Valid {
+			continue
+		}
+		room, err := s.roomRepo.GetRoomByID(ctx, mappings[i].RoomID.UUID)
+		if err != nil {
+			return 0, err
+		}
+		if room != nil {
+			rooms[room.RoomID] = room
+		}
+	}
+	if len(rooms) > 0 {
+		roomNights, err = s.store.ListNightlyRoomOccupancy(ctx, db.ListNightlyRoomOccupancyParams{
+			HotelID:   hotelID,
+			StartDate: nightsStart,
+			EndDate:   nightsEnd,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Departures on the first date leave at check-out, before its night begins
+	dbRestrictions, err := s.store.ListStayRestrictionsInRange(ctx, db.ListStayRestrictionsInRangeParams{
+		HotelID:   hotelID,
+		StartDate: firstDeparture,
+		EndDate:   nightsEnd,
+	})
+	if err != nil {
+		return 0, err
+	}
+	restrictions := make([]*model.StayRestriction, 0, len(dbRestrictions))
+	for i := range dbRestrictions {
+		restrictions = append(restrictions, model.FromDBStayRestriction(&dbRestrictions[i]))
+	}
+
+	items := buildChannelARI(hotel, mappings, rooms, typeNights, roomNights, restrictions, from, to)
+
+	ctx, cancel := context.WithTimeout(ctx, channelTimeout)
+	defer cancel()
+
+	if err := provider.PushARI(ctx, &channel.ARIUpdate{HotelCode: connection.HotelCode, Items: items}); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// buildChannelARI returns an item for every mapping and date in [from, to). A mapped type offers
+// the rooms of the type still free that night at the price of its cheapest room; a mapped room
+// offers itself at its own price while it is free and its type is not sold out. Restrictions are
+// those a stay arriving, or for closed to departure leaving, on the date would be checked against.
+func buildChannelARI(hotel *model.Hotel, mappings []db.ChannelMapping, rooms map[uuid.UUID]*model.Room, typeNights []db.ListNightlyTypeAvailabilityRow, roomNights []db.ListNightlyRoomOccupancyRow, restrictions []*model.StayRestriction, from, to time.Time) []*channel.ARIItem {
+	loc := hotelLocation(hotel)
+
+	typeAvailable := make(map[string]int32)
+	typePrice := make(map[string]int32)
+	for _, night := range typeNights {
+		typeAvailable[night.TypeID+"/"+night.Night.In(loc).Format(channel.DateLayout)] = night.Available
+		typePrice[night.TypeID] = night.MinPrice
+	}
+	roomOccupied := make(map[string]bool)
+	for _, night := range roomNights {
+		roomOccupied[night.RoomID.String()+"/"+night.Night.In(loc).Format(channel.DateLayout)] = night.Occupied
+	}
+
+	var items []*channel.ARIItem
+	for _, mapping := range mappings {
+		typeID := mapping.TypeID.String
+		var room *model.Room
+		if mapping.RoomID.Valid {
+			room = rooms[mapping.RoomID.UUID]
+			if room == nil {
+				continue
+			}
+			typeID = room.TypeID.String
+		}
+
+		for date := from; date.Before(to); date = date.AddDate(0, 0, 1) {
+			key := date.Format(channel.DateLayout)
+
+			available := typeAvailable[typeID+"/"+key]
+			rate := typePrice[typeID]
+			if room != nil {
+				if roomOccupied[room.RoomID.String()+"/"+key] {
+					available = 0
+				} else if available > 1 {
+					available = 1
+				}
+				rate = room.Price.Int32
+			}
+			if available < 0 {
+				available = 0
+			}
+
+			item := &channel.ARIItem{
+				RoomCode:  mapping.RoomCode,
+				RateCode:  mapping.RateCode.String,
+				Date:      channel.Date(date),
+				Available: available,
+				Rate:      rate,
+				StopSell:  available == 0,
+			}
+			applyChannelRestrictions(item, hotel, typeID, date, restrictions)
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// applyChannelRestrictions sets an item's restrictions the way checkStayRestrictions applies them
+// to a stay arriving or leaving on date. Overlapping restrictions combine to the strictest.
+func applyChannelRestrictions(item *channel.ARIItem, hotel *model.Hotel, typeID string, date time.Time, restrictions []*model.StayRestriction) {
+	arrival, departure := stayWindow(hotel, date, date)
+	for _, restriction := range restrictions {
+		if restriction.TypeID.Valid && restriction.TypeID.String != typeID {
+			continue
+		}
+
+		coversArrival := !arrival.Before(restriction.StartDate.Time) && arrival.Before(restriction.EndDate.Time)
+		coversDeparture := !departure.Before(restriction.StartDate.Time) && departure.Before(restriction.EndDate.Time)
+
+		if coversArrival {
+			item.ClosedToArrival = item.ClosedToArrival || restriction.ClosedToArrival.Bool
+			if restriction.MinNights.Valid && restriction.MinNights.Int32 > item.MinNights {
+				item.MinNights = restriction.MinNights.Int32
+			}
+			if restriction.MaxNights.Valid && (item.MaxNights == 0 || restriction.MaxNights.Int32 < item.MaxNights) {
+				item.MaxNights = restriction.MaxNights.Int32
+			}
+		}
+		if coversDeparture {
+			item.ClosedToDeparture = item.ClosedToDeparture || restriction.ClosedToDeparture.Bool
+		}
+	}
+}
+
+// pull imports a connection's reservations changed since shortly before its previous pull and
+// records the outcome. Reservations the connection cannot import are skipped; a failure to
+// import one it can fails the pull, so the next pull tries again from the same point.
+func (s *channelService) pull(ctx context.Context, connection *db.ChannelConnection, now time.Time) (*model.ChannelPullResult, error) {
+	result, err := s.importReservations(ctx, connection)
+	if err != nil {
+		if markErr := s.store.MarkChannelPullFailed(ctx, db.MarkChannelPullFailedParams{
+			ConnectionID: connection.ConnectionID,
+			PullError:    sql.NullString{String: err.Error(), Valid: true},
+		}); markErr != nil {
+			return nil, markErr
+		}
+		return nil, err
+	}
+
+	err = s.store.MarkChannelPulled(ctx, db.MarkChannelPulledParams{
+		ConnectionID: connection.ConnectionID,
+		LastPulledAt: sql.NullTime{Time: now, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if logger.Log != nil && (len(result.Conflicts) > 0 || len(result.Skipped) > 0) {
+		logger.Log.Warn("Channel reservations need attention",
+			zap.String("connection_id", connection.ConnectionID.String()),
+			zap.String("channel", connection.Channel),
+			zap.Int("conflicts", len(result.Conflicts)),
+			zap.Strings("skipped", result.Skipped),
+		)
+	}
+	return result, nil
+}
+
+func (s *channelService) importReservations(ctx context.Context, connection *db.ChannelConnection) (*model.ChannelPullResult, error) {
+	provider, ok := s.channels.Provider(connection.Channel)
+	if !ok {
+		return nil, fmt.Errorf("channel %s is not available", connection.Channel)
+	}
+
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, connection.HotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+
+	dbMappings, err := s.store.ListChannelMappingsByConnection(ctx, connection.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	mappings := make(map[string]*db.ChannelMapping, len(dbMappings))
+	for i := range dbMappings {
+		mappings[dbMappings[i].RoomCode] = &dbMappings[i]
+	}
+
+	var since time.Time
+	if connection.LastPulledAt.Valid {
+		since = connection.LastPulledAt.Time.Add(-channelPullOverlap)
+	}
+
+	pullCtx, cancel := context.WithTimeout(ctx, channelTimeout)
+	reservations, err := provider.PullReservations(pullCtx, connection.HotelCode, since)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ChannelPullResult{ConnectionID: connection.ConnectionID, Received: len(reservations)}
+	for _, incoming := range reservations {
+		mapping := mappings[incoming.RoomCode]
+		if problem := channelReservationProblem(incoming, mapping); problem != "" {
+			result.Skipped = append(result.Skipped, incoming.ExternalID+": "+problem)
+			continue
+		}
+
+		if err := s.importReservation(ctx, connection, hotel, mapping, incoming, result); err != nil {
+			return nil, fmt.Errorf("reservation %s: %w", incoming.ExternalID, err)
+		}
+	}
+
+	return result, nil
+}
+
+// channelReservationProblem explains why a pulled reservation cannot be imported, or returns ""
+func channelReservationProblem(incoming *channel.Reservation, mapping *db.ChannelMapping) string {
+	switch {
+	case strings.TrimSpace(incoming.ExternalID) == "":
+		return "missing external ID"
+	case incoming.Status != channel.StatusBooked && incoming.Status != channel.StatusModified && incoming.Status != channel.StatusCancelled:
+		return "unknown status " + incoming.Status
+	case mapping == nil:
+		return "room code " + incoming.RoomCode + " is not mapped"
+	case incoming.Nights() < 1:
+		return "departure must be after arrival"
+	case incoming.Adults < 0 || incoming.Children < 0 || incoming.TotalPrice < 0:
+		return "invalid party or price"
+	}
+	return ""
+}
+
+// importReservation creates, modifies or cancels the reservation a channel reservation was
+// imported as, recognizing it by the channel and its external ID
+func (s *channelService) importReservation(ctx context.Context, connection *db.ChannelConnection, hotel *model.Hotel, mapping *db.ChannelMapping, incoming *channel.Reservation, result *model.ChannelPullResult) error {
+	var outcome string
+	var conflict bool
+	var imported, released *model.Reservation
+
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		outcome, conflict, imported, released = "", false, nil, nil
+
+		dbExisting, err := q.GetChannelReservationForUpdate(ctx, db.GetChannelReservationForUpdateParams{
+			Source:     sql.NullString{String: connection.Channel, Valid: true},
+			ExternalID: sql.NullString{String: incoming.ExternalID, Valid: true},
+		})
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		var existing *model.Reservation
+		if err == nil {
+			existing = model.FromDBReservation(&dbExisting)
+		}
+
+		switch {
+		case existing == nil && incoming.Status == channel.StatusCancelled:
+			// Cancelled before it was ever pulled
+			outcome = "unchanged"
+			return nil
+		case existing == nil:
+			imported, err = channelReservation(ctx, q, connection, hotel, mapping, incoming)
+			if err != nil {
+				return err
+			}
+			conflict, err = s.createChannelReservation(ctx, q, imported)
+			outcome = "created"
+			return err
+		case existing.Status.String == "CANCELLED" && incoming.Status == channel.StatusCancelled:
+			outcome = "unchanged"
+			return nil
+		case existing.Status.String == "CANCELLED" || existing.Status.String == "COMPLETED":
+			outcome = "skipped: reservation is " + strings.ToLower(existing.Status.String) + " here"
+			return nil
+		case incoming.Status == channel.StatusCancelled:
+			dbCancelled, err := q.UpdateReservationStatus(ctx, db.UpdateReservationStatusParams{
+				ReservationID: existing.ReservationID,
+				Status:        sql.NullString{String: "CANCELLED", Valid: true},
+				UpdateAt:      sql.NullTime{Time: time.Now(), Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			released = model.FromDBReservation(&dbCancelled)
+			outcome = "cancelled"
+			return recordReservationEvent(ctx, q, model.EventReservationCancelled, released)
+		}
+
+		imported, err = channelReservation(ctx, q, connection, hotel, mapping, incoming)
+		if err != nil {
+			return err
+		}
+		imported.ReservationID = existing.ReservationID
+		if !channelReservationChanged(existing, imported) {
+			outcome = "unchanged"
+			return nil
+		}
+		conflict, err = s.modifyChannelReservation(ctx, q, connection, existing, imported)
+		outcome = "modified"
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	switch outcome {
+	case "created":
+		result.Created++
+	case "modified":
+		result.Modified++
+	case "cancelled":
+		result.Cancelled++
+	case "unchanged":
+		result.Unchanged++
+	default:
+		result.Skipped = append(result.Skipped, incoming.ExternalID+": "+strings.TrimPrefix(outcome, "skipped: "))
+	}
+	if conflict {
+		result.Conflicts = append(result.Conflicts, imported.ReservationID)
+	}
+
+	if released != nil {
+		s.waitlist.MatchReleasedInventory(ctx, released)
+	}
+	return nil
+}
+
+// channelReservation builds the reservation a channel reservation is imported as: a confirmed
+// stay of the mapped room or room type, at the price the guest paid on the channel
+func channelReservation(ctx context.Context, q *db.Queries, connection *db.ChannelConnection, hotel *model.Hotel, mapping *db.ChannelMapping, incoming *channel.Reservation) (*model.Reservation, error) {
+	reservation := &model.Reservation{
+		ReservationID:     uuid.New(),
+		HotelID:           uuid.NullUUID{UUID: connection.HotelID, Valid: true},
+		TypeID:            mapping.TypeID,
+		RoomID:            mapping.RoomID,
+		Status:            sql.NullString{String: "CONFIRMED", Valid: true},
+		StayType:          sql.NullString{String: model.StayTypeOvernight, Valid: true},
+		Adults:            sql.NullInt32{Int32: incoming.Adults, Valid: true},
+		Children:          sql.NullInt32{Int32: incoming.Children, Valid: true},
+		TotalPrice:        sql.NullInt32{Int32: incoming.TotalPrice, Valid: true},
+		ExtraPersonCharge: sql.NullInt32{Int32: 0, Valid: true},
+		ExtrasAmount:      sql.NullInt32{Int32: 0, Valid: true},
+		SpecialRequests:   sql.NullString{String: incoming.SpecialRequests, Valid: incoming.SpecialRequests != ""},
+		Source:            sql.NullString{String: connection.Channel, Valid: true},
+		ExternalID:        sql.NullString{String: incoming.ExternalID, Valid: true},
+	}
+	if reservation.Adults.Int32 < 1 {
+		reservation.Adults.Int32 = 1
+	}
+
+	if mapping.RoomID.Valid {
+		room, err := q.GetRoom(ctx, mapping.RoomID.UUID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.New("room not found")
+			}
+			return nil, err
+		}
+		reservation.TypeID = room.TypeID
+	}
+
+	start, end := stayWindow(hotel, incoming.Arrival, incoming.Departure)
+	reservation.StartDate = sql.NullTime{Time: start, Valid: true}
+	reservation.EndDate = sql.NullTime{Time: end, Valid: true}
+
+	first := strings.TrimSpace(incoming.Guest.FirstName)
+	last := strings.TrimSpace(incoming.Guest.LastName)
+	if first != "" && last != "" {
+		reservation.Guests = []*model.ReservationGuest{{
+			FirstName: sql.NullString{String: first, Valid: true},
+			LastName:  sql.NullString{String: last, Valid: true},
+			IsPrimary: sql.NullBool{Bool: true, Valid: true},
+			Email:     sql.NullString{String: strings.TrimSpace(incoming.Guest.Email), Valid: strings.TrimSpace(incoming.Guest.Email) != ""},
+			Phone:     sql.NullString{String: strings.TrimSpace(incoming.Guest.Phone), Valid: strings.TrimSpace(incoming.Guest.Phone) != ""},
+		}}
+	}
+
+	return reservation, nil
+}
+
+// channelReservationChanged reports whether an imported reservation differs from the one it was
+// imported as before in anything the channel decides
+func channelReservationChanged(existing, imported *model.Reservation) bool {
+	return !existing.StartDate.Time.Equal(imported.StartDate.Time) ||
+		!existing.EndDate.Time.Equal(imported.EndDate.Time) ||
+		existing.TypeID != imported.TypeID ||
+		(imported.RoomID.Valid && existing.RoomID != imported.RoomID) ||
+		existing.Adults.Int32 != imported.Adults.Int32 ||
+		existing.Children.Int32 != imported.Children.Int32 ||
+		existing.TotalPrice.Int32 != imported.TotalPrice.Int32
+}
+
+func (s *channelService) createChannelReservation(ctx context.Context, q *db.Queries, reservation *model.Reservation) (bool, error) {
+	conflict, err := checkChannelInventory(ctx, q, reservation)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	reservation.CreatedAt = sql.NullTime{Time: now, Valid: true}
+	reservation.ConfirmationCode, err = newConfirmationCode(ctx, q)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = q.CreateReservation(ctx, db.CreateReservationParams{
+		ReservationID:     reservation.ReservationID,
+		RoomID:            reservation.RoomID,
+		StartDate:         reservation.StartDate,
+		EndDate:           reservation.EndDate,
+		Status:            reservation.Status,
+		CreatedAt:         reservation.CreatedAt,
+		TotalPrice:        reservation.TotalPrice,
+		HotelID:           reservation.HotelID,
+		TypeID:            reservation.TypeID,
+		StayType:          reservation.StayType,
+		Adults:            reservation.Adults,
+		Children:          reservation.Children,
+		ExtraPersonCharge: reservation.ExtraPersonCharge,
+		ExtrasAmount:      reservation.ExtrasAmount,
+		SpecialRequests:   reservation.SpecialRequests,
+		ConfirmationCode:  reservation.ConfirmationCode,
+		Source:            reservation.Source,
+		ExternalID:        reservation.ExternalID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := createStaySegment(ctx, q, reservation); err != nil {
+		return false, err
+	}
+
+	if err := saveReservationGuests(ctx, q, reservation); err != nil {
+		return false, err
+	}
+
+	return conflict, recordReservationEvent(ctx, q, model.EventReservationCreated, reservation)
+}
+
+// modifyChannelReservation applies a channel's changes to a reservation imported from it. A
+// stay the guest was moved within keeps its rooms when the channel did not change its dates.
+func (s *channelService) modifyChannelReservation(ctx context.Context, q *db.Queries, connection *db.ChannelConnection, existing, imported *model.Reservation) (bool, error) {
+	segments, err := q.ListReservationSegmentsForUpdate(ctx, existing.ReservationID)
+	if err != nil {
+		return false, err
+	}
+
+	reservation := *existing
+	reservation.StartDate = imported.StartDate
+	reservation.EndDate = imported.EndDate
+	reservation.TypeID = imported.TypeID
+	reservation.Adults = imported.Adults
+	reservation.Children = imported.Children
+	reservation.TotalPrice = imported.TotalPrice
+	reservation.Guests = imported.Guests
+
+	rebooked := !reservation.StartDate.Time.Equal(existing.StartDate.Time) || !reservation.EndDate.Time.Equal(existing.EndDate.Time) || reservation.TypeID != existing.TypeID
+	if imported.RoomID.Valid && imported.RoomID != existing.RoomID {
+		reservation.RoomID = imported.RoomID
+		rebooked = true
+	} else if rebooked && !imported.RoomID.Valid && existing.RoomID.Valid {
+		// A room staff assigned is kept if it is still of the booked type and free for the new dates
+		dbRoom, err := q.GetRoom(ctx, existing.RoomID.UUID)
+		if err != nil {
+			return false, err
+		}
+		if dbRoom.TypeID != reservation.TypeID {
+			reservation.RoomID = uuid.NullUUID{}
+		}
+	}
+
+	var conflict bool
+	if rebooked {
+		conflict, err = checkChannelInventory(ctx, q, &reservation)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+	reservation.UpdateAt = now
+
+	_, err = q.ModifyReservation(ctx, db.ModifyReservationParams{
+		ReservationID:     reservation.ReservationID,
+		RoomID:            reservation.RoomID,
+		UserID:            reservation.UserID,
+		StartDate:         reservation.StartDate,
+		EndDate:           reservation.EndDate,
+		HotelID:           reservation.HotelID,
+		TypeID:            reservation.TypeID,
+		TotalPrice:        reservation.TotalPrice,
+		DiscountAmount:    reservation.DiscountAmount,
+		UpdateAt:          reservation.UpdateAt,
+		Adults:            reservation.Adults,
+		Children:          reservation.Children,
+		ChildAges:         reservation.ChildAges,
+		ExtraPersonCharge: reservation.ExtraPersonCharge,
+		ExtrasAmount:      reservation.ExtrasAmount,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if reservation.Guests != nil {
+		if err := saveReservationGuests(ctx, q, &reservation); err != nil {
+			return false, err
+		}
+	}
+
+	if rebooked || len(segments) == 0 {
+		if err := q.DeleteReservationSegments(ctx, reservation.ReservationID); err != nil {
+			return false, err
+		}
+		if err := createStaySegment(ctx, q, &reservation); err != nil {
+			return false, err
+		}
+	}
+
+	_, err = q.CreateReservationModification(ctx, db.CreateReservationModificationParams{
+		ModificationID: uuid.New(),
+		ReservationID:  uuid.NullUUID{UUID: reservation.ReservationID, Valid: true},
+		ModifiedByRole: sql.NullString{String: roleChannel, Valid: true},
+		Reason:         sql.NullString{String: "Modified on " + connection.Channel, Valid: true},
+		OldRoomID:      existing.RoomID,
+		NewRoomID:      reservation.RoomID,
+		OldUserID:      existing.UserID,
+		NewUserID:      reservation.UserID,
+		OldStartDate:   existing.StartDate,
+		NewStartDate:   reservation.StartDate,
+		OldEndDate:     existing.EndDate,
+		NewEndDate:     reservation.EndDate,
+		OldTotalPrice:  existing.TotalPrice,
+		NewTotalPrice:  reservation.TotalPrice,
+		CreatedAt:      now,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	*imported = reservation
+	return conflict, recordReservationEvent(ctx, q, model.EventReservationModified, &reservation)
+}
+
+// checkChannelInventory locks the rooms of a channel reservation's type and reports whether the
+// hotel had no room left for the stay. A room the stay cannot have, because another stay or a
+// block holds it, is taken off the reservation so that it is not double-booked.
+func checkChannelInventory(ctx context.Context, q *db.Queries, reservation *model.Reservation) (bool, error) {
+	rooms, err := q.LockRoomsByHotelAndType(ctx, db.LockRoomsByHotelAndTypeParams{
+		HotelID: reservation.HotelID,
+		TypeID:  reservation.TypeID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var conflict bool
+	if reservation.RoomID.Valid {
+		overlapping, err := countRoomConflicts(ctx, q, reservation, reservation.RoomID.UUID)
+		if err != nil {
+			return false, err
+		}
+
+		blocked, err := q.CountOverlappingRoomBlocks(ctx, db.CountOverlappingRoomBlocksParams{
+			RoomID:    reservation.RoomID,
+			StartDate: reservation.StartDate.Time,
+			EndDate:   reservation.EndDate.Time,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		if overlapping > 0 || blocked > 0 {
+			reservation.RoomID = uuid.NullUUID{}
+			conflict = true
+		}
+	}
+
+	available, err := q.GetMinNightlyTypeAvailability(ctx, db.GetMinNightlyTypeAvailabilityParams{
+		TotalRooms:           int32(len(rooms)),
+		StartDate:            reservation.StartDate.Time,
+		EndDate:              reservation.EndDate.Time,
+		HotelID:              reservation.HotelID,
+		TypeID:               reservation.TypeID,
+		ExcludeReservationID: reservation.ReservationID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return conflict || available < 1, nil
+}
\ No newline at end of file
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/channel"
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+func TestBuildChannelARI(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hotel := &model.Hotel{
+		TimeZone:     sql.NullString{String: "Asia/Ho_Chi_Minh", Valid: true},
+		CheckInTime:  sql.NullString{String: "14:00", Valid: true},
+		CheckOutTime: sql.NullString{String: "12:00", Valid: true},
+	}
+	local := func(d int) time.Time { return time.Date(2026, 11, d, 0, 0, 0, 0, hcm) }
+	// Nights begin at check-in, which is 07:00 UTC
+	night := func(d int) time.Time { return time.Date(2026, 11, d, 7, 0, 0, 0, time.UTC) }
+
+	room := &model.Room{
+		RoomID: uuid.New(),
+		TypeID: sql.NullString{String: "SUITE", Valid: true},
+		Price:  sql.NullInt32{Int32: 250, Valid: true},
+	}
+	mappings := []db.ChannelMapping{
+		{RoomCode: "DBL", RateCode: sql.NullString{String: "BAR", Valid: true}, TypeID: sql.NullString{String: "DOUBLE", Valid: true}},
+		{RoomCode: "STE-101", RoomID: uuid.NullUUID{UUID: room.RoomID, Valid: true}},
+	}
+	typeNights := []db.ListNightlyTypeAvailabilityRow{
+		{TypeID: "DOUBLE", Night: night(1), Available: 3, MinPrice: 100},
+		{TypeID: "DOUBLE", Night: night(2), Available: 0, MinPrice: 100},
+		{TypeID: "DOUBLE", Night: night(3), Available: -1, MinPrice: 100},
+		{TypeID: "SUITE", Night: night(1), Available: 2, MinPrice: 200},
+		{TypeID: "SUITE", Night: night(2), Available: 2, MinPrice: 200},
+		{TypeID: "SUITE", Night: night(3), Available: 0, MinPrice: 200},
+	}
+	roomNights := []db.ListNightlyRoomOccupancyRow{
+		{RoomID: room.RoomID, Night: night(1), Occupied: false},
+		{RoomID: room.RoomID, Night: night(2), Occupied: true},
+		{RoomID: room.RoomID, Night: night(3), Occupied: false},
+	}
+	restrictions := []*model.StayRestriction{
+		// Arrivals and departures on the 2nd for every type
+		{StartDate: sql.NullTime{Time: local(2), Valid: true}, EndDate: sql.NullTime{Time: local(3), Valid: true}, MinNights: sql.NullInt32{Int32: 2, Valid: true}, MaxNights: sql.NullInt32{Int32: 7, Valid: true}, ClosedToDeparture: sql.NullBool{Bool: true, Valid: true}},
+		// A stricter restriction on doubles only
+		{TypeID: sql.NullString{String: "DOUBLE", Valid: true}, StartDate: sql.NullTime{Time: local(2), Valid: true}, EndDate: sql.NullTime{Time: local(4), Valid: true}, MinNights: sql.NullInt32{Int32: 3, Valid: true}, MaxNights: sql.NullInt32{Int32: 5, Valid: true}, ClosedToArrival: sql.NullBool{Bool: true, Valid: true}},
+	}
+
+	items := buildChannelARI(hotel, mappings, map[uuid.UUID]*model.Room{room.RoomID: room}, typeNights, roomNights, restrictions, local(1), local(4))
+	if len(items) != 6 {
+		t.Fatalf("got %d items, want 6", len(items))
+	}
+
+	tests := []struct {
+		roomCode string
+		date     string
+		want     channel.ARIItem
+	}{
+		{"DBL", "2026-11-01", channel.ARIItem{RateCode: "BAR", Available: 3, Rate: 100}},
+		{"DBL", "2026-11-02", channel.ARIItem{RateCode: "BAR", Rate: 100, StopSell: true, MinNights: 3, MaxNights: 5, ClosedToArrival: true, ClosedToDeparture: true}},
+		{"DBL", "2026-11-03", channel.ARIItem{RateCode: "BAR", Rate: 100, StopSell: true, MinNights: 3, MaxNights: 5, ClosedToArrival: true}},
+		{"STE-101", "2026-11-01", channel.ARIItem{Available: 1, Rate: 250}},
+		{"STE-101", "2026-11-02", channel.ARIItem{Rate: 250, StopSell: true, MinNights: 2, MaxNights: 7, ClosedToDeparture: true}},
+		{"STE-101", "2026-11-03", channel.ARIItem{Rate: 250, StopSell: true}},
+	}
+	for i, tt := range tests {
+		item := items[i]
+		if item.RoomCode != tt.roomCode || item.Date.Format(channel.DateLayout) != tt.date {
+			t.Errorf("item %d is %s on %s, want %s on %s", i, item.RoomCode, item.Date.Format(channel.DateLayout), tt.roomCode, tt.date)
+			continue
+		}
+
+		got := *item
+		got.RoomCode, got.Date = "", time.Time{}
+		if got != tt.want {
+			t.Errorf("%s on %s = %+v, want %+v", tt.roomCode, tt.date, got, tt.want)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+// normalizeStayType defaults a reservation to an overnight stay and rejects unknown stay types
+func normalizeStayType(reservation *model.Reservation) error {
+	if !reservation.StayType.Valid || reservation.StayType.String == "" {
+		reservation.StayType = sql.NullString{String: model.StayTypeOvernight, Valid: true}
+		return nil
+	}
+
+	switch reservation.StayType.String {
+	case model.StayTypeOvernight, model.StayTypeDayUse:
+		return nil
+	default:
+		return errors.New("invalid stay type. Use OVERNIGHT or DAY_USE")
+	}
+}
+
+func isDayUse(reservation *model.Reservation) bool {
+	return reservation.StayType.Valid && reservation.StayType.String == model.StayTypeDayUse
+}
+
+func dayUseSlotLength(hotel *model.Hotel) time.Duration {
+	minutes := hotel.DayUseSlotMinutes.Int32
+	if !hotel.DayUseSlotMinutes.Valid || minutes <= 0 {
+		minutes = defaultDayUseSlotMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func dayUseBuffer(hotel *model.Hotel) time.Duration {
+	return time.Duration(hotel.DayUseBufferMinutes.Int32) * time.Minute
+}
+
+// dayUseHours returns when day-use slots open and close on date's calendar day, in hotel-local time
+func dayUseHours(hotel *model.Hotel, date time.Time) (time.Time, time.Time) {
+	loc := hotelLocation(hotel)
+	start := hotel.DayUseStartTime.String
+	if !hotel.DayUseStartTime.Valid {
+		start = defaultDayUseStartTime
+	}
+	end := hotel.DayUseEndTime.String
+	if !hotel.DayUseEndTime.Valid {
+		end = defaultDayUseEndTime
+	}
+	return atLocalTime(date, start, loc), atLocalTime(date, end, loc)
+}
+
+// localWallClock reads t's date and clock as written by the client and places them in loc
+func localWallClock(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+}
+
+// localizeDayUse loads the reservation's hotel and interprets the reservation's start and end as
+// hotel-local clock times. The stay must fill whole slots of the hotel's slot grid within its
+// day-use hours on a single day. It returns the hotel and the number of slots booked.
+func localizeDayUse(ctx context.Context, q *db.Queries, reservation *model.Reservation) (*model.Hotel, int32, error) {
+	dbHotel, err := q.GetHotel(ctx, reservation.HotelID.UUID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, errors.New("hotel not found")
+		}
+		return nil, 0, err
+	}
+	hotel := model.FromDBHotel(&dbHotel)
+
+	loc := hotelLocation(hotel)
+	start := localWallClock(reservation.StartDate.Time, loc)
+	end := localWallClock(reservation.EndDate.Time, loc)
+	if !start.Before(end) {
+		return nil, 0, errors.New("invalid date range: start must be before end")
+	}
+
+	opens, closes := dayUseHours(hotel, start)
+	if !localMidnight(start, loc).Equal(localMidnight(end, loc)) || start.Before(opens) || end.After(closes) {
+		return nil, 0, fmt.Errorf("day-use bookings must fall within the hotel's day-use hours (%s-%s)", opens.Format("15:04"), closes.Format("15:04"))
+	}
+
+	slot := dayUseSlotLength(hotel)
+	if start.Sub(opens)%slot != 0 || end.Sub(start)%slot != 0 {
+		return nil, 0, fmt.Errorf("day-use bookings must start on a slot boundary and last whole %d-minute slots", int(slot.Minutes()))
+	}
+
+	if start.Before(time.Now()) {
+		return nil, 0, errors.New("start time cannot be in the past")
+	}
+
+	reservation.StartDate = sql.NullTime{Time: start, Valid: true}
+	reservation.EndDate = sql.NullTime{Time: end, Valid: true}
+	return hotel, int32(end.Sub(start) / slot), nil
+}
+
+// roomTurnaround returns the hotel's cleaning buffer in minutes, which day-use stays hold a room
+// for after they end
+func roomTurnaround(ctx context.Context, q *db.Queries, hotelID uuid.NullUUID) (int32, error) {
+	if !hotelID.Valid {
+		return 0, nil
+	}
+	dbHotel, err := q.GetHotel(ctx, hotelID.UUID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return dbHotel.DayUseBufferMinutes.Int32, nil
+}
+
+// countRoomConflicts counts the stays overlapping the reservation's half-open interval on roomID,
+// keeping the hotel's cleaning buffer free after any day-use stay on either side.
+func countRoomConflicts(ctx context.Context, q *db.Queries, reservation *model.Reservation, roomID uuid.UUID) (int64, error) {
+	buffer, err := roomTurnaround(ctx, q, reservation.HotelID)
+	if err != nil {
+		return 0, err
+	}
+
+	end := reservation.EndDate.Time
+	if isDayUse(reservation) {
+		end = end.Add(time.Duration(buffer) * time.Minute)
+	}
+
+	return q.CountOverlappingRoomReservations(ctx, db.CountOverlappingRoomReservationsParams{
+		RoomID:               roomID,
+		ExcludeReservationID: reservation.ReservationID,
+		StartDate:            reservation.StartDate.Time,
+		EndDate:              end,
+		BufferMinutes:        buffer,
+	})
+}
+
+// buildDayUseSlots splits the hotel's day-use hours on date into slots and marks each slot free
+// when neither a stay, including the cleaning buffer after day-use stays, nor a block overlaps it.
+// Slots starting before now are never available.
+func buildDayUseSlots(hotel *model.Hotel, date, now time.Time, occupancy []*model.RoomOccupancy) []*model.DayUseSlot {
+	opens, closes := dayUseHours(hotel, date)
+	slot := dayUseSlotLength(hotel)
+	buffer := dayUseBuffer(hotel)
+
+	slots := []*model.DayUseSlot{}
+	for start := opens; !start.Add(slot).After(closes); start = start.Add(slot) {
+		end := start.Add(slot)
+		available := !start.Before(now)
+		for _, busy := range occupancy {
+			busyEnd := busy.EndDate
+			if busy.StayType == model.StayTypeDayUse {
+				busyEnd = busyEnd.Add(buffer)
+			}
+			if busy.StartDate.Before(end.Add(buffer)) && busyEnd.After(start) {
+				available = false
+				break
+			}
+		}
+		slots = append(slots, &model.DayUseSlot{Start: start, End: end, Available: available})
+	}
+	return slots
+}
\ No newline at end of file
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/devsirose/hotel-reservation/logger"
+	"github.com/devsirose/hotel-reservation/model"
+	"go.uber.org/zap"
+)
+
+// EventPublisher hands domain events such as waitlist offers to whatever notifies guests or
+// downstream systems. The outbox dispatcher delivers every committed event to its publishers.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *model.Event) error
+}
+
+type logEventPublisher struct{}
+
+// NewLogEventPublisher returns a publisher that only writes events to the application log
+func NewLogEventPublisher() EventPublisher {
+	return &logEventPublisher{}
+}
+
+func (p *logEventPublisher) Publish(ctx context.Context, event *model.Event) error {
+	if logger.Log == nil {
+		return nil
+	}
+
+	logger.Log.Info("event published",
+		zap.String("event_id", event.EventID.String()),
+		zap.String("type", event.Type),
+		zap.String("aggregate_type", event.AggregateType),
+		zap.String("aggregate_id", event.AggregateID.String()),
+		zap.Any("payload", event.Payload),
+	)
+	return nil
+}
+
+// EventHandler reacts to an event delivered in process
+type EventHandler func(ctx context.Context, event *model.Event) error
+
+// InProcessEventPublisher delivers events to handlers subscribed in the same process, such as
+// tests or features that react to bookings without a message broker
+type InProcessEventPublisher struct {
+	mu       sync.RWMutex
+	handlers []EventHandler
+}
+
+func NewInProcessEventPublisher() *InProcessEventPublisher {
+	return &InProcessEventPublisher{}
+}
+
+// Subscribe registers a handler for every event published from now on
+func (p *InProcessEventPublisher) Subscribe(handler EventHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers = append(p.handlers, handler)
+}
+
+// Publish calls every handler in turn. A failing handler does not stop the others, but fails
+// the delivery so the dispatcher retries it.
+func (p *InProcessEventPublisher) Publish(ctx context.Context, event *model.Event) error {
+	p.mu.RLock()
+	handlers := p.handlers
+	p.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
\ No newline at end of file
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+)
+
+type ExtraService interface {
+	CreateExtra(ctx context.Context, extra *model.Extra) error
+	GetExtraByID(ctx context.Context, extraID uuid.UUID) (*model.Extra, error)
+	ListExtrasByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.Extra, error)
+	UpdateExtra(ctx context.Context, extra *model.Extra) error
+	DeleteExtra(ctx context.Context, extraID uuid.UUID) error
+	GetExtrasReport(ctx context.Context, hotelID uuid.UUID, from, to time.Time) (*model.ExtrasReport, error)
+}
+
+type extraService struct {
+	extraRepo repository.ExtraRepository
+	hotelRepo repository.HotelRepository
+}
+
+func NewExtraService(extraRepo repository.ExtraRepository, hotelRepo repository.HotelRepository) ExtraService {
+	return &extraService{
+		extraRepo: extraRepo,
+		hotelRepo: hotelRepo,
+	}
+}
+
+func (s *extraService) CreateExtra(ctx context.Context, extra *model.Extra) error {
+	if extra.ExtraID == uuid.Nil {
+		extra.ExtraID = uuid.New()
+	}
+
+	if !extra.HotelID.Valid {
+		return errors.New("invalid hotel ID")
+	}
+
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, extra.HotelID.UUID)
+	if err != nil {
+		return err
+	}
+	if hotel == nil {
+		return errors.New("hotel not found")
+	}
+
+	extra.Code = sql.NullString{String: normalizeExtraCode(extra.Code.String), Valid: true}
+	if extra.Code.String == "" {
+		return errors.New("extra code is required")
+	}
+
+	if err := validateExtra(extra); err != nil {
+		return err
+	}
+
+	existingExtra, err := s.extraRepo.GetExtraByCode(ctx, extra.HotelID.UUID, extra.Code.String)
+	if err != nil {
+		return err
+	}
+	if existingExtra != nil {
+		return errors.New("extra code already exists")
+	}
+
+	if !extra.IsActive.Valid {
+		extra.IsActive = sql.NullBool{Bool: true, Valid: true}
+	}
+	extra.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return s.extraRepo.CreateExtra(ctx, extra)
+}
+
+func (s *extraService) GetExtraByID(ctx context.Context, extraID uuid.UUID) (*model.Extra, error) {
+	extra, err := s.extraRepo.GetExtraByID(ctx, extraID)
+	if err != nil {
+		return nil, err
+	}
+
+	if extra == nil {
+		return nil, errors.New("extra not found")
+	}
+
+	return extra, nil
+}
+
+func (s *extraService) ListExtrasByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.Extra, error) {
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.extraRepo.ListExtrasByHotel(ctx, hotelID, pageSize, offset)
+}
+
+// UpdateExtra changes the catalog entry of an extra. Reservations keep the price they were
+// booked at, so only stays booked or changed afterwards pay the new price.
+func (s *extraService) UpdateExtra(ctx context.Context, extra *model.Extra) error {
+	existingExtra, err := s.extraRepo.GetExtraByID(ctx, extra.ExtraID)
+	if err != nil {
+		return err
+	}
+
+	if existingExtra == nil {
+		return errors.New("extra not found")
+	}
+
+	if !extra.Name.Valid {
+		extra.Name = existingExtra.Name
+	}
+	if !extra.Description.Valid {
+		extra.Description = existingExtra.Description
+	}
+	if !extra.Pricing.Valid {
+		extra.Pricing = existingExtra.Pricing
+	}
+	if !extra.Price.Valid {
+		extra.Price = existingExtra.Price
+	}
+	if !extra.IsActive.Valid {
+		extra.IsActive = existingExtra.IsActive
+	}
+
+	if err := validateExtra(extra); err != nil {
+		return err
+	}
+
+	extra.UpdateAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return s.extraRepo.UpdateExtra(ctx, extra)
+}
+
+// DeleteExtra removes an extra no reservation has booked. Extras that have been sold are
+// deactivated instead, so the folios that list them stay intact.
+func (s *extraService) DeleteExtra(ctx context.Context, extraID uuid.UUID) error {
+	existingExtra, err := s.extraRepo.GetExtraByID(ctx, extraID)
+	if err != nil {
+		return err
+	}
+
+	if existingExtra == nil {
+		return errors.New("extra not found")
+	}
+
+	usage, err := s.extraRepo.CountExtraUsage(ctx, extraID)
+	if err != nil {
+		return err
+	}
+	if usage > 0 {
+		return errors.New("extra is still in use")
+	}
+
+	return s.extraRepo.DeleteExtra(ctx, extraID)
+}
+
+// GetExtrasReport lists, for each night in [from, to), how many units of each extra confirmed
+// stays have booked, so the hotel knows how many breakfasts or parking spaces to prepare
+func (s *extraService) GetExtrasReport(ctx context.Context, hotelID uuid.UUID, from, to time.Time) (*model.ExtrasReport, error) {
+	if !from.Before(to) {
+		return nil, errors.New("invalid date range: from must be before to")
+	}
+
+	if to.Sub(from) > maxCalendarNights*24*time.Hour {
+		return nil, errors.New("date range cannot exceed 92 nights")
+	}
+
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+
+	// Nights are sampled at check-in time, as on the hotel calendar
+	loc := hotelLocation(hotel)
+	checkIn, _ := stayWindow(hotel, from, to)
+	last, _ := stayWindow(hotel, to, to)
+	counts, err := s.extraRepo.ListExtraDailyCounts(ctx, hotelID, checkIn.Format(time.RFC3339), last.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, count := range counts {
+		count.Date = count.Night.In(loc).Format("2006-01-02")
+	}
+	if counts == nil {
+		counts = []*model.ExtraDailyCount{}
+	}
+
+	return &model.ExtrasReport{
+		HotelID: hotelID,
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		Counts:  counts,
+	}, nil
+}
+
+func normalizeExtraCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validateExtra(extra *model.Extra) error {
+	if !extra.Name.Valid || strings.TrimSpace(extra.Name.String) == "" {
+		return errors.New("extra name is required")
+	}
+
+	switch extra.Pricing.String {
+	case model.ExtraPerStay, model.ExtraPerNight, model.ExtraPerPerson, model.ExtraPerPersonNight:
+	default:
+		return errors.New("pricing must be PER_STAY, PER_NIGHT, PER_PERSON or PER_PERSON_NIGHT")
+	}
+
+	if !extra.Price.Valid || extra.Price.Int32 < 0 {
+		return errors.New("price cannot be negative")
+	}
+
+	return nil
+}
+
+// extraUnits is how many times the unit price of an extra is charged for one unit of quantity
+func extraUnits(pricing string, nights, persons int32) int32 {
+	switch pricing {
+	case model.ExtraPerNight:
+		return nights
+	case model.ExtraPerPerson:
+		return persons
+	case model.ExtraPerPersonNight:
+		return nights * persons
+	default:
+		return 1
+	}
+}
+
+// normalizeReservationExtras defaults each extra to a quantity of one and rejects extras listed
+// more than once
+func normalizeReservationExtras(extras []*model.ReservationExtra) error {
+	seen := make(map[uuid.UUID]bool, len(extras))
+	for _, extra := range extras {
+		if extra.ExtraID == uuid.Nil {
+			return errors.New("invalid extra ID")
+		}
+		if seen[extra.ExtraID] {
+			return errors.New("each extra can only be listed once")
+		}
+		seen[extra.ExtraID] = true
+
+		if extra.Quantity == 0 {
+			extra.Quantity = 1
+		}
+		if extra.Quantity < 0 {
+			return errors.New("extra quantity must be at least 1")
+		}
+	}
+	return nil
+}
+
+// priceReservationExtras prices the extras of a reservation at the current catalog prices and
+// sets the reservation's extras amount. Extras must be active and offered by the reserved hotel,
+// except those in sold, which keep the pricing they were sold at.
+func priceReservationExtras(ctx context.Context, q *db.Queries, reservation *model.Reservation, sold map[uuid.UUID]db.ReservationExtra) error {
+	nights := stayNights(reservation.StartDate.Time, reservation.EndDate.Time)
+	persons := reservation.Adults.Int32 + reservation.Children.Int32
+
+	var total int32
+	for _, extra := range reservation.Extras {
+		dbExtra, err := q.GetExtra(ctx, extra.ExtraID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("extra not found")
+			}
+			return err
+		}
+
+		if dbExtra.HotelID != reservation.HotelID {
+			return errors.New("extra is not offered by the reserved hotel")
+		}
+
+		extra.Code = dbExtra.Code
+		extra.Name = dbExtra.Name
+		if soldExtra, ok := sold[extra.ExtraID]; ok {
+			extra.Pricing = soldExtra.Pricing
+			extra.UnitPrice = soldExtra.UnitPrice
+		} else {
+			if dbExtra.IsActive.Valid && !dbExtra.IsActive.Bool {
+				return errors.New("extra is no longer offered")
+			}
+			extra.Pricing = dbExtra.Pricing
+			extra.UnitPrice = sql.NullInt32{Int32: dbExtra.Price.Int32, Valid: true}
+		}
+		extra.Amount = sql.NullInt32{Int32: extra.Quantity * extraUnits(extra.Pricing.String, nights, persons) * extra.UnitPrice.Int32, Valid: true}
+		total += extra.Amount.Int32
+	}
+
+	reservation.ExtrasAmount = sql.NullInt32{Int32: total, Valid: true}
+	return nil
+}
+
+// saveReservationExtras replaces the extras of a reservation with its priced extras
+func saveReservationExtras(ctx context.Context, q *db.Queries, reservation *model.Reservation) error {
+	if err := q.DeleteReservationExtras(ctx, reservation.ReservationID); err != nil {
+		return err
+	}
+
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+	for _, extra := range reservation.Extras {
+		extra.ReservationExtraID = uuid.New()
+		extra.ReservationID = reservation.ReservationID
+		extra.CreatedAt = now
+
+		_, err := q.CreateReservationExtra(ctx, db.CreateReservationExtraParams{
+			ReservationExtraID: extra.ReservationExtraID,
+			ReservationID:      extra.ReservationID,
+			ExtraID:            extra.ExtraID,
+			Pricing:            extra.Pricing,
+			Quantity:           extra.Quantity,
+			UnitPrice:          extra.UnitPrice,
+			Amount:             extra.Amount,
+			CreatedAt:          extra.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// repriceReservationExtras recomputes the booked extras of a reservation whose dates or party
+// changed, keeping the unit prices they were sold at, and sets the reservation's extras amount
+func repriceReservationExtras(ctx context.Context, q *db.Queries, reservation *model.Reservation) error {
+	extras, err := q.ListReservationExtrasForUpdate(ctx, reservation.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	nights := stayNights(reservation.StartDate.Time, reservation.EndDate.Time)
+	persons := reservation.Adults.Int32 + reservation.Children.Int32
+
+	var total int32
+	for _, extra := range extras {
+		amount := sql.NullInt32{Int32: extra.Quantity * extraUnits(extra.Pricing.String, nights, persons) * extra.UnitPrice.Int32, Valid: true}
+		if amount != extra.Amount {
+			err := q.UpdateReservationExtraAmount(ctx, db.UpdateReservationExtraAmountParams{
+				ReservationExtraID: extra.ReservationExtraID,
+				Amount:             amount,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		total += amount.Int32
+	}
+
+	reservation.ExtrasAmount = sql.NullInt32{Int32: total, Valid: true}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"math/big"
+	"strings"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+// Confirmation codes leave out letters and digits that are easily confused when read out or
+// typed, such as O and 0 or I and 1. Eight characters give about 10^12 codes, and a lookup
+// also needs the guest's last name, so codes cannot practically be guessed.
+const (
+	confirmationCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	confirmationCodeLength   = 8
+	confirmationCodeAttempts = 5
+)
+
+// newConfirmationCode draws a random confirmation code that no reservation uses yet
+func newConfirmationCode(ctx context.Context, q *db.Queries) (sql.NullString, error) {
+	size := big.NewInt(int64(len(confirmationCodeAlphabet)))
+	for attempt := 0; attempt < confirmationCodeAttempts; attempt++ {
+		var code strings.Builder
+		for i := 0; i < confirmationCodeLength; i++ {
+			n, err := rand.Int(rand.Reader, size)
+			if err != nil {
+				return sql.NullString{}, err
+			}
+			code.WriteByte(confirmationCodeAlphabet[n.Int64()])
+		}
+
+		confirmationCode := sql.NullString{String: code.String(), Valid: true}
+		count, err := q.CountReservationsByConfirmationCode(ctx, confirmationCode)
+		if err != nil {
+			return sql.NullString{}, err
+		}
+		if count == 0 {
+			return confirmationCode, nil
+		}
+	}
+
+	return sql.NullString{}, errors.New("could not generate a confirmation code")
+}
+
+func normalizeConfirmationCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// checkGuestCheckout requires bookings made without an account to name a primary guest with an
+// email address, who receives the confirmation code and can look the booking up with it
+func checkGuestCheckout(reservation *model.Reservation) error {
+	if reservation.UserID.Valid {
+		return nil
+	}
+
+	primary := primaryGuest(reservation.Guests)
+	if primary == nil || !primary.Email.Valid || strings.TrimSpace(primary.Email.String) == "" {
+		return errors.New("bookings without an account require a primary guest with a name and email")
+	}
+
+	return nil
+}
+
+func primaryGuest(guests []*model.ReservationGuest) *model.ReservationGuest {
+	for _, guest := range guests {
+		if guest.IsPrimary.Bool {
+			return guest
+		}
+	}
+	return nil
+}
+
+// Modifications made through a confirmation code are recorded under the guest role, as there is
+// no user to record them against
+const roleGuest = "GUEST"
+
+// LookupReservation finds a booking by its confirmation code and the last name of its primary
+// guest. A wrong last name is reported like an unknown code, so codes cannot be probed.
+func (s *reservationService) LookupReservation(ctx context.Context, confirmationCode, lastName string) (*model.Reservation, error) {
+	reservation, err := s.findReservationByCode(ctx, confirmationCode, lastName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReservationByID(ctx, reservation.ReservationID)
+}
+
+// UpdateReservationByLookup changes the dates or party of a booking found by its confirmation code
+func (s *reservationService) UpdateReservationByLookup(ctx context.Context, confirmationCode, lastName string, change *model.GuestReservationChange) (*model.Reservation, error) {
+	reservation, err := s.findReservationByCode(ctx, confirmationCode, lastName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.UpdateReservationAsGuest(ctx, reservation.ReservationID, change)
+}
+
+// UpdateReservationAsGuest changes the dates or party of a reservation for a guest who proved
+// access to it without an account, with the same checks and repricing as any other modification
+func (s *reservationService) UpdateReservationAsGuest(ctx context.Context, reservationID uuid.UUID, change *model.GuestReservationChange) (*model.Reservation, error) {
+	reservationChange := &model.ReservationChange{
+		StartDate: change.StartDate,
+		EndDate:   change.EndDate,
+		Adults:    change.Adults,
+		Children:  change.Children,
+		ChildAges: change.ChildAges,
+		Guests:    change.Guests,
+		Reason:    change.Reason,
+	}
+
+	return s.updateReservation(ctx, reservationID, reservationChange, func(q *db.Queries, existing *model.Reservation) (db.User, error) {
+		return db.User{Role: sql.NullString{String: roleGuest, Valid: true}}, nil
+	})
+}
+
+// CancelReservationByLookup cancels a booking found by its confirmation code
+func (s *reservationService) CancelReservationByLookup(ctx context.Context, confirmationCode, lastName string) error {
+	reservation, err := s.findReservationByCode(ctx, confirmationCode, lastName)
+	if err != nil {
+		return err
+	}
+
+	return s.CancelReservation(ctx, reservation.ReservationID)
+}
+
+func (s *reservationService) findReservationByCode(ctx context.Context, confirmationCode, lastName string) (*model.Reservation, error) {
+	confirmationCode = normalizeConfirmationCode(confirmationCode)
+	lastName = strings.TrimSpace(lastName)
+	if confirmationCode == "" || lastName == "" {
+		return nil, errors.New("confirmation code and last name are required")
+	}
+
+	reservation, err := s.reservationRepo.GetReservationByConfirmationCode(ctx, confirmationCode)
+	if err != nil {
+		return nil, err
+	}
+	if reservation == nil {
+		return nil, errors.New("reservation not found")
+	}
+
+	guests, err := s.reservationRepo.ListReservationGuests(ctx, reservation.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	primary := primaryGuest(guests)
+	if primary == nil || !strings.EqualFold(strings.TrimSpace(primary.LastName.String), lastName) {
+		return nil, errors.New("reservation not found")
+	}
+
+	return reservation, nil
+}
\ No newline at end of file
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
 	"github.com/devsirose/hotel-reservation/model"
 	"github.com/devsirose/hotel-reservation/repository"
 	"github.com/google/uuid"
@@ -18,12 +19,16 @@ type HotelService interface {
 }
 
 type hotelService struct {
-	hotelRepo repository.HotelRepository
+	store        db.Store
+	hotelRepo    repository.HotelRepository
+	roomTypeRepo repository.RoomTypeRepository
 }
 
-func NewHotelService(hotelRepo repository.HotelRepository) HotelService {
+func NewHotelService(store db.Store, hotelRepo repository.HotelRepository, roomTypeRepo repository.RoomTypeRepository) HotelService {
 	return &hotelService{
-		hotelRepo: hotelRepo,
+		store:        store,
+		hotelRepo:    hotelRepo,
+		roomTypeRepo: roomTypeRepo,
 	}
 }
 
@@ -40,7 +45,36 @@ func (s *hotelService) CreateHotel(ctx context.Context, hotel *model.Hotel) erro
 		return errors.New("rating must be between 0 and 5")
 	}
 	
-	return s.hotelRepo.CreateHotel(ctx, hotel)
+	if _, err := checkRoomTypeExists(ctx, s.roomTypeRepo, hotel.TypeID); err != nil {
+		return err
+	}
+	
+	if err := applyHotelTimeDefaults(hotel); err != nil {
+		return err
+	}
+	
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbHotel, err := q.CreateHotel(ctx, db.CreateHotelParams{
+			HotelID:             hotel.HotelID,
+			DestinationID:       hotel.DestinationID,
+			TypeID:              hotel.TypeID,
+			TotalRoom:           hotel.TotalRoom,
+			Rating:              hotel.Rating,
+			TimeZone:            hotel.TimeZone,
+			CheckInTime:         hotel.CheckInTime,
+			CheckOutTime:        hotel.CheckOutTime,
+			DayUseStartTime:     hotel.DayUseStartTime,
+			DayUseEndTime:       hotel.DayUseEndTime,
+			DayUseSlotMinutes:   hotel.DayUseSlotMinutes,
+			DayUseBufferMinutes: hotel.DayUseBufferMinutes,
+		})
+		if err != nil {
+			return err
+		}
+
+		hotelID := uuid.NullUUID{UUID: hotel.HotelID, Valid: true}
+		return recordEvent(ctx, q, model.EventHotelCreated, model.AggregateHotel, hotel.HotelID, hotelID, model.FromDBHotel(&dbHotel))
+	})
 }
 
 func (s *hotelService) GetHotelByID(ctx context.Context, hotelID uuid.UUID) (*model.Hotel, error) {
@@ -87,7 +121,57 @@ func (s *hotelService) UpdateHotel(ctx context.Context, hotel *model.Hotel) erro
 		return errors.New("rating must be between 0 and 5")
 	}
 	
-	return s.hotelRepo.UpdateHotel(ctx, hotel)
+	if _, err := checkRoomTypeExists(ctx, s.roomTypeRepo, hotel.TypeID); err != nil {
+		return err
+	}
+	
+	if !hotel.TimeZone.Valid {
+		hotel.TimeZone = existingHotel.TimeZone
+	}
+	if !hotel.CheckInTime.Valid {
+		hotel.CheckInTime = existingHotel.CheckInTime
+	}
+	if !hotel.CheckOutTime.Valid {
+		hotel.CheckOutTime = existingHotel.CheckOutTime
+	}
+	if !hotel.DayUseStartTime.Valid {
+		hotel.DayUseStartTime = existingHotel.DayUseStartTime
+	}
+	if !hotel.DayUseEndTime.Valid {
+		hotel.DayUseEndTime = existingHotel.DayUseEndTime
+	}
+	if !hotel.DayUseSlotMinutes.Valid {
+		hotel.DayUseSlotMinutes = existingHotel.DayUseSlotMinutes
+	}
+	if !hotel.DayUseBufferMinutes.Valid {
+		hotel.DayUseBufferMinutes = existingHotel.DayUseBufferMinutes
+	}
+	if err := applyHotelTimeDefaults(hotel); err != nil {
+		return err
+	}
+	
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbHotel, err := q.UpdateHotel(ctx, db.UpdateHotelParams{
+			HotelID:             hotel.HotelID,
+			DestinationID:       hotel.DestinationID,
+			TypeID:              hotel.TypeID,
+			TotalRoom:           hotel.TotalRoom,
+			Rating:              hotel.Rating,
+			TimeZone:            hotel.TimeZone,
+			CheckInTime:         hotel.CheckInTime,
+			CheckOutTime:        hotel.CheckOutTime,
+			DayUseStartTime:     hotel.DayUseStartTime,
+			DayUseEndTime:       hotel.DayUseEndTime,
+			DayUseSlotMinutes:   hotel.DayUseSlotMinutes,
+			DayUseBufferMinutes: hotel.DayUseBufferMinutes,
+		})
+		if err != nil {
+			return err
+		}
+
+		hotelID := uuid.NullUUID{UUID: hotel.HotelID, Valid: true}
+		return recordEvent(ctx, q, model.EventHotelUpdated, model.AggregateHotel, hotel.HotelID, hotelID, model.FromDBHotel(&dbHotel))
+	})
 }
 
 func (s *hotelService) DeleteHotel(ctx context.Context, hotelID uuid.UUID) error {
@@ -100,5 +184,11 @@ func (s *hotelService) DeleteHotel(ctx context.Context, hotelID uuid.UUID) error
 		return errors.New("hotel not found")
 	}
 	
-	return s.hotelRepo.DeleteHotel(ctx, hotelID)
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		if err := q.DeleteHotel(ctx, hotelID); err != nil {
+			return err
+		}
+
+		return recordEvent(ctx, q, model.EventHotelDeleted, model.AggregateHotel, hotelID, uuid.NullUUID{UUID: hotelID, Valid: true}, existingHotel)
+	})
 }
\ No newline at end of file
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/model"
+)
+
+// Defaults applied to hotels that do not configure their own time zone or stay times
+const (
+	defaultTimeZone     = "UTC"
+	defaultCheckInTime  = "14:00"
+	defaultCheckOutTime = "12:00"
+
+	defaultDayUseStartTime     = "09:00"
+	defaultDayUseEndTime       = "18:00"
+	defaultDayUseSlotMinutes   = 60
+	defaultDayUseBufferMinutes = 0
+)
+
+// applyHotelTimeDefaults fills in the time zone, check-in/check-out times and day-use settings a
+// hotel leaves unset and validates the ones it sets.
+func applyHotelTimeDefaults(hotel *model.Hotel) error {
+	if !hotel.TimeZone.Valid || hotel.TimeZone.String == "" {
+		hotel.TimeZone = sql.NullString{String: defaultTimeZone, Valid: true}
+	}
+	if !hotel.CheckInTime.Valid || hotel.CheckInTime.String == "" {
+		hotel.CheckInTime = sql.NullString{String: defaultCheckInTime, Valid: true}
+	}
+	if !hotel.CheckOutTime.Valid || hotel.CheckOutTime.String == "" {
+		hotel.CheckOutTime = sql.NullString{String: defaultCheckOutTime, Valid: true}
+	}
+
+	if !hotel.DayUseStartTime.Valid || hotel.DayUseStartTime.String == "" {
+		hotel.DayUseStartTime = sql.NullString{String: defaultDayUseStartTime, Valid: true}
+	}
+	if !hotel.DayUseEndTime.Valid || hotel.DayUseEndTime.String == "" {
+		hotel.DayUseEndTime = sql.NullString{String: defaultDayUseEndTime, Valid: true}
+	}
+	if !hotel.DayUseSlotMinutes.Valid {
+		hotel.DayUseSlotMinutes = sql.NullInt32{Int32: defaultDayUseSlotMinutes, Valid: true}
+	}
+	if !hotel.DayUseBufferMinutes.Valid {
+		hotel.DayUseBufferMinutes = sql.NullInt32{Int32: defaultDayUseBufferMinutes, Valid: true}
+	}
+
+	if _, err := time.LoadLocation(hotel.TimeZone.String); err != nil {
+		return errors.New("invalid time zone: " + hotel.TimeZone.String)
+	}
+	if _, err := time.Parse("15:04", hotel.CheckInTime.String); err != nil {
+		return errors.New("invalid check-in time format (use HH:MM)")
+	}
+	if _, err := time.Parse("15:04", hotel.CheckOutTime.String); err != nil {
+		return errors.New("invalid check-out time format (use HH:MM)")
+	}
+
+	dayUseStart, err := time.Parse("15:04", hotel.DayUseStartTime.String)
+	if err != nil {
+		return errors.New("invalid day-use start time format (use HH:MM)")
+	}
+	dayUseEnd, err := time.Parse("15:04", hotel.DayUseEndTime.String)
+	if err != nil {
+		return errors.New("invalid day-use end time format (use HH:MM)")
+	}
+	if !dayUseStart.Before(dayUseEnd) {
+		return errors.New("day-use start time must be before day-use end time")
+	}
+	if hotel.DayUseSlotMinutes.Int32 < 15 || hotel.DayUseSlotMinutes.Int32 > 720 {
+		return errors.New("day-use slot length must be between 15 and 720 minutes")
+	}
+	if hotel.DayUseBufferMinutes.Int32 < 0 || hotel.DayUseBufferMinutes.Int32 > 240 {
+		return errors.New("day-use buffer must be between 0 and 240 minutes")
+	}
+
+	return nil
+}
+
+// hotelLocation returns the hotel's time zone, falling back to UTC for hotels stored before
+// time zones were validated.
+func hotelLocation(hotel *model.Hotel) *time.Location {
+	if hotel.TimeZone.Valid {
+		if loc, err := time.LoadLocation(hotel.TimeZone.String); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// atLocalTime returns the calendar date of date, as written by the client, at clock o'clock in
+// the hotel's time zone.
+func atLocalTime(date time.Time, clock string, loc *time.Location) time.Time {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		t = time.Time{}
+	}
+	y, m, d := date.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, loc)
+}
+
+// localMidnight returns the start of date's calendar day in the hotel's time zone
+func localMidnight(date time.Time, loc *time.Location) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// hotelToday returns the start of the current day in the hotel's time zone
+func hotelToday(hotel *model.Hotel, now time.Time) time.Time {
+	return localMidnight(now.In(hotelLocation(hotel)), hotelLocation(hotel))
+}
+
+// stayWindow turns arrival and departure calendar dates into the instants a stay occupies a
+// room: from the hotel's check-in time on the arrival date to its check-out time on the
+// departure date, both in hotel-local time.
+func stayWindow(hotel *model.Hotel, arrival, departure time.Time) (time.Time, time.Time) {
+	loc := hotelLocation(hotel)
+	checkIn := hotel.CheckInTime.String
+	if !hotel.CheckInTime.Valid {
+		checkIn = defaultCheckInTime
+	}
+	checkOut := hotel.CheckOutTime.String
+	if !hotel.CheckOutTime.Valid {
+		checkOut = defaultCheckOutTime
+	}
+	return atLocalTime(arrival, checkIn, loc), atLocalTime(departure, checkOut, loc)
+}
+
+// checkArrivalNotPast rejects stays arriving before the current day in the hotel's time zone
+func checkArrivalNotPast(hotel *model.Hotel, arrival time.Time) error {
+	if localMidnight(arrival, hotelLocation(hotel)).Before(hotelToday(hotel, time.Now())) {
+		return errors.New("start date cannot be in the past")
+	}
+	return nil
+}
\ No newline at end of file
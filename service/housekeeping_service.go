@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+)
+
+type HousekeepingService interface {
+	UpdateHousekeepingStatus(ctx context.Context, roomID uuid.UUID, status string) error
+	GetHousekeepingBoard(ctx context.Context, hotelID uuid.UUID, date time.Time) (*model.HousekeepingBoard, error)
+}
+
+type housekeepingService struct {
+	roomRepo        repository.RoomRepository
+	hotelRepo       repository.HotelRepository
+	reservationRepo repository.ReservationRepository
+}
+
+func NewHousekeepingService(roomRepo repository.RoomRepository, hotelRepo repository.HotelRepository, reservationRepo repository.ReservationRepository) HousekeepingService {
+	return &housekeepingService{
+		roomRepo:        roomRepo,
+		hotelRepo:       hotelRepo,
+		reservationRepo: reservationRepo,
+	}
+}
+
+func isHousekeepingStatus(status string) bool {
+	switch status {
+	case model.HousekeepingClean, model.HousekeepingDirty, model.HousekeepingInspected, model.HousekeepingOutOfOrder:
+		return true
+	}
+	return false
+}
+
+func (s *housekeepingService) UpdateHousekeepingStatus(ctx context.Context, roomID uuid.UUID, status string) error {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if !isHousekeepingStatus(status) {
+		return errors.New("invalid housekeeping status. Use CLEAN, DIRTY, INSPECTED or OUT_OF_ORDER")
+	}
+
+	room, err := s.roomRepo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if room == nil {
+		return errors.New("room not found")
+	}
+
+	return s.roomRepo.UpdateHousekeepingStatus(ctx, roomID, status)
+}
+
+func (s *housekeepingService) GetHousekeepingBoard(ctx context.Context, hotelID uuid.UUID, date time.Time) (*model.HousekeepingBoard, error) {
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+
+	// A zero date means today in the hotel's time zone. Arrivals and departures are matched
+	// against the local calendar day, while a room is occupied tonight if a stay holds it
+	// between today's and tomorrow's check-in times.
+	loc := hotelLocation(hotel)
+	if date.IsZero() {
+		date = hotelToday(hotel, time.Now())
+	}
+	date = localMidnight(date, loc)
+	nextDay := date.AddDate(0, 0, 1)
+	tonight, _ := stayWindow(hotel, date, date)
+	tomorrow, _ := stayWindow(hotel, nextDay, nextDay)
+
+	rooms, err := s.roomRepo.ListHousekeepingRooms(ctx, hotelID, tonight.Format(time.RFC3339), tomorrow.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	movements, err := s.reservationRepo.ListHotelArrivalsAndDepartures(ctx, hotelID, date.Format(time.RFC3339), nextDay.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	board := &model.HousekeepingBoard{
+		HotelID: hotelID,
+		Date:    date.Format("2006-01-02"),
+		RoomsByStatus: map[string][]*model.HousekeepingRoom{
+			model.HousekeepingClean:      {},
+			model.HousekeepingDirty:      {},
+			model.HousekeepingInspected:  {},
+			model.HousekeepingOutOfOrder: {},
+		},
+		Arrivals:   []*model.Reservation{},
+		Departures: []*model.Reservation{},
+	}
+
+	roomsByID := make(map[uuid.UUID]*model.HousekeepingRoom, len(rooms))
+	for _, room := range rooms {
+		roomsByID[room.RoomID] = room
+		board.RoomsByStatus[room.Status] = append(board.RoomsByStatus[room.Status], room)
+	}
+
+	for _, reservation := range movements {
+		var room *model.HousekeepingRoom
+		if reservation.RoomID.Valid {
+			room = roomsByID[reservation.RoomID.UUID]
+		}
+		reservationID := reservation.ReservationID
+
+		// Arrivals carry the party's names and contact so the front desk can prepare check-in
+		if !reservation.StartDate.Time.Before(date) && reservation.StartDate.Time.Before(nextDay) && reservation.Status.String != "COMPLETED" {
+			reservation.Guests, err = s.reservationRepo.ListReservationGuests(ctx, reservation.ReservationID)
+			if err != nil {
+				return nil, err
+			}
+			board.Arrivals = append(board.Arrivals, reservation)
+			if room != nil {
+				room.ArrivalID = &reservationID
+			}
+		}
+
+		if !reservation.EndDate.Time.Before(date) && reservation.EndDate.Time.Before(nextDay) {
+			board.Departures = append(board.Departures, reservation)
+			if room != nil {
+				room.DepartureID = &reservationID
+			}
+		}
+	}
+
+	return board, nil
+}
\ No newline at end of file
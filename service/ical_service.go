@@ -0,0 +1,586 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/ical"
+	"github.com/devsirose/hotel-reservation/logger"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// icalSyncInterval is how often each active feed is fetched
+	icalSyncInterval = 30 * time.Minute
+	// icalSyncBatchSize bounds how many feeds one pass synchronizes
+	icalSyncBatchSize = 20
+	// icalFetchTimeout bounds how long fetching one feed may take
+	icalFetchTimeout = 30 * time.Second
+	// icalMaxFeedSize bounds how much of a feed is read, so a misbehaving server cannot exhaust memory
+	icalMaxFeedSize = 5 << 20
+	// icalImportDays is how far ahead events of a feed, and recurring events in particular, are
+	// imported as blocks
+	icalImportDays = 730
+	// icalUIDDomain makes the UIDs of exported events globally unique
+	icalUIDDomain = "hotel-reservation"
+)
+
+// ICalService publishes each room's calendar as an iCal feed and imports external iCal feeds
+// into room blocks, so rooms listed on other platforms are not booked twice. Imported blocks are
+// managed by their feed: each synchronization adds, moves and removes them to match it.
+type ICalService interface {
+	IssueExportToken(ctx context.Context, roomID uuid.UUID) (*model.ICalExport, error)
+	ExportRoomCalendar(ctx context.Context, roomID uuid.UUID, token string) ([]byte, error)
+	CreateICalFeed(ctx context.Context, feed *model.ICalFeed) error
+	GetICalFeedByID(ctx context.Context, feedID uuid.UUID) (*model.ICalFeed, error)
+	ListICalFeedsByRoom(ctx context.Context, roomID uuid.UUID, page, pageSize int) ([]*model.ICalFeed, error)
+	UpdateICalFeed(ctx context.Context, feed *model.ICalFeed) error
+	DeleteICalFeed(ctx context.Context, feedID uuid.UUID) error
+	SyncICalFeed(ctx context.Context, feedID uuid.UUID, now time.Time) (*model.ICalSyncResult, error)
+	SyncDueICalFeeds(ctx context.Context, now time.Time) (int, error)
+}
+
+type icalService struct {
+	store     db.Store
+	icalRepo  repository.ICalRepository
+	roomRepo  repository.RoomRepository
+	hotelRepo repository.HotelRepository
+	client    *http.Client
+}
+
+// NewICalService fetches feeds with client, or with a client that times out after
+// icalFetchTimeout when client is nil
+func NewICalService(store db.Store, icalRepo repository.ICalRepository, roomRepo repository.RoomRepository, hotelRepo repository.HotelRepository, client *http.Client) ICalService {
+	if client == nil {
+		client = &http.Client{Timeout: icalFetchTimeout}
+	}
+
+	return &icalService{
+		store:     store,
+		icalRepo:  icalRepo,
+		roomRepo:  roomRepo,
+		hotelRepo: hotelRepo,
+		client:    client,
+	}
+}
+
+// IssueExportToken issues the token that opens a room's calendar feed, revoking any earlier
+// token. Only a hash of the token is stored.
+func (s *icalService) IssueExportToken(ctx context.Context, roomID uuid.UUID) (*model.ICalExport, error) {
+	room, err := s.roomRepo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, errors.New("room not found")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(secret)
+
+	_, err = s.store.UpsertICalExport(ctx, db.UpsertICalExportParams{
+		RoomID:    roomID,
+		TokenHash: hashICalToken(token),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ICalExport{
+		RoomID: roomID,
+		Token:  token,
+		Path:   "/api/v1/rooms/" + roomID.String() + "/calendar.ics?token=" + token,
+	}, nil
+}
+
+// ExportRoomCalendar writes the room's calendar with an event for every stay in the room that is
+// not cancelled and every block. Nightly stays and blocks are all-day events over the nights
+// they hold, and day-use stays are timed events. Events carry no guest details.
+func (s *icalService) ExportRoomCalendar(ctx context.Context, roomID uuid.UUID, token string) ([]byte, error) {
+	room, err := s.roomRepo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, errors.New("room not found")
+	}
+
+	export, err := s.store.GetICalExport(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("invalid calendar token")
+		}
+		return nil, err
+	}
+	if token == "" || subtle.ConstantTimeCompare([]byte(hashICalToken(token)), []byte(export.TokenHash)) != 1 {
+		return nil, errors.New("invalid calendar token")
+	}
+
+	loc := time.UTC
+	if room.HotelID.Valid {
+		hotel, err := s.hotelRepo.GetHotelByID(ctx, room.HotelID.UUID)
+		if err != nil {
+			return nil, err
+		}
+		if hotel != nil {
+			loc = hotelLocation(hotel)
+		}
+	}
+
+	segments, err := s.store.ListICalRoomSegments(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := s.store.ListICalRoomBlocks(ctx, uuid.NullUUID{UUID: roomID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &ical.Calendar{Name: room.RoomName.String}
+	for _, segment := range segments {
+		status := ical.StatusConfirmed
+		if segment.Status.String == "PENDING" {
+			status = ical.StatusTentative
+		}
+		stamp := segment.CreatedAt
+		if segment.UpdateAt.Valid {
+			stamp = segment.UpdateAt
+		}
+
+		event := icalEvent(segment.SegmentID, "Reserved", segment.StartDate, segment.EndDate, segment.StayType.String == model.StayTypeDayUse, loc)
+		event.Status = status
+		event.Stamp = stamp.Time
+		cal.Events = append(cal.Events, event)
+	}
+	for _, block := range blocks {
+		if !block.StartDate.Valid || !block.EndDate.Valid {
+			continue
+		}
+		stamp := block.CreatedAt
+		if block.UpdateAt.Valid {
+			stamp = block.UpdateAt
+		}
+
+		event := icalEvent(block.BlockID, "Not available", block.StartDate.Time, block.EndDate.Time, false, loc)
+		event.Status = ical.StatusConfirmed
+		event.Stamp = stamp.Time
+		cal.Events = append(cal.Events, event)
+	}
+
+	return ical.Marshal(cal), nil
+}
+
+// icalEvent describes a period a room is taken as an event. Periods spanning several hotel-local
+// dates become all-day events over those dates, the way booking platforms exchange nights;
+// day-use periods and periods within one date keep their times.
+func icalEvent(id uuid.UUID, summary string, start, end time.Time, dayUse bool, loc *time.Location) *ical.Event {
+	event := &ical.Event{
+		UID:     id.String() + "@" + icalUIDDomain,
+		Summary: summary,
+		Start:   start,
+		End:     end,
+	}
+
+	startDate := localMidnight(start.In(loc), time.UTC)
+	endDate := localMidnight(end.In(loc), time.UTC)
+	if !dayUse && endDate.After(startDate) {
+		event.Start = startDate
+		event.End = endDate
+		event.AllDay = true
+	}
+	return event
+}
+
+func hashICalToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *icalService) CreateICalFeed(ctx context.Context, feed *model.ICalFeed) error {
+	if feed.FeedID == uuid.Nil {
+		feed.FeedID = uuid.New()
+	}
+
+	if err := s.validateICalFeed(ctx, feed); err != nil {
+		return err
+	}
+
+	if !feed.IsActive.Valid {
+		feed.IsActive = sql.NullBool{Bool: true, Valid: true}
+	}
+	feed.LastAttemptAt = sql.NullTime{}
+	feed.LastSyncedAt = sql.NullTime{}
+	feed.LastError = sql.NullString{}
+	feed.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return s.icalRepo.CreateICalFeed(ctx, feed)
+}
+
+func (s *icalService) GetICalFeedByID(ctx context.Context, feedID uuid.UUID) (*model.ICalFeed, error) {
+	feed, err := s.icalRepo.GetICalFeedByID(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	if feed == nil {
+		return nil, errors.New("ical feed not found")
+	}
+
+	return feed, nil
+}
+
+func (s *icalService) ListICalFeedsByRoom(ctx context.Context, roomID uuid.UUID, page, pageSize int) ([]*model.ICalFeed, error) {
+	room, err := s.roomRepo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, errors.New("room not found")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.icalRepo.ListICalFeedsByRoom(ctx, roomID, pageSize, offset)
+}
+
+// UpdateICalFeed changes a feed's URL, name or whether it is synchronized. A feed stays attached
+// to its room.
+func (s *icalService) UpdateICalFeed(ctx context.Context, feed *model.ICalFeed) error {
+	existing, err := s.icalRepo.GetICalFeedByID(ctx, feed.FeedID)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		return errors.New("ical feed not found")
+	}
+
+	feed.RoomID = existing.RoomID
+	if err := s.validateICalFeed(ctx, feed); err != nil {
+		return err
+	}
+
+	if !feed.IsActive.Valid {
+		feed.IsActive = existing.IsActive
+	}
+	feed.LastAttemptAt = existing.LastAttemptAt
+	feed.LastSyncedAt = existing.LastSyncedAt
+	feed.LastError = existing.LastError
+	feed.CreatedAt = existing.CreatedAt
+	feed.CreatedBy = existing.CreatedBy
+	feed.UpdateAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return s.icalRepo.UpdateICalFeed(ctx, feed)
+}
+
+// DeleteICalFeed deletes a feed and the blocks imported from it
+func (s *icalService) DeleteICalFeed(ctx context.Context, feedID uuid.UUID) error {
+	feed, err := s.icalRepo.GetICalFeedByID(ctx, feedID)
+	if err != nil {
+		return err
+	}
+
+	if feed == nil {
+		return errors.New("ical feed not found")
+	}
+
+	if err := s.icalRepo.DeleteICalFeed(ctx, feedID); err != nil {
+		return err
+	}
+
+	// The feed's blocks went with it
+	room, err := s.roomRepo.GetRoomByID(ctx, feed.RoomID)
+	if err != nil || room == nil {
+		return err
+	}
+	return markChannelsDue(ctx, s.store, room.HotelID)
+}
+
+func (s *icalService) validateICalFeed(ctx context.Context, feed *model.ICalFeed) error {
+	if feed.RoomID == uuid.Nil {
+		return errors.New("invalid room ID")
+	}
+
+	feedURL, err := url.Parse(strings.TrimSpace(feed.URL))
+	if err != nil || feedURL.Host == "" {
+		return errors.New("invalid feed URL")
+	}
+	switch strings.ToLower(feedURL.Scheme) {
+	case "http", "https", "webcal":
+	default:
+		return errors.New("feed URL must use http, https or webcal")
+	}
+	feed.URL = feedURL.String()
+
+	room, err := s.roomRepo.GetRoomByID(ctx, feed.RoomID)
+	if err != nil {
+		return err
+	}
+	if room == nil {
+		return errors.New("room not found")
+	}
+
+	return nil
+}
+
+// SyncICalFeed fetches a feed now, whether or not it is due or active
+func (s *icalService) SyncICalFeed(ctx context.Context, feedID uuid.UUID, now time.Time) (*model.ICalSyncResult, error) {
+	feed, err := s.icalRepo.GetICalFeedByID(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	if feed == nil {
+		return nil, errors.New("ical feed not found")
+	}
+
+	return s.syncFeed(ctx, feed.ToDBModel(), now)
+}
+
+// SyncDueICalFeeds synchronizes the active feeds not attempted within the sync interval and
+// returns how many were synchronized. A feed that fails is retried on the next interval, and
+// keeps the blocks of its last successful synchronization meanwhile.
+func (s *icalService) SyncDueICalFeeds(ctx context.Context, now time.Time) (int, error) {
+	feeds, err := s.store.ListDueICalFeeds(ctx, db.ListDueICalFeedsParams{
+		AttemptedBefore: sql.NullTime{Time: now.Add(-icalSyncInterval), Valid: true},
+		MaxFeeds:        icalSyncBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var synced int
+	for i := range feeds {
+		if _, err := s.syncFeed(ctx, &feeds[i], now); err != nil {
+			if logger.Log != nil {
+				logger.Log.Warn("Failed to synchronize iCal feed",
+					zap.String("feed_id", feeds[i].FeedID.String()),
+					zap.Error(err),
+				)
+			}
+			continue
+		}
+		synced++
+	}
+
+	return synced, nil
+}
+
+// syncFeed fetches a feed and imports it, recording the failure on the feed when either fails
+func (s *icalService) syncFeed(ctx context.Context, feed *db.IcalFeed, now time.Time) (*model.ICalSyncResult, error) {
+	cal, err := s.fetch(ctx, feed.Url)
+	var result *model.ICalSyncResult
+	if err == nil {
+		result, err = s.importCalendar(ctx, feed.FeedID, cal, now)
+	}
+	if err != nil {
+		if markErr := s.store.MarkICalFeedFailed(ctx, db.MarkICalFeedFailedParams{
+			FeedID:        feed.FeedID,
+			LastAttemptAt: sql.NullTime{Time: now, Valid: true},
+			LastError:     sql.NullString{String: err.Error(), Valid: true},
+		}); markErr != nil {
+			return nil, markErr
+		}
+		return nil, err
+	}
+
+	if len(result.Conflicts) > 0 && logger.Log != nil {
+		logger.Log.Warn("iCal feed blocks overlap reservations",
+			zap.String("feed_id", feed.FeedID.String()),
+			zap.Int("conflicts", len(result.Conflicts)),
+		)
+	}
+	return result, nil
+}
+
+func (s *icalService) fetch(ctx context.Context, rawURL string) (*ical.Calendar, error) {
+	// webcal is http by another name, used so calendar apps subscribe to the link
+	if strings.HasPrefix(strings.ToLower(rawURL), "webcal://") {
+		rawURL = "https://" + rawURL[len("webcal://"):]
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("feed responded with status %d", resp.StatusCode)
+	}
+
+	return ical.Parse(io.LimitReader(resp.Body, icalMaxFeedSize))
+}
+
+// importCalendar makes the feed's blocks match the calendar from the current day on. All-day
+// events block the room from check-in on their first date to check-out after their last, like a
+// stay over those dates; timed events block their exact times. Blocks that ended before the
+// current day are kept as history.
+func (s *icalService) importCalendar(ctx context.Context, feedID uuid.UUID, cal *ical.Calendar, now time.Time) (*model.ICalSyncResult, error) {
+	result := &model.ICalSyncResult{FeedID: feedID}
+
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		feed, err := q.GetICalFeedForUpdate(ctx, feedID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("ical feed not found")
+			}
+			return err
+		}
+
+		dbRoom, err := q.GetRoom(ctx, feed.RoomID)
+		if err != nil {
+			return err
+		}
+		hotel := &model.Hotel{}
+		if dbRoom.HotelID.Valid {
+			dbHotel, err := q.GetHotel(ctx, dbRoom.HotelID.UUID)
+			if err != nil {
+				return err
+			}
+			hotel = model.FromDBHotel(&dbHotel)
+		}
+
+		from := hotelToday(hotel, now)
+		occurrences, err := cal.Expand(from, from.AddDate(0, 0, icalImportDays))
+		if err != nil {
+			// Events that cannot be read are skipped rather than failing the whole feed
+			result.Skipped = strings.Split(err.Error(), "\n")
+		}
+		result.Events = len(occurrences)
+
+		// The keys of every block the feed still has, so the others can be removed. Never nil,
+		// since the array parameter would then be NULL and match nothing.
+		keys := []string{}
+		seen := make(map[string]bool)
+		conflicts := make(map[uuid.UUID]bool)
+		for _, occurrence := range occurrences {
+			start, end := occurrence.Start, occurrence.End
+			if occurrence.AllDay {
+				start, end = stayWindow(hotel, occurrence.Start, occurrence.End)
+			}
+			if !start.Before(end) {
+				continue
+			}
+
+			// Some platforms reuse a UID for separate events
+			key := occurrence.Key()
+			if seen[key] {
+				key += "@" + start.UTC().Format(time.RFC3339)
+			}
+			seen[key] = true
+			keys = append(keys, key)
+
+			_, err := q.UpsertICalBlock(ctx, db.UpsertICalBlockParams{
+				BlockID:     uuid.New(),
+				RoomID:      uuid.NullUUID{UUID: feed.RoomID, Valid: true},
+				BlockType:   sql.NullString{String: model.RoomBlockExternal, Valid: true},
+				Reason:      sql.NullString{String: icalBlockReason(&feed, occurrence), Valid: true},
+				StartDate:   sql.NullTime{Time: start, Valid: true},
+				EndDate:     sql.NullTime{Time: end, Valid: true},
+				CreatedAt:   sql.NullTime{Time: now, Valid: true},
+				FeedID:      uuid.NullUUID{UUID: feed.FeedID, Valid: true},
+				ExternalUid: sql.NullString{String: key, Valid: true},
+			})
+			if err != nil {
+				// No row is returned when the block is already up to date
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return err
+			}
+			result.Upserted++
+
+			// The other platform has already taken the booking, so the block is kept even when
+			// it overlaps a reservation here; the overlap is reported instead
+			reservations, err := q.ListOverlappingRoomReservations(ctx, db.ListOverlappingRoomReservationsParams{
+				RoomID:    feed.RoomID,
+				StartDate: start,
+				EndDate:   end,
+			})
+			if err != nil {
+				return err
+			}
+			for _, reservation := range reservations {
+				if !conflicts[reservation.ReservationID] {
+					conflicts[reservation.ReservationID] = true
+					result.Conflicts = append(result.Conflicts, reservation.ReservationID)
+				}
+			}
+		}
+
+		result.Removed, err = q.DeleteStaleICalBlocks(ctx, db.DeleteStaleICalBlocksParams{
+			FeedID:       uuid.NullUUID{UUID: feed.FeedID, Valid: true},
+			EndsAfter:    from,
+			ExternalUids: keys,
+		})
+		if err != nil {
+			return err
+		}
+
+		if result.Upserted > 0 || result.Removed > 0 {
+			if err := markChannelsDue(ctx, q, dbRoom.HotelID); err != nil {
+				return err
+			}
+		}
+
+		return q.MarkICalFeedSynced(ctx, db.MarkICalFeedSyncedParams{
+			FeedID:   feed.FeedID,
+			SyncedAt: sql.NullTime{Time: now, Valid: true},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// icalBlockReason names the feed a block came from, and the event when it has a summary
+func icalBlockReason(feed *db.IcalFeed, occurrence *ical.Occurrence) string {
+	source := feed.Name.String
+	if source == "" {
+		source = feed.Url
+		if feedURL, err := url.Parse(feed.Url); err == nil && feedURL.Host != "" {
+			source = feedURL.Host
+		}
+	}
+
+	reason := "Imported from " + source
+	if occurrence.Summary != "" {
+		reason += ": " + occurrence.Summary
+	}
+	return reason
+}
\ No newline at end of file
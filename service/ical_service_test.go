@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/ical"
+	"github.com/google/uuid"
+)
+
+func TestICalFetchParsesFeed(t *testing.T) {
+	feed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/listing.ics" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar")
+		http.ServeFile(w, r, "../ical/testdata/all_day.ics")
+	}))
+	defer feed.Close()
+
+	s := &icalService{client: feed.Client()}
+
+	cal, err := s.fetch(context.Background(), feed.URL+"/listing.ics")
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(cal.Events) != 3 {
+		t.Errorf("got %d events, want 3", len(cal.Events))
+	}
+
+	if _, err := s.fetch(context.Background(), feed.URL+"/missing.ics"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("fetch of a missing feed: err = %v, want status 404", err)
+	}
+}
+
+func TestICalEventShapes(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := uuid.New()
+
+	// A nightly stay from 14:00 to 12:00 local time holds the nights of its dates
+	stay := icalEvent(id, "Reserved", time.Date(2026, 11, 2, 14, 0, 0, 0, hcm), time.Date(2026, 11, 5, 12, 0, 0, 0, hcm), false, hcm)
+	if !stay.AllDay || stay.Start.Format("20060102") != "20261102" || stay.End.Format("20060102") != "20261105" {
+		t.Errorf("stay = %v..%v all day %v", stay.Start, stay.End, stay.AllDay)
+	}
+	if stay.UID != id.String()+"@"+icalUIDDomain {
+		t.Errorf("UID = %q", stay.UID)
+	}
+
+	// Late at night in UTC is already the next date at the hotel
+	late := icalEvent(id, "Reserved", time.Date(2026, 11, 1, 20, 0, 0, 0, time.UTC), time.Date(2026, 11, 3, 5, 0, 0, 0, time.UTC), false, hcm)
+	if late.Start.Format("20060102") != "20261102" || late.End.Format("20060102") != "20261103" {
+		t.Errorf("late = %v..%v", late.Start, late.End)
+	}
+
+	dayUse := icalEvent(id, "Reserved", time.Date(2026, 11, 6, 9, 0, 0, 0, hcm), time.Date(2026, 11, 6, 13, 0, 0, 0, hcm), true, hcm)
+	if dayUse.AllDay || dayUse.End.Sub(dayUse.Start) != 4*time.Hour {
+		t.Errorf("day use = %v..%v all day %v", dayUse.Start, dayUse.End, dayUse.AllDay)
+	}
+}
+
+func TestICalBlockReason(t *testing.T) {
+	occurrence := &ical.Occurrence{Summary: "Reserved"}
+
+	named := &db.IcalFeed{Url: "https://www.airbnb.com/calendar/ical/1.ics", Name: sql.NullString{String: "Airbnb", Valid: true}}
+	if reason := icalBlockReason(named, occurrence); reason != "Imported from Airbnb: Reserved" {
+		t.Errorf("reason = %q", reason)
+	}
+
+	unnamed := &db.IcalFeed{Url: "https://www.airbnb.com/calendar/ical/1.ics"}
+	if reason := icalBlockReason(unnamed, &ical.Occurrence{}); reason != "Imported from www.airbnb.com" {
+		t.Errorf("reason = %q", reason)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+)
+
+// defaultMagicLinkTTL is how long a magic link is valid when no lifetime is configured
+const defaultMagicLinkTTL = 72 * time.Hour
+
+type MagicLinkService interface {
+	IssueMagicLink(ctx context.Context, reservationID uuid.UUID, action string) (*model.MagicLink, error)
+	VerifyMagicLink(ctx context.Context, token string, reservationID uuid.UUID, action string) error
+	AuthorizeReservationAccess(ctx context.Context, reservationID uuid.UUID) error
+}
+
+type magicLinkService struct {
+	store           db.Store
+	reservationRepo repository.ReservationRepository
+	keys            [][]byte
+	ttl             time.Duration
+}
+
+// NewMagicLinkService signs links with the first of keys and accepts links signed with any of
+// them, so a new key can be put in front and the old one dropped once its links have expired
+func NewMagicLinkService(store db.Store, reservationRepo repository.ReservationRepository, keys []string, ttl time.Duration) MagicLinkService {
+	var signingKeys [][]byte
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			signingKeys = append(signingKeys, []byte(key))
+		}
+	}
+
+	if ttl <= 0 {
+		ttl = defaultMagicLinkTTL
+	}
+
+	return &magicLinkService{
+		store:           store,
+		reservationRepo: reservationRepo,
+		keys:            signingKeys,
+		ttl:             ttl,
+	}
+}
+
+// IssueMagicLink signs a token for action on a reservation. The token carries the reservation's
+// current status, so it stops working as soon as the reservation is confirmed, cancelled or
+// completed, without any revocation list. Links are issued by the authenticated owner of the
+// reservation, or by staff on the guest's behalf.
+func (s *magicLinkService) IssueMagicLink(ctx context.Context, reservationID uuid.UUID, action string) (*model.MagicLink, error) {
+	if len(s.keys) == 0 {
+		return nil, errors.New("magic links are not configured")
+	}
+
+	if err := validateMagicLinkAction(action); err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.reservationRepo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if reservation == nil {
+		return nil, errors.New("reservation not found")
+	}
+
+	if err := s.checkAccess(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	if reservation.Status.String == "CANCELLED" {
+		return nil, errors.New("cannot issue links for a cancelled reservation")
+	}
+
+	expiresAt := time.Now().Add(s.ttl).Truncate(time.Second)
+	payload := strings.Join([]string{
+		reservationID.String(),
+		action,
+		strconv.FormatInt(expiresAt.Unix(), 10),
+		reservation.Status.String,
+	}, "|")
+
+	encoding := base64.RawURLEncoding
+	token := encoding.EncodeToString([]byte(payload)) + "." + encoding.EncodeToString(signMagicLink(s.keys[0], payload))
+
+	return &model.MagicLink{
+		ReservationID: reservationID,
+		Action:        action,
+		Token:         token,
+		ExpiresAt:     expiresAt,
+	}, nil
+}
+
+// AuthorizeReservationAccess checks that the authenticated caller, who came without a magic link,
+// owns the reservation or is staff
+func (s *magicLinkService) AuthorizeReservationAccess(ctx context.Context, reservationID uuid.UUID) error {
+	reservation, err := s.reservationRepo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		return err
+	}
+	if reservation == nil {
+		return errors.New("reservation not found")
+	}
+
+	return s.checkAccess(ctx, reservation)
+}
+
+// checkAccess checks that the authenticated caller owns the reservation or is staff, who may act
+// on it, or issue links for it, on the guest's behalf
+func (s *magicLinkService) checkAccess(ctx context.Context, reservation *model.Reservation) error {
+	actor := ActorFromContext(ctx)
+	if actor == "" {
+		return errors.New("authentication required")
+	}
+
+	user, err := s.store.GetUser(ctx, actor)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	if user.Role.String == roleAdmin || user.Role.String == roleStaff {
+		return nil
+	}
+	if reservation.UserID.Valid && reservation.UserID.String == user.Username {
+		return nil
+	}
+	return errors.New("only the reservation owner or staff can access the reservation")
+}
+
+// VerifyMagicLink checks that token was signed with a configured key for action on the
+// reservation, has not expired and was issued for the reservation's current status
+func (s *magicLinkService) VerifyMagicLink(ctx context.Context, token string, reservationID uuid.UUID, action string) error {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return errors.New("invalid magic link")
+	}
+
+	encoding := base64.RawURLEncoding
+	payloadBytes, err := encoding.DecodeString(encodedPayload)
+	if err != nil {
+		return errors.New("invalid magic link")
+	}
+	mac, err := encoding.DecodeString(encodedMAC)
+	if err != nil {
+		return errors.New("invalid magic link")
+	}
+
+	payload := string(payloadBytes)
+	signed := false
+	for _, key := range s.keys {
+		if hmac.Equal(mac, signMagicLink(key, payload)) {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return errors.New("invalid magic link")
+	}
+
+	fields := strings.Split(payload, "|")
+	if len(fields) != 4 || fields[0] != reservationID.String() || fields[1] != action {
+		return errors.New("invalid magic link")
+	}
+
+	expiresAt, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return errors.New("invalid magic link")
+	}
+	if time.Now().Unix() >= expiresAt {
+		return errors.New("magic link has expired")
+	}
+
+	reservation, err := s.reservationRepo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		return err
+	}
+	if reservation == nil || reservation.Status.String != fields[3] {
+		return errors.New("magic link has been revoked")
+	}
+
+	return nil
+}
+
+func signMagicLink(key []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func validateMagicLinkAction(action string) error {
+	switch action {
+	case model.MagicLinkView, model.MagicLinkCancel, model.MagicLinkModify:
+		return nil
+	}
+	return errors.New("action must be VIEW, CANCEL or MODIFY")
+}
\ No newline at end of file
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/logger"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/notification"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// notificationReminderBatchSize bounds how many arrival reminders one pass sends
+	notificationReminderBatchSize = 100
+	// notificationDefaultReminderDays is how many days before arrival the reminder is sent when
+	// no other lead time is configured
+	notificationDefaultReminderDays = 1
+	// notificationDateLayout formats stay dates in every locale
+	notificationDateLayout = "2006-01-02"
+	// notificationClaimTimeout is how long an email may stay claimed but unsent before another
+	// call takes it over, as when the instance sending it crashed. It is well beyond any send.
+	notificationClaimTimeout = 15 * time.Minute
+)
+
+// notificationKinds maps the reservation events guests are emailed about to the email they get
+var notificationKinds = map[string]string{
+	model.EventReservationCreated:   model.NotificationBookingReceived,
+	model.EventReservationConfirmed: model.NotificationBookingConfirmation,
+	model.EventReservationCancelled: model.NotificationCancellation,
+	model.EventReservationCompleted: model.NotificationReviewInvitation,
+}
+
+// NotificationService emails guests about their bookings. As an event publisher it sends the
+// email for a reservation event; SendArrivalReminders sends reminders for upcoming stays. Emails
+// go to the primary guest, in their locale, and each kind of email is sent at most once per
+// reservation however often the event is delivered.
+type NotificationService interface {
+	EventPublisher
+	SendArrivalReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+type notificationService struct {
+	store        db.Store
+	renderer     *notification.Renderer
+	sender       notification.Sender
+	reminderDays int
+	reviewURL    string
+}
+
+// NewNotificationService sends arrival reminders reminderDays before arrival, and links review
+// invitations to reviewURL with the booking's confirmation code added as the code parameter
+func NewNotificationService(store db.Store, renderer *notification.Renderer, sender notification.Sender, reminderDays int, reviewURL string) NotificationService {
+	if reminderDays < 1 {
+		reminderDays = notificationDefaultReminderDays
+	}
+
+	return &notificationService{
+		store:        store,
+		renderer:     renderer,
+		sender:       sender,
+		reminderDays: reminderDays,
+		reviewURL:    reviewURL,
+	}
+}
+
+// Publish emails the guest about reservation events. Other events are ignored.
+func (s *notificationService) Publish(ctx context.Context, event *model.Event) error {
+	kind, ok := notificationKinds[event.Type]
+	if !ok || event.AggregateType != model.AggregateReservation {
+		return nil
+	}
+
+	_, err := s.notify(ctx, event.AggregateID, kind)
+	return err
+}
+
+// SendArrivalReminders reminds guests of confirmed stays starting within the reminder lead time
+// and returns how many reminders were sent. A reminder that fails is retried on the next pass.
+func (s *notificationService) SendArrivalReminders(ctx context.Context, now time.Time) (int, error) {
+	reservations, err := s.store.ListReservationsDueForReminder(ctx, db.ListReservationsDueForReminderParams{
+		Now:         sql.NullTime{Time: now, Valid: true},
+		Until:       sql.NullTime{Time: now.AddDate(0, 0, s.reminderDays), Valid: true},
+		StaleBefore: now.Add(-notificationClaimTimeout),
+		Limit:       notificationReminderBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+	for _, reservation := range reservations {
+		ok, err := s.notify(ctx, reservation.ReservationID, model.NotificationArrivalReminder)
+		if err != nil {
+			if logger.Log != nil {
+				logger.Log.Warn("Failed to send arrival reminder",
+					zap.String("reservation_id", reservation.ReservationID.String()),
+					zap.Error(err),
+				)
+			}
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// notify sends one kind of email about a reservation and reports whether it was sent. Nothing
+// is sent when the reservation has no guest with an email address, or when the email was
+// already sent or is being sent. A failed email is recorded and retried by the next call, as is
+// one still unsent notificationClaimTimeout after it was claimed.
+func (s *notificationService) notify(ctx context.Context, reservationID uuid.UUID, kind string) (bool, error) {
+	reservation, err := s.store.GetReservation(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	guests, err := s.store.ListReservationGuests(ctx, reservationID)
+	if err != nil {
+		return false, err
+	}
+
+	guest := notificationRecipient(guests)
+	if guest == nil {
+		return false, nil
+	}
+
+	var hotel db.Hotel
+	if reservation.HotelID.Valid {
+		hotel, err = s.store.GetHotel(ctx, reservation.HotelID.UUID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return false, err
+		}
+	}
+
+	locale := s.renderer.ResolveLocale(guest.Locale.String)
+	now := time.Now()
+	claimed, err := s.store.ClaimNotification(ctx, db.ClaimNotificationParams{
+		NotificationID: uuid.New(),
+		ReservationID:  reservationID,
+		Kind:           kind,
+		Locale:         locale,
+		Recipient:      guest.Email.String,
+		CreatedAt:      now,
+		ClaimedAt:      now,
+		StaleBefore:    now.Add(-notificationClaimTimeout),
+	})
+	if err != nil {
+		// No row is claimed when the email was already sent or another instance is sending it
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	msg, err := s.renderer.Render(kind, locale, s.bookingData(&reservation, guest, &hotel))
+	if err == nil {
+		msg.To = guest.Email.String
+		err = s.sender.Send(ctx, msg)
+	}
+	if err != nil {
+		if markErr := s.store.MarkNotificationFailed(ctx, db.MarkNotificationFailedParams{
+			NotificationID: claimed.NotificationID,
+			LastError:      sql.NullString{String: err.Error(), Valid: true},
+		}); markErr != nil {
+			return false, markErr
+		}
+		return false, err
+	}
+
+	return true, s.store.MarkNotificationSent(ctx, db.MarkNotificationSentParams{
+		NotificationID: claimed.NotificationID,
+		Subject:        sql.NullString{String: msg.Subject, Valid: true},
+		SentAt:         sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+func (s *notificationService) bookingData(reservation *db.Reservation, guest *db.ReservationGuest, hotel *db.Hotel) *notification.Booking {
+	data := &notification.Booking{
+		GuestName:        strings.TrimSpace(guest.FirstName.String + " " + guest.LastName.String),
+		ConfirmationCode: reservation.ConfirmationCode.String,
+		CheckInTime:      hotel.CheckInTime.String,
+		CheckOutTime:     hotel.CheckOutTime.String,
+		RoomType:         reservation.TypeID.String,
+		Adults:           reservation.Adults.Int32,
+		Children:         reservation.Children.Int32,
+		TotalPrice:       reservation.TotalPrice.Int32,
+		SpecialRequests:  reservation.SpecialRequests.String,
+	}
+
+	// Stay dates are stored in UTC; guests read them as dates at the hotel
+	loc := hotelLocation(model.FromDBHotel(hotel))
+	if reservation.StartDate.Valid {
+		data.CheckIn = reservation.StartDate.Time.In(loc).Format(notificationDateLayout)
+	}
+	if reservation.EndDate.Valid {
+		data.CheckOut = reservation.EndDate.Time.In(loc).Format(notificationDateLayout)
+	}
+
+	if s.reviewURL != "" {
+		if reviewURL, err := url.Parse(s.reviewURL); err == nil {
+			query := reviewURL.Query()
+			query.Set("code", reservation.ConfirmationCode.String)
+			reviewURL.RawQuery = query.Encode()
+			data.ReviewURL = reviewURL.String()
+		}
+	}
+
+	return data
+}
+
+// notificationRecipient picks the primary guest when they have an email address, or else the
+// first guest who has one
+func notificationRecipient(guests []db.ReservationGuest) *db.ReservationGuest {
+	var recipient *db.ReservationGuest
+	for i := range guests {
+		guest := &guests[i]
+		if !guest.Email.Valid || guest.Email.String == "" {
+			continue
+		}
+		if guest.IsPrimary.Bool {
+			return guest
+		}
+		if recipient == nil {
+			recipient = guest
+		}
+	}
+	return recipient
+}
\ No newline at end of file
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+// Guests older than maxChildAge are adults
+const maxChildAge = 17
+
+// normalizeOccupancy defaults the party to one adult and the children whose ages are given, then
+// checks that every child has an age and that the named guests fit the party.
+func normalizeOccupancy(reservation *model.Reservation) error {
+	if !reservation.Adults.Valid {
+		reservation.Adults = sql.NullInt32{Int32: 1, Valid: true}
+	}
+	if !reservation.Children.Valid {
+		reservation.Children = sql.NullInt32{Int32: int32(len(reservation.ChildAges)), Valid: true}
+	}
+
+	if reservation.Adults.Int32 < 1 {
+		return errors.New("at least one adult is required")
+	}
+	if reservation.Children.Int32 < 0 {
+		return errors.New("children cannot be negative")
+	}
+
+	if int32(len(reservation.ChildAges)) != reservation.Children.Int32 {
+		return errors.New("child ages are required for every child")
+	}
+	for _, age := range reservation.ChildAges {
+		if age < 0 || age > maxChildAge {
+			return fmt.Errorf("child ages must be between 0 and %d", maxChildAge)
+		}
+	}
+
+	return checkGuests(reservation)
+}
+
+// checkGuests validates the named guests of a reservation. Naming guests is optional, but when
+// any are named exactly one of them is the primary guest, an adult who can be contacted.
+func checkGuests(reservation *model.Reservation) error {
+	if len(reservation.Guests) == 0 {
+		return nil
+	}
+
+	var adults, children, primaries int32
+	for _, guest := range reservation.Guests {
+		if !guest.FirstName.Valid || strings.TrimSpace(guest.FirstName.String) == "" || !guest.LastName.Valid || strings.TrimSpace(guest.LastName.String) == "" {
+			return errors.New("guests require a first and last name")
+		}
+
+		if guest.IsChild.Bool {
+			if !guest.Age.Valid || guest.Age.Int32 < 0 || guest.Age.Int32 > maxChildAge {
+				return fmt.Errorf("child guests require an age between 0 and %d", maxChildAge)
+			}
+			children++
+		} else {
+			adults++
+		}
+
+		if guest.IsPrimary.Bool {
+			if guest.IsChild.Bool {
+				return errors.New("the primary guest must be an adult")
+			}
+			if (!guest.Email.Valid || strings.TrimSpace(guest.Email.String) == "") && (!guest.Phone.Valid || strings.TrimSpace(guest.Phone.String) == "") {
+				return errors.New("the primary guest requires an email or phone number")
+			}
+			primaries++
+		}
+	}
+
+	if adults > reservation.Adults.Int32 || children > reservation.Children.Int32 {
+		return errors.New("more guests are named than the party holds")
+	}
+	if primaries != 1 {
+		return errors.New("exactly one primary guest is required")
+	}
+
+	return nil
+}
+
+// priceOccupancy checks the party against the room's capacity, or its type's default capacity
+// for rooms without their own maximum, and returns the nightly charge for the guests beyond the
+// type's base occupancy. Adults take the places the room rate includes before children do.
+func priceOccupancy(ctx context.Context, q *db.Queries, reservation *model.Reservation, room *model.Room) (int32, error) {
+	var roomType *model.RoomType
+	if room.TypeID.Valid {
+		dbType, err := q.GetType(ctx, room.TypeID.String)
+		if err != nil && err != sql.ErrNoRows {
+			return 0, err
+		}
+		if err == nil {
+			roomType = model.FromDBRoomType(&dbType)
+		}
+	}
+
+	party := reservation.Adults.Int32 + reservation.Children.Int32
+	capacity := room.MaxCapacity
+	if !capacity.Valid && roomType != nil {
+		capacity = roomType.DefaultCapacity
+	}
+	if capacity.Valid && party > capacity.Int32 {
+		return 0, fmt.Errorf("party of %d exceeds the room's capacity of %d", party, capacity.Int32)
+	}
+
+	if roomType == nil || !roomType.BaseOccupancy.Valid {
+		return 0, nil
+	}
+
+	extraAdults := reservation.Adults.Int32 - roomType.BaseOccupancy.Int32
+	extraChildren := reservation.Children.Int32
+	if extraAdults < 0 {
+		extraChildren += extraAdults
+		extraAdults = 0
+	}
+	if extraChildren < 0 {
+		extraChildren = 0
+	}
+
+	return extraAdults*roomType.ExtraAdultPrice.Int32 + extraChildren*roomType.ExtraChildPrice.Int32, nil
+}
+
+// saveReservationGuests replaces the named guests of a reservation
+func saveReservationGuests(ctx context.Context, q *db.Queries, reservation *model.Reservation) error {
+	if err := q.DeleteReservationGuests(ctx, reservation.ReservationID); err != nil {
+		return err
+	}
+
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+	for _, guest := range reservation.Guests {
+		guest.GuestID = uuid.New()
+		guest.ReservationID = reservation.ReservationID
+		guest.IsChild = sql.NullBool{Bool: guest.IsChild.Bool, Valid: true}
+		guest.IsPrimary = sql.NullBool{Bool: guest.IsPrimary.Bool, Valid: true}
+		guest.CreatedAt = now
+		if !guest.IsChild.Bool {
+			guest.Age = sql.NullInt32{}
+		}
+
+		_, err := q.CreateReservationGuest(ctx, db.CreateReservationGuestParams{
+			GuestID:       guest.GuestID,
+			ReservationID: guest.ReservationID,
+			FirstName:     guest.FirstName,
+			LastName:      guest.LastName,
+			Age:           guest.Age,
+			IsChild:       guest.IsChild,
+			IsPrimary:     guest.IsPrimary,
+			Email:         guest.Email,
+			Phone:         guest.Phone,
+			CreatedAt:     guest.CreatedAt,
+			Locale:        guest.Locale,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/logger"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// outboxBatchSize bounds how many events one dispatch pass delivers
+	outboxBatchSize = 100
+	// Failed deliveries are retried after a delay that doubles with every attempt, up to an hour,
+	// and given up after outboxMaxAttempts attempts, about ten hours after the event occurred
+	outboxBaseRetryDelay = 5 * time.Second
+	outboxMaxRetryDelay  = time.Hour
+	outboxMaxAttempts    = 20
+	// outboxLeaseDuration is how long a claimed batch is held back from other dispatchers. It has
+	// to outlast delivering the whole batch.
+	outboxLeaseDuration = 5 * time.Minute
+)
+
+// recordEvent writes a domain event to the outbox in the caller's transaction, so the event is
+// dispatched if and only if the change it describes is committed
+func recordEvent(ctx context.Context, q *db.Queries, eventType, aggregateType string, aggregateID uuid.UUID, hotelID uuid.NullUUID, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return q.CreateOutboxEvent(ctx, db.CreateOutboxEventParams{
+		EventID:       uuid.New(),
+		EventType:     eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		HotelID:       hotelID,
+		Payload:       data,
+		OccurredAt:    time.Now(),
+	})
+}
+
+// recordReservationEvent writes an event about a reservation to the outbox
+func recordReservationEvent(ctx context.Context, q *db.Queries, eventType string, reservation *model.Reservation) error {
+	return recordEvent(ctx, q, eventType, model.AggregateReservation, reservation.ReservationID, reservation.HotelID, reservation)
+}
+
+type OutboxDispatcher interface {
+	DispatchPending(ctx context.Context, now time.Time) (int, error)
+}
+
+// OutboxSink is a publisher the outbox delivers events to. The name records which sinks accepted
+// an event, so it has to stay the same across releases.
+type OutboxSink struct {
+	Name      string
+	Publisher EventPublisher
+}
+
+type outboxDispatcher struct {
+	store db.Store
+	sinks []OutboxSink
+}
+
+// NewOutboxDispatcher returns a dispatcher that delivers outbox events to every sink. Delivery
+// is at least once: an event is retried, only to the sinks that have not accepted it yet, until
+// every sink accepts it or it has failed outboxMaxAttempts times. A sink can still see an event
+// twice when its dispatcher stops before recording the delivery, so sinks must treat the event
+// ID as an idempotency key.
+func NewOutboxDispatcher(store db.Store, sinks ...OutboxSink) OutboxDispatcher {
+	return &outboxDispatcher{
+		store: store,
+		sinks: sinks,
+	}
+}
+
+// DispatchPending delivers a batch of undelivered events in the order they occurred and returns
+// how many were delivered. The batch is claimed in a short transaction that leases its events, so
+// several instances can dispatch from the same outbox without delivering an event twice at the
+// same time. Events are delivered after the claim commits, so slow sinks hold no locks, and the
+// outcome of each event is recorded as soon as it is known. An event whose dispatcher stopped
+// before recording the outcome is claimed again once its lease runs out.
+func (d *outboxDispatcher) DispatchPending(ctx context.Context, now time.Time) (int, error) {
+	var dbEvents []db.OutboxEvent
+	err := d.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		dbEvents, err = q.ListPendingOutboxEvents(ctx, db.ListPendingOutboxEventsParams{
+			Now:   sql.NullTime{Time: now, Valid: true},
+			Limit: outboxBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i := range dbEvents {
+			err := q.LeaseOutboxEvent(ctx, db.LeaseOutboxEventParams{
+				EventID:       dbEvents[i].EventID,
+				NextAttemptAt: sql.NullTime{Time: now.Add(outboxLeaseDuration), Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var dispatched int
+	for i := range dbEvents {
+		event := model.FromDBOutboxEvent(&dbEvents[i])
+
+		if deliverErr := d.deliver(ctx, event); deliverErr != nil {
+			attempts := dbEvents[i].Attempts + 1
+			nextAttemptAt := sql.NullTime{Time: time.Now().Add(outboxRetryDelay(dbEvents[i].Attempts)), Valid: true}
+			var failedAt sql.NullTime
+			if attempts >= outboxMaxAttempts {
+				nextAttemptAt = sql.NullTime{}
+				failedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			}
+
+			if logger.Log != nil {
+				fields := []zap.Field{
+					zap.String("event_id", event.EventID.String()),
+					zap.String("type", event.Type),
+					zap.Int32("attempts", attempts),
+					zap.Error(deliverErr),
+				}
+				if failedAt.Valid {
+					logger.Log.Error("Giving up on dispatching event", fields...)
+				} else {
+					logger.Log.Warn("Failed to dispatch event", fields...)
+				}
+			}
+
+			err = d.store.MarkOutboxEventFailed(ctx, db.MarkOutboxEventFailedParams{
+				EventID:       event.EventID,
+				LastError:     sql.NullString{String: deliverErr.Error(), Valid: true},
+				NextAttemptAt: nextAttemptAt,
+				FailedAt:      failedAt,
+			})
+			if err != nil {
+				return dispatched, err
+			}
+			continue
+		}
+
+		err := d.store.MarkOutboxEventDispatched(ctx, db.MarkOutboxEventDispatchedParams{
+			EventID:      event.EventID,
+			DispatchedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			return dispatched, err
+		}
+		dispatched++
+	}
+
+	return dispatched, nil
+}
+
+// deliver hands an event to every sink that has not accepted it yet, even when an earlier one
+// fails, and records each sink that accepts it. A delivery that could not be recorded counts as
+// failed, so the sink sees the event again.
+func (d *outboxDispatcher) deliver(ctx context.Context, event *model.Event) error {
+	sinks, err := d.store.ListOutboxEventSinks(ctx, event.EventID)
+	if err != nil {
+		return err
+	}
+	delivered := make(map[string]bool, len(sinks))
+	for _, sink := range sinks {
+		delivered[sink] = true
+	}
+
+	var errs []error
+	for _, sink := range d.sinks {
+		if delivered[sink.Name] {
+			continue
+		}
+		if err := sink.Publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name, err))
+			continue
+		}
+
+		err := d.store.CreateOutboxDelivery(ctx, db.CreateOutboxDeliveryParams{
+			EventID:     event.EventID,
+			Sink:        sink.Name,
+			DeliveredAt: time.Now(),
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: recording delivery: %w", sink.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// outboxRetryDelay is how long to wait before retrying an event that has failed attempts times
+func outboxRetryDelay(attempts int32) time.Duration {
+	delay := outboxBaseRetryDelay
+	for i := int32(0); i < attempts && delay < outboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > outboxMaxRetryDelay {
+		delay = outboxMaxRetryDelay
+	}
+	return delay
+}
\ No newline at end of file
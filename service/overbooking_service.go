@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+)
+
+// maxOverbookPercent caps how far a room type can be oversold
+const maxOverbookPercent = 50
+
+type OverbookingService interface {
+	CreateOverbookingAllowance(ctx context.Context, allowance *model.OverbookingAllowance) error
+	GetOverbookingAllowanceByID(ctx context.Context, allowanceID uuid.UUID) (*model.OverbookingAllowance, error)
+	ListOverbookingAllowancesByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.OverbookingAllowance, error)
+	UpdateOverbookingAllowance(ctx context.Context, allowance *model.OverbookingAllowance) error
+	DeleteOverbookingAllowance(ctx context.Context, allowanceID uuid.UUID) error
+	GetOverbookingReport(ctx context.Context, hotelID uuid.UUID, from, to time.Time) (*model.OverbookingReport, error)
+}
+
+type overbookingService struct {
+	store           db.Store
+	overbookingRepo repository.OverbookingRepository
+	hotelRepo       repository.HotelRepository
+	roomTypeRepo    repository.RoomTypeRepository
+}
+
+func NewOverbookingService(store db.Store, overbookingRepo repository.OverbookingRepository, hotelRepo repository.HotelRepository, roomTypeRepo repository.RoomTypeRepository) OverbookingService {
+	return &overbookingService{
+		store:           store,
+		overbookingRepo: overbookingRepo,
+		hotelRepo:       hotelRepo,
+		roomTypeRepo:    roomTypeRepo,
+	}
+}
+
+func (s *overbookingService) CreateOverbookingAllowance(ctx context.Context, allowance *model.OverbookingAllowance) error {
+	if allowance.AllowanceID == uuid.Nil {
+		allowance.AllowanceID = uuid.New()
+	}
+
+	if !allowance.HotelID.Valid {
+		return errors.New("invalid hotel ID")
+	}
+
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, allowance.HotelID.UUID)
+	if err != nil {
+		return err
+	}
+	if hotel == nil {
+		return errors.New("hotel not found")
+	}
+
+	if err := s.validateOverbookingAllowance(ctx, allowance); err != nil {
+		return err
+	}
+	localizeAllowance(hotel, allowance)
+
+	allowance.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.overbookingRepo.CreateOverbookingAllowance(ctx, allowance); err != nil {
+		return err
+	}
+	return markChannelsDue(ctx, s.store, allowance.HotelID)
+}
+
+func (s *overbookingService) GetOverbookingAllowanceByID(ctx context.Context, allowanceID uuid.UUID) (*model.OverbookingAllowance, error) {
+	allowance, err := s.overbookingRepo.GetOverbookingAllowanceByID(ctx, allowanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowance == nil {
+		return nil, errors.New("overbooking allowance not found")
+	}
+
+	return allowance, nil
+}
+
+func (s *overbookingService) ListOverbookingAllowancesByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.OverbookingAllowance, error) {
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.overbookingRepo.ListOverbookingAllowancesByHotel(ctx, hotelID, pageSize, offset)
+}
+
+func (s *overbookingService) UpdateOverbookingAllowance(ctx context.Context, allowance *model.OverbookingAllowance) error {
+	existingAllowance, err := s.overbookingRepo.GetOverbookingAllowanceByID(ctx, allowance.AllowanceID)
+	if err != nil {
+		return err
+	}
+
+	if existingAllowance == nil {
+		return errors.New("overbooking allowance not found")
+	}
+
+	// Allowances cannot move between hotels, so dates are localized with the stored hotel
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, existingAllowance.HotelID.UUID)
+	if err != nil {
+		return err
+	}
+	if hotel == nil {
+		return errors.New("hotel not found")
+	}
+
+	if !allowance.TypeID.Valid {
+		allowance.TypeID = existingAllowance.TypeID
+	}
+	if !allowance.StartDate.Valid {
+		allowance.StartDate = existingAllowance.StartDate
+	}
+	if !allowance.EndDate.Valid {
+		allowance.EndDate = existingAllowance.EndDate
+	}
+	if !allowance.OverbookPercent.Valid {
+		allowance.OverbookPercent = existingAllowance.OverbookPercent
+	}
+
+	if err := s.validateOverbookingAllowance(ctx, allowance); err != nil {
+		return err
+	}
+	localizeAllowance(hotel, allowance)
+
+	allowance.UpdateAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.overbookingRepo.UpdateOverbookingAllowance(ctx, allowance); err != nil {
+		return err
+	}
+	return markChannelsDue(ctx, s.store, existingAllowance.HotelID)
+}
+
+func (s *overbookingService) DeleteOverbookingAllowance(ctx context.Context, allowanceID uuid.UUID) error {
+	existingAllowance, err := s.overbookingRepo.GetOverbookingAllowanceByID(ctx, allowanceID)
+	if err != nil {
+		return err
+	}
+
+	if existingAllowance == nil {
+		return errors.New("overbooking allowance not found")
+	}
+
+	if err := s.overbookingRepo.DeleteOverbookingAllowance(ctx, allowanceID); err != nil {
+		return err
+	}
+	return markChannelsDue(ctx, s.store, existingAllowance.HotelID)
+}
+
+// GetOverbookingReport lists the nights in [from, to) on which a room type has more confirmed
+// bookings than rooms in service, so staff can plan relocations ahead of arrival
+func (s *overbookingService) GetOverbookingReport(ctx context.Context, hotelID uuid.UUID, from, to time.Time) (*model.OverbookingReport, error) {
+	if !from.Before(to) {
+		return nil, errors.New("invalid date range: from must be before to")
+	}
+
+	if to.Sub(from) > maxCalendarNights*24*time.Hour {
+		return nil, errors.New("date range cannot exceed 92 nights")
+	}
+
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+
+	// Nights are sampled at check-in time, as on the hotel calendar
+	loc := hotelLocation(hotel)
+	checkIn, _ := stayWindow(hotel, from, to)
+	last, _ := stayWindow(hotel, to, to)
+	nights, err := s.overbookingRepo.ListOverbookedNights(ctx, hotelID, checkIn.Format(time.RFC3339), last.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, night := range nights {
+		night.Date = night.Night.In(loc).Format("2006-01-02")
+	}
+	if nights == nil {
+		nights = []*model.OverbookedNight{}
+	}
+
+	return &model.OverbookingReport{
+		HotelID: hotelID,
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		Nights:  nights,
+	}, nil
+}
+
+// localizeAllowance stores an allowance's dates as midnight in the hotel's time zone, so it
+// covers whole local nights
+func localizeAllowance(hotel *model.Hotel, allowance *model.OverbookingAllowance) {
+	loc := hotelLocation(hotel)
+	allowance.StartDate = sql.NullTime{Time: localMidnight(allowance.StartDate.Time, loc), Valid: true}
+	allowance.EndDate = sql.NullTime{Time: localMidnight(allowance.EndDate.Time, loc), Valid: true}
+}
+
+func (s *overbookingService) validateOverbookingAllowance(ctx context.Context, allowance *model.OverbookingAllowance) error {
+	if !allowance.TypeID.Valid || allowance.TypeID.String == "" {
+		return errors.New("room type is required")
+	}
+
+	if !allowance.StartDate.Valid || !allowance.EndDate.Valid {
+		return errors.New("invalid allowance dates")
+	}
+
+	if !allowance.StartDate.Time.Before(allowance.EndDate.Time) {
+		return errors.New("invalid date range: start date must be before end date")
+	}
+
+	if !allowance.OverbookPercent.Valid || allowance.OverbookPercent.Int32 < 1 || allowance.OverbookPercent.Int32 > maxOverbookPercent {
+		return errors.New("overbook percent must be between 1 and 50")
+	}
+
+	if _, err := checkRoomTypeExists(ctx, s.roomTypeRepo, allowance.TypeID); err != nil {
+		return err
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+)
+
+type PromoCodeService interface {
+	CreatePromoCode(ctx context.Context, promo *model.PromoCode) error
+	GetPromoCode(ctx context.Context, code string) (*model.PromoCode, error)
+	ListPromoCodes(ctx context.Context, page, pageSize int) ([]*model.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, promo *model.PromoCode) error
+	DeletePromoCode(ctx context.Context, code string) error
+}
+
+type promoCodeService struct {
+	promoCodeRepo repository.PromoCodeRepository
+	hotelRepo     repository.HotelRepository
+	roomTypeRepo  repository.RoomTypeRepository
+}
+
+func NewPromoCodeService(promoCodeRepo repository.PromoCodeRepository, hotelRepo repository.HotelRepository, roomTypeRepo repository.RoomTypeRepository) PromoCodeService {
+	return &promoCodeService{
+		promoCodeRepo: promoCodeRepo,
+		hotelRepo:     hotelRepo,
+		roomTypeRepo:  roomTypeRepo,
+	}
+}
+
+func (s *promoCodeService) CreatePromoCode(ctx context.Context, promo *model.PromoCode) error {
+	promo.Code = normalizePromoCode(promo.Code)
+	if promo.Code == "" {
+		return errors.New("promo code is required")
+	}
+
+	existingPromo, err := s.promoCodeRepo.GetPromoCodeByCode(ctx, promo.Code)
+	if err != nil {
+		return err
+	}
+	if existingPromo != nil {
+		return errors.New("promo code already exists")
+	}
+
+	if err := s.validatePromoCode(ctx, promo); err != nil {
+		return err
+	}
+
+	if !promo.IsActive.Valid {
+		promo.IsActive = sql.NullBool{Bool: true, Valid: true}
+	}
+	promo.RedemptionCount = sql.NullInt32{Int32: 0, Valid: true}
+	promo.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return s.promoCodeRepo.CreatePromoCode(ctx, promo)
+}
+
+func (s *promoCodeService) GetPromoCode(ctx context.Context, code string) (*model.PromoCode, error) {
+	promo, err := s.promoCodeRepo.GetPromoCodeByCode(ctx, normalizePromoCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	if promo == nil {
+		return nil, errors.New("promo code not found")
+	}
+
+	return promo, nil
+}
+
+func (s *promoCodeService) ListPromoCodes(ctx context.Context, page, pageSize int) ([]*model.PromoCode, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.promoCodeRepo.ListPromoCodes(ctx, pageSize, offset)
+}
+
+func (s *promoCodeService) UpdatePromoCode(ctx context.Context, promo *model.PromoCode) error {
+	promo.Code = normalizePromoCode(promo.Code)
+	existingPromo, err := s.promoCodeRepo.GetPromoCodeByCode(ctx, promo.Code)
+	if err != nil {
+		return err
+	}
+
+	if existingPromo == nil {
+		return errors.New("promo code not found")
+	}
+
+	if err := s.validatePromoCode(ctx, promo); err != nil {
+		return err
+	}
+
+	if !promo.IsActive.Valid {
+		promo.IsActive = existingPromo.IsActive
+	}
+	promo.UpdateAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return s.promoCodeRepo.UpdatePromoCode(ctx, promo)
+}
+
+func (s *promoCodeService) DeletePromoCode(ctx context.Context, code string) error {
+	code = normalizePromoCode(code)
+	existingPromo, err := s.promoCodeRepo.GetPromoCodeByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if existingPromo == nil {
+		return errors.New("promo code not found")
+	}
+
+	// Redeemed codes are referenced by reservations, so they can only be deactivated
+	if existingPromo.RedemptionCount.Valid && existingPromo.RedemptionCount.Int32 > 0 {
+		return errors.New("cannot delete a promo code that has been redeemed, deactivate it instead")
+	}
+
+	return s.promoCodeRepo.DeletePromoCode(ctx, code)
+}
+
+func (s *promoCodeService) validatePromoCode(ctx context.Context, promo *model.PromoCode) error {
+	if !promo.DiscountType.Valid {
+		return errors.New("discount type is required")
+	}
+	promo.DiscountType.String = strings.ToUpper(promo.DiscountType.String)
+
+	if !promo.DiscountValue.Valid || promo.DiscountValue.Int32 <= 0 {
+		return errors.New("discount value must be greater than 0")
+	}
+
+	switch promo.DiscountType.String {
+	case "PERCENTAGE":
+		if promo.DiscountValue.Int32 > 100 {
+			return errors.New("percentage discount cannot exceed 100")
+		}
+	case "FIXED":
+	default:
+		return errors.New("invalid discount type. Use PERCENTAGE or FIXED")
+	}
+
+	if promo.ValidFrom.Valid && promo.ValidTo.Valid && !promo.ValidFrom.Time.Before(promo.ValidTo.Time) {
+		return errors.New("invalid validity window: valid_from must be before valid_to")
+	}
+
+	if promo.StayFrom.Valid && promo.StayTo.Valid && !promo.StayFrom.Time.Before(promo.StayTo.Time) {
+		return errors.New("invalid stay window: stay_from must be before stay_to")
+	}
+
+	if promo.MinNights.Valid && promo.MinNights.Int32 < 1 {
+		return errors.New("min nights must be at least 1")
+	}
+
+	if promo.MaxRedemptions.Valid && promo.MaxRedemptions.Int32 < 1 {
+		return errors.New("max redemptions must be at least 1")
+	}
+
+	if promo.MaxRedemptionsPerUser.Valid && promo.MaxRedemptionsPerUser.Int32 < 1 {
+		return errors.New("max redemptions per user must be at least 1")
+	}
+
+	if promo.HotelID.Valid {
+		hotel, err := s.hotelRepo.GetHotelByID(ctx, promo.HotelID.UUID)
+		if err != nil {
+			return err
+		}
+		if hotel == nil {
+			return errors.New("hotel not found")
+		}
+	}
+
+	if _, err := checkRoomTypeExists(ctx, s.roomTypeRepo, promo.TypeID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// checkPromoCodeEligibility verifies that a promo code can be applied to a stay in the given room.
+// userRedemptions is the number of times the guest has already redeemed the code.
+func checkPromoCodeEligibility(promo *model.PromoCode, room *model.Room, startDate, endDate time.Time, userRedemptions int64, now time.Time) error {
+	if promo.IsActive.Valid && !promo.IsActive.Bool {
+		return errors.New("promo code is not active")
+	}
+
+	if (promo.ValidFrom.Valid && now.Before(promo.ValidFrom.Time)) || (promo.ValidTo.Valid && !now.Before(promo.ValidTo.Time)) {
+		return errors.New("promo code is not valid at this time")
+	}
+
+	if err := checkPromoCodeStay(promo, room, startDate, endDate); err != nil {
+		return err
+	}
+
+	if promo.MaxRedemptions.Valid && promo.RedemptionCount.Int32 >= promo.MaxRedemptions.Int32 {
+		return errors.New("promo code redemption limit reached")
+	}
+
+	if promo.MaxRedemptionsPerUser.Valid && userRedemptions >= int64(promo.MaxRedemptionsPerUser.Int32) {
+		return errors.New("promo code redemption limit reached for this user")
+	}
+
+	return nil
+}
+
+// checkPromoCodeStay verifies that a promo code applies to a stay in the given room, whenever it
+// was booked. Modified stays are checked again against it.
+func checkPromoCodeStay(promo *model.PromoCode, room *model.Room, startDate, endDate time.Time) error {
+	if (promo.StayFrom.Valid && startDate.Before(promo.StayFrom.Time)) || (promo.StayTo.Valid && endDate.After(promo.StayTo.Time)) {
+		return errors.New("promo code does not apply to the selected stay dates")
+	}
+
+	if promo.MinNights.Valid && stayNights(startDate, endDate) < promo.MinNights.Int32 {
+		return fmt.Errorf("promo code requires a minimum stay of %d nights", promo.MinNights.Int32)
+	}
+
+	if promo.HotelID.Valid && (!room.HotelID.Valid || room.HotelID.UUID != promo.HotelID.UUID) {
+		return errors.New("promo code does not apply to this hotel")
+	}
+
+	if promo.TypeID.Valid && (!room.TypeID.Valid || room.TypeID.String != promo.TypeID.String) {
+		return errors.New("promo code does not apply to this room type")
+	}
+
+	return nil
+}
+
+// calculateDiscount returns the discount a promo code grants on subtotal, never more than subtotal itself.
+func calculateDiscount(promo *model.PromoCode, subtotal int32) int32 {
+	var discount int32
+	switch promo.DiscountType.String {
+	case "PERCENTAGE":
+		discount = int32(int64(subtotal) * int64(promo.DiscountValue.Int32) / 100)
+	case "FIXED":
+		discount = promo.DiscountValue.Int32
+	}
+
+	if discount > subtotal {
+		discount = subtotal
+	}
+	return discount
+}
+
+// stayNights counts the nights between check-in and check-out. Stays run from the hotel's
+// check-in time to its check-out time, which may be a few hours more or less than whole days,
+// so the span is rounded to the nearest night, with at least one night for any stay.
+func stayNights(startDate, endDate time.Time) int32 {
+	hours := endDate.Sub(startDate).Hours()
+	if hours <= 0 {
+		return 0
+	}
+	nights := int32((hours + 12) / 24)
+	if nights < 1 {
+		nights = 1
+	}
+	return nights
+}
\ No newline at end of file
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
 	"github.com/devsirose/hotel-reservation/model"
 	"github.com/devsirose/hotel-reservation/repository"
 	"github.com/google/uuid"
@@ -14,22 +16,36 @@ import (
 type ReservationService interface {
 	CreateReservation(ctx context.Context, reservation *model.Reservation) error
 	GetReservationByID(ctx context.Context, reservationID uuid.UUID) (*model.Reservation, error)
+	LookupReservation(ctx context.Context, confirmationCode, lastName string) (*model.Reservation, error)
 	ListReservationsByUser(ctx context.Context, userID string, page, pageSize int) ([]*model.Reservation, error)
 	ListReservationsByRoom(ctx context.Context, roomID uuid.UUID, page, pageSize int) ([]*model.Reservation, error)
-	UpdateReservation(ctx context.Context, reservation *model.Reservation) error
+	UpdateReservation(ctx context.Context, reservationID uuid.UUID, change *model.ReservationChange) (*model.Reservation, error)
+	UpdateReservationByLookup(ctx context.Context, confirmationCode, lastName string, change *model.GuestReservationChange) (*model.Reservation, error)
+	UpdateReservationAsGuest(ctx context.Context, reservationID uuid.UUID, change *model.GuestReservationChange) (*model.Reservation, error)
+	ListReservationModifications(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationModification, error)
 	CancelReservation(ctx context.Context, reservationID uuid.UUID) error
+	CancelReservationByLookup(ctx context.Context, confirmationCode, lastName string) error
 	ConfirmReservation(ctx context.Context, reservationID uuid.UUID) error
+	AssignRoom(ctx context.Context, reservationID, roomID uuid.UUID) error
+	MoveGuest(ctx context.Context, reservationID uuid.UUID, move *model.RoomMove) (*model.Reservation, error)
+	UpdateReservationExtras(ctx context.Context, reservationID uuid.UUID, change *model.ReservationExtrasChange) (*model.Reservation, error)
+	GetReservationFolio(ctx context.Context, reservationID uuid.UUID) (*model.Folio, error)
+	CheckOutReservation(ctx context.Context, reservationID uuid.UUID) error
 }
 
 type reservationService struct {
+	store           db.Store
 	reservationRepo repository.ReservationRepository
 	roomRepo        repository.RoomRepository
+	waitlist        WaitlistMatcher
 }
 
-func NewReservationService(reservationRepo repository.ReservationRepository, roomRepo repository.RoomRepository) ReservationService {
+func NewReservationService(store db.Store, reservationRepo repository.ReservationRepository, roomRepo repository.RoomRepository, waitlist WaitlistMatcher) ReservationService {
 	return &reservationService{
+		store:           store,
 		reservationRepo: reservationRepo,
 		roomRepo:        roomRepo,
+		waitlist:        waitlist,
 	}
 }
 
@@ -38,55 +54,433 @@ func (s *reservationService) CreateReservation(ctx context.Context, reservation
 		reservation.ReservationID = uuid.New()
 	}
 
-	roomID := reservation.RoomID.UUID
-	if !reservation.RoomID.Valid {
-		return errors.New("invalid room ID")
+	if !reservation.StartDate.Valid || !reservation.EndDate.Valid {
+		return errors.New("invalid reservation dates")
 	}
 
-	room, err := s.roomRepo.GetRoomByID(ctx, roomID)
-	if err != nil {
-		return err
+	if !reservation.StartDate.Time.Before(reservation.EndDate.Time) {
+		return errors.New("invalid date range: start date must be before end date")
 	}
-	if room == nil {
-		return errors.New("room not found")
+
+	if err := normalizeStayType(reservation); err != nil {
+		return err
 	}
 
-	// Fix: room.HotelID is likely a uuid.NullUUID, so use .UUID and check .Valid
-	if !room.HotelID.Valid {
-		return errors.New("invalid hotel ID")
+	if err := normalizeOccupancy(reservation); err != nil {
+		return err
 	}
-	// Fix: reservation.StartDate and EndDate are sql.NullTime, so use .Time and check .Valid
-	if !reservation.StartDate.Valid || !reservation.EndDate.Valid {
-		return errors.New("invalid reservation dates")
+
+	if err := checkGuestCheckout(reservation); err != nil {
+		return err
 	}
 
-	availableRooms, err := s.roomRepo.GetAvailableRooms(
-		ctx,
-		room.HotelID.UUID,
-		reservation.StartDate.Time.Format(time.RFC3339),
-		reservation.EndDate.Time.Format(time.RFC3339),
-	)
-	if err != nil {
+	if err := normalizeReservationExtras(reservation.Extras); err != nil {
 		return err
 	}
-	
-	isAvailable := false
-	for _, availableRoom := range availableRooms {
-		// availableRoom.RoomID is uuid.UUID, reservation.RoomID is uuid.NullUUID
-		if reservation.RoomID.Valid && availableRoom.RoomID == reservation.RoomID.UUID {
-			isAvailable = true
-			break
+
+	// Day-use stays are sold per room and slot, so they always name a room
+	if isDayUse(reservation) {
+		if !reservation.RoomID.Valid {
+			return errors.New("day-use bookings require a room ID")
+		}
+		if reservation.PromoCode.Valid {
+			return errors.New("promo codes are not supported for day-use bookings")
+		}
+		if reservation.BlockCode.Valid {
+			return errors.New("block codes are not supported for day-use bookings")
+		}
+		if len(reservation.Extras) > 0 {
+			return errors.New("extras are not supported for day-use bookings")
 		}
 	}
-	
-	if !isAvailable {
-		return errors.New("room is not available for the selected dates")
+
+	if reservation.BlockCode.Valid && reservation.PromoCode.Valid {
+		return errors.New("promo codes cannot be combined with a block code")
 	}
 
+	if reservation.RoomID.Valid {
+		room, err := s.roomRepo.GetRoomByID(ctx, reservation.RoomID.UUID)
+		if err != nil {
+			return err
+		}
+		if room == nil {
+			return errors.New("room not found")
+		}
+
+		if !room.HotelID.Valid {
+			return errors.New("invalid hotel ID")
+		}
+
+		// Booking a concrete room also consumes one unit of its room type
+		reservation.HotelID = room.HotelID
+		reservation.TypeID = room.TypeID
+		if isDayUse(reservation) && !room.DayUse.Bool {
+			return errors.New("room is not available for day use")
+		}
+	} else if !reservation.HotelID.Valid || !reservation.TypeID.Valid {
+		return errors.New("either room ID or hotel ID and room type are required")
+	}
+
+	now := time.Now()
 	reservation.Status = sql.NullString{String: "PENDING", Valid: true}
-	reservation.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	reservation.CreatedAt = sql.NullTime{Time: now, Valid: true}
+	reservation.DiscountAmount = sql.NullInt32{}
+	reservation.Source = sql.NullString{String: model.ReservationSourceDirect, Valid: true}
+	reservation.ExternalID = sql.NullString{}
+	if reservation.PromoCode.Valid {
+		reservation.PromoCode.String = normalizePromoCode(reservation.PromoCode.String)
+	}
+	if reservation.BlockCode.Valid {
+		reservation.BlockCode.String = normalizeBlockCode(reservation.BlockCode.String)
+	}
+
+	// Inventory and the promo code row are locked for the whole transaction so that
+	// concurrent bookings can neither oversell rooms nor redeem a code beyond its limits
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var slots int32
+		if isDayUse(reservation) {
+			_, n, err := localizeDayUse(ctx, q, reservation)
+			if err != nil {
+				return err
+			}
+			slots = n
+		} else {
+			hotel, err := localizeStay(ctx, q, reservation)
+			if err != nil {
+				return err
+			}
+
+			if err := checkArrivalNotPast(hotel, reservation.StartDate.Time); err != nil {
+				return err
+			}
+		}
+
+		if reservation.BlockCode.Valid {
+			if err := checkBlockCodeOpen(ctx, q, reservation.BlockCode.String, now); err != nil {
+				return err
+			}
+		}
+
+		room, err := reserveInventory(ctx, q, reservation)
+		if err != nil {
+			return err
+		}
+
+		extra, err := priceOccupancy(ctx, q, reservation, room)
+		if err != nil {
+			return err
+		}
+
+		// Extra guests are charged per night, so day-use slots carry no extra-person charge
+		var subtotal int32
+		if isDayUse(reservation) {
+			reservation.ExtraPersonCharge = sql.NullInt32{Int32: 0, Valid: true}
+			subtotal = room.DayUsePrice.Int32 * slots
+			reservation.TotalPrice = sql.NullInt32{Int32: subtotal, Valid: room.DayUsePrice.Valid}
+		} else {
+			nights := stayNights(reservation.StartDate.Time, reservation.EndDate.Time)
+			reservation.ExtraPersonCharge = sql.NullInt32{Int32: extra * nights, Valid: true}
+			subtotal = room.Price.Int32*nights + reservation.ExtraPersonCharge.Int32
+			reservation.TotalPrice = sql.NullInt32{Int32: subtotal, Valid: room.Price.Valid}
+		}
+
+		var promo *model.PromoCode
+		if reservation.PromoCode.Valid {
+			dbPromo, err := q.GetPromoCodeForUpdate(ctx, reservation.PromoCode.String)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return errors.New("promo code not found")
+				}
+				return err
+			}
+			promo = model.FromDBPromoCode(&dbPromo)
+
+			var userRedemptions int64
+			if reservation.UserID.Valid {
+				userRedemptions, err = q.CountPromoRedemptionsByUser(ctx, db.CountPromoRedemptionsByUserParams{
+					Code:   sql.NullString{String: promo.Code, Valid: true},
+					UserID: reservation.UserID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := checkPromoCodeEligibility(promo, room, reservation.StartDate.Time, reservation.EndDate.Time, userRedemptions, now); err != nil {
+				return err
+			}
+
+			discount := calculateDiscount(promo, subtotal)
+			reservation.DiscountAmount = sql.NullInt32{Int32: discount, Valid: true}
+			reservation.TotalPrice = sql.NullInt32{Int32: subtotal - discount, Valid: true}
+		}
+
+		// Extras are charged on top of the room and are not discounted by promo codes
+		if err := priceReservationExtras(ctx, q, reservation, nil); err != nil {
+			return err
+		}
+		reservation.TotalPrice.Int32 += reservation.ExtrasAmount.Int32
+
+		reservation.ConfirmationCode, err = newConfirmationCode(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateReservation(ctx, db.CreateReservationParams{
+			ReservationID:     reservation.ReservationID,
+			RoomID:            reservation.RoomID,
+			UserID:            reservation.UserID,
+			StartDate:         reservation.StartDate,
+			EndDate:           reservation.EndDate,
+			Status:            reservation.Status,
+			CreatedAt:         reservation.CreatedAt,
+			CreatedBy:         reservation.CreatedBy,
+			TotalPrice:        reservation.TotalPrice,
+			PromoCode:         reservation.PromoCode,
+			DiscountAmount:    reservation.DiscountAmount,
+			HotelID:           reservation.HotelID,
+			TypeID:            reservation.TypeID,
+			StayType:          reservation.StayType,
+			BlockCode:         reservation.BlockCode,
+			Adults:            reservation.Adults,
+			Children:          reservation.Children,
+			ChildAges:         reservation.ChildAges,
+			ExtraPersonCharge: reservation.ExtraPersonCharge,
+			ExtrasAmount:      reservation.ExtrasAmount,
+			SpecialRequests:   reservation.SpecialRequests,
+			ConfirmationCode:  reservation.ConfirmationCode,
+			Source:            reservation.Source,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := createStaySegment(ctx, q, reservation); err != nil {
+			return err
+		}
+
+		if err := saveReservationGuests(ctx, q, reservation); err != nil {
+			return err
+		}
+
+		if err := saveReservationExtras(ctx, q, reservation); err != nil {
+			return err
+		}
+
+		if err := recordReservationEvent(ctx, q, model.EventReservationCreated, reservation); err != nil {
+			return err
+		}
+
+		if promo == nil {
+			return nil
+		}
+
+		_, err = q.CreatePromoRedemption(ctx, db.CreatePromoRedemptionParams{
+			RedemptionID:   uuid.New(),
+			Code:           sql.NullString{String: promo.Code, Valid: true},
+			ReservationID:  uuid.NullUUID{UUID: reservation.ReservationID, Valid: true},
+			UserID:         reservation.UserID,
+			DiscountAmount: reservation.DiscountAmount,
+			CreatedAt:      reservation.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.IncrementPromoCodeRedemptions(ctx, promo.Code)
+		return err
+	})
+}
+
+// createStaySegment records the room an assigned reservation occupies for its whole stay
+func createStaySegment(ctx context.Context, q *db.Queries, reservation *model.Reservation) error {
+	if !reservation.RoomID.Valid {
+		return nil
+	}
+
+	_, err := q.CreateReservationSegment(ctx, db.CreateReservationSegmentParams{
+		SegmentID:     uuid.New(),
+		ReservationID: reservation.ReservationID,
+		RoomID:        reservation.RoomID.UUID,
+		StartDate:     reservation.StartDate.Time,
+		EndDate:       reservation.EndDate.Time,
+		CreatedAt:     sql.NullTime{Time: time.Now(), Valid: true},
+		CreatedBy:     reservation.CreatedBy,
+	})
+	return err
+}
+
+// localizeStay loads the reservation's hotel and moves the reservation's dates to the hotel's
+// check-in and check-out times in its time zone, so clients only need to send calendar dates.
+func localizeStay(ctx context.Context, q *db.Queries, reservation *model.Reservation) (*model.Hotel, error) {
+	dbHotel, err := q.GetHotel(ctx, reservation.HotelID.UUID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("hotel not found")
+		}
+		return nil, err
+	}
+	hotel := model.FromDBHotel(&dbHotel)
+
+	loc := hotelLocation(hotel)
+	if !localMidnight(reservation.StartDate.Time, loc).Before(localMidnight(reservation.EndDate.Time, loc)) {
+		return nil, errors.New("invalid date range: stay must include at least one night")
+	}
+
+	start, end := stayWindow(hotel, reservation.StartDate.Time, reservation.EndDate.Time)
+
+	reservation.StartDate = sql.NullTime{Time: start, Valid: true}
+	reservation.EndDate = sql.NullTime{Time: end, Valid: true}
+	return hotel, nil
+}
+
+// reserveInventory checks the stay against the hotel's stay restrictions, then locks the rooms a
+// reservation draws from and checks that they are still free for its dates. It returns the room
+// the stay is priced against: the assigned room, or the cheapest room of the type for
+// reservations that have not been assigned a room yet.
+func reserveInventory(ctx context.Context, q *db.Queries, reservation *model.Reservation) (*model.Room, error) {
+	var allotment *model.Allotment
+	if reservation.BlockCode.Valid {
+		dbAllotment, err := q.GetAllotmentByCodeForUpdate(ctx, reservation.BlockCode.String)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.New("block code not found")
+			}
+			return nil, err
+		}
+		allotment = model.FromDBAllotment(&dbAllotment)
+
+		if allotment.HotelID != reservation.HotelID || allotment.TypeID != reservation.TypeID {
+			return nil, errors.New("room type does not match the block")
+		}
+		if reservation.StartDate.Time.Before(allotment.StartDate.Time) || reservation.EndDate.Time.After(allotment.EndDate.Time) {
+			return nil, errors.New("stay must fall within the block's dates")
+		}
+	}
+
+	// Stay restrictions govern nights, so they do not apply to day-use stays. Allotments are
+	// sold on terms negotiated with the group, so they are exempt as well.
+	if reservation.HotelID.Valid && !isDayUse(reservation) && allotment == nil {
+		dbRestrictions, err := q.ListApplicableStayRestrictions(ctx, db.ListApplicableStayRestrictionsParams{
+			HotelID:   reservation.HotelID,
+			TypeID:    reservation.TypeID,
+			Arrival:   reservation.StartDate.Time,
+			Departure: reservation.EndDate.Time,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		restrictions := make([]*model.StayRestriction, 0, len(dbRestrictions))
+		for i := range dbRestrictions {
+			restrictions = append(restrictions, model.FromDBStayRestriction(&dbRestrictions[i]))
+		}
+		if err := checkStayRestrictions(restrictions, reservation.StartDate.Time, reservation.EndDate.Time); err != nil {
+			return nil, err
+		}
+	}
+
+	var room *model.Room
+	if reservation.TypeID.Valid {
+		rooms, err := q.LockRoomsByHotelAndType(ctx, db.LockRoomsByHotelAndTypeParams{
+			HotelID: reservation.HotelID,
+			TypeID:  reservation.TypeID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(rooms) == 0 {
+			return nil, errors.New("hotel has no rooms of this type")
+		}
+
+		// While an allotment is active its bookings draw on the rooms it holds, which public
+		// availability already counts as taken; once released they compete for general inventory
+		var heldFor sql.NullString
+		if allotment != nil && allotment.Status.String == model.AllotmentActive {
+			pickedUp, err := q.GetMaxNightlyAllotmentPickup(ctx, db.GetMaxNightlyAllotmentPickupParams{
+				BlockCode:            reservation.BlockCode,
+				ExcludeReservationID: reservation.ReservationID,
+				StartDate:            reservation.StartDate.Time,
+				EndDate:              reservation.EndDate.Time,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if pickedUp >= allotment.Quantity.Int32 {
+				return nil, errors.New("no rooms left in the block for the selected dates")
+			}
+			heldFor = reservation.BlockCode
+		}
+
+		// The hotel's overbooking allowance lets type-level sales exceed the physical rooms
+		available, err := q.GetMinNightlyTypeAvailability(ctx, db.GetMinNightlyTypeAvailabilityParams{
+			TotalRooms:           int32(len(rooms)),
+			StartDate:            reservation.StartDate.Time,
+			EndDate:              reservation.EndDate.Time,
+			HotelID:              reservation.HotelID,
+			TypeID:               reservation.TypeID,
+			ExcludeReservationID: reservation.ReservationID,
+			BlockCode:            heldFor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if available < 1 {
+			return nil, errors.New("room type is not available for the selected dates")
+		}
 
-	return s.reservationRepo.CreateReservation(ctx, reservation)
+		for i := range rooms {
+			candidate := model.FromDBRoom(&rooms[i])
+			if reservation.RoomID.Valid && candidate.RoomID == reservation.RoomID.UUID {
+				room = candidate
+				break
+			}
+			if !reservation.RoomID.Valid && (room == nil || (candidate.Price.Valid && (!room.Price.Valid || candidate.Price.Int32 < room.Price.Int32))) {
+				room = candidate
+			}
+		}
+		if room == nil {
+			return nil, errors.New("room type does not match the reserved room")
+		}
+	} else {
+		dbRoom, err := q.GetRoomForUpdate(ctx, reservation.RoomID.UUID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.New("room not found")
+			}
+			return nil, err
+		}
+		room = model.FromDBRoom(&dbRoom)
+	}
+
+	if reservation.RoomID.Valid {
+		overlapping, err := countRoomConflicts(ctx, q, reservation, reservation.RoomID.UUID)
+		if err != nil {
+			return nil, err
+		}
+		if overlapping > 0 {
+			return nil, errors.New("room is not available for the selected dates")
+		}
+
+		blocked, err := q.CountOverlappingRoomBlocks(ctx, db.CountOverlappingRoomBlocksParams{
+			RoomID:    reservation.RoomID,
+			StartDate: reservation.StartDate.Time,
+			EndDate:   reservation.EndDate.Time,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if blocked > 0 {
+			return nil, errors.New("room is blocked for the selected dates")
+		}
+	}
+
+	// Allotment bookings are priced at the rate negotiated for the block
+	if allotment != nil && allotment.Rate.Valid {
+		room.Price = allotment.Rate
+	}
+
+	return room, nil
 }
 
 func (s *reservationService) GetReservationByID(ctx context.Context, reservationID uuid.UUID) (*model.Reservation, error) {
@@ -98,6 +492,21 @@ func (s *reservationService) GetReservationByID(ctx context.Context, reservation
 	if reservation == nil {
 		return nil, errors.New("reservation not found")
 	}
+
+	reservation.Guests, err = s.reservationRepo.ListReservationGuests(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation.Segments, err = s.reservationRepo.ListReservationSegments(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation.Extras, err = s.reservationRepo.ListReservationExtras(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
 	
 	return reservation, nil
 }
@@ -136,83 +545,984 @@ func (s *reservationService) ListReservationsByRoom(ctx context.Context, roomID
 	return s.reservationRepo.ListReservationsByRoom(ctx, roomID, pageSize, offset)
 }
 
-func (s *reservationService) UpdateReservation(ctx context.Context, reservation *model.Reservation) error {
-	existingReservation, err := s.reservationRepo.GetReservationByID(ctx, reservation.ReservationID)
-	if err != nil {
-		return err
+// Roles allowed to change more than the dates of a reservation. Any other role is treated as a guest.
+const (
+	roleAdmin = "ADMIN"
+	roleStaff = "STAFF"
+)
+
+// UpdateReservation applies a change made by the authenticated caller, within what the caller's
+// role may change
+func (s *reservationService) UpdateReservation(ctx context.Context, reservationID uuid.UUID, change *model.ReservationChange) (*model.Reservation, error) {
+	actor := ActorFromContext(ctx)
+	if actor == "" {
+		return nil, errors.New("authentication required")
 	}
-	
-	if existingReservation == nil {
-		return errors.New("reservation not found")
+
+	return s.updateReservation(ctx, reservationID, change, func(q *db.Queries, existing *model.Reservation) (db.User, error) {
+		user, err := q.GetUser(ctx, actor)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return db.User{}, errors.New("user not found")
+			}
+			return db.User{}, err
+		}
+
+		if err := checkModificationAllowed(existing, change, user.Username, user.Role.String); err != nil {
+			return db.User{}, err
+		}
+
+		return user, nil
+	})
+}
+
+// updateReservation applies a change once authorize has accepted it for the locked reservation.
+// authorize returns the user the modification is recorded against.
+func (s *reservationService) updateReservation(ctx context.Context, reservationID uuid.UUID, change *model.ReservationChange, authorize func(q *db.Queries, existing *model.Reservation) (db.User, error)) (*model.Reservation, error) {
+	if change.StartDate.Valid != change.EndDate.Valid {
+		return nil, errors.New("start date and end date must be changed together")
 	}
-	
-	if existingReservation.Status.Valid && existingReservation.Status.String == "CANCELLED" {
-		return errors.New("cannot update cancelled reservation")
+
+	if change.StartDate.Valid && !change.StartDate.Time.Before(change.EndDate.Time) {
+		return nil, errors.New("invalid date range: start date must be before end date")
 	}
-	
-	if !reservation.StartDate.Valid || !reservation.EndDate.Valid {
-		return errors.New("invalid reservation dates")
+
+	var updated *model.Reservation
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbReservation, err := q.GetReservationForUpdate(ctx, reservationID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("reservation not found")
+			}
+			return err
+		}
+		existing := model.FromDBReservation(&dbReservation)
+
+		if existing.Status.Valid && (existing.Status.String == "CANCELLED" || existing.Status.String == "COMPLETED") {
+			return errors.New("cannot modify a " + strings.ToLower(existing.Status.String) + " reservation")
+		}
+
+		if isDayUse(existing) && (change.StartDate.Valid || change.RoomID.Valid) {
+			return errors.New("day-use bookings cannot be moved; cancel and book a new slot")
+		}
+
+		user, err := authorize(q, existing)
+		if err != nil {
+			return err
+		}
+
+		// A guest who has been moved no longer has a single room to rebook the stay in
+		segments, err := q.ListReservationSegmentsForUpdate(ctx, reservationID)
+		if err != nil {
+			return err
+		}
+		partyChanged := change.Adults.Valid || change.Children.Valid || change.ChildAges != nil
+		split := len(segments) > 1
+		if split && (change.StartDate.Valid || change.RoomID.Valid || partyChanged) {
+			return errors.New("room, dates and party of a split stay cannot be changed; move the guest instead")
+		}
+
+		reservation := *existing
+		if change.StartDate.Valid {
+			if existing.GroupID.Valid {
+				return errors.New("dates of a group booking can only be changed for the whole group")
+			}
+			reservation.StartDate = change.StartDate
+			reservation.EndDate = change.EndDate
+		}
+
+		if change.UserID.Valid {
+			reservation.UserID = change.UserID
+		}
+
+		// The named guests are replaced as a whole; the current ones are kept when none are sent
+		if change.Adults.Valid {
+			reservation.Adults = change.Adults
+		}
+		if change.Children.Valid || change.ChildAges != nil {
+			reservation.Children = change.Children
+			reservation.ChildAges = change.ChildAges
+		}
+		if change.Guests != nil {
+			reservation.Guests = change.Guests
+		} else {
+			dbGuests, err := q.ListReservationGuests(ctx, reservationID)
+			if err != nil {
+				return err
+			}
+			for i := range dbGuests {
+				reservation.Guests = append(reservation.Guests, model.FromDBReservationGuest(&dbGuests[i]))
+			}
+		}
+		if err := normalizeOccupancy(&reservation); err != nil {
+			return err
+		}
+		// Bookings without an account are found and emailed through their primary guest, so a
+		// modification must leave them one
+		if err := checkGuestCheckout(&reservation); err != nil {
+			return err
+		}
+
+		if change.RoomID.Valid {
+			dbRoom, err := q.GetRoom(ctx, change.RoomID.UUID)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return errors.New("room not found")
+				}
+				return err
+			}
+			reservation.RoomID = change.RoomID
+			reservation.HotelID = dbRoom.HotelID
+			reservation.TypeID = dbRoom.TypeID
+		}
+
+		if !change.StartDate.Valid && reservation.HotelID != existing.HotelID && existing.HotelID.Valid {
+			// Moving to another hotel keeps the calendar dates but uses the new hotel's times
+			dbHotel, err := q.GetHotel(ctx, existing.HotelID.UUID)
+			if err != nil {
+				return err
+			}
+			loc := hotelLocation(model.FromDBHotel(&dbHotel))
+			reservation.StartDate = sql.NullTime{Time: existing.StartDate.Time.In(loc), Valid: true}
+			reservation.EndDate = sql.NullTime{Time: existing.EndDate.Time.In(loc), Valid: true}
+		}
+
+		if reservation.StartDate != existing.StartDate || reservation.HotelID != existing.HotelID {
+			hotel, err := localizeStay(ctx, q, &reservation)
+			if err != nil {
+				return err
+			}
+			if !reservation.StartDate.Time.Equal(existing.StartDate.Time) {
+				if err := checkArrivalNotPast(hotel, reservation.StartDate.Time); err != nil {
+					return err
+				}
+			}
+		}
+
+		// The new room and dates are checked against every other reservation, never against itself.
+		// Split stays keep their rooms and price; their segments were checked as the guest moved.
+		if !split {
+			room, err := reserveInventory(ctx, q, &reservation)
+			if err != nil {
+				return err
+			}
+
+			extra, err := priceOccupancy(ctx, q, &reservation, room)
+			if err != nil {
+				return err
+			}
+			if isDayUse(&reservation) {
+				extra = 0
+			}
+
+			nights := stayNights(reservation.StartDate.Time, reservation.EndDate.Time)
+			reservation.ExtraPersonCharge = sql.NullInt32{Int32: extra * nights, Valid: true}
+			subtotal := room.Price.Int32*nights + reservation.ExtraPersonCharge.Int32
+			reservation.TotalPrice = sql.NullInt32{Int32: subtotal, Valid: room.Price.Valid}
+			if reservation.PromoCode.Valid {
+				dbPromo, err := q.GetPromoCode(ctx, reservation.PromoCode.String)
+				if err != nil {
+					return err
+				}
+				promo := model.FromDBPromoCode(&dbPromo)
+
+				// A stay moved to dates or a room the promo code does not cover cannot keep the code.
+				// Whether the code could still be redeemed today does not matter; it already was.
+				rebooked := !reservation.StartDate.Time.Equal(existing.StartDate.Time) || !reservation.EndDate.Time.Equal(existing.EndDate.Time) || reservation.RoomID != existing.RoomID
+				if rebooked {
+					if err := checkPromoCodeStay(promo, room, reservation.StartDate.Time, reservation.EndDate.Time); err != nil {
+						return err
+					}
+				}
+
+				discount := calculateDiscount(promo, subtotal)
+				reservation.DiscountAmount = sql.NullInt32{Int32: discount, Valid: true}
+				reservation.TotalPrice = sql.NullInt32{Int32: subtotal - discount, Valid: true}
+
+				err = q.UpdatePromoRedemptionDiscount(ctx, db.UpdatePromoRedemptionDiscountParams{
+					ReservationID:  uuid.NullUUID{UUID: reservation.ReservationID, Valid: true},
+					DiscountAmount: reservation.DiscountAmount,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			// Booked extras follow the new nights and party at the prices they were sold at
+			if err := repriceReservationExtras(ctx, q, &reservation); err != nil {
+				return err
+			}
+			reservation.TotalPrice.Int32 += reservation.ExtrasAmount.Int32
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		reservation.UpdateAt = now
+
+		_, err = q.ModifyReservation(ctx, db.ModifyReservationParams{
+			ReservationID:     reservation.ReservationID,
+			RoomID:            reservation.RoomID,
+			UserID:            reservation.UserID,
+			StartDate:         reservation.StartDate,
+			EndDate:           reservation.EndDate,
+			HotelID:           reservation.HotelID,
+			TypeID:            reservation.TypeID,
+			TotalPrice:        reservation.TotalPrice,
+			DiscountAmount:    reservation.DiscountAmount,
+			UpdateAt:          reservation.UpdateAt,
+			Adults:            reservation.Adults,
+			Children:          reservation.Children,
+			ChildAges:         reservation.ChildAges,
+			ExtraPersonCharge: reservation.ExtraPersonCharge,
+			ExtrasAmount:      reservation.ExtrasAmount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if change.Guests != nil {
+			if err := saveReservationGuests(ctx, q, &reservation); err != nil {
+				return err
+			}
+		}
+
+		// A rebooked stay occupies its room, possibly a new one, for the whole new stay
+		if reservation.RoomID != existing.RoomID || reservation.StartDate != existing.StartDate || reservation.EndDate != existing.EndDate {
+			if err := q.DeleteReservationSegments(ctx, reservationID); err != nil {
+				return err
+			}
+			if err := createStaySegment(ctx, q, &reservation); err != nil {
+				return err
+			}
+		}
+
+		if reservation.GroupID.Valid {
+			group, err := q.GetBookingGroupForUpdate(ctx, reservation.GroupID.UUID)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.UpdateBookingGroupTotalPrice(ctx, db.UpdateBookingGroupTotalPriceParams{
+				GroupID:    group.GroupID,
+				TotalPrice: sql.NullInt32{Int32: group.TotalPrice.Int32 - existing.TotalPrice.Int32 + reservation.TotalPrice.Int32, Valid: group.TotalPrice.Valid && reservation.TotalPrice.Valid},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = q.CreateReservationModification(ctx, db.CreateReservationModificationParams{
+			ModificationID: uuid.New(),
+			ReservationID:  uuid.NullUUID{UUID: reservation.ReservationID, Valid: true},
+			ModifiedBy:     sql.NullString{String: user.Username, Valid: user.Username != ""},
+			ModifiedByRole: user.Role,
+			Reason:         change.Reason,
+			OldRoomID:      existing.RoomID,
+			NewRoomID:      reservation.RoomID,
+			OldUserID:      existing.UserID,
+			NewUserID:      reservation.UserID,
+			OldStartDate:   existing.StartDate,
+			NewStartDate:   reservation.StartDate,
+			OldEndDate:     existing.EndDate,
+			NewEndDate:     reservation.EndDate,
+			OldTotalPrice:  existing.TotalPrice,
+			NewTotalPrice:  reservation.TotalPrice,
+			CreatedAt:      now,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := recordReservationEvent(ctx, q, model.EventReservationModified, &reservation); err != nil {
+			return err
+		}
+
+		updated = &reservation
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	
-	if reservation.StartDate.Time.After(reservation.EndDate.Time) || reservation.StartDate.Time.Equal(reservation.EndDate.Time) {
-		return errors.New("invalid date range: start date must be before end date")
+
+	return updated, nil
+}
+
+// checkModificationAllowed enforces which fields each role may change. Guests may only move the
+// dates of their own reservation, staff may also move it to another room, and only admins may
+// transfer it to another user.
+func checkModificationAllowed(reservation *model.Reservation, change *model.ReservationChange, username, role string) error {
+	switch role {
+	case roleAdmin:
+		return nil
+	case roleStaff:
+		if change.UserID.Valid && change.UserID != reservation.UserID {
+			return errors.New("only admins can change the reservation owner")
+		}
+		return nil
 	}
-	
-	if reservation.StartDate.Time.Before(time.Now().Truncate(24 * time.Hour)) {
-		return errors.New("start date cannot be in the past")
+
+	if !reservation.UserID.Valid || reservation.UserID.String != username {
+		return errors.New("guests can only modify their own reservations")
 	}
-	
-	if !reservation.RoomID.Valid {
-		return errors.New("invalid room ID")
+
+	if change.UserID.Valid && change.UserID != reservation.UserID {
+		return errors.New("only admins can change the reservation owner")
 	}
-	
-	room, err := s.roomRepo.GetRoomByID(ctx, reservation.RoomID.UUID)
+
+	if change.RoomID.Valid && change.RoomID != reservation.RoomID {
+		return errors.New("only staff can change the room")
+	}
+
+	return nil
+}
+
+func (s *reservationService) ListReservationModifications(ctx context.Context, reservationID uuid.UUID) ([]*model.ReservationModification, error) {
+	reservation, err := s.reservationRepo.GetReservationByID(ctx, reservationID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if room == nil {
-		return errors.New("room not found")
+
+	if reservation == nil {
+		return nil, errors.New("reservation not found")
 	}
-	
-	reservation.UpdateAt = sql.NullTime{Time: time.Now(), Valid: true}
-	
-	return s.reservationRepo.UpdateReservation(ctx, reservation)
+
+	return s.reservationRepo.ListReservationModifications(ctx, reservationID)
 }
 
+
 func (s *reservationService) CancelReservation(ctx context.Context, reservationID uuid.UUID) error {
-	reservation, err := s.reservationRepo.GetReservationByID(ctx, reservationID)
+	var cancelled *model.Reservation
+
+	// Cancelling releases the promo code redemption so it can be used again, and declines the
+	// waitlist offer the reservation may have been created for. The reservation is locked so that
+	// concurrent cancels release the redemption only once.
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbReservation, err := q.GetReservationForUpdate(ctx, reservationID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("reservation not found")
+			}
+			return err
+		}
+		reservation := model.FromDBReservation(&dbReservation)
+
+		if reservation.Status.Valid && reservation.Status.String == "CANCELLED" {
+			return errors.New("reservation is already cancelled")
+		}
+
+		if reservation.Status.Valid && reservation.Status.String == "COMPLETED" {
+			return errors.New("cannot cancel completed reservation")
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		dbCancelled, err := q.UpdateReservationStatus(ctx, db.UpdateReservationStatusParams{
+			ReservationID: reservationID,
+			Status:        sql.NullString{String: "CANCELLED", Valid: true},
+			UpdateAt:      now,
+		})
+		if err != nil {
+			return err
+		}
+		cancelled = model.FromDBReservation(&dbCancelled)
+
+		if err := recordReservationEvent(ctx, q, model.EventReservationCancelled, cancelled); err != nil {
+			return err
+		}
+
+		err = q.ResolveWaitlistOffer(ctx, db.ResolveWaitlistOfferParams{
+			Status:        sql.NullString{String: model.WaitlistDeclined, Valid: true},
+			UpdateAt:      now,
+			ReservationID: uuid.NullUUID{UUID: reservationID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if !reservation.PromoCode.Valid {
+			return nil
+		}
+
+		released, err := q.DeletePromoRedemptionByReservation(ctx, uuid.NullUUID{UUID: reservationID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		// The count only goes down for a redemption that was still recorded
+		if released == 0 {
+			return nil
+		}
+
+		_, err = q.DecrementPromoCodeRedemptions(ctx, reservation.PromoCode.String)
+		return err
+	})
 	if err != nil {
 		return err
 	}
-	
-	if reservation == nil {
-		return errors.New("reservation not found")
+
+	s.waitlist.MatchReleasedInventory(ctx, cancelled)
+	return nil
+}
+
+func (s *reservationService) ConfirmReservation(ctx context.Context, reservationID uuid.UUID) error {
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		// Locked so a concurrent cancellation cannot be confirmed over
+		dbReservation, err := q.GetReservationForUpdate(ctx, reservationID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("reservation not found")
+			}
+			return err
+		}
+		reservation := model.FromDBReservation(&dbReservation)
+
+		if !reservation.Status.Valid || reservation.Status.String != "PENDING" {
+			return errors.New("only pending reservations can be confirmed")
+		}
+
+		if reservation.HoldExpiresAt.Valid && !reservation.HoldExpiresAt.Time.After(time.Now()) {
+			return errors.New("offer has expired")
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		dbConfirmed, err := q.UpdateReservationStatus(ctx, db.UpdateReservationStatusParams{
+			ReservationID: reservationID,
+			Status:        sql.NullString{String: "CONFIRMED", Valid: true},
+			UpdateAt:      now,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := recordReservationEvent(ctx, q, model.EventReservationConfirmed, model.FromDBReservation(&dbConfirmed)); err != nil {
+			return err
+		}
+
+		if !reservation.HoldExpiresAt.Valid {
+			return nil
+		}
+
+		if err := q.ClearReservationHold(ctx, reservationID); err != nil {
+			return err
+		}
+
+		return q.ResolveWaitlistOffer(ctx, db.ResolveWaitlistOfferParams{
+			Status:        sql.NullString{String: model.WaitlistBooked, Valid: true},
+			UpdateAt:      now,
+			ReservationID: uuid.NullUUID{UUID: reservationID, Valid: true},
+		})
+	})
+}
+
+func (s *reservationService) AssignRoom(ctx context.Context, reservationID, roomID uuid.UUID) error {
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbReservation, err := q.GetReservationForUpdate(ctx, reservationID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("reservation not found")
+			}
+			return err
+		}
+		reservation := model.FromDBReservation(&dbReservation)
+
+		if reservation.Status.Valid && (reservation.Status.String == "CANCELLED" || reservation.Status.String == "COMPLETED") {
+			return errors.New("cannot assign a room to a " + strings.ToLower(reservation.Status.String) + " reservation")
+		}
+
+		dbRoom, err := q.GetRoomForUpdate(ctx, roomID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("room not found")
+			}
+			return err
+		}
+
+		if reservation.HotelID.Valid && dbRoom.HotelID != reservation.HotelID {
+			return errors.New("room does not belong to the reserved hotel")
+		}
+
+		if reservation.TypeID.Valid && dbRoom.TypeID != reservation.TypeID {
+			return errors.New("room type does not match the reservation")
+		}
+
+		if isDayUse(reservation) && !dbRoom.DayUse.Bool {
+			return errors.New("room is not available for day use")
+		}
+
+		if _, err := priceOccupancy(ctx, q, reservation, model.FromDBRoom(&dbRoom)); err != nil {
+			return err
+		}
+
+		segments, err := q.ListReservationSegmentsForUpdate(ctx, reservationID)
+		if err != nil {
+			return err
+		}
+		if len(segments) > 1 {
+			return errors.New("room and dates of a split stay cannot be changed; move the guest instead")
+		}
+
+		overlapping, err := countRoomConflicts(ctx, q, reservation, roomID)
+		if err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return errors.New("room is not available for the selected dates")
+		}
+
+		blocked, err := q.CountOverlappingRoomBlocks(ctx, db.CountOverlappingRoomBlocksParams{
+			RoomID:    uuid.NullUUID{UUID: roomID, Valid: true},
+			StartDate: reservation.StartDate.Time,
+			EndDate:   reservation.EndDate.Time,
+		})
+		if err != nil {
+			return err
+		}
+		if blocked > 0 {
+			return errors.New("room is blocked for the selected dates")
+		}
+
+		dbAssigned, err := q.AssignReservationRoom(ctx, db.AssignReservationRoomParams{
+			ReservationID: reservationID,
+			RoomID:        uuid.NullUUID{UUID: roomID, Valid: true},
+			UpdateAt:      sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeleteReservationSegments(ctx, reservationID); err != nil {
+			return err
+		}
+		reservation.RoomID = uuid.NullUUID{UUID: roomID, Valid: true}
+		if err := createStaySegment(ctx, q, reservation); err != nil {
+			return err
+		}
+
+		return recordReservationEvent(ctx, q, model.EventReservationModified, model.FromDBReservation(&dbAssigned))
+	})
+}
+
+// MoveGuest moves the guest of a stay to another room from the check-in time of move.Date until
+// the end of the room segment covering that date. The segment is cut at the move and the rest of
+// it is booked in the new room; the price of the reservation does not change.
+func (s *reservationService) MoveGuest(ctx context.Context, reservationID uuid.UUID, move *model.RoomMove) (*model.Reservation, error) {
+	actor := ActorFromContext(ctx)
+	if actor == "" {
+		return nil, errors.New("authentication required")
 	}
-	
-	if reservation.Status.Valid && reservation.Status.String == "CANCELLED" {
-		return errors.New("reservation is already cancelled")
+
+	var moved *model.Reservation
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbReservation, err := q.GetReservationForUpdate(ctx, reservationID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("reservation not found")
+			}
+			return err
+		}
+		reservation := model.FromDBReservation(&dbReservation)
+
+		if !reservation.Status.Valid || (reservation.Status.String != "PENDING" && reservation.Status.String != "CONFIRMED") {
+			return errors.New("only pending or confirmed reservations can be moved")
+		}
+
+		if isDayUse(reservation) {
+			return errors.New("day-use bookings cannot be moved; cancel and book a new slot")
+		}
+
+		user, err := q.GetUser(ctx, actor)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("user not found")
+			}
+			return err
+		}
+		if user.Role.String != roleAdmin && user.Role.String != roleStaff {
+			return errors.New("only staff can move guests")
+		}
+
+		dbHotel, err := q.GetHotel(ctx, reservation.HotelID.UUID)
+		if err != nil {
+			return err
+		}
+		hotel := model.FromDBHotel(&dbHotel)
+
+		// The guest changes rooms at check-in time, so the nights before stay in the old room.
+		// Without a date the move starts tonight, or at arrival for stays that have not begun.
+		date := hotelToday(hotel, time.Now())
+		if move.Date.Valid {
+			date = move.Date.Time
+		}
+		boundary, _ := stayWindow(hotel, date, date)
+		if !move.Date.Valid && boundary.Before(reservation.StartDate.Time) {
+			boundary = reservation.StartDate.Time
+		}
+		if boundary.Before(reservation.StartDate.Time) || !boundary.Before(reservation.EndDate.Time) {
+			return errors.New("move date must fall within the stay")
+		}
+
+		segments, err := q.ListReservationSegmentsForUpdate(ctx, reservationID)
+		if err != nil {
+			return err
+		}
+		if len(segments) == 0 {
+			return errors.New("reservation has no room assigned")
+		}
+
+		var current *db.ReservationSegment
+		for i := range segments {
+			if !segments[i].StartDate.After(boundary) && segments[i].EndDate.After(boundary) {
+				current = &segments[i]
+			}
+		}
+		if current == nil {
+			return errors.New("move date must fall within the stay")
+		}
+		if current.RoomID == move.RoomID {
+			return errors.New("guest is already in this room")
+		}
+
+		dbRoom, err := q.GetRoomForUpdate(ctx, move.RoomID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("room not found")
+			}
+			return err
+		}
+		if dbRoom.HotelID != reservation.HotelID {
+			return errors.New("room does not belong to the reserved hotel")
+		}
+
+		if _, err := priceOccupancy(ctx, q, reservation, model.FromDBRoom(&dbRoom)); err != nil {
+			return err
+		}
+
+		// The new room has to be free only for the part of the stay the guest spends in it
+		window := *reservation
+		window.StartDate = sql.NullTime{Time: boundary, Valid: true}
+		window.EndDate = sql.NullTime{Time: current.EndDate, Valid: true}
+
+		overlapping, err := countRoomConflicts(ctx, q, &window, move.RoomID)
+		if err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return errors.New("room is not available for the selected dates")
+		}
+
+		blocked, err := q.CountOverlappingRoomBlocks(ctx, db.CountOverlappingRoomBlocksParams{
+			RoomID:    uuid.NullUUID{UUID: move.RoomID, Valid: true},
+			StartDate: window.StartDate.Time,
+			EndDate:   window.EndDate.Time,
+		})
+		if err != nil {
+			return err
+		}
+		if blocked > 0 {
+			return errors.New("room is blocked for the selected dates")
+		}
+
+		// Moving to a room of another type takes a unit of that type for the rest of the segment
+		oldRoom, err := q.GetRoom(ctx, current.RoomID)
+		if err != nil {
+			return err
+		}
+		if dbRoom.TypeID.Valid && dbRoom.TypeID != oldRoom.TypeID {
+			rooms, err := q.LockRoomsByHotelAndType(ctx, db.LockRoomsByHotelAndTypeParams{
+				HotelID: dbRoom.HotelID,
+				TypeID:  dbRoom.TypeID,
+			})
+			if err != nil {
+				return err
+			}
+
+			available, err := q.GetMinNightlyTypeAvailability(ctx, db.GetMinNightlyTypeAvailabilityParams{
+				TotalRooms:           int32(len(rooms)),
+				StartDate:            window.StartDate.Time,
+				EndDate:              window.EndDate.Time,
+				HotelID:              dbRoom.HotelID,
+				TypeID:               dbRoom.TypeID,
+				ExcludeReservationID: reservationID,
+			})
+			if err != nil {
+				return err
+			}
+			if available < 1 {
+				return errors.New("room type is not available for the selected dates")
+			}
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		if current.StartDate.Equal(boundary) {
+			_, err = q.UpdateReservationSegmentRoom(ctx, db.UpdateReservationSegmentRoomParams{
+				SegmentID: current.SegmentID,
+				RoomID:    move.RoomID,
+			})
+		} else {
+			_, err = q.UpdateReservationSegmentEnd(ctx, db.UpdateReservationSegmentEndParams{
+				SegmentID: current.SegmentID,
+				EndDate:   boundary,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateReservationSegment(ctx, db.CreateReservationSegmentParams{
+				SegmentID:     uuid.New(),
+				ReservationID: reservationID,
+				RoomID:        move.RoomID,
+				StartDate:     boundary,
+				EndDate:       current.EndDate,
+				CreatedAt:     now,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		// The reservation's room is the one the guest checks out of
+		if current.EndDate.Equal(reservation.EndDate.Time) {
+			_, err = q.AssignReservationRoom(ctx, db.AssignReservationRoomParams{
+				ReservationID: reservationID,
+				RoomID:        uuid.NullUUID{UUID: move.RoomID, Valid: true},
+				UpdateAt:      now,
+			})
+			if err != nil {
+				return err
+			}
+			reservation.RoomID = uuid.NullUUID{UUID: move.RoomID, Valid: true}
+			reservation.UpdateAt = now
+		}
+
+		_, err = q.CreateReservationModification(ctx, db.CreateReservationModificationParams{
+			ModificationID: uuid.New(),
+			ReservationID:  uuid.NullUUID{UUID: reservationID, Valid: true},
+			ModifiedBy:     sql.NullString{String: user.Username, Valid: true},
+			ModifiedByRole: user.Role,
+			Reason:         move.Reason,
+			OldRoomID:      uuid.NullUUID{UUID: current.RoomID, Valid: true},
+			NewRoomID:      uuid.NullUUID{UUID: move.RoomID, Valid: true},
+			OldUserID:      reservation.UserID,
+			NewUserID:      reservation.UserID,
+			OldStartDate:   sql.NullTime{Time: current.StartDate, Valid: true},
+			NewStartDate:   window.StartDate,
+			OldEndDate:     sql.NullTime{Time: current.EndDate, Valid: true},
+			NewEndDate:     window.EndDate,
+			OldTotalPrice:  reservation.TotalPrice,
+			NewTotalPrice:  reservation.TotalPrice,
+			CreatedAt:      now,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := recordReservationEvent(ctx, q, model.EventReservationModified, reservation); err != nil {
+			return err
+		}
+
+		moved = reservation
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	
-	if reservation.Status.Valid && reservation.Status.String == "COMPLETED" {
-		return errors.New("cannot cancel completed reservation")
+
+	moved.Segments, err = s.reservationRepo.ListReservationSegments(ctx, reservationID)
+	if err != nil {
+		return nil, err
 	}
-	
-	return s.reservationRepo.UpdateReservationStatus(ctx, reservationID, "CANCELLED")
+
+	return moved, nil
 }
 
-func (s *reservationService) ConfirmReservation(ctx context.Context, reservationID uuid.UUID) error {
+// UpdateReservationExtras replaces the extras, and the special requests when given, of a pending
+// or confirmed reservation. Extras added are priced at the current catalog prices, extras kept
+// at the price they were sold at, and the total follows.
+func (s *reservationService) UpdateReservationExtras(ctx context.Context, reservationID uuid.UUID, change *model.ReservationExtrasChange) (*model.Reservation, error) {
+	if err := normalizeReservationExtras(change.Extras); err != nil {
+		return nil, err
+	}
+
+	var updated *model.Reservation
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbReservation, err := q.GetReservationForUpdate(ctx, reservationID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("reservation not found")
+			}
+			return err
+		}
+		reservation := model.FromDBReservation(&dbReservation)
+
+		if !reservation.Status.Valid || (reservation.Status.String != "PENDING" && reservation.Status.String != "CONFIRMED") {
+			return errors.New("only pending or confirmed reservations can change extras")
+		}
+
+		if isDayUse(reservation) && len(change.Extras) > 0 {
+			return errors.New("extras are not supported for day-use bookings")
+		}
+
+		// Extras the guest already has keep the price they were sold at
+		soldExtras, err := q.ListReservationExtrasForUpdate(ctx, reservationID)
+		if err != nil {
+			return err
+		}
+		sold := make(map[uuid.UUID]db.ReservationExtra, len(soldExtras))
+		for _, extra := range soldExtras {
+			sold[extra.ExtraID] = extra
+		}
+
+		previousExtras := reservation.ExtrasAmount.Int32
+		reservation.Extras = change.Extras
+		if err := priceReservationExtras(ctx, q, reservation, sold); err != nil {
+			return err
+		}
+		totalPrice := sql.NullInt32{Int32: reservation.TotalPrice.Int32 - previousExtras + reservation.ExtrasAmount.Int32, Valid: true}
+
+		dbUpdated, err := q.UpdateReservationExtras(ctx, db.UpdateReservationExtrasParams{
+			ReservationID:   reservationID,
+			ExtrasAmount:    reservation.ExtrasAmount,
+			TotalPrice:      totalPrice,
+			SpecialRequests: change.SpecialRequests,
+			UpdateAt:        sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := saveReservationExtras(ctx, q, reservation); err != nil {
+			return err
+		}
+
+		if reservation.GroupID.Valid {
+			group, err := q.GetBookingGroupForUpdate(ctx, reservation.GroupID.UUID)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.UpdateBookingGroupTotalPrice(ctx, db.UpdateBookingGroupTotalPriceParams{
+				GroupID:    group.GroupID,
+				TotalPrice: sql.NullInt32{Int32: group.TotalPrice.Int32 - reservation.TotalPrice.Int32 + totalPrice.Int32, Valid: group.TotalPrice.Valid},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		updated = model.FromDBReservation(&dbUpdated)
+		updated.Extras = reservation.Extras
+		return recordReservationEvent(ctx, q, model.EventReservationModified, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// GetReservationFolio itemizes the total of a reservation. The room line is what remains of the
+// total once extra guests, extras and the discount are accounted for, so the lines always add up.
+func (s *reservationService) GetReservationFolio(ctx context.Context, reservationID uuid.UUID) (*model.Folio, error) {
 	reservation, err := s.reservationRepo.GetReservationByID(ctx, reservationID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	
+
 	if reservation == nil {
-		return errors.New("reservation not found")
+		return nil, errors.New("reservation not found")
 	}
-	
-	if !reservation.Status.Valid || reservation.Status.String != "PENDING" {
-		return errors.New("only pending reservations can be confirmed")
+
+	extras, err := s.reservationRepo.ListReservationExtras(ctx, reservationID)
+	if err != nil {
+		return nil, err
 	}
-	
-	return s.reservationRepo.UpdateReservationStatus(ctx, reservationID, "CONFIRMED")
+
+	nights := int32(1)
+	if !isDayUse(reservation) {
+		nights = stayNights(reservation.StartDate.Time, reservation.EndDate.Time)
+	}
+
+	total := reservation.TotalPrice.Int32
+	roomAmount := total - reservation.ExtrasAmount.Int32 - reservation.ExtraPersonCharge.Int32 + reservation.DiscountAmount.Int32
+	lines := []*model.FolioLine{{
+		Description: "Room",
+		Quantity:    nights,
+		UnitPrice:   roomAmount / nights,
+		Amount:      roomAmount,
+	}}
+
+	if reservation.ExtraPersonCharge.Int32 > 0 {
+		lines = append(lines, &model.FolioLine{
+			Description: "Extra guests",
+			Quantity:    nights,
+			UnitPrice:   reservation.ExtraPersonCharge.Int32 / nights,
+			Amount:      reservation.ExtraPersonCharge.Int32,
+		})
+	}
+
+	if reservation.DiscountAmount.Int32 > 0 {
+		lines = append(lines, &model.FolioLine{
+			Description: "Promo code " + reservation.PromoCode.String,
+			Quantity:    1,
+			UnitPrice:   -reservation.DiscountAmount.Int32,
+			Amount:      -reservation.DiscountAmount.Int32,
+		})
+	}
+
+	for _, extra := range extras {
+		lines = append(lines, &model.FolioLine{
+			Description: extra.Name.String + " (" + strings.ToLower(strings.ReplaceAll(extra.Pricing.String, "_", " ")) + ")",
+			Quantity:    extra.Quantity,
+			UnitPrice:   extra.UnitPrice.Int32,
+			Amount:      extra.Amount.Int32,
+		})
+	}
+
+	return &model.Folio{
+		ReservationID:   reservation.ReservationID,
+		Status:          reservation.Status,
+		SpecialRequests: reservation.SpecialRequests,
+		Lines:           lines,
+		Total:           total,
+	}, nil
+}
+
+// CheckOutReservation completes a confirmed stay and marks its room dirty for housekeeping
+func (s *reservationService) CheckOutReservation(ctx context.Context, reservationID uuid.UUID) error {
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbReservation, err := q.GetReservationForUpdate(ctx, reservationID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("reservation not found")
+			}
+			return err
+		}
+
+		if !dbReservation.Status.Valid || dbReservation.Status.String != "CONFIRMED" {
+			return errors.New("only confirmed reservations can be checked out")
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		dbCompleted, err := q.UpdateReservationStatus(ctx, db.UpdateReservationStatusParams{
+			ReservationID: reservationID,
+			Status:        sql.NullString{String: "COMPLETED", Valid: true},
+			UpdateAt:      now,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := recordReservationEvent(ctx, q, model.EventReservationCompleted, model.FromDBReservation(&dbCompleted)); err != nil {
+			return err
+		}
+
+		if !dbReservation.RoomID.Valid {
+			return nil
+		}
+
+		_, err = q.UpdateRoomHousekeepingStatus(ctx, db.UpdateRoomHousekeepingStatusParams{
+			RoomID:                dbReservation.RoomID.UUID,
+			HousekeepingStatus:    sql.NullString{String: model.HousekeepingDirty, Valid: true},
+			HousekeepingUpdatedAt: now,
+		})
+		return err
+	})
 }
\ No newline at end of file
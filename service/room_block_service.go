@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+)
+
+type RoomBlockService interface {
+	CreateRoomBlock(ctx context.Context, block *model.RoomBlock) ([]*model.Reservation, error)
+	GetRoomBlockByID(ctx context.Context, blockID uuid.UUID) (*model.RoomBlock, error)
+	ListRoomBlocksByRoom(ctx context.Context, roomID uuid.UUID, page, pageSize int) ([]*model.RoomBlock, error)
+	DeleteRoomBlock(ctx context.Context, blockID uuid.UUID) error
+}
+
+type roomBlockService struct {
+	store         db.Store
+	roomBlockRepo repository.RoomBlockRepository
+	roomRepo      repository.RoomRepository
+}
+
+func NewRoomBlockService(store db.Store, roomBlockRepo repository.RoomBlockRepository, roomRepo repository.RoomRepository) RoomBlockService {
+	return &roomBlockService{
+		store:         store,
+		roomBlockRepo: roomBlockRepo,
+		roomRepo:      roomRepo,
+	}
+}
+
+// CreateRoomBlock takes a room out of service. When reservations already occupy the room
+// during the block they are returned together with an error so staff can relocate them.
+func (s *roomBlockService) CreateRoomBlock(ctx context.Context, block *model.RoomBlock) ([]*model.Reservation, error) {
+	if block.BlockID == uuid.Nil {
+		block.BlockID = uuid.New()
+	}
+
+	if !block.RoomID.Valid {
+		return nil, errors.New("invalid room ID")
+	}
+
+	if !block.StartDate.Valid || !block.EndDate.Valid {
+		return nil, errors.New("invalid block dates")
+	}
+
+	if !block.StartDate.Time.Before(block.EndDate.Time) {
+		return nil, errors.New("invalid date range: start date must be before end date")
+	}
+
+	if !block.Reason.Valid || strings.TrimSpace(block.Reason.String) == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	if !block.BlockType.Valid {
+		block.BlockType = sql.NullString{String: "MAINTENANCE", Valid: true}
+	}
+	block.BlockType.String = strings.ToUpper(block.BlockType.String)
+	if block.BlockType.String != "MAINTENANCE" && block.BlockType.String != "OUT_OF_ORDER" {
+		return nil, errors.New("block type must be MAINTENANCE or OUT_OF_ORDER")
+	}
+
+	block.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	var conflicts []*model.Reservation
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbRoom, err := q.GetRoomForUpdate(ctx, block.RoomID.UUID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("room not found")
+			}
+			return err
+		}
+
+		// Blocks take rooms out for whole nights, from check-in on the first day to check-out
+		// on the last, the same window a stay over those dates would hold
+		if dbRoom.HotelID.Valid {
+			dbHotel, err := q.GetHotel(ctx, dbRoom.HotelID.UUID)
+			if err != nil {
+				return err
+			}
+			start, end := stayWindow(model.FromDBHotel(&dbHotel), block.StartDate.Time, block.EndDate.Time)
+			block.StartDate = sql.NullTime{Time: start, Valid: true}
+			block.EndDate = sql.NullTime{Time: end, Valid: true}
+		}
+
+		reservations, err := q.ListOverlappingRoomReservations(ctx, db.ListOverlappingRoomReservationsParams{
+			RoomID:    block.RoomID.UUID,
+			StartDate: block.StartDate.Time,
+			EndDate:   block.EndDate.Time,
+		})
+		if err != nil {
+			return err
+		}
+		if len(reservations) > 0 {
+			for i := range reservations {
+				conflicts = append(conflicts, model.FromDBReservation(&reservations[i]))
+			}
+			return errors.New("room has reservations during the block")
+		}
+
+		_, err = q.CreateRoomBlock(ctx, db.CreateRoomBlockParams{
+			BlockID:   block.BlockID,
+			RoomID:    block.RoomID,
+			BlockType: block.BlockType,
+			Reason:    block.Reason,
+			StartDate: block.StartDate,
+			EndDate:   block.EndDate,
+			CreatedAt: block.CreatedAt,
+			CreatedBy: block.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := markChannelsDue(ctx, q, dbRoom.HotelID); err != nil {
+			return err
+		}
+
+		if !dbRoom.TypeID.Valid {
+			return nil
+		}
+
+		// Reservations made against the room type without a room still need a room every night
+		rooms, err := q.LockRoomsByHotelAndType(ctx, db.LockRoomsByHotelAndTypeParams{
+			HotelID: dbRoom.HotelID,
+			TypeID:  dbRoom.TypeID,
+		})
+		if err != nil {
+			return err
+		}
+
+		available, err := q.GetMinNightlyTypeAvailability(ctx, db.GetMinNightlyTypeAvailabilityParams{
+			TotalRooms: int32(len(rooms)),
+			HotelID:    dbRoom.HotelID,
+			TypeID:     dbRoom.TypeID,
+			StartDate:  block.StartDate.Time,
+			EndDate:    block.EndDate.Time,
+		})
+		if err != nil {
+			return err
+		}
+		if available < 0 {
+			return errors.New("blocking this room would overbook its room type")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return conflicts, err
+	}
+
+	return nil, nil
+}
+
+func (s *roomBlockService) GetRoomBlockByID(ctx context.Context, blockID uuid.UUID) (*model.RoomBlock, error) {
+	block, err := s.roomBlockRepo.GetRoomBlockByID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	if block == nil {
+		return nil, errors.New("room block not found")
+	}
+
+	return block, nil
+}
+
+func (s *roomBlockService) ListRoomBlocksByRoom(ctx context.Context, roomID uuid.UUID, page, pageSize int) ([]*model.RoomBlock, error) {
+	room, err := s.roomRepo.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, errors.New("room not found")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.roomBlockRepo.ListRoomBlocksByRoom(ctx, roomID, pageSize, offset)
+}
+
+func (s *roomBlockService) DeleteRoomBlock(ctx context.Context, blockID uuid.UUID) error {
+	block, err := s.roomBlockRepo.GetRoomBlockByID(ctx, blockID)
+	if err != nil {
+		return err
+	}
+
+	if block == nil {
+		return errors.New("room block not found")
+	}
+
+	// The feed would only import the block again; it goes when the event leaves the feed
+	if block.FeedID.Valid {
+		return errors.New("room block is managed by an ical feed")
+	}
+
+	if err := s.roomBlockRepo.DeleteRoomBlock(ctx, blockID); err != nil {
+		return err
+	}
+
+	room, err := s.roomRepo.GetRoomByID(ctx, block.RoomID.UUID)
+	if err != nil || room == nil {
+		return err
+	}
+	return markChannelsDue(ctx, s.store, room.HotelID)
+}
\ No newline at end of file
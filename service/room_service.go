@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
 	"github.com/devsirose/hotel-reservation/model"
 	"github.com/devsirose/hotel-reservation/repository"
 	"github.com/google/uuid"
@@ -18,17 +19,24 @@ type RoomService interface {
 	UpdateRoom(ctx context.Context, room *model.Room) error
 	DeleteRoom(ctx context.Context, roomID uuid.UUID) error
 	GetAvailableRooms(ctx context.Context, hotelID uuid.UUID, checkIn, checkOut time.Time) ([]*model.Room, error)
+	GetRoomTypeAvailability(ctx context.Context, hotelID uuid.UUID, checkIn, checkOut time.Time) ([]*model.RoomTypeAvailability, error)
+	GetHotelCalendar(ctx context.Context, hotelID uuid.UUID, from, to time.Time) (*model.HotelCalendar, error)
+	GetDayUseSlots(ctx context.Context, hotelID uuid.UUID, date time.Time) (*model.HotelDayUseSlots, error)
 }
 
 type roomService struct {
-	roomRepo  repository.RoomRepository
-	hotelRepo repository.HotelRepository
+	store        db.Store
+	roomRepo     repository.RoomRepository
+	hotelRepo    repository.HotelRepository
+	roomTypeRepo repository.RoomTypeRepository
 }
 
-func NewRoomService(roomRepo repository.RoomRepository, hotelRepo repository.HotelRepository) RoomService {
+func NewRoomService(store db.Store, roomRepo repository.RoomRepository, hotelRepo repository.HotelRepository, roomTypeRepo repository.RoomTypeRepository) RoomService {
 	return &roomService{
-		roomRepo:  roomRepo,
-		hotelRepo: hotelRepo,
+		store:        store,
+		roomRepo:     roomRepo,
+		hotelRepo:    hotelRepo,
+		roomTypeRepo: roomTypeRepo,
 	}
 }
 
@@ -49,6 +57,15 @@ func (s *roomService) CreateRoom(ctx context.Context, room *model.Room) error {
 		return errors.New("hotel not found")
 	}
 	
+	roomType, err := checkRoomTypeExists(ctx, s.roomTypeRepo, room.TypeID)
+	if err != nil {
+		return err
+	}
+	
+	if !room.MaxCapacity.Valid && roomType != nil {
+		room.MaxCapacity = roomType.DefaultCapacity
+	}
+	
 	if room.MaxCapacity.Valid && room.MaxCapacity.Int32 <= 0 {
 		return errors.New("max capacity must be greater than 0")
 	}
@@ -61,9 +78,34 @@ func (s *roomService) CreateRoom(ctx context.Context, room *model.Room) error {
 		return errors.New("rate must be between 0 and 5")
 	}
 	
+	if err := validateDayUse(room); err != nil {
+		return err
+	}
+	
 	room.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
 	
-	return s.roomRepo.CreateRoom(ctx, room)
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbRoom, err := q.CreateRoom(ctx, db.CreateRoomParams{
+			RoomID:      room.RoomID,
+			RoomName:    room.RoomName,
+			HotelID:     room.HotelID,
+			Floor:       room.Floor,
+			TypeID:      room.TypeID,
+			MaxCapacity: room.MaxCapacity,
+			Rate:        room.Rate,
+			Description: room.Description,
+			Price:       room.Price,
+			CreatedAt:   room.CreatedAt,
+			CreatedBy:   room.CreatedBy,
+			DayUse:      room.DayUse,
+			DayUsePrice: room.DayUsePrice,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(ctx, q, model.EventRoomCreated, model.AggregateRoom, room.RoomID, room.HotelID, model.FromDBRoom(&dbRoom))
+	})
 }
 
 func (s *roomService) GetRoomByID(ctx context.Context, roomID uuid.UUID) (*model.Room, error) {
@@ -110,6 +152,10 @@ func (s *roomService) UpdateRoom(ctx context.Context, room *model.Room) error {
 		return errors.New("room not found")
 	}
 	
+	if _, err := checkRoomTypeExists(ctx, s.roomTypeRepo, room.TypeID); err != nil {
+		return err
+	}
+	
 	if room.MaxCapacity.Valid && room.MaxCapacity.Int32 <= 0 {
 		return errors.New("max capacity must be greater than 0")
 	}
@@ -122,9 +168,41 @@ func (s *roomService) UpdateRoom(ctx context.Context, room *model.Room) error {
 		return errors.New("rate must be between 0 and 5")
 	}
 	
+	// Clients that predate day-use leave these fields out; keep the stored settings
+	if !room.DayUse.Valid {
+		room.DayUse = existingRoom.DayUse
+	}
+	if !room.DayUsePrice.Valid {
+		room.DayUsePrice = existingRoom.DayUsePrice
+	}
+	if err := validateDayUse(room); err != nil {
+		return err
+	}
+	
 	room.UpdateAt = sql.NullTime{Time: time.Now(), Valid: true}
 	
-	return s.roomRepo.UpdateRoom(ctx, room)
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbRoom, err := q.UpdateRoom(ctx, db.UpdateRoomParams{
+			RoomID:      room.RoomID,
+			RoomName:    room.RoomName,
+			HotelID:     existingRoom.HotelID,
+			Floor:       room.Floor,
+			TypeID:      room.TypeID,
+			MaxCapacity: room.MaxCapacity,
+			Rate:        room.Rate,
+			Description: room.Description,
+			Price:       room.Price,
+			UpdateAt:    room.UpdateAt,
+			UpdateBy:    room.UpdateBy,
+			DayUse:      room.DayUse,
+			DayUsePrice: room.DayUsePrice,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(ctx, q, model.EventRoomUpdated, model.AggregateRoom, room.RoomID, dbRoom.HotelID, model.FromDBRoom(&dbRoom))
+	})
 }
 
 func (s *roomService) DeleteRoom(ctx context.Context, roomID uuid.UUID) error {
@@ -137,7 +215,13 @@ func (s *roomService) DeleteRoom(ctx context.Context, roomID uuid.UUID) error {
 		return errors.New("room not found")
 	}
 	
-	return s.roomRepo.DeleteRoom(ctx, roomID)
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		if err := q.DeleteRoom(ctx, roomID); err != nil {
+			return err
+		}
+
+		return recordEvent(ctx, q, model.EventRoomDeleted, model.AggregateRoom, roomID, existingRoom.HotelID, existingRoom)
+	})
 }
 
 func (s *roomService) GetAvailableRooms(ctx context.Context, hotelID uuid.UUID, checkIn, checkOut time.Time) ([]*model.Room, error) {
@@ -145,9 +229,193 @@ func (s *roomService) GetAvailableRooms(ctx context.Context, hotelID uuid.UUID,
 		return nil, errors.New("invalid date range: check-in must be before check-out")
 	}
 	
-	if checkIn.Before(time.Now().Truncate(24 * time.Hour)) {
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+	
+	if err := checkArrivalNotPast(hotel, checkIn); err != nil {
+		return nil, errors.New("check-in date cannot be in the past")
+	}
+	
+	// Minimum and maximum stays are checked against the nights bookings are held to
+	start, end := stayWindow(hotel, checkIn, checkOut)
+	return s.roomRepo.GetAvailableRooms(ctx, hotelID, start.Format(time.RFC3339), end.Format(time.RFC3339), stayNights(start, end))
+}
+
+func (s *roomService) GetRoomTypeAvailability(ctx context.Context, hotelID uuid.UUID, checkIn, checkOut time.Time) ([]*model.RoomTypeAvailability, error) {
+	if checkIn.After(checkOut) || checkIn.Equal(checkOut) {
+		return nil, errors.New("invalid date range: check-in must be before check-out")
+	}
+	
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+	
+	if err := checkArrivalNotPast(hotel, checkIn); err != nil {
 		return nil, errors.New("check-in date cannot be in the past")
 	}
 	
-	return s.roomRepo.GetAvailableRooms(ctx, hotelID, checkIn.Format(time.RFC3339), checkOut.Format(time.RFC3339))
+	// Minimum and maximum stays are checked against the nights bookings are held to
+	start, end := stayWindow(hotel, checkIn, checkOut)
+	return s.roomRepo.GetRoomTypeAvailability(ctx, hotelID, start.Format(time.RFC3339), end.Format(time.RFC3339), stayNights(start, end))
+}
+
+// validateDayUse checks that a room offered for day use has a slot price
+func validateDayUse(room *model.Room) error {
+	if !room.DayUse.Valid {
+		room.DayUse = sql.NullBool{Bool: false, Valid: true}
+	}
+	
+	if room.DayUsePrice.Valid && room.DayUsePrice.Int32 < 0 {
+		return errors.New("day-use price cannot be negative")
+	}
+	
+	if room.DayUse.Bool && !room.DayUsePrice.Valid {
+		return errors.New("day-use price is required for day-use rooms")
+	}
+	
+	return nil
+}
+
+// maxCalendarNights bounds the calendar range so a single request cannot scan years of nights
+const maxCalendarNights = 92
+
+func (s *roomService) GetHotelCalendar(ctx context.Context, hotelID uuid.UUID, from, to time.Time) (*model.HotelCalendar, error) {
+	if !from.Before(to) {
+		return nil, errors.New("invalid date range: from must be before to")
+	}
+	
+	if to.Sub(from) > maxCalendarNights*24*time.Hour {
+		return nil, errors.New("date range cannot exceed 92 nights")
+	}
+	
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+	
+	// Each night is sampled at the hotel's check-in time, when a stay starting that day holds the
+	// room and one ending that day has already checked out
+	loc := hotelLocation(hotel)
+	checkIn, _ := stayWindow(hotel, from, to)
+	last, _ := stayWindow(hotel, to, to)
+	cells, err := s.roomRepo.GetHotelCalendar(ctx, hotelID, checkIn.Format(time.RFC3339), last.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	
+	calendar := &model.HotelCalendar{
+		HotelID: hotelID,
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		Dates:   []string{},
+		Rooms:   []*model.RoomCalendar{},
+	}
+	for night := from; night.Before(to); night = night.AddDate(0, 0, 1) {
+		calendar.Dates = append(calendar.Dates, night.Format("2006-01-02"))
+	}
+	
+	// Cells arrive ordered by room and night, so each room's nights are contiguous
+	var current *model.RoomCalendar
+	for _, cell := range cells {
+		if current == nil || current.RoomID != cell.RoomID {
+			current = &model.RoomCalendar{
+				RoomID:   cell.RoomID,
+				RoomName: cell.RoomName.String,
+				TypeID:   cell.TypeID.String,
+			}
+			calendar.Rooms = append(calendar.Rooms, current)
+		}
+		
+		night := &model.CalendarNight{
+			Date:   cell.Night.In(loc).Format("2006-01-02"),
+			Status: model.CalendarFree,
+		}
+		if cell.ReservationID.Valid {
+			reservationID := cell.ReservationID.UUID
+			night.ReservationID = &reservationID
+			night.Status = model.CalendarBooked
+			if cell.Status.Valid && cell.Status.String == "PENDING" {
+				night.Status = model.CalendarPending
+			}
+		} else if cell.BlockID.Valid {
+			blockID := cell.BlockID.UUID
+			night.BlockID = &blockID
+			night.Status = model.CalendarBlocked
+		}
+		current.Nights = append(current.Nights, night)
+	}
+	
+	return calendar, nil
+}
+
+func (s *roomService) GetDayUseSlots(ctx context.Context, hotelID uuid.UUID, date time.Time) (*model.HotelDayUseSlots, error) {
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+	
+	if date.IsZero() {
+		date = hotelToday(hotel, time.Now())
+	}
+	
+	if err := checkArrivalNotPast(hotel, date); err != nil {
+		return nil, errors.New("date cannot be in the past")
+	}
+	
+	rooms, err := s.roomRepo.ListDayUseRooms(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Stays ending up to one buffer before the first slot still hold the room for cleaning
+	opens, closes := dayUseHours(hotel, date)
+	buffer := dayUseBuffer(hotel)
+	occupancy, err := s.roomRepo.ListDayUseRoomOccupancy(ctx, hotelID, opens.Add(-buffer).Format(time.RFC3339), closes.Add(buffer).Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	
+	byRoom := make(map[uuid.UUID][]*model.RoomOccupancy)
+	for _, item := range occupancy {
+		byRoom[item.RoomID] = append(byRoom[item.RoomID], item)
+	}
+	
+	result := &model.HotelDayUseSlots{
+		HotelID:       hotelID,
+		Date:          opens.Format("2006-01-02"),
+		SlotMinutes:   int32(dayUseSlotLength(hotel).Minutes()),
+		BufferMinutes: int32(buffer.Minutes()),
+		Rooms:         []*model.RoomDayUseSlots{},
+	}
+	now := time.Now()
+	for _, room := range rooms {
+		roomSlots := &model.RoomDayUseSlots{
+			RoomID:   room.RoomID,
+			RoomName: room.RoomName.String,
+			TypeID:   room.TypeID.String,
+			Slots:    buildDayUseSlots(hotel, date, now, byRoom[room.RoomID]),
+		}
+		if room.DayUsePrice.Valid {
+			price := room.DayUsePrice.Int32
+			roomSlots.Price = &price
+		}
+		result.Rooms = append(result.Rooms, roomSlots)
+	}
+	
+	return result, nil
 }
\ No newline at end of file
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+)
+
+type RoomTypeService interface {
+	CreateRoomType(ctx context.Context, roomType *model.RoomType) error
+	GetRoomType(ctx context.Context, typeCode string) (*model.RoomType, error)
+	ListRoomTypes(ctx context.Context, page, pageSize int) ([]*model.RoomType, error)
+	UpdateRoomType(ctx context.Context, roomType *model.RoomType) error
+	DeleteRoomType(ctx context.Context, typeCode string) error
+}
+
+type roomTypeService struct {
+	roomTypeRepo repository.RoomTypeRepository
+}
+
+func NewRoomTypeService(roomTypeRepo repository.RoomTypeRepository) RoomTypeService {
+	return &roomTypeService{
+		roomTypeRepo: roomTypeRepo,
+	}
+}
+
+func (s *roomTypeService) CreateRoomType(ctx context.Context, roomType *model.RoomType) error {
+	roomType.TypeCode = strings.TrimSpace(roomType.TypeCode)
+	if roomType.TypeCode == "" {
+		return errors.New("type code is required")
+	}
+
+	existingType, err := s.roomTypeRepo.GetRoomTypeByCode(ctx, roomType.TypeCode)
+	if err != nil {
+		return err
+	}
+	if existingType != nil {
+		return errors.New("room type already exists")
+	}
+
+	if err := validateRoomType(roomType); err != nil {
+		return err
+	}
+
+	roomType.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return s.roomTypeRepo.CreateRoomType(ctx, roomType)
+}
+
+func (s *roomTypeService) GetRoomType(ctx context.Context, typeCode string) (*model.RoomType, error) {
+	roomType, err := s.roomTypeRepo.GetRoomTypeByCode(ctx, typeCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if roomType == nil {
+		return nil, errors.New("room type not found")
+	}
+
+	return roomType, nil
+}
+
+func (s *roomTypeService) ListRoomTypes(ctx context.Context, page, pageSize int) ([]*model.RoomType, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.roomTypeRepo.ListRoomTypes(ctx, pageSize, offset)
+}
+
+func (s *roomTypeService) UpdateRoomType(ctx context.Context, roomType *model.RoomType) error {
+	existingType, err := s.roomTypeRepo.GetRoomTypeByCode(ctx, roomType.TypeCode)
+	if err != nil {
+		return err
+	}
+
+	if existingType == nil {
+		return errors.New("room type not found")
+	}
+
+	if err := validateRoomType(roomType); err != nil {
+		return err
+	}
+
+	roomType.UpdateAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	return s.roomTypeRepo.UpdateRoomType(ctx, roomType)
+}
+
+func (s *roomTypeService) DeleteRoomType(ctx context.Context, typeCode string) error {
+	existingType, err := s.roomTypeRepo.GetRoomTypeByCode(ctx, typeCode)
+	if err != nil {
+		return err
+	}
+
+	if existingType == nil {
+		return errors.New("room type not found")
+	}
+
+	usage, err := s.roomTypeRepo.CountRoomTypeUsage(ctx, typeCode)
+	if err != nil {
+		return err
+	}
+	if usage > 0 {
+		return errors.New("room type is still in use")
+	}
+
+	return s.roomTypeRepo.DeleteRoomType(ctx, typeCode)
+}
+
+func validateRoomType(roomType *model.RoomType) error {
+	if roomType.SizeSqm.Valid && roomType.SizeSqm.Int32 <= 0 {
+		return errors.New("size must be greater than 0")
+	}
+
+	if roomType.DefaultCapacity.Valid && roomType.DefaultCapacity.Int32 <= 0 {
+		return errors.New("default capacity must be greater than 0")
+	}
+
+	if roomType.BaseOccupancy.Valid && roomType.BaseOccupancy.Int32 <= 0 {
+		return errors.New("base occupancy must be greater than 0")
+	}
+
+	if roomType.BaseOccupancy.Valid && roomType.DefaultCapacity.Valid && roomType.BaseOccupancy.Int32 > roomType.DefaultCapacity.Int32 {
+		return errors.New("base occupancy cannot exceed default capacity")
+	}
+
+	if (roomType.ExtraAdultPrice.Valid && roomType.ExtraAdultPrice.Int32 < 0) || (roomType.ExtraChildPrice.Valid && roomType.ExtraChildPrice.Int32 < 0) {
+		return errors.New("extra person prices cannot be negative")
+	}
+
+	return nil
+}
+
+// checkRoomTypeExists returns an error when typeID refers to a room type that is not in the catalog.
+func checkRoomTypeExists(ctx context.Context, roomTypeRepo repository.RoomTypeRepository, typeID sql.NullString) (*model.RoomType, error) {
+	if !typeID.Valid {
+		return nil, nil
+	}
+
+	roomType, err := roomTypeRepo.GetRoomTypeByCode(ctx, typeID.String)
+	if err != nil {
+		return nil, err
+	}
+	if roomType == nil {
+		return nil, errors.New("room type not found")
+	}
+
+	return roomType, nil
+}
\ No newline at end of file
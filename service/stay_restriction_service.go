@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+)
+
+type StayRestrictionService interface {
+	CreateStayRestriction(ctx context.Context, restriction *model.StayRestriction) error
+	GetStayRestrictionByID(ctx context.Context, restrictionID uuid.UUID) (*model.StayRestriction, error)
+	ListStayRestrictionsByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.StayRestriction, error)
+	UpdateStayRestriction(ctx context.Context, restriction *model.StayRestriction) error
+	DeleteStayRestriction(ctx context.Context, restrictionID uuid.UUID) error
+}
+
+type stayRestrictionService struct {
+	store           db.Store
+	restrictionRepo repository.StayRestrictionRepository
+	hotelRepo       repository.HotelRepository
+	roomTypeRepo    repository.RoomTypeRepository
+}
+
+func NewStayRestrictionService(store db.Store, restrictionRepo repository.StayRestrictionRepository, hotelRepo repository.HotelRepository, roomTypeRepo repository.RoomTypeRepository) StayRestrictionService {
+	return &stayRestrictionService{
+		store:           store,
+		restrictionRepo: restrictionRepo,
+		hotelRepo:       hotelRepo,
+		roomTypeRepo:    roomTypeRepo,
+	}
+}
+
+func (s *stayRestrictionService) CreateStayRestriction(ctx context.Context, restriction *model.StayRestriction) error {
+	if restriction.RestrictionID == uuid.Nil {
+		restriction.RestrictionID = uuid.New()
+	}
+
+	if !restriction.HotelID.Valid {
+		return errors.New("invalid hotel ID")
+	}
+
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, restriction.HotelID.UUID)
+	if err != nil {
+		return err
+	}
+	if hotel == nil {
+		return errors.New("hotel not found")
+	}
+
+	if err := s.validateStayRestriction(ctx, restriction); err != nil {
+		return err
+	}
+	localizeRestriction(hotel, restriction)
+
+	restriction.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.restrictionRepo.CreateStayRestriction(ctx, restriction); err != nil {
+		return err
+	}
+	return markChannelsDue(ctx, s.store, restriction.HotelID)
+}
+
+func (s *stayRestrictionService) GetStayRestrictionByID(ctx context.Context, restrictionID uuid.UUID) (*model.StayRestriction, error) {
+	restriction, err := s.restrictionRepo.GetStayRestrictionByID(ctx, restrictionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if restriction == nil {
+		return nil, errors.New("stay restriction not found")
+	}
+
+	return restriction, nil
+}
+
+func (s *stayRestrictionService) ListStayRestrictionsByHotel(ctx context.Context, hotelID uuid.UUID, page, pageSize int) ([]*model.StayRestriction, error) {
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	if hotel == nil {
+		return nil, errors.New("hotel not found")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.restrictionRepo.ListStayRestrictionsByHotel(ctx, hotelID, pageSize, offset)
+}
+
+func (s *stayRestrictionService) UpdateStayRestriction(ctx context.Context, restriction *model.StayRestriction) error {
+	existingRestriction, err := s.restrictionRepo.GetStayRestrictionByID(ctx, restriction.RestrictionID)
+	if err != nil {
+		return err
+	}
+
+	if existingRestriction == nil {
+		return errors.New("stay restriction not found")
+	}
+
+	// Restrictions cannot move between hotels, so dates are localized with the stored hotel
+	hotel, err := s.hotelRepo.GetHotelByID(ctx, existingRestriction.HotelID.UUID)
+	if err != nil {
+		return err
+	}
+	if hotel == nil {
+		return errors.New("hotel not found")
+	}
+
+	if err := s.validateStayRestriction(ctx, restriction); err != nil {
+		return err
+	}
+	localizeRestriction(hotel, restriction)
+
+	restriction.UpdateAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.restrictionRepo.UpdateStayRestriction(ctx, restriction); err != nil {
+		return err
+	}
+	return markChannelsDue(ctx, s.store, existingRestriction.HotelID)
+}
+
+func (s *stayRestrictionService) DeleteStayRestriction(ctx context.Context, restrictionID uuid.UUID) error {
+	existingRestriction, err := s.restrictionRepo.GetStayRestrictionByID(ctx, restrictionID)
+	if err != nil {
+		return err
+	}
+
+	if existingRestriction == nil {
+		return errors.New("stay restriction not found")
+	}
+
+	if err := s.restrictionRepo.DeleteStayRestriction(ctx, restrictionID); err != nil {
+		return err
+	}
+	return markChannelsDue(ctx, s.store, existingRestriction.HotelID)
+}
+
+// localizeRestriction stores a restriction's dates as midnight in the hotel's time zone, so it
+// covers the whole local days a guest arrives or departs on.
+func localizeRestriction(hotel *model.Hotel, restriction *model.StayRestriction) {
+	loc := hotelLocation(hotel)
+	restriction.StartDate = sql.NullTime{Time: localMidnight(restriction.StartDate.Time, loc), Valid: true}
+	restriction.EndDate = sql.NullTime{Time: localMidnight(restriction.EndDate.Time, loc), Valid: true}
+}
+
+func (s *stayRestrictionService) validateStayRestriction(ctx context.Context, restriction *model.StayRestriction) error {
+	if !restriction.StartDate.Valid || !restriction.EndDate.Valid {
+		return errors.New("invalid restriction dates")
+	}
+
+	if !restriction.StartDate.Time.Before(restriction.EndDate.Time) {
+		return errors.New("invalid date range: start date must be before end date")
+	}
+
+	if restriction.MinNights.Valid && restriction.MinNights.Int32 < 1 {
+		return errors.New("minimum nights must be at least 1")
+	}
+
+	if restriction.MaxNights.Valid && restriction.MaxNights.Int32 < 1 {
+		return errors.New("maximum nights must be at least 1")
+	}
+
+	if restriction.MinNights.Valid && restriction.MaxNights.Valid && restriction.MinNights.Int32 > restriction.MaxNights.Int32 {
+		return errors.New("minimum nights cannot exceed maximum nights")
+	}
+
+	if !restriction.MinNights.Valid && !restriction.MaxNights.Valid && !restriction.ClosedToArrival.Bool && !restriction.ClosedToDeparture.Bool {
+		return errors.New("restriction must set minimum nights, maximum nights, closed to arrival or closed to departure")
+	}
+
+	if _, err := checkRoomTypeExists(ctx, s.roomTypeRepo, restriction.TypeID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkStayRestrictions returns an error naming the first restriction the stay violates
+func checkStayRestrictions(restrictions []*model.StayRestriction, arrival, departure time.Time) error {
+	nights := stayNights(arrival, departure)
+	for _, restriction := range restrictions {
+		coversArrival := !arrival.Before(restriction.StartDate.Time) && arrival.Before(restriction.EndDate.Time)
+		coversDeparture := !departure.Before(restriction.StartDate.Time) && departure.Before(restriction.EndDate.Time)
+
+		if coversArrival {
+			if restriction.ClosedToArrival.Bool {
+				return fmt.Errorf("closed to arrival on %s", arrival.Format("2006-01-02"))
+			}
+			if restriction.MinNights.Valid && nights < restriction.MinNights.Int32 {
+				return fmt.Errorf("minimum stay of %d nights required for arrival on %s", restriction.MinNights.Int32, arrival.Format("2006-01-02"))
+			}
+			if restriction.MaxNights.Valid && nights > restriction.MaxNights.Int32 {
+				return fmt.Errorf("maximum stay of %d nights allowed for arrival on %s", restriction.MaxNights.Int32, arrival.Format("2006-01-02"))
+			}
+		}
+
+		if coversDeparture && restriction.ClosedToDeparture.Bool {
+			return fmt.Errorf("closed to departure on %s", departure.Format("2006-01-02"))
+		}
+	}
+
+	return nil
+}
\ No newline at end of file
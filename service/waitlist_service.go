@@ -0,0 +1,476 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/logger"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/devsirose/hotel-reservation/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// waitlistOfferTTL is how long a waitlisted guest has to confirm the hold offered to them
+const waitlistOfferTTL = 24 * time.Hour
+
+// WaitlistMatcher is told about inventory freed by cancellations and expired holds, so it can
+// offer the released dates to waitlisted guests.
+type WaitlistMatcher interface {
+	MatchReleasedInventory(ctx context.Context, released *model.Reservation)
+}
+
+type WaitlistService interface {
+	WaitlistMatcher
+	JoinWaitlist(ctx context.Context, entry *model.WaitlistEntry) error
+	GetWaitlistEntryByID(ctx context.Context, entryID uuid.UUID) (*model.WaitlistEntry, error)
+	ListWaitlistEntriesByHotel(ctx context.Context, hotelID uuid.UUID, status string, page, pageSize int) ([]*model.WaitlistEntry, error)
+	ListWaitlistEntriesByUser(ctx context.Context, userID string, page, pageSize int) ([]*model.WaitlistEntry, error)
+	WithdrawWaitlistEntry(ctx context.Context, entryID uuid.UUID) error
+	ExpireOffers(ctx context.Context, now time.Time) error
+}
+
+type waitlistService struct {
+	store        db.Store
+	waitlistRepo repository.WaitlistRepository
+	roomRepo     repository.RoomRepository
+}
+
+func NewWaitlistService(store db.Store, waitlistRepo repository.WaitlistRepository, roomRepo repository.RoomRepository) WaitlistService {
+	return &waitlistService{
+		store:        store,
+		waitlistRepo: waitlistRepo,
+		roomRepo:     roomRepo,
+	}
+}
+
+func (s *waitlistService) JoinWaitlist(ctx context.Context, entry *model.WaitlistEntry) error {
+	if entry.EntryID == uuid.Nil {
+		entry.EntryID = uuid.New()
+	}
+
+	if !entry.StartDate.Valid || !entry.EndDate.Valid {
+		return errors.New("invalid waitlist dates")
+	}
+
+	if !entry.StartDate.Time.Before(entry.EndDate.Time) {
+		return errors.New("invalid date range: start date must be before end date")
+	}
+
+	if !entry.Guests.Valid {
+		entry.Guests = sql.NullInt32{Int32: 1, Valid: true}
+	}
+	if entry.Guests.Int32 < 1 {
+		return errors.New("guests must be at least 1")
+	}
+
+	if !entry.ContactName.Valid || strings.TrimSpace(entry.ContactName.String) == "" {
+		return errors.New("contact name is required")
+	}
+
+	if !entry.ContactEmail.Valid && !entry.ContactPhone.Valid {
+		return errors.New("contact email or phone is required")
+	}
+
+	if entry.RoomID.Valid {
+		room, err := s.roomRepo.GetRoomByID(ctx, entry.RoomID.UUID)
+		if err != nil {
+			return err
+		}
+		if room == nil {
+			return errors.New("room not found")
+		}
+		if room.MaxCapacity.Valid && entry.Guests.Int32 > room.MaxCapacity.Int32 {
+			return errors.New("guests exceed the room's capacity")
+		}
+
+		entry.HotelID = room.HotelID
+		entry.TypeID = room.TypeID
+	} else if !entry.HotelID.Valid || !entry.TypeID.Valid {
+		return errors.New("either room ID or hotel ID and room type are required")
+	}
+
+	entry.Status = sql.NullString{String: model.WaitlistWaiting, Valid: true}
+	entry.CreatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		stay := &model.Reservation{HotelID: entry.HotelID, StartDate: entry.StartDate, EndDate: entry.EndDate}
+		hotel, err := localizeStay(ctx, q, stay)
+		if err != nil {
+			return err
+		}
+		if err := checkArrivalNotPast(hotel, stay.StartDate.Time); err != nil {
+			return err
+		}
+		entry.StartDate = stay.StartDate
+		entry.EndDate = stay.EndDate
+
+		_, err = q.CreateWaitlistEntry(ctx, db.CreateWaitlistEntryParams{
+			EntryID:      entry.EntryID,
+			HotelID:      entry.HotelID,
+			TypeID:       entry.TypeID,
+			RoomID:       entry.RoomID,
+			UserID:       entry.UserID,
+			StartDate:    entry.StartDate,
+			EndDate:      entry.EndDate,
+			Guests:       entry.Guests,
+			ContactName:  entry.ContactName,
+			ContactEmail: entry.ContactEmail,
+			ContactPhone: entry.ContactPhone,
+			Status:       entry.Status,
+			CreatedAt:    entry.CreatedAt,
+			CreatedBy:    entry.CreatedBy,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Inventory may already be free, e.g. when the guest raced a cancellation; the queue decides who gets it
+	s.MatchReleasedInventory(ctx, &model.Reservation{HotelID: entry.HotelID, StartDate: entry.StartDate, EndDate: entry.EndDate})
+
+	if updated, err := s.waitlistRepo.GetWaitlistEntryByID(ctx, entry.EntryID); err == nil && updated != nil {
+		*entry = *updated
+	}
+	return nil
+}
+
+func (s *waitlistService) GetWaitlistEntryByID(ctx context.Context, entryID uuid.UUID) (*model.WaitlistEntry, error) {
+	entry, err := s.waitlistRepo.GetWaitlistEntryByID(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry == nil {
+		return nil, errors.New("waitlist entry not found")
+	}
+
+	return entry, nil
+}
+
+func (s *waitlistService) ListWaitlistEntriesByHotel(ctx context.Context, hotelID uuid.UUID, status string, page, pageSize int) ([]*model.WaitlistEntry, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.waitlistRepo.ListWaitlistEntriesByHotel(ctx, hotelID, strings.ToUpper(status), pageSize, offset)
+}
+
+func (s *waitlistService) ListWaitlistEntriesByUser(ctx context.Context, userID string, page, pageSize int) ([]*model.WaitlistEntry, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	return s.waitlistRepo.ListWaitlistEntriesByUser(ctx, userID, pageSize, offset)
+}
+
+// WithdrawWaitlistEntry takes a guest off the waitlist. Withdrawing an entry with an open offer
+// also cancels its hold, which is then offered to the next guest in line.
+func (s *waitlistService) WithdrawWaitlistEntry(ctx context.Context, entryID uuid.UUID) error {
+	var released *model.Reservation
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbEntry, err := q.GetWaitlistEntryForUpdate(ctx, entryID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("waitlist entry not found")
+			}
+			return err
+		}
+
+		status := dbEntry.Status.String
+		if status != model.WaitlistWaiting && status != model.WaitlistOffered {
+			return errors.New("only waiting or offered entries can be withdrawn")
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		if status == model.WaitlistOffered && dbEntry.ReservationID.Valid {
+			dbReservation, err := q.GetReservationForUpdate(ctx, dbEntry.ReservationID.UUID)
+			if err != nil {
+				return err
+			}
+			if dbReservation.Status.String == "PENDING" {
+				dbCancelled, err := q.UpdateReservationStatus(ctx, db.UpdateReservationStatusParams{
+					ReservationID: dbReservation.ReservationID,
+					Status:        sql.NullString{String: "CANCELLED", Valid: true},
+					UpdateAt:      now,
+				})
+				if err != nil {
+					return err
+				}
+
+				released = model.FromDBReservation(&dbCancelled)
+				if err := recordReservationEvent(ctx, q, model.EventReservationCancelled, released); err != nil {
+					return err
+				}
+			}
+		}
+
+		_, err = q.UpdateWaitlistEntryStatus(ctx, db.UpdateWaitlistEntryStatusParams{
+			EntryID:  entryID,
+			Status:   sql.NullString{String: model.WaitlistWithdrawn, Valid: true},
+			UpdateAt: now,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if released != nil {
+		s.MatchReleasedInventory(ctx, released)
+	}
+	return nil
+}
+
+// MatchReleasedInventory offers inventory freed by a cancelled or expired stay to the first
+// waitlisted guest, in queue order, whose stay now fits. The offer is a pending reservation that
+// lapses after waitlistOfferTTL, announced by a waitlist offer event recorded with it. Matching is
+// best effort: failures are logged, not returned, so they never undo the cancellation that
+// triggered them.
+func (s *waitlistService) MatchReleasedInventory(ctx context.Context, released *model.Reservation) {
+	if !released.HotelID.Valid || !released.StartDate.Valid || !released.EndDate.Valid {
+		return
+	}
+
+	entries, err := s.store.ListWaitingEntriesForRelease(ctx, db.ListWaitingEntriesForReleaseParams{
+		HotelID:   released.HotelID,
+		StartDate: released.StartDate.Time,
+		EndDate:   released.EndDate.Time,
+	})
+	if err != nil {
+		logWaitlistError("list waitlist entries", err)
+		return
+	}
+
+	for i := range entries {
+		entry, err := s.offerHold(ctx, entries[i].EntryID)
+		if err != nil || entry == nil {
+			// The guest's stay still does not fit, or another matcher got to the entry first
+			continue
+		}
+		return
+	}
+}
+
+// offerHold places a pending hold for a waiting entry if its stay and party fit the current
+// inventory, priced as CreateReservation prices bookings. It returns a nil entry when the entry is no longer waiting.
+func (s *waitlistService) offerHold(ctx context.Context, entryID uuid.UUID) (*model.WaitlistEntry, error) {
+	var entry *model.WaitlistEntry
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		dbEntry, err := q.GetWaitlistEntryForUpdate(ctx, entryID)
+		if err != nil {
+			return err
+		}
+		if dbEntry.Status.String != model.WaitlistWaiting {
+			return nil
+		}
+
+		now := time.Now()
+		expiresAt := now.Add(waitlistOfferTTL)
+		if dbEntry.StartDate.Time.Before(expiresAt) {
+			expiresAt = dbEntry.StartDate.Time
+		}
+
+		// Entries only count their guests, so the whole party is held and priced as adults
+		adults := dbEntry.Guests.Int32
+		if adults < 1 {
+			adults = 1
+		}
+		hold := &model.Reservation{
+			ReservationID: uuid.New(),
+			RoomID:        dbEntry.RoomID,
+			UserID:        dbEntry.UserID,
+			StartDate:     dbEntry.StartDate,
+			EndDate:       dbEntry.EndDate,
+			Status:        sql.NullString{String: "PENDING", Valid: true},
+			CreatedAt:     sql.NullTime{Time: now, Valid: true},
+			HotelID:       dbEntry.HotelID,
+			TypeID:        dbEntry.TypeID,
+			StayType:      sql.NullString{String: model.StayTypeOvernight, Valid: true},
+			HoldExpiresAt: sql.NullTime{Time: expiresAt, Valid: true},
+			Adults:        sql.NullInt32{Int32: adults, Valid: true},
+			Children:      sql.NullInt32{Int32: 0, Valid: true},
+			Source:        sql.NullString{String: model.ReservationSourceDirect, Valid: true},
+		}
+
+		room, err := reserveInventory(ctx, q, hold)
+		if err != nil {
+			return err
+		}
+
+		extra, err := priceOccupancy(ctx, q, hold, room)
+		if err != nil {
+			return err
+		}
+		nights := stayNights(hold.StartDate.Time, hold.EndDate.Time)
+		hold.ExtraPersonCharge = sql.NullInt32{Int32: extra * nights, Valid: true}
+		hold.TotalPrice = sql.NullInt32{Int32: room.Price.Int32*nights + hold.ExtraPersonCharge.Int32, Valid: room.Price.Valid}
+
+		hold.ConfirmationCode, err = newConfirmationCode(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateReservation(ctx, db.CreateReservationParams{
+			ReservationID:     hold.ReservationID,
+			RoomID:            hold.RoomID,
+			UserID:            hold.UserID,
+			StartDate:         hold.StartDate,
+			EndDate:           hold.EndDate,
+			Status:            hold.Status,
+			CreatedAt:         hold.CreatedAt,
+			TotalPrice:        hold.TotalPrice,
+			ExtraPersonCharge: hold.ExtraPersonCharge,
+			HotelID:           hold.HotelID,
+			TypeID:            hold.TypeID,
+			StayType:          hold.StayType,
+			HoldExpiresAt:     hold.HoldExpiresAt,
+			Adults:            hold.Adults,
+			Children:          hold.Children,
+			ConfirmationCode:  hold.ConfirmationCode,
+			Source:            hold.Source,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := createStaySegment(ctx, q, hold); err != nil {
+			return err
+		}
+
+		// Guests who joined without an account find the offer and get its emails through the
+		// contact they left
+		hold.Guests = []*model.ReservationGuest{waitlistContactGuest(&dbEntry)}
+		if err := saveReservationGuests(ctx, q, hold); err != nil {
+			return err
+		}
+
+		if err := recordReservationEvent(ctx, q, model.EventReservationCreated, hold); err != nil {
+			return err
+		}
+
+		dbEntry, err = q.UpdateWaitlistEntryOffer(ctx, db.UpdateWaitlistEntryOfferParams{
+			EntryID:        entryID,
+			ReservationID:  uuid.NullUUID{UUID: hold.ReservationID, Valid: true},
+			OfferExpiresAt: hold.HoldExpiresAt,
+			UpdateAt:       hold.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		entry = model.FromDBWaitlistEntry(&dbEntry)
+
+		payload := map[string]interface{}{
+			"entry":       entry,
+			"reservation": hold,
+		}
+		return recordEvent(ctx, q, model.EventWaitlistOfferCreated, model.AggregateWaitlistEntry, entry.EntryID, entry.HotelID, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// waitlistContactGuest returns the primary guest of an entry's hold, named after its contact. The
+// last word of the contact name is taken as the last name.
+func waitlistContactGuest(entry *db.WaitlistEntry) *model.ReservationGuest {
+	guest := &model.ReservationGuest{
+		IsPrimary: sql.NullBool{Bool: true, Valid: true},
+		Email:     entry.ContactEmail,
+		Phone:     entry.ContactPhone,
+	}
+
+	names := strings.Fields(entry.ContactName.String)
+	if len(names) > 0 {
+		guest.LastName = sql.NullString{String: names[len(names)-1], Valid: true}
+	}
+	if len(names) > 1 {
+		guest.FirstName = sql.NullString{String: strings.Join(names[:len(names)-1], " "), Valid: true}
+	}
+	return guest
+}
+
+// ExpireOffers cancels holds whose offer lapsed, offering their inventory to the next guest in
+// line, and closes waiting entries whose arrival date has passed.
+func (s *waitlistService) ExpireOffers(ctx context.Context, now time.Time) error {
+	if _, err := s.store.ExpireStaleWaitlistEntries(ctx, now); err != nil {
+		return err
+	}
+
+	holds, err := s.store.ListExpiredHolds(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range holds {
+		var released *model.Reservation
+		err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+			dbReservation, err := q.GetReservationForUpdate(ctx, holds[i].ReservationID)
+			if err != nil {
+				return err
+			}
+			// The guest may have confirmed the hold since it was listed
+			if dbReservation.Status.String != "PENDING" || !dbReservation.HoldExpiresAt.Valid || dbReservation.HoldExpiresAt.Time.After(now) {
+				return nil
+			}
+
+			updateAt := sql.NullTime{Time: now, Valid: true}
+			dbExpired, err := q.UpdateReservationStatus(ctx, db.UpdateReservationStatusParams{
+				ReservationID: dbReservation.ReservationID,
+				Status:        sql.NullString{String: "CANCELLED", Valid: true},
+				UpdateAt:      updateAt,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := recordReservationEvent(ctx, q, model.EventReservationExpired, model.FromDBReservation(&dbExpired)); err != nil {
+				return err
+			}
+
+			err = q.ResolveWaitlistOffer(ctx, db.ResolveWaitlistOfferParams{
+				Status:        sql.NullString{String: model.WaitlistExpired, Valid: true},
+				UpdateAt:      updateAt,
+				ReservationID: uuid.NullUUID{UUID: dbReservation.ReservationID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			released = model.FromDBReservation(&dbExpired)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if released != nil {
+			s.MatchReleasedInventory(ctx, released)
+		}
+	}
+
+	return nil
+}
+
+func logWaitlistError(action string, err error) {
+	if logger.Log == nil {
+		return
+	}
+	logger.Log.Error("waitlist: failed to "+action, zap.Error(err))
+}
\ No newline at end of file
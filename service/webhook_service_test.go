@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/devsirose/hotel-reservation/db/sqlc"
+	"github.com/devsirose/hotel-reservation/model"
+	"github.com/google/uuid"
+)
+
+func TestWebhookSendSignsDelivery(t *testing.T) {
+	const secret = "test-secret-0123456789"
+	now := time.Unix(1760000000, 0)
+	body := []byte(`{"event_id":"e1","type":"reservation.created"}`)
+
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan received, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		requests <- received{header: r.Header.Clone(), body: data}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	s := &webhookService{client: receiver.Client()}
+	subscription := &db.WebhookSubscription{SubscriptionID: uuid.New(), Url: receiver.URL, Secret: secret}
+	delivery := &db.WebhookDelivery{DeliveryID: uuid.New(), EventType: model.EventReservationCreated, Payload: body}
+
+	status, err := s.send(context.Background(), subscription, delivery, now)
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if status != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", status, http.StatusNoContent)
+	}
+
+	got := <-requests
+	if string(got.body) != string(body) {
+		t.Errorf("body = %s, want %s", got.body, body)
+	}
+	if event := got.header.Get(WebhookEventHeader); event != model.EventReservationCreated {
+		t.Errorf("event header = %q, want %q", event, model.EventReservationCreated)
+	}
+	if id := got.header.Get(WebhookDeliveryHeader); id != delivery.DeliveryID.String() {
+		t.Errorf("delivery header = %q, want %q", id, delivery.DeliveryID)
+	}
+
+	// Verify the signature the way a receiver would
+	var timestamp, signature string
+	for _, part := range strings.Split(got.header.Get(WebhookSignatureHeader), ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	if timestamp != strconv.FormatInt(now.Unix(), 10) {
+		t.Errorf("signature timestamp = %q, want %d", timestamp, now.Unix())
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(got.body)))
+	if !hmac.Equal([]byte(signature), []byte(hex.EncodeToString(mac.Sum(nil)))) {
+		t.Errorf("signature %q does not verify", signature)
+	}
+}
+
+func TestWebhookSendFailsOnErrorStatus(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	s := &webhookService{client: receiver.Client()}
+	subscription := &db.WebhookSubscription{Url: receiver.URL, Secret: "test-secret-0123456789"}
+	delivery := &db.WebhookDelivery{DeliveryID: uuid.New(), Payload: []byte(`{}`)}
+
+	status, err := s.send(context.Background(), subscription, delivery, time.Now())
+	if err == nil {
+		t.Fatal("send succeeded, want an error")
+	}
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", status, http.StatusServiceUnavailable)
+	}
+}
+
+func TestWebhookSendFailsWhenReceiverIsDown(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	client := receiver.Client()
+	url := receiver.URL
+	receiver.Close()
+
+	s := &webhookService{client: client}
+	subscription := &db.WebhookSubscription{Url: url, Secret: "test-secret-0123456789"}
+	delivery := &db.WebhookDelivery{DeliveryID: uuid.New(), Payload: []byte(`{}`)}
+
+	status, err := s.send(context.Background(), subscription, delivery, time.Now())
+	if err == nil {
+		t.Fatal("send succeeded, want an error")
+	}
+	if status != 0 {
+		t.Errorf("status = %d, want 0", status)
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{5, 16 * time.Minute},
+		{20, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := webhookRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("webhookRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSubscribedToEvent(t *testing.T) {
+	if !subscribedToEvent(nil, model.EventRoomUpdated) {
+		t.Error("a subscription without event types should receive every event")
+	}
+	types := []string{model.EventReservationCreated, model.EventReservationCancelled}
+	if !subscribedToEvent(types, model.EventReservationCancelled) {
+		t.Error("subscription should receive an event type it lists")
+	}
+	if subscribedToEvent(types, model.EventRoomUpdated) {
+		t.Error("subscription should not receive an event type it does not list")
+	}
+}
\ No newline at end of file